@@ -4,10 +4,9 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/nhost/nhost/cli/internal/schemadiff"
+	"github.com/nhost/nhost/internal/lib/schemadiff"
 	"github.com/pmezard/go-difflib/difflib"
 	"github.com/urfave/cli/v3"
-	"github.com/vektah/gqlparser/v2/ast"
 )
 
 const (
@@ -45,19 +44,6 @@ outputting a unified diff in GraphQL SDL format.`,
 	}
 }
 
-func normalize(schemaA, schemaB *ast.Schema) {
-	schemadiff.NormalizeAggregateTypes(schemaA, schemaB)
-
-	schemadiff.StripNoopUpdateMutations(schemaA)
-	schemadiff.StripNoopUpdateMutations(schemaB)
-
-	schemadiff.StripBuiltinDirectives(schemaA)
-	schemadiff.StripBuiltinDirectives(schemaB)
-
-	schemadiff.NormalizeFuncArgNullability(schemaA)
-	schemadiff.NormalizeFuncArgNullability(schemaB)
-}
-
 func diff(_ context.Context, cmd *cli.Command) error {
 	schemaAPath := cmd.String(flagSchemaA)
 	schemaBPath := cmd.String(flagSchemaB)
@@ -74,7 +60,7 @@ func diff(_ context.Context, cmd *cli.Command) error {
 	}
 
 	if !noClean {
-		normalize(schemaA, schemaB)
+		schemadiff.Normalize(schemaA, schemaB)
 	}
 
 	schemadiff.SortFields(schemaA)
@@ -37,6 +37,8 @@ let
 
       (fs.fileFilter (f: f.hasExt "go") ../internal/lib/oapi)
 
+      (fs.fileFilter (f: f.hasExt "go") ../internal/lib/schemadiff)
+
       ./cmd/configserver/logsapi/gqlgen.yml
       ./cmd/configserver/logsapi/schema.graphqls
 
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 )
 
 // Value is the runtime JSON value type. Concrete types:
@@ -148,7 +149,13 @@ func decodeOne(dec *jsontext.Decoder) (Value, error) {
 	case '"':
 		return tok.String(), nil
 	case '0':
-		return tok.Float(), nil
+		// Parse the raw number text rather than calling Token.Float, whose
+		// signature differs between Go releases. The token is a valid JSON
+		// number, so the only possible error is ErrRange, for which
+		// ParseFloat yields ±Inf just as Token.Float does.
+		f, _ := strconv.ParseFloat(tok.String(), 64)
+
+		return f, nil
 	case '[':
 		out := []Value{}
 		for dec.PeekKind() != ']' {
@@ -0,0 +1,311 @@
+package schemadiff
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ChangeKind classifies a [Change].
+type ChangeKind string
+
+const (
+	TypeAdded           ChangeKind = "TYPE_ADDED"
+	TypeRemoved         ChangeKind = "TYPE_REMOVED"
+	TypeKindChanged     ChangeKind = "TYPE_KIND_CHANGED"
+	FieldAdded          ChangeKind = "FIELD_ADDED"
+	FieldRemoved        ChangeKind = "FIELD_REMOVED"
+	FieldTypeChanged    ChangeKind = "FIELD_TYPE_CHANGED"
+	ArgumentAdded       ChangeKind = "ARGUMENT_ADDED"
+	ArgumentRemoved     ChangeKind = "ARGUMENT_REMOVED"
+	ArgumentTypeChanged ChangeKind = "ARGUMENT_TYPE_CHANGED"
+	EnumValueAdded      ChangeKind = "ENUM_VALUE_ADDED"
+	EnumValueRemoved    ChangeKind = "ENUM_VALUE_REMOVED"
+)
+
+// Change is one client-facing difference between two schemas.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Path locates the change: "Type", "Type.field" or "Type.field(arg:)".
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	// Breaking reports whether an operation valid against the old schema
+	// may fail against the new one.
+	Breaking bool `json:"breaking"`
+}
+
+// Compare returns the changes that turn from into to, ordered by path.
+//
+// Types, fields, arguments and enum values are matched by name, so the
+// ordering differences SortFields normalises for SDL output never show up;
+// directives and descriptions are not compared, and introspection types are
+// skipped. Neither schema is modified, so Compare is safe on schemas that are
+// being served. Removals are breaking, and so are type changes that narrow
+// what a client may send (an input or argument made non-null) or widen what
+// it may receive (an output field made nullable), plus new required
+// arguments and input fields.
+func Compare(from, to *ast.Schema) []Change {
+	var changes []Change
+
+	for _, name := range slices.Sorted(maps.Keys(from.Types)) {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+
+		oldDef := from.Types[name]
+
+		newDef, ok := to.Types[name]
+		if !ok {
+			changes = append(changes, Change{
+				Kind:        TypeRemoved,
+				Path:        name,
+				Description: fmt.Sprintf("type %s was removed", name),
+				Breaking:    true,
+			})
+
+			continue
+		}
+
+		changes = append(changes, compareDefinitions(oldDef, newDef)...)
+	}
+
+	for name := range to.Types {
+		if _, ok := from.Types[name]; !ok && !strings.HasPrefix(name, "__") {
+			changes = append(changes, Change{
+				Kind:        TypeAdded,
+				Path:        name,
+				Description: fmt.Sprintf("type %s was added", name),
+				Breaking:    false,
+			})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Kind, b.Kind))
+	})
+
+	return changes
+}
+
+func compareDefinitions(oldDef, newDef *ast.Definition) []Change {
+	if oldDef.Kind != newDef.Kind {
+		return []Change{{
+			Kind: TypeKindChanged,
+			Path: oldDef.Name,
+			Description: fmt.Sprintf(
+				"type %s changed from %s to %s", oldDef.Name, oldDef.Kind, newDef.Kind,
+			),
+			Breaking: true,
+		}}
+	}
+
+	switch oldDef.Kind {
+	case ast.Object, ast.Interface:
+		return compareFields(oldDef, newDef, false)
+	case ast.InputObject:
+		return compareFields(oldDef, newDef, true)
+	case ast.Enum:
+		return compareEnumValues(oldDef, newDef)
+	case ast.Scalar, ast.Union:
+		return nil
+	}
+
+	return nil
+}
+
+// compareFields compares the fields of two object, interface or input object
+// definitions. Input object fields follow the same rules as arguments.
+func compareFields(oldDef, newDef *ast.Definition, input bool) []Change {
+	var changes []Change
+
+	for _, oldField := range oldDef.Fields {
+		path := oldDef.Name + "." + oldField.Name
+
+		newField := newDef.Fields.ForName(oldField.Name)
+		if newField == nil {
+			changes = append(changes, Change{
+				Kind:        FieldRemoved,
+				Path:        path,
+				Description: fmt.Sprintf("field %s was removed", path),
+				Breaking:    true,
+			})
+
+			continue
+		}
+
+		if oldField.Type.String() != newField.Type.String() {
+			safe := safeOutputChange(oldField.Type, newField.Type)
+			if input {
+				safe = safeInputChange(oldField.Type, newField.Type)
+			}
+
+			changes = append(changes, Change{
+				Kind: FieldTypeChanged,
+				Path: path,
+				Description: fmt.Sprintf(
+					"field %s changed type from %s to %s", path, oldField.Type, newField.Type,
+				),
+				Breaking: !safe,
+			})
+		}
+
+		changes = append(changes, compareArguments(path, oldField.Arguments, newField.Arguments)...)
+	}
+
+	for _, newField := range newDef.Fields {
+		if oldDef.Fields.ForName(newField.Name) == nil {
+			changes = append(changes, addedField(newDef.Name+"."+newField.Name, newField, input))
+		}
+	}
+
+	return changes
+}
+
+func addedField(path string, field *ast.FieldDefinition, input bool) Change {
+	if input && required(field.Type, field.DefaultValue) {
+		return Change{
+			Kind:        FieldAdded,
+			Path:        path,
+			Description: fmt.Sprintf("required input field %s was added", path),
+			Breaking:    true,
+		}
+	}
+
+	return Change{
+		Kind:        FieldAdded,
+		Path:        path,
+		Description: fmt.Sprintf("field %s was added", path),
+		Breaking:    false,
+	}
+}
+
+func compareArguments(fieldPath string, oldArgs, newArgs ast.ArgumentDefinitionList) []Change {
+	var changes []Change
+
+	for _, oldArg := range oldArgs {
+		path := fieldPath + "(" + oldArg.Name + ":)"
+
+		newArg := newArgs.ForName(oldArg.Name)
+		if newArg == nil {
+			changes = append(changes, Change{
+				Kind:        ArgumentRemoved,
+				Path:        path,
+				Description: fmt.Sprintf("argument %s was removed", path),
+				Breaking:    true,
+			})
+
+			continue
+		}
+
+		typeChanged := oldArg.Type.String() != newArg.Type.String()
+		nowRequired := !required(oldArg.Type, oldArg.DefaultValue) &&
+			required(newArg.Type, newArg.DefaultValue)
+
+		if typeChanged || nowRequired {
+			changes = append(changes, Change{
+				Kind: ArgumentTypeChanged,
+				Path: path,
+				Description: fmt.Sprintf(
+					"argument %s changed type from %s to %s", path, oldArg.Type, newArg.Type,
+				),
+				Breaking: nowRequired || !safeInputChange(oldArg.Type, newArg.Type),
+			})
+		}
+	}
+
+	for _, newArg := range newArgs {
+		if oldArgs.ForName(newArg.Name) != nil {
+			continue
+		}
+
+		path := fieldPath + "(" + newArg.Name + ":)"
+		breaking := required(newArg.Type, newArg.DefaultValue)
+
+		description := fmt.Sprintf("argument %s was added", path)
+		if breaking {
+			description = fmt.Sprintf("required argument %s was added", path)
+		}
+
+		changes = append(changes, Change{
+			Kind:        ArgumentAdded,
+			Path:        path,
+			Description: description,
+			Breaking:    breaking,
+		})
+	}
+
+	return changes
+}
+
+func compareEnumValues(oldDef, newDef *ast.Definition) []Change {
+	var changes []Change
+
+	for _, v := range oldDef.EnumValues {
+		if newDef.EnumValues.ForName(v.Name) == nil {
+			path := oldDef.Name + "." + v.Name
+			changes = append(changes, Change{
+				Kind:        EnumValueRemoved,
+				Path:        path,
+				Description: fmt.Sprintf("enum value %s was removed", path),
+				Breaking:    true,
+			})
+		}
+	}
+
+	for _, v := range newDef.EnumValues {
+		if oldDef.EnumValues.ForName(v.Name) == nil {
+			path := newDef.Name + "." + v.Name
+			changes = append(changes, Change{
+				Kind:        EnumValueAdded,
+				Path:        path,
+				Description: fmt.Sprintf("enum value %s was added", path),
+				Breaking:    false,
+			})
+		}
+	}
+
+	return changes
+}
+
+// required reports whether a client must supply an argument or input field.
+func required(t *ast.Type, defaultValue *ast.Value) bool {
+	return t.NonNull && defaultValue == nil
+}
+
+// safeOutputChange reports whether clients reading a value of type from can
+// also read one of type to: the named type and list nesting must match, and
+// a position may only become non-null.
+func safeOutputChange(from, to *ast.Type) bool {
+	if from.NonNull && !to.NonNull {
+		return false
+	}
+
+	return sameShape(from, to, safeOutputChange)
+}
+
+// safeInputChange reports whether every value clients could send for type
+// from is still accepted for type to: the named type and list nesting must
+// match, and a position may only become nullable.
+func safeInputChange(from, to *ast.Type) bool {
+	if !from.NonNull && to.NonNull {
+		return false
+	}
+
+	return sameShape(from, to, safeInputChange)
+}
+
+func sameShape(from, to *ast.Type, elem func(from, to *ast.Type) bool) bool {
+	if (from.Elem == nil) != (to.Elem == nil) {
+		return false
+	}
+
+	if from.Elem != nil {
+		return elem(from.Elem, to.Elem)
+	}
+
+	return from.NamedType == to.NamedType
+}
@@ -0,0 +1,136 @@
+package schemadiff_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/nhost/internal/lib/schemadiff"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	from := mustParse(t, `
+type Query {
+	users(where: user_filter, limit: Int, offset: Int!): [user!]!
+	posts: [post!]!
+}
+type user { id: ID! name: String! email: String }
+type post { id: ID! }
+input user_filter { id: ID, name: String! }
+enum order_by { asc desc }
+`)
+	to := mustParse(t, `
+type Query {
+	users(where: user_filter, limit: Int!, offset: Int, since: String!): [user!]!
+	comments: [String!]
+}
+type user { id: ID! name: String email: String! }
+input user_filter { id: ID, name: String, role: String! }
+enum order_by { asc nulls }
+`)
+
+	want := []schemadiff.Change{
+		{
+			Kind:        schemadiff.FieldAdded,
+			Path:        "Query.comments",
+			Description: "field Query.comments was added",
+			Breaking:    false,
+		},
+		{
+			Kind:        schemadiff.FieldRemoved,
+			Path:        "Query.posts",
+			Description: "field Query.posts was removed",
+			Breaking:    true,
+		},
+		{
+			Kind:        schemadiff.ArgumentTypeChanged,
+			Path:        "Query.users(limit:)",
+			Description: "argument Query.users(limit:) changed type from Int to Int!",
+			Breaking:    true,
+		},
+		{
+			Kind:        schemadiff.ArgumentTypeChanged,
+			Path:        "Query.users(offset:)",
+			Description: "argument Query.users(offset:) changed type from Int! to Int",
+			Breaking:    false,
+		},
+		{
+			Kind:        schemadiff.ArgumentAdded,
+			Path:        "Query.users(since:)",
+			Description: "required argument Query.users(since:) was added",
+			Breaking:    true,
+		},
+		{
+			Kind:        schemadiff.EnumValueRemoved,
+			Path:        "order_by.desc",
+			Description: "enum value order_by.desc was removed",
+			Breaking:    true,
+		},
+		{
+			Kind:        schemadiff.EnumValueAdded,
+			Path:        "order_by.nulls",
+			Description: "enum value order_by.nulls was added",
+			Breaking:    false,
+		},
+		{
+			Kind:        schemadiff.TypeRemoved,
+			Path:        "post",
+			Description: "type post was removed",
+			Breaking:    true,
+		},
+		{
+			Kind:        schemadiff.FieldTypeChanged,
+			Path:        "user.email",
+			Description: "field user.email changed type from String to String!",
+			Breaking:    false,
+		},
+		{
+			Kind:        schemadiff.FieldTypeChanged,
+			Path:        "user.name",
+			Description: "field user.name changed type from String! to String",
+			Breaking:    true,
+		},
+		{
+			Kind:        schemadiff.FieldTypeChanged,
+			Path:        "user_filter.name",
+			Description: "field user_filter.name changed type from String! to String",
+			Breaking:    false,
+		},
+		{
+			Kind:        schemadiff.FieldAdded,
+			Path:        "user_filter.role",
+			Description: "required input field user_filter.role was added",
+			Breaking:    true,
+		},
+	}
+
+	if diff := cmp.Diff(want, schemadiff.Compare(from, to)); diff != "" {
+		t.Errorf("Compare mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCompareIdenticalSchemas(t *testing.T) {
+	t.Parallel()
+
+	const sdl = `
+type Query { users(limit: Int): [user!]! }
+type user { id: ID! name: String }
+`
+
+	if changes := schemadiff.Compare(mustParse(t, sdl), mustParse(t, sdl)); len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestCompareTypeKindChanged(t *testing.T) {
+	t.Parallel()
+
+	from := mustParse(t, `type Query { s: status } enum status { on off }`)
+	to := mustParse(t, `type Query { s: status } scalar status`)
+
+	changes := schemadiff.Compare(from, to)
+	if len(changes) != 1 || changes[0].Kind != schemadiff.TypeKindChanged || !changes[0].Breaking {
+		t.Errorf("expected one breaking TYPE_KIND_CHANGED, got %+v", changes)
+	}
+}
@@ -1,16 +1,18 @@
 // Package schemadiff provides utilities for parsing two GraphQL schemas,
 // normalising away semantically meaningless differences, and rendering them as
 // deterministic SDL for unified diffing. It is the engine behind the
-// `nhost schema diff` command.
+// `nhost schema diff` command, and [Compare] reports the client-facing changes
+// between two schemas for Constellation's metadata reloads, which [Clone] and
+// [Normalize] the schemas they serve first.
 //
 // The exported functions form an ordered pipeline, and the order matters. The
-// canonical sequence (see cmd/schema/diff.go) is:
+// canonical sequence (see cli/cmd/schema/diff.go) is:
 //
 //  1. Load each schema from disk.
-//  2. Normalise: NormalizeAggregateTypes, then StripNoopUpdateMutations,
-//     StripBuiltinDirectives, and NormalizeFuncArgNullability. These mutate the
-//     schema graph and must run before sorting, since they add and remove
-//     fields and types.
+//  2. Normalise (see Normalize): NormalizeAggregateTypes, then
+//     StripNoopUpdateMutations, StripBuiltinDirectives, and
+//     NormalizeFuncArgNullability. These mutate the schema graph and must run
+//     before sorting, since they add and remove fields and types.
 //  3. SortFields, to make SDL output deterministic regardless of source order.
 //  4. ToSDL, then diff the two SDL strings.
 //  5. AddHunkContext, to annotate the resulting diff's hunk headers.
@@ -20,6 +22,7 @@ import (
 	"bytes"
 	"cmp"
 	"fmt"
+	"maps"
 	"os"
 	"regexp"
 	"slices"
@@ -51,6 +54,112 @@ func Load(path string) (*ast.Schema, error) {
 	return schema, nil
 }
 
+// Normalize runs the normalisers over both schemas in the canonical order,
+// removing the differences that are not semantically meaningful.
+func Normalize(schemaA, schemaB *ast.Schema) {
+	NormalizeAggregateTypes(schemaA, schemaB)
+
+	StripNoopUpdateMutations(schemaA)
+	StripNoopUpdateMutations(schemaB)
+
+	StripBuiltinDirectives(schemaA)
+	StripBuiltinDirectives(schemaB)
+
+	NormalizeFuncArgNullability(schemaA)
+	NormalizeFuncArgNullability(schemaB)
+}
+
+// Clone returns a copy of schema that Normalize and SortFields can modify
+// without touching schema, which may be being served: the type, possible-type
+// and directive maps, the definitions, and their fields, arguments, enum
+// values and types are copied. Positions, descriptions and directive usages
+// are shared.
+func Clone(schema *ast.Schema) *ast.Schema {
+	out := *schema
+
+	out.Types = make(map[string]*ast.Definition, len(schema.Types))
+	for name, def := range schema.Types {
+		out.Types[name] = cloneDefinition(def)
+	}
+
+	out.Query = clonedRoot(out.Types, schema.Query)
+	out.Mutation = clonedRoot(out.Types, schema.Mutation)
+	out.Subscription = clonedRoot(out.Types, schema.Subscription)
+	out.Directives = maps.Clone(schema.Directives)
+	out.PossibleTypes = cloneDefinitionLists(out.Types, schema.PossibleTypes)
+	out.Implements = cloneDefinitionLists(out.Types, schema.Implements)
+
+	return &out
+}
+
+func cloneDefinition(def *ast.Definition) *ast.Definition {
+	out := *def
+
+	out.Fields = make(ast.FieldList, len(def.Fields))
+	for i, field := range def.Fields {
+		f := *field
+		f.Type = cloneType(field.Type)
+
+		f.Arguments = make(ast.ArgumentDefinitionList, len(field.Arguments))
+		for j, arg := range field.Arguments {
+			a := *arg
+			a.Type = cloneType(arg.Type)
+			f.Arguments[j] = &a
+		}
+
+		out.Fields[i] = &f
+	}
+
+	out.EnumValues = slices.Clone(def.EnumValues)
+	out.Interfaces = slices.Clone(def.Interfaces)
+	out.Types = slices.Clone(def.Types)
+
+	return &out
+}
+
+func cloneType(t *ast.Type) *ast.Type {
+	if t == nil {
+		return nil
+	}
+
+	out := *t
+	out.Elem = cloneType(t.Elem)
+
+	return &out
+}
+
+// clonedRoot returns the copy in types of the root operation type root.
+func clonedRoot(types map[string]*ast.Definition, root *ast.Definition) *ast.Definition {
+	if root == nil {
+		return nil
+	}
+
+	return types[root.Name]
+}
+
+// cloneDefinitionLists copies a map of definition lists, pointing each entry
+// at its copy in types.
+func cloneDefinitionLists(
+	types map[string]*ast.Definition, lists map[string][]*ast.Definition,
+) map[string][]*ast.Definition {
+	if lists == nil {
+		return nil
+	}
+
+	out := make(map[string][]*ast.Definition, len(lists))
+
+	for name, defs := range lists {
+		cloned := make([]*ast.Definition, len(defs))
+		for i, def := range defs {
+			cloned[i] = cmp.Or(types[def.Name], def)
+		}
+
+		out[name] = cloned
+	}
+
+	return out
+}
+
 // StripNoopUpdateMutations removes update mutations for tables where the role
 // has no update column permissions (the _update_column enum only contains
 // _PLACEHOLDER). Hasura still generates these mutations even though they can't
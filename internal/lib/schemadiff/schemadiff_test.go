@@ -6,7 +6,7 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/nhost/nhost/cli/internal/schemadiff"
+	"github.com/nhost/nhost/internal/lib/schemadiff"
 	"github.com/vektah/gqlparser/v2"
 	"github.com/vektah/gqlparser/v2/ast"
 )
@@ -397,6 +397,58 @@ enum order_by { asc, desc }
 	}
 }
 
+// TestCloneIsolatesNormalize checks that normalising clones leaves the
+// original schemas, which may be being served, untouched.
+func TestCloneIsolatesNormalize(t *testing.T) {
+	t.Parallel()
+
+	sdl := `
+type Query { users_aggregate: users_aggregate_fields }
+type Mutation { update_users(_set: Int): users_mutation_response }
+type users_mutation_response { affected_rows: Int! }
+type users_aggregate_fields { max: users_max_fields }
+type users_max_fields { id: Int, name: String }
+enum users_update_column { _PLACEHOLDER }
+input users_updates { x: Int }
+input search_users_args { query: String! }
+`
+
+	schemaA := mustParse(t, sdl)
+	schemaB := mustParse(t, `type Query { x: String }`)
+
+	cloneA, cloneB := schemadiff.Clone(schemaA), schemadiff.Clone(schemaB)
+	schemadiff.Normalize(cloneA, cloneB)
+	schemadiff.SortFields(cloneA)
+
+	if _, ok := cloneA.Types["users_max_fields"]; ok {
+		t.Error("expected the clone's unmatched aggregate type to be removed")
+	}
+
+	if len(cloneA.Mutation.Fields) != 0 || cloneA.Mutation != cloneA.Types["Mutation"] {
+		t.Errorf("expected the clone's noop update mutation removed, got %v", cloneA.Mutation.Fields)
+	}
+
+	if _, ok := schemaA.Types["users_max_fields"]; !ok {
+		t.Error("original aggregate type was removed")
+	}
+
+	if len(schemaA.Types["users_aggregate_fields"].Fields) != 1 {
+		t.Error("original aggregate fields were modified")
+	}
+
+	if len(schemaA.Mutation.Fields) != 1 || schemaA.Types["users_updates"] == nil {
+		t.Error("original update mutation was removed")
+	}
+
+	if !schemaA.Types["search_users_args"].Fields.ForName("query").Type.NonNull {
+		t.Error("original _args field was made nullable")
+	}
+
+	if _, ok := schemaA.Directives["deprecated"]; !ok {
+		t.Error("original built-in directive was removed")
+	}
+}
+
 func TestNormalizeFuncArgNullability(t *testing.T) {
 	t.Parallel()
 
@@ -20,57 +20,65 @@ import (
 // const string: with thousands of chunks the chained `+` fold is several
 // times slower for the Go compiler than parsing a slice literal.
 var swaggerSpec = []string{
-	"tFptb9tGtv4rB7wFKt8rUU7a3g8KLoq0yXXTNrFbe9MFUiMckUfi1MMZZmYoWzUE7I/YX7i/ZHHmhRRF",
-	"Kk62yTeLnNfz8pznOfR9kquqVhKlNcniPjF5iRVzfz5tbPlca6XpBysKbrmSTFxoVaO2HE2yWDFhcJrU",
-	"e4/uE4xzCjS55jVNSxZuOXDvIFcFwgTTdQpZI1ljS6X5n1hkJ8k0wTtW1QKTRbL/KpkmdlvTU2M1l+tk",
-	"N000MkNLH+70Q1MxOdPICrYUCGHYyAIG80Zzu72kS+NwoVesQlArsCXCeY3y6cULiHPAWQrBlsxCgZJj",
-	"4cZpfNegsfF6T4uKy0vMNdpsCtl3yDRqMkV2ksJ5xa3FAm5LlG7yinHRaIRbZkAzbrCAJa6UxrhbwU3N",
-	"bF6mw+s4g7xruMYiWbwJXmiNdN2OV8s/MLd0/TPN6vKXnx/wsdXN0MV3FqXhSpqH5g02FSpnNs7kFivz",
-	"keGVK9FUzuthbS4trlG7xbnEsTe7kYOEB0xrtqXfFRrD1vvTu0CpmS2H4fErmlpJgzN6HeMk2r29mpJ4",
-	"vkoWb4bLDs95PTjXgVfjId/jzl99AH5s0n6wR/s2uNDKqlwJ6BaASY3acEOR/a5BzdFMwWqWc7kGq9Zr",
-	"QQ/SNKVsl40QlKVH44W2dwFDyTj0wXl8DVYB3mHeWOzyqVB5U6GkBF1xiQYqyiVbMglK4vHtO8/TBbbD",
-	"bYOtuw0s3tkxiNkwzWmDjzTr6zANllwWXK4NaFyhRpkTJGx7l3vYigdB5O/03hDygf1xZ4420WE2oNyg",
-	"UDWmkBXMsgyYLCBzCWIyqNgWlsqWsESoNRqy4kppqJm2nAmxnZkmz9GYVSOgjQJDyNcPXVr7fYGh0TTC",
-	"PgEyErhAsUxEqB0Ybzf10NnHpy80rpJF8l/zrlrOQ6mc92B0BFg+aWqFPJpCm2Mz505g+U2XVUP/D1z9",
-	"Ei0jy/0nFZ7q90jZZabRbK/Ep/CTVLcSNkw0aIDLXDQFQiaVnZmmrpW2WFBVZM7PM19F6UHNtMEZucj/",
-	"3jDBC+fNvYdMUInfzsgkN/1HeMeNNVnaoxO9bcdy9QhxOaAT/n4RiEeWITTXkomP8/Z57ccByjWXOIur",
-	"QIGWcQETFcgCl1BrVTQ5jR9x9rFq9ePl+StwlYpLq3pc5bZEB4vxckrzNZfMm6kz4Bcp02vzIPNw0RGt",
-	"ef2e0HuwUo1RD3eEweUuUM9ajACm1w4ZTQqXJasRuCEzoq649PiZ0aE8IJVMFqJD1TPVkizUMPGnnoLL",
-	"5imQcbwfTlJPQY1qdI5vN5SOY2SU/FpxY3k+y5XMG00gvgWrblDCSqvK7VopY0FjTiCY4R3F6NsqmCnr",
-	"LL5Hdfy+w/0u3XOQRF3bOqhqsEyv0RpgYGrM+YrnQItDWGckjv2D47hacHpeUaAoHfnu4dkpmddvXYq+",
-	"tZQ/Bxz/YPxoCT1m2hhHQLw8jILJI3LS4ydU8lkjrCFi8OhkxIYHYetej4Xr6xZ8PqEaCosqHfGSWVwr",
-	"vT2Gmb7YzPaA0M3LYLJUxRbmVDZZ5WUCAw+SJ77ghiwfmxsr9EzgBkUkG3F6Hz6PnOAvCzOfBDdcUgJL",
-	"VvO/Lm0G0s7r2U6KHSteMyrvzHI6HaPhJPc02hTONJMUS2V8oZXAFL5X0lgm7czyCsHN1lgAWzMujXXD",
-	"nSchV3LF1w293HAG2Wzmlpn59V2l4nSOElmBdDnp6G7y91k4mTv9LBy/Ixk1/wkdy+iUJV1u6X79v9IV",
-	"s4T9v10lhwXHT4Aff7tK4apEj0hfGghlPBeMVwYmIY0coJia5QhZaW1tFvN56UamXM3/uLVzPyE76ZDW",
-	"Xb8xqL80zlyOu5mgtcMRu6vQqslu5wroSnmaIS3LnbOCNV6VhJOXvogn06TRIsyk80h6m3JF5jjw7tXV",
-	"BZhGr+j8BvXG473zHgrhwjmF57KoFfduZrb/FgqFBqSysEULnHLC8X7JLN+g2Ab8m28ezx0fm8Kc1dzM",
-	"//sEmKZQJ3zCWa3VnesUqGhnSlEawYrCP7clNw6jgbl427rXFV9rKshkQsFzDOw82OXliyv4OTw9tApl",
-	"lQf5VOn1PEw285cvrhzKcys60/bv/PTiRbKHv8lp+r/paRBlLlMXyVfpaXqaeM7hsmxeIhO2/JP+Xo9l",
-	"2mvUfLUF7uUyOYPnrkK39ZsJaAzh0NnzK6jQlop4SPv6RUEe9bucuWSIisMd4PHpaQweEkck5/HOzmvB",
-	"uOw6XF7zRmxTNyOgM4iiy+6s/pJb5729c/egJ1m8uZ4mpqkqRhIyHBnyEvMbmJw9v3JFiRGdeZOYrbFY",
-	"Jde7qYOAv2S2H54/ffaA3X6gPcYN9xnvTAcbvfRumsw3j+ZrUlLvxNHQ+Vu91qxAD8O5khLzKP0Z/IbL",
-	"S5XfoKXkYTeu2VAiZGFRkgnSEG7Mbk0GplnWUV+R6ozi1TTLdkeTQqCoAREKBa/Or8AgldVwmAXc4tK4",
-	"jbOQ6lSEsIBbbkv4+vSbFH4hRJj5wGp3mmTfOqT4v+yErMtduDqLiq0DmlarwL/+8U+oGusFsLN/6KtA",
-	"1RhLEAsX55dX6cDbZ/7uI1ny6PTRiLNvuc1LOmTUnsbt3ZmWyLIp2Q0CabaaFMJumnx9+s0IJaeEcylM",
-	"hlCN7fmo8dajm/fu6qOpjZ8xt8BksMx+UMUgolSqlRkJo+ehTcRaX7RWS+Gpu5cPMSrtBdMFdK0NL5gi",
-	"b4JJ5nyYTUFF/Za1LR8iv732FT3oxHx2kgLVa3K774n6dPbrLIDJHgeBtacgHf14AgwcHaMiDr6exlEH",
-	"pfcJMKnktlKNiZcwLlpDMXSxSnPqZil47tnNsXC6IKt6SobGfqeK7QHgsroW4UrzPwIT7GD3A3opURnu",
-	"+tQv9GcegPtPsHtof43UgKNNLphUbAs503obW1heYP2PZ/jmxGfKCMa+ZGKldIVF9/GAy+DYy/NXU6i4",
-	"ccAemAXaPA2rjSTxizC1FztKx/hwNO8Qsw/I8Zvr3fS+xyjdk931WGq6Q80jPI0mYoD3VuQt7o9k5rOo",
-	"vA0oCRlp/dQrdaugdho/6HVtUmilaEfMIk0CtfKFMrAs4l1b0GgbLSG7zxUB9+/9ltDvyS5LwfNsJcV2",
-	"0WVKh2DettkoLc/As/fRMvAIJlK5RF0xIZYsvzkZJthhZ+TzJNnhLp8hyz7ik9Awx/rNHN++9RrFaV3j",
-	"WjqEsmi6Xs7C91BUbTo3hyYdeTr0ksnH02GXIkwxkN0fdnSmEEfRTEo9eKkKFIJkngEGK41I6Qv+QtBI",
-	"y0UM1mDyHoWP/bdA89M9XPhg+3YflT7E0bE1/f7Rh32O3fWIb87r0Cvo+gDtV8tJ1tswW4RMm3oEnLru",
-	"4xRiZ/PbHVXAyxrzsGRYx3OOPgKSiwkGK48OHSTGsIUVR1H4qb30Q6YFRx3be6Z0Aj1+zd20bRhiVTFw",
-	"DiyRwSS7D1fwPYcdcTZSSqxI4TtlS7fn16enPtuz770fZ1fbGhdw6MrsCeSCk3p0H8IdtVxqJiPu2Rjp",
-	"jt1ZJSgVEJDbEnUI/4kr1u6zSe6+UWe0VgYFN5bLdcNN6XlydZLulYpPgh/dPwaMBMjL4BilWxf2OynP",
-	"Qx+775D2s3rFi0LgLdkzfPp2N+1hP+hGmikY1ZnKxD5v1p5u6LYp9NtCu+xk6krHQeCmD9fHvsoZNI+q",
-	"vdbkXklsK2BbE/eam6OS51e0muMG2/4mlyvX1XF97iUVpT09OGRsaF+HHT4Loo+2PI82bAnC4z3U6uDg",
-	"nRp/lD5Ov3qwCxh3uf6AivJ6xHjd10XhmIEzc/FeNXtG4jII4k1r1qGadUtoGuFip3+WfovFj2ubNvOE",
-	"IiusOJBnboO9b6GLoMm9vvbKcHi+0CMKx6Mq8FDrc5+s7W3XrdXG8XC1cVo47wk4DI22FJ6Fb9hYgPsG",
-	"RVLcY+P88tlPsEYZNn/iP1hRca2wxQFuoFKNm15wjbkVW9deXXNJdItyQ6tmXQY1p3luZ97ivgqtWI4n",
-	"sMSckYY+5PZBIVmNrCJII/NKbPcCyh2tRAxl2tSl051NO1NFFry73v07AAD//w==",
+	"vFr9cttGkn+VLtxWhbwDQTmbvT/outpSNorijW3JltbZK6/KGAJNYqLBDDwzkMR1seoe4p7wnuSq5wMg",
+	"SEiyEnv/I4H57I9f/7obn5JC1Y2SKK1JFp8SU1RYM/fzuLXVidZK0x9WltxyJZk416pBbTmaZLFiwmCa",
+	"NDuPPiUY55RoCs0bmpYs3HLg3kGhSoQJZusM8lay1lZK839imU+TNME7VjcCk0Wy+ypJE7tp6Kmxmst1",
+	"sk0TjcwoebjTT23N5EwjK9lSIIRhIwsYLFrN7eaCLo2HC71mNYJaga0QzhqUx+cvIM4BJykEWzELJUqO",
+	"pRun8WOLxsbrHZc1lxdYaLR5Cvn3yDRqEkU+zeCs5tZiCbcVSjd5xbhoNcItM6AZN1jCEldKY9yt5KZh",
+	"tqiyw+s4gXxsucYyWbwPWuiEdNWNV8tfsbB0/ZO7RjAu3/oTP1HLH1vUG/rxB42rZJH827w3pHmwovmp",
+	"Zk315mXcYZsmrcEH7OnToY6GGrlAY7iScMM0J+UasArQ38NJ0B0LVkpnkN/NKmZazWZaCczBoMDCGq8l",
+	"JfA5cNLcirXCunVyRsrKSbayFYLWTxZWt3gguz1Ze1k8IGIsf+QoyieKeBXnDGXwVikL7h1oNI2SBuEa",
+	"NzDJJavRNKzAzL3OSQ7QPSzBqFYXaKZjvtAINuJKlxVCySxbMoPfGKBBbtHcfBR5CmSmaFstyVA3cPL3",
+	"85fHL1471/ooDlc7RYmakcVfvHmZwi23FTTKBHnQ6gVWSpSozaP27YXjNwqnH1NAsMBHcMwreQ/G7ixK",
+	"Mjbz2LyDTYUqmI0zucXaPFHzhRJtLXf8gUuLa9RucS5x7M125CDhAdOabeh/jcawNY46WsNsNWJswcJm",
+	"9DpiYcSW7mpK4tkqWbw/XPbwnFcH59rTbDzkA+r8bZD1+RodyuBcK6sKJaBfACYNasMN2TJ5P0eTgtWs",
+	"4HINVq3Xgh5kWTZ9HEzShLZ3BkMB51AHZ/G1BzssWot9zChV0dYoHZRxiQZqpV1YkqAk3r99r/kOyve8",
+	"1cu638DinR2Djg6MnybWd2EaLLksuVwb0LhCjbLwaLJ7uS8IyZ0JecN+2pmjTDrgRXmDQjWYQU44mQOT",
+	"JeTOQUwONdvAUtkKlgiNRkNSJPhsmLacCbGZmbYo0JhVK6CzApMl+6ZLaz9kGBpNK+xzICGBMxTLRKQT",
+	"B8Lbpp4eDPHpMwK5h9ERYPmirhX8KIXOx2Y+rLPiuveqQ/0fqPoVWkaS+y0sljjqCLV0hGKHxmbws1S3",
+	"REhEiwa4LERbIuRS2Zlpm0ZpiyUxP+b0PPNMkR40TBuckYr8/xsmeOm0ufOQCY2s3MxIJNfDR3jHjTV5",
+	"NqDMg23HfPUecr5Hmf39IhCPLMOlRS2ZeJq2zxo/DlCuucRZXAVKtIwLmKhAiLmERquyLWj8iLLvi1Z/",
+	"vTh7DfQKuLRqwMdvK3SwGC+nNF9zybyYegH+IWN6/Tj7cNYRpXn1gOk9GqnGqIc7wsHlzlHPOowAptcO",
+	"GU0GFxVrELghMaKueWBjOR3KA1LFZCl6VD1VXSKBGib+1Ck4b06BhOP1MM18muV544cb1IaPJVyk15ob",
+	"y4tZoWTRagLxDVh1jRJWWtVu11oZCxoLlBZyvCMb/VAHMeW9xHeojt/3cL8L99wx2z4OqgYs02u0BhiY",
+	"Bgu+4oUjr4H3jtmxf3A/rpacntdkKErHnG7/7OTM6w/ORT9Y8p+9PHZv/GgIvU+00Y6Acs8wCibPSEnf",
+	"Ph9kL8+mIzLcM1v3esxc33Xg8wUz/rCo0hEvmcW10pv7MNMHm9kOELp5OUyWqtzAnMImq30qzMCD5NQH",
+	"3ODlY3NjhJ4JvEERyUacPoTPe07wu4sP3gmuuSQHlqzhvz99Pyhf+JpNX264L3jNKLwzy+l0LuEF48Zn",
+	"cKqZDPmxf0FZcgZ/UdJYJu3M8hrBzdZYAlszLo11w50moVByxdctvbzhDPLZzC0z8+u7SMXpHBWyEuly",
+	"0tHd5O+zcDJ3+lk4fndj1vCf0bGMvnpCl1u6fz8qXTNL2P/LZbIfcPwE+OsvlxlQKusQ6RsDfj8oBOO1",
+	"gUlwoz5VhryytjGL+dxXEDKu5r/e2rmfkE97pHXXbw3qb4wTl+NuJtSTwhH7q9CqyXbrAuhKeZohLSuc",
+	"soI0XleEkxc+iCdp0moRZtJ5JL3NuDosjfx0eXkOptUrOr9BfePx3mkPhXDmnMGJLBvFvZqZHb6FUqEB",
+	"qSxs0AInn3C8XzLLb1BsAv7Nb76dOz6Wwpw13Mz/fQpMI2gkfMJZo9Wdq4apKGdyURrBytI/txU3DqOB",
+	"OXvbuNc1X7vqAIlQ8AIDOw9yefXiEl6Gp/tSIa/yIJ8pvZ6HyWb+6sWlQ3luRS/a4Z2Pz18kO/ibHGX/",
+	"mR2FpMx56iL5Y3aUHSWeczgvm1fIhK3+Sb/XY572DjVfbYD7dJmUwQsXobv4zQS0hnDo9OQSarSVIh7S",
+	"vX5Rkkb9LqfOGWLG4Q7w7dFRNB6Ubn/Kzeau1tRXcX3OG7FNXY+AzkiBrTurv+TGaW/n3APoSRbvr9LE",
+	"tHXN9KY7MhQVFtcwOT25dEGJEZ15n5iNsVgnVAAgCPhdYvvp5PiHR+T2E+0xLriveGc62Oilt2kyv3k2",
+	"X1Mm9VHcazp/a9aalehhuFBSYhFTfwa/4PJCFddoyXnYNQmChuVhUUoTpCHcmN2aHEy7bGJ+RVlnTF5N",
+	"u+x2NBkEihoQoVTw+uwSDFJYDYdZwC0ujds4D65OQQhLX8L77uhPGbwhRJh5w+p2muR/dkjxX/mUpMud",
+	"uTqJio0Dmi5Xgf/7n/+FurU+AXbyD3UVqFtjCWLh/OziMjvQ9qm/+4iXPDt6NqLsW26Lig4Zc0/j9u5F",
+	"S2TZVOwagRUFNhZLgoPvjv40Qsld3ZlcmAShWjvQUeulRzcf3NVbU2c/Y2qBycEyu0YVjYhcqVFmxIxO",
+	"QpmIdbropJbBsbuXNzEK7SXTZTcuJkyRN8EkdzrMU1Axf8u7kg+R30H5ih70yTx1OShek9p9TdS7s19n",
+	"AUwOOAisPQXp6cdzYODoGAVx8PE0jtoLvc+BSSU3tWpNvIRx1hqCobNVmtO0S8ELz27uM6dzZbw9uXW+",
+	"V+VmD3BZ04hwpfmvRu3B7lOaIkPqF+ozj8D9F9jdrz8WA+4tcsGECloF03oTS1g+wfoPz/DN1HvKCMa+",
+	"YmKldI1l3yDjMij24ux1CjU3DtgDs0BbZGG1ESd+EaYObEfpaB+O5u1j9h45fn9FxeldRumebK/GXNMd",
+	"ah7hadQRh/A+Dz0pOvq4gx6ScbdJbGYRLFNjxaQOupEVFei+8RMK8UFY9PPizcs9ZnOrWmoRtb5jQ2PW",
+	"/AZJXL6HRhhr7+nvcAuTPLRzYPLj2dtXx5dOUdMclATyjrVG18Xphr3528nb/4bzl8evc9Dq1tDAizcv",
+	"ucWpZ9/+ggENQx27zOBMik0H9uoGdXei2LCCgklYYpQNTfKpgpJis+idvQdhbx75aGaRg09ARiPZs3sR",
+	"IbTyvhIo7PVivwIofFadd69fediqOQCLM4mA0hIioO41tGOsKXAZdK90iXoHI76I6IZV3pEjDmwvok70",
+	"ieANIYak0TxZ9C2lu8Yx2/XA20p1Bkr2SZMGJroDXv+aa74KCKp0d8ldfHwcDwfQFyxhh0A4gTyEfV2B",
+	"617Q+yFWHR045FTnzHyV0iqyn5lqQq1Smwy6MlyflMYUEdTKI2DIMCnn3IReNOSfClXiAv4xLIf/I9nm",
+	"XxE4YCKVIykrJsSSFdfTQyjZrwp/HSzZ3+UrgMkT2uGHhjosZPvWlY8Qrs5nXDmbGCaavo698PVj1Zhe",
+	"zaFBQZoOfTTScXpYoQ1TDOSf9qvZKcRR29zTDnilShQCS2Dk8yuNSNQF/IWglZaLaKxB5IPyRew9hBJH",
+	"9lvwrm+oPwES0odH79d4t1djcN6EOmlfA+2+Sprkgw3zRfC01LO/1HVeUohdnT9vif1fNFiEJcM6Pt8a",
+	"sj9SMVHA2qNDTwej2XrU9VMH7odMC446tjZM5YqT8Wutm64ETWwnGs6eJHKY5J/CFXy9dUv5qjQWWZnB",
+	"99TApT2/Ozry3p7/xetxdrlpcAH7qsyfQyE4Sus/dHNp9VIzGXHPRkt3ma1VAjWzCMhthTqY/8RWsWVc",
+	"uG/Qclorh5Iby+W65abyNYJ6+sUjTf/h39OjTAYnoYc3VEj32VzNy1LgLckzfNrmbjrAfiKtJgWjelGZ",
+	"2OPKu9Mdqi2FYUl8m09TFzr2DDd7Yiw85Or1TltmJyTGx31M3GnsjJZ73qLVHG+w6+1QYVjXoce3pKC0",
+	"Uws75KZo34Udvgqij7Z77m1WEYTHe6jV3sH7SuSz7Nvsj492QOIuV58RUd6NCK//skI4ZuDEXD5YyTtF",
+	"Gw8c7zFayXNLaBrhbGd4lmES5sd1Bet5sr3qVjwoTbkNdr4DWUC1U1v0VbHD84X6eDjeNt1f9tB6d8na",
+	"znb9WvHlyGrjKfF8ULzC0GTI4Ifw/Q6W4PrvxLo9Ns4vfvgZ1v6DQK7kc9+sp+BaY4cD3ECtWje95BoL",
+	"KzautbTmkugW+YZW7boKlSzNCzvzEvdRiHohU1hiwVqDsF/XCNUhq5HVBGkkXondXlR9tVqJaMq0KT3C",
+	"O5v1oooseHu1/f8BAA==",
 }
 
 // decodeSpec returns the embedded OpenAPI spec as raw JSON bytes,
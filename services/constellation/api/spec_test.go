@@ -27,7 +27,14 @@ func TestEmbeddedSpecIncludesManuallyMountedGraphQLContract(t *testing.T) {
 		t.Error("embedded OpenAPI spec is missing POST /v1/graphql")
 	}
 
-	for _, schemaName := range []string{"GraphQLRequest", "GraphQLResponse"} {
+	explainPath, ok := spec.Paths.Map()["/v1/graphql/explain"]
+	if !ok || explainPath.Post == nil {
+		t.Error("embedded OpenAPI spec is missing POST /v1/graphql/explain")
+	}
+
+	for _, schemaName := range []string{
+		"GraphQLRequest", "GraphQLResponse", "ExplainRequest", "ExplainedField",
+	} {
 		if _, ok := spec.Components.Schemas[schemaName]; !ok {
 			t.Errorf("embedded OpenAPI spec is missing %s schema", schemaName)
 		}
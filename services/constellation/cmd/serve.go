@@ -36,11 +36,13 @@ const (
 	flagMetadataPath                 = "metadata-path"
 	flagAdminSecret                  = "admin-secret"
 	flagJWTSecret                    = "jwt-secret"
+	flagJWTSecrets                   = "jwt-secrets"
 	flagSubscriptionPollInterval     = "subscription-poll-interval"
 	flagMetadataDatabaseURL          = "metadata-database-url"
 	flagProfileAddress               = "profile-address"
 	flagCORSAllowedOrigins           = "cors-allowed-origins"
 	flagDevMode                      = "dev-mode"
+	flagDisableIntrospection         = "disable-introspection"
 	flagGraphQLRequestBodyLimitBytes = "graphql-request-body-limit-bytes"
 	flagHTTPReadTimeout              = "http-read-timeout"
 	//nolint:gosec // CLI flag name contains "write" but is not a credential.
@@ -48,6 +50,9 @@ const (
 	flagHTTPIdleTimeout                  = "http-idle-timeout"
 	flagHasuraUpstreamURL                = "hasura-upstream-url"
 	flagHasuraProxyRequestBodyLimitBytes = "hasura-proxy-request-body-limit-bytes"
+	flagSubscriptionCoordination         = "subscription-coordination"
+	flagRejectBreakingSchemaChanges      = "reject-breaking-schema-changes"
+	flagSessionSettingsSources           = "session-settings-sources"
 
 	// defaultHasuraUpstreamURL intentionally targets the Nhost Hasura sidecar so
 	// compatibility endpoints proxy by default in normal side-by-side deployments.
@@ -65,6 +70,10 @@ const (
 	defaultHTTPIdleTimeout   = 120 * time.Second
 	maxHTTPReadHeaderTimeout = 5 * time.Second
 	shutdownTimeout          = 30 * time.Second
+
+	// metadataFileDebounce is how long the metadata files must stay unchanged
+	// before file mode reloads them, so a save or multi-file edit reloads once.
+	metadataFileDebounce = 200 * time.Millisecond
 )
 
 var errFlagMustBeGreaterThanZero = errors.New("must be greater than 0")
@@ -108,6 +117,22 @@ func serverFlags() []cli.Flag { //nolint:funlen // long flag list; splitting har
 			Value:    time.Second,
 			Sources:  cli.EnvVars("CONSTELLATION_SUBSCRIPTION_POLL_INTERVAL"),
 		},
+		&cli.BoolFlag{ //nolint:exhaustruct
+			Name: flagSubscriptionCoordination,
+			Usage: "Share live-query subscription polls across replicas: each cohort is polled by one " +
+				"replica, which fans the results out over Postgres LISTEN/NOTIFY",
+			Category: "server",
+			Value:    false,
+			Sources:  cli.EnvVars("CONSTELLATION_SUBSCRIPTION_COORDINATION"),
+		},
+		&cli.StringSliceFlag{ //nolint:exhaustruct
+			Name: flagSessionSettingsSources,
+			Usage: "Postgres sources whose queries, mutations and subscription polls first set " +
+				"the transaction-local hasura.user setting to the JSON of the caller's session " +
+				"variables and role, for row-level security policies and triggers",
+			Category: "server",
+			Sources:  cli.EnvVars("CONSTELLATION_SESSION_SETTINGS_SOURCES"),
+		},
 		&cli.StringFlag{ //nolint:exhaustruct
 			Name:     flagProfileAddress,
 			Usage:    "Enable CPU/memory profiling server on this address (e.g. :6060)",
@@ -132,10 +157,28 @@ func serverFlags() []cli.Flag { //nolint:funlen // long flag list; splitting har
 			Category: "server",
 			Sources:  cli.EnvVars("CONSTELLATION_DEV_MODE"),
 		},
+		&cli.BoolFlag{ //nolint:exhaustruct
+			Name: flagDisableIntrospection,
+			Usage: "reject __schema / __type introspection queries from every " +
+				"non-admin role, over HTTP and WebSocket. Roles listed in the " +
+				"metadata's graphql_schema_introspection.disabled_for_roles are " +
+				"rejected regardless",
+			Category: "server",
+			Sources:  cli.EnvVars("CONSTELLATION_DISABLE_INTROSPECTION"),
+		},
+		&cli.StringSliceFlag{ //nolint:exhaustruct
+			Name: flagRejectBreakingSchemaChanges,
+			Usage: "Roles for which a metadata reload is refused, keeping the current " +
+				"schema, when it would make a breaking change to the role's schema " +
+				"(removed fields, changed types, new required arguments). The diff " +
+				"of every reload is logged and served on GET /v1/graphql/schema-diff",
+			Category: "server",
+			Sources:  cli.EnvVars("CONSTELLATION_REJECT_BREAKING_SCHEMA_CHANGES"),
+		},
 		&cli.Int64Flag{ //nolint:exhaustruct
 			Name: flagGraphQLRequestBodyLimitBytes,
 			Usage: "maximum JSON request body size accepted by POST /v1/graphql " +
-				"and POST /v1, in bytes",
+				"and POST /v1, and of all parts of a multipart file upload together, in bytes",
 			Value:    controller.DefaultMaxGraphQLRequestBodyBytes,
 			Category: "server",
 			Sources:  cli.EnvVars("CONSTELLATION_GRAPHQL_REQUEST_BODY_LIMIT_BYTES"),
@@ -177,7 +220,7 @@ func serverFlags() []cli.Flag { //nolint:funlen // long flag list; splitting har
 			Name: flagHasuraProxyRequestBodyLimitBytes,
 			Usage: "maximum request body size, in bytes, forwarded to the Hasura " +
 				"upstream by the proxy fallback for routes Constellation does not " +
-				"serve natively. 0 disables the limit",
+				"serve natively, and accepted by the native /v2/query. 0 disables the limit",
 			Value:    defaultHasuraProxyRequestBodyLimitBytes,
 			Category: "server",
 			Sources: cli.EnvVars(
@@ -229,12 +272,22 @@ func securityFlags() []cli.Flag {
 			Sources:  cli.EnvVars("CONSTELLATION_ADMIN_SECRET"),
 		},
 		&cli.StringFlag{ //nolint:exhaustruct
-			Name:     flagJWTSecret,
-			Usage:    "JWT secret configuration (JSON string or JSON array of secrets)",
+			Name: flagJWTSecret,
+			Usage: "JWT secret configuration (JSON string or JSON array of secrets). " +
+				"At least one of --" + flagJWTSecret + " or --" + flagJWTSecrets +
+				" is required",
 			Category: "security",
-			Required: true,
 			Sources:  cli.EnvVars("CONSTELLATION_JWT_SECRET"),
 		},
+		&cli.StringFlag{ //nolint:exhaustruct
+			Name: flagJWTSecrets,
+			Usage: "JSON array of JWT secret configurations, equivalent to Hasura's " +
+				"HASURA_GRAPHQL_JWT_SECRETS. Each token is verified only against the " +
+				"secrets whose issuer/audience match it, in order. Combined with " +
+				"--" + flagJWTSecret + " when both are set",
+			Category: "security",
+			Sources:  cli.EnvVars("CONSTELLATION_JWT_SECRETS"),
+		},
 	}
 }
 
@@ -408,6 +461,8 @@ func getRouter(
 	postHandler := ctrl.HandlerPostWithMaxBodyBytes(maxBodyBytes)
 	router.POST("/v1/graphql", postHandler)
 	router.GET("/v1/graphql", ctrl.HandlerGet)
+	router.POST("/v1/graphql/explain", ctrl.HandlerExplain)
+	router.GET("/v1/graphql/schema-diff", ctrl.HandlerSchemaDiff)
 
 	// legacy endpoints for backward compatibility with hasura deployment
 	// to be removed when we do the one binary thing
@@ -433,6 +488,11 @@ func getRouter(
 
 			hasuraProxy.ServeHTTP(c.Writer, c.Request)
 		})
+	} else {
+		// Without an upstream, run_sql is served natively on /v2/query so
+		// migration tooling keeps working. With one, Hasura stays
+		// authoritative and the request takes the NoRoute proxy above.
+		router.POST("/v2/query", ctrl.HandlerQueryWithMaxBodyBytes(proxyBodyLimit)) //nolint:contextcheck
 	}
 
 	return router, nil
@@ -457,7 +517,9 @@ func getMaxGraphQLRequestBodyBytes(cmd *cli.Command) (int64, error) {
 func initJWTAuth(
 	ctx context.Context, cmd *cli.Command, logger *slog.Logger,
 ) (*jwt.Authenticator, error) {
-	jwtCfg, err := jwtconfig.ParseConfig([]string{cmd.String(flagJWTSecret)})
+	jwtCfg, err := jwtconfig.ParseConfig(
+		[]string{cmd.String(flagJWTSecret), cmd.String(flagJWTSecrets)},
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse jwt config: %w", err)
 	}
@@ -466,7 +528,8 @@ func initJWTAuth(
 	if err != nil {
 		if errors.Is(err, jwt.ErrNoSecrets) {
 			return nil, fmt.Errorf(
-				"at least one jwt secret must be configured via %s: %w", flagJWTSecret, err,
+				"at least one jwt secret must be configured via %s or %s: %w",
+				flagJWTSecret, flagJWTSecrets, err,
 			)
 		}
 
@@ -495,7 +558,11 @@ func serve(ctx context.Context, cmd *cli.Command) error {
 
 		metadataSource = databaseMetadataSource
 	} else {
-		metadataSource = source.NewFileMetadataSource(cmd.String(flagMetadataPath))
+		metadataSource = source.NewFileMetadataSource(
+			cmd.String(flagMetadataPath),
+			metadataFileDebounce,
+			logger,
+		)
 	}
 
 	defer metadataSource.Close()
@@ -528,8 +595,12 @@ func serve(ctx context.Context, cmd *cli.Command) error {
 	ctrl, err := controller.New(
 		ctx,
 		cmd.Duration(flagSubscriptionPollInterval),
+		cmd.Bool(flagSubscriptionCoordination),
+		cmd.StringSlice(flagSessionSettingsSources),
 		cmd.String(flagAdminSecret),
 		cmd.Bool(flagDevMode),
+		cmd.Bool(flagDisableIntrospection),
+		cmd.StringSlice(flagRejectBreakingSchemaChanges),
 		jwtAuth,
 		metadataSource,
 		logger,
@@ -102,8 +102,8 @@ func buildRealServeRouter(t *testing.T, ctrl *controller.Controller) *gin.Engine
 	err := cmd.Run(context.Background(), []string{
 		"serve",
 		"--" + flagAdminSecret, routerTestAdminSecret,
-		// jwt-secret is required by serveFlags; getRouter does not read it (the
-		// authenticator is injected) but the command will not run without it.
+		// getRouter does not read jwt-secret (the authenticator is injected);
+		// it is passed so the flag set mirrors a real invocation.
 		"--" + flagJWTSecret, `{"type":"HS256","key":"router-test-jwt-secret-32-bytes-long!"}`,
 		"--" + flagCORSAllowedOrigins, "https://app.example.com",
 	})
@@ -194,3 +194,50 @@ func TestGetRouter_MetadataGatedByValidator(t *testing.T) {
 		)
 	}
 }
+
+// TestGetRouter_ExplainIsAdminOnly checks that getRouter mounts POST
+// /v1/graphql/explain outside the OpenAPI validator and that the handler
+// itself gates it on the admin secret: anonymous callers get 401, admins reach
+// the explain handler, which rejects the in-memory source as not explainable.
+func TestGetRouter_ExplainIsAdminOnly(t *testing.T) {
+	t.Parallel()
+
+	router := buildRealServeRouter(t, newRouterTestController(t))
+
+	cases := []struct {
+		name     string
+		admin    bool
+		wantCode int
+		wantBody string
+	}{
+		{name: "anonymous", admin: false, wantCode: http.StatusUnauthorized, wantBody: `"access-denied"`},
+		{name: "admin", admin: true, wantCode: http.StatusBadRequest, wantBody: `"not-supported"`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(
+				http.MethodPost, "/v1/graphql/explain",
+				strings.NewReader(`{"query":{"query":"{ users { id name } }"}}`),
+			)
+			req.Header.Set("Content-Type", "application/json")
+
+			if tc.admin {
+				req.Header.Set("X-Hasura-Admin-Secret", routerTestAdminSecret)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantCode {
+				t.Fatalf("status = %d, want %d (body = %s)", rec.Code, tc.wantCode, rec.Body.String())
+			}
+
+			if !strings.Contains(rec.Body.String(), tc.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", rec.Body.String(), tc.wantBody)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ func supportedServeRoutes() []struct{ Method, Path string } {
 		{http.MethodPost, "/v1/metadata"},
 		{http.MethodPost, "/v1/graphql"},
 		{http.MethodGet, "/v1/graphql"},
+		{http.MethodPost, "/v1/graphql/explain"},
 		{http.MethodPost, "/v1"},
 		{http.MethodGet, "/v1"},
 	}
@@ -86,6 +87,7 @@ func buildServeRouter(t *testing.T, proxy http.Handler) *gin.Engine {
 
 	router.POST("/v1/graphql", handled)
 	router.GET("/v1/graphql", handled)
+	router.POST("/v1/graphql/explain", handled)
 	router.POST("/v1", handled)
 	router.GET("/v1", handled)
 
@@ -10,6 +10,7 @@ import (
 	"maps"
 	"slices"
 
+	"github.com/nhost/nhost/services/constellation/connector/federation"
 	"github.com/nhost/nhost/services/constellation/connector/relationships"
 	"github.com/nhost/nhost/services/constellation/connector/schemamerge"
 	"github.com/nhost/nhost/services/constellation/graph"
@@ -59,7 +60,7 @@ func New(
 //
 // The maps are owned by the Composer that produced this Result: callers must
 // treat them as read-only. Mutating them is undefined behaviour, and because
-// the value is small (four map headers) Result is returned and embedded by
+// the value is small (five map headers) Result is returned and embedded by
 // value so the ownership contract is not blurred by pointer aliasing.
 type Result struct {
 	// SchemaDocs is keyed by role name.
@@ -73,6 +74,10 @@ type Result struct {
 	// TypeToConnectors maps each GraphQL type name to the names of the
 	// connectors that own it; used by the controller to route operations.
 	TypeToConnectors map[string][]string
+	// Entities maps role name → GraphQL type name → the Apollo Federation
+	// entity the role may resolve through _entities. Roles without entities
+	// are absent.
+	Entities map[string]map[string]federation.Target
 }
 
 // Compose collects schemas from all providers, adds remote relationship
@@ -93,6 +98,7 @@ func (c *Composer) Compose(
 		ValidatedSchemas: make(map[string]*ast.Schema),
 		FieldToConnector: make(map[string]string),
 		TypeToConnectors: make(map[string][]string),
+		Entities:         make(map[string]map[string]federation.Target),
 	}
 
 	connectorNames := make([]string, 0, len(roleSchemas))
@@ -164,6 +170,17 @@ func (c *Composer) composeRole(
 		)
 	}
 
+	entities, err := federation.Augment(
+		&combinedSchema, c.federationTargets(role, connectorNames, roleSchemas),
+	)
+	if err != nil {
+		c.inconsistencies.RecordRole(
+			ctx, logger,
+			role,
+			fmt.Sprintf("apollo federation disabled for role: %v", err),
+		)
+	}
+
 	schemaDoc, validatedSchema, err := schemamerge.BuildValidatedSchema(&combinedSchema, role)
 	if err != nil {
 		c.inconsistencies.RecordRole(
@@ -181,9 +198,46 @@ func (c *Composer) composeRole(
 	maps.Copy(result.FieldToConnector, fieldToConnector)
 	maps.Copy(result.TypeToConnectors, typeToConnectors)
 
+	if len(entities) > 0 {
+		byType := make(map[string]federation.Target, len(entities))
+		for _, entity := range entities {
+			byType[entity.TypeName] = entity
+		}
+
+		result.Entities[role] = byType
+	}
+
 	logger.InfoContext(ctx, "validated schema for role", slog.String("role", role))
 }
 
+// federationTargets collects the federation entities role may resolve from
+// every provider implementing federation.Provider, bound to the provider's
+// connector name. Providers without a schema for role are skipped.
+func (c *Composer) federationTargets(
+	role string,
+	connectorNames []string,
+	roleSchemas map[string]map[string]*graph.Schema,
+) []federation.Target {
+	var targets []federation.Target
+
+	for _, connName := range connectorNames {
+		if _, ok := roleSchemas[connName][role]; !ok {
+			continue
+		}
+
+		provider, ok := c.providers[connName].(federation.Provider)
+		if !ok {
+			continue
+		}
+
+		for _, entity := range provider.FederationEntities(role) {
+			targets = append(targets, federation.Target{Entity: entity, Connector: connName})
+		}
+	}
+
+	return targets
+}
+
 func (c *Composer) collectSchemas(
 	ctx context.Context,
 	logger *slog.Logger,
@@ -316,28 +370,37 @@ func dbRelationshipSpec(
 	}
 
 	if rel.Definition.ToRemoteSchema != nil {
-		toRS := rel.Definition.ToRemoteSchema
-		path := metadata.ExtractRemoteFieldPath(toRS.RemoteField)
+		return toRemoteSchemaSpec(dbName, sourceType, rel.Name, rel.Definition.ToRemoteSchema)
+	}
 
-		if len(path) == 0 {
-			return relationships.RelationshipSpec{}, false //nolint:exhaustruct
-		}
+	return relationships.RelationshipSpec{}, false //nolint:exhaustruct
+}
 
-		return relationships.RelationshipSpec{
-			SourceConnector:   dbName,
-			SourceType:        sourceType,
-			Name:              rel.Name,
-			TargetConnector:   toRS.RemoteSchema,
-			TargetIdentifier:  path[0].FieldName,
-			IsArray:           false,
-			WithSQLArgs:       false,
-			RemoteFieldName:   path[0].FieldName,
-			BoundArguments:    path[0].Arguments,
-			ObjectDescription: "",
-		}, true
+// toRemoteSchemaSpec translates a to_remote_schema relationship, rooted in a
+// database table (db→rs) or a remote-schema type (rs→rs), into a
+// RelationshipSpec importing the first remote field of the path. Returns
+// ok=false if the remote field path is empty.
+func toRemoteSchemaSpec(
+	sourceConnector, sourceType, name string,
+	toRS *metadata.ToRemoteSchemaRelationship,
+) (relationships.RelationshipSpec, bool) {
+	path := metadata.ExtractRemoteFieldPath(toRS.RemoteField)
+	if len(path) == 0 {
+		return relationships.RelationshipSpec{}, false //nolint:exhaustruct
 	}
 
-	return relationships.RelationshipSpec{}, false //nolint:exhaustruct
+	return relationships.RelationshipSpec{
+		SourceConnector:   sourceConnector,
+		SourceType:        sourceType,
+		Name:              name,
+		TargetConnector:   toRS.RemoteSchema,
+		TargetIdentifier:  path[0].FieldName,
+		IsArray:           false,
+		WithSQLArgs:       false,
+		RemoteFieldName:   path[0].FieldName,
+		BoundArguments:    path[0].Arguments,
+		ObjectDescription: "",
+	}, true
 }
 
 // dbToDBObjectDescription returns the description text Hasura emits for a
@@ -353,9 +416,10 @@ func dbToDBObjectDescription(isArray bool) string {
 }
 
 // rsRelationshipSpec translates a metadata RemoteSchemaRelationshipDef
-// (rooted in a remote-schema type) into a single RelationshipSpec. Returns
-// ok=false if the relationship has no ToSource definition (rs→rs is not
-// supported and rs→db is currently the only outbound shape).
+// (rooted in a remote-schema type) into a single RelationshipSpec: rs→db for
+// a ToSource definition, rs→rs for a ToRemoteSchema one. Returns ok=false if
+// the relationship has neither a valid ToSource nor a usable ToRemoteSchema
+// definition.
 //
 // Object descriptions are intentionally left empty: Hasura does not synthesise
 // an "An object relationship" string for rs→db object relationships the way
@@ -364,6 +428,10 @@ func rsRelationshipSpec(
 	rsName, typeName string,
 	rel metadata.RemoteSchemaRelationshipDef,
 ) (relationships.RelationshipSpec, bool) {
+	if rel.Definition.ToRemoteSchema != nil {
+		return toRemoteSchemaSpec(rsName, typeName, rel.Name, rel.Definition.ToRemoteSchema)
+	}
+
 	if rel.Definition.ToSource == nil {
 		return relationships.RelationshipSpec{}, false //nolint:exhaustruct
 	}
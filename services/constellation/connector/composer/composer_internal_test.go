@@ -3,6 +3,8 @@ package composer
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/nhost/services/constellation/connector/relationships"
 	"github.com/nhost/nhost/services/constellation/graph"
 	"github.com/nhost/nhost/services/constellation/metadata"
 )
@@ -365,6 +367,53 @@ func TestRSRelationshipSpec(t *testing.T) {
 	}
 }
 
+func TestRSRelationshipSpec_ToRemoteSchema(t *testing.T) {
+	t.Parallel()
+
+	rel := metadata.RemoteSchemaRelationshipDef{
+		Name: "customer",
+		Definition: metadata.RemoteSchemaRelationshipDefinition{
+			ToSource: nil,
+			ToRemoteSchema: &metadata.ToRemoteSchemaRelationship{
+				RemoteSchema: "crm",
+				LHSFields:    []string{"customerId"},
+				RemoteField: map[string]metadata.RemoteFieldCall{
+					"customer": {
+						Arguments: map[string]string{"id": "$customerId"},
+						Field:     nil,
+					},
+				},
+			},
+		},
+	}
+
+	spec, ok := rsRelationshipSpec("billing", "Invoice", rel)
+	if !ok {
+		t.Fatal("rsRelationshipSpec ok = false, want true")
+	}
+
+	want := relationships.RelationshipSpec{
+		SourceConnector:   "billing",
+		SourceType:        "Invoice",
+		Name:              "customer",
+		TargetConnector:   "crm",
+		TargetIdentifier:  "customer",
+		IsArray:           false,
+		WithSQLArgs:       false,
+		RemoteFieldName:   "customer",
+		BoundArguments:    map[string]string{"id": "$customerId"},
+		ObjectDescription: "",
+	}
+	if diff := cmp.Diff(want, spec); diff != "" {
+		t.Errorf("rsRelationshipSpec mismatch (-want +got):\n%s", diff)
+	}
+
+	rel.Definition.ToRemoteSchema.RemoteField = nil
+	if _, ok := rsRelationshipSpec("billing", "Invoice", rel); ok {
+		t.Error("rsRelationshipSpec ok = true for an empty remote field, want false")
+	}
+}
+
 // TestRelationshipSpecs_SkipsEnumAndMissingConnector verifies the two
 // composer-level guards that the M3 refactor moved out of relationships.go:
 // IsEnum tables are skipped, and tables whose database has no provider in the
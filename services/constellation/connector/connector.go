@@ -9,10 +9,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 
 	"github.com/nhost/nhost/services/constellation/connector/composer"
 	"github.com/nhost/nhost/services/constellation/connector/customization"
 	"github.com/nhost/nhost/services/constellation/connector/remoteschema"
+	"github.com/nhost/nhost/services/constellation/connector/sql/mysql"
 	"github.com/nhost/nhost/services/constellation/connector/sql/postgres"
 	"github.com/nhost/nhost/services/constellation/connector/sql/sqlite"
 	"github.com/nhost/nhost/services/constellation/graph"
@@ -106,21 +108,41 @@ type RemoteSchemaFactory func(
 ) (Connector, error)
 
 // defaultDBFactories returns the production registry of database factories
-// keyed by dbMeta.Kind.
-func defaultDBFactories() map[string]DBFactory {
+// keyed by dbMeta.Kind. coordinateSubscriptions enables cross-replica
+// subscription coordination on Postgres sources (see
+// postgres.WithSubscriptionCoordination); the Postgres sources named in
+// sessionSettingsSources publish the caller's session as hasura.user (see
+// postgres.WithSessionSettings).
+func defaultDBFactories(
+	coordinateSubscriptions bool, sessionSettingsSources []string,
+) map[string]DBFactory {
+	var postgresOpts []postgres.Option
+	if coordinateSubscriptions {
+		postgresOpts = append(postgresOpts, postgres.WithSubscriptionCoordination())
+	}
+
 	return map[string]DBFactory{
-		"postgres": newPostgresConnector,
+		"postgres": newPostgresConnector(sessionSettingsSources, postgresOpts...),
 		"sqlite":   newSQLiteConnector,
+		"mysql":    newMySQLConnector,
 	}
 }
 
 // defaultRemoteSchemaFactory returns a RemoteSchemaFactory that delegates to
 // remoteschema.New with the supplied HTTPDoer. Passing nil preserves the
 // production default: remoteschema.New falls back to an *http.Client whose
-// timeout is taken from meta.Definition.TimeoutSeconds.
-func defaultRemoteSchemaFactory(doer remoteschema.HTTPDoer) RemoteSchemaFactory {
+// timeout is taken from meta.Definition.TimeoutSeconds. A non-nil notify
+// enables background re-introspection (see remoteschema.WithSchemaRefreshes).
+func defaultRemoteSchemaFactory(
+	doer remoteschema.HTTPDoer,
+	notify func(remoteschema.SchemaRefresh),
+) RemoteSchemaFactory {
 	return func(ctx context.Context, rsMeta *metadata.RemoteSchemaMetadata) (Connector, error) {
-		return remoteschema.New(ctx, rsMeta, doer)
+		if notify == nil {
+			return remoteschema.New(ctx, rsMeta, doer)
+		}
+
+		return remoteschema.New(ctx, rsMeta, doer, remoteschema.WithSchemaRefreshes(notify))
 	}
 }
 
@@ -129,7 +151,14 @@ type buildConfig struct {
 	dbFactories         map[string]DBFactory
 	remoteSchemaFactory RemoteSchemaFactory
 	httpDoer            remoteschema.HTTPDoer
+	schemaRefreshes     func(remoteschema.SchemaRefresh)
 	inconsistencies     *metadata.Inconsistencies
+	// connectors are registered as-is in place of building their sources.
+	connectors map[string]Connector
+	// coordinateSubscriptions is applied to the default database factories.
+	coordinateSubscriptions bool
+	// sessionSettingsSources is applied to the default database factories.
+	sessionSettingsSources []string
 }
 
 // Option customises BuildConnectorsFromMetadata. Production callers pass none;
@@ -162,6 +191,34 @@ func WithHTTPDoer(doer remoteschema.HTTPDoer) Option {
 	}
 }
 
+// WithSchemaRefreshes makes the default remote-schema factory re-introspect
+// remote schemas that set introspection_cache_ttl and report the outcomes to
+// notify. It has no effect when WithRemoteSchemaFactory is also supplied.
+func WithSchemaRefreshes(notify func(remoteschema.SchemaRefresh)) Option {
+	return func(c *buildConfig) {
+		c.schemaRefreshes = notify
+	}
+}
+
+// WithSubscriptionCoordination makes the default Postgres factory share
+// live-query subscription polls across the replicas serving the same source.
+// It has no effect when WithDBFactories is also supplied.
+func WithSubscriptionCoordination(enabled bool) Option {
+	return func(c *buildConfig) {
+		c.coordinateSubscriptions = enabled
+	}
+}
+
+// WithSessionSettings makes the default Postgres factory publish the caller's
+// session variables as hasura.user in every transaction of the named sources,
+// for row-level security policies and triggers. It has no effect when
+// WithDBFactories is also supplied.
+func WithSessionSettings(sources []string) Option {
+	return func(c *buildConfig) {
+		c.sessionSettingsSources = sources
+	}
+}
+
 // WithInconsistencies routes per-source / per-role build failures into the
 // supplied collector instead of an internally-allocated one. The collector
 // itself stays with the caller; BuildResult.Inconsistencies always exposes
@@ -172,6 +229,16 @@ func WithInconsistencies(inc *metadata.Inconsistencies) Option {
 	}
 }
 
+// WithConnectors registers the supplied connectors, keyed by source name, in
+// place of building those sources, so a rebuild re-introspects only the rest.
+// Only sources the metadata still declares are registered. The connectors
+// stay owned by the caller, and no inconsistencies are recorded for them.
+func WithConnectors(connectors map[string]Connector) Option {
+	return func(c *buildConfig) {
+		c.connectors = connectors
+	}
+}
+
 // BuildConnectorsFromMetadata creates connectors from metadata configuration
 // and builds validated GraphQL schemas for each role. Per-source failures
 // (unsupported kind, factory error, customization error, GetSchema error) and
@@ -185,17 +252,25 @@ func BuildConnectorsFromMetadata(
 	opts ...Option,
 ) (*BuildResult, error) {
 	cfg := &buildConfig{
-		dbFactories:         defaultDBFactories(),
-		remoteSchemaFactory: nil,
-		httpDoer:            nil,
-		inconsistencies:     nil,
+		dbFactories:             nil,
+		remoteSchemaFactory:     nil,
+		httpDoer:                nil,
+		schemaRefreshes:         nil,
+		inconsistencies:         nil,
+		connectors:              nil,
+		coordinateSubscriptions: false,
+		sessionSettingsSources:  nil,
 	}
 	for _, opt := range opts {
 		opt(cfg)
 	}
 
+	if cfg.dbFactories == nil {
+		cfg.dbFactories = defaultDBFactories(cfg.coordinateSubscriptions, cfg.sessionSettingsSources)
+	}
+
 	if cfg.remoteSchemaFactory == nil {
-		cfg.remoteSchemaFactory = defaultRemoteSchemaFactory(cfg.httpDoer)
+		cfg.remoteSchemaFactory = defaultRemoteSchemaFactory(cfg.httpDoer, cfg.schemaRefreshes)
 	}
 
 	if cfg.inconsistencies == nil {
@@ -221,8 +296,9 @@ func BuildConnectorsFromMetadata(
 	}, nil
 }
 
-// buildRemoteSchemaConnectors instantiates every remote-schema connector,
-// applies any schema customization, and registers it in connectors. Sources
+// buildRemoteSchemaConnectors instantiates every remote-schema connector not
+// supplied through WithConnectors, applies any schema customization, and
+// registers it in connectors. Sources
 // that fail to build are recorded in cfg.inconsistencies and skipped.
 func (cfg *buildConfig) buildRemoteSchemaConnectors(
 	ctx context.Context,
@@ -233,6 +309,12 @@ func (cfg *buildConfig) buildRemoteSchemaConnectors(
 	for i := range meta.RemoteSchemas {
 		rsMeta := &meta.RemoteSchemas[i]
 
+		if conn, ok := cfg.connectors[rsMeta.Name]; ok {
+			connectors[rsMeta.Name] = conn
+
+			continue
+		}
+
 		raw, err := cfg.remoteSchemaFactory(ctx, rsMeta)
 		if err != nil {
 			cfg.inconsistencies.RecordRemoteSchema(
@@ -268,8 +350,9 @@ func (cfg *buildConfig) buildRemoteSchemaConnectors(
 	}
 }
 
-// buildDatabaseConnectors instantiates every database connector by kind,
-// applies any source-level customization, and registers it in connectors.
+// buildDatabaseConnectors instantiates every database connector not supplied
+// through WithConnectors by kind, applies any source-level customization, and
+// registers it in connectors.
 // Sources that fail to build are recorded in cfg.inconsistencies and skipped.
 func (cfg *buildConfig) buildDatabaseConnectors(
 	ctx context.Context,
@@ -280,6 +363,12 @@ func (cfg *buildConfig) buildDatabaseConnectors(
 	for i := range meta.Databases {
 		dbMeta := &meta.Databases[i]
 
+		if conn, ok := cfg.connectors[dbMeta.Name]; ok {
+			connectors[dbMeta.Name] = conn
+
+			continue
+		}
+
 		factory, ok := cfg.dbFactories[dbMeta.Kind]
 		if !ok {
 			cfg.inconsistencies.RecordDatabase(
@@ -342,7 +431,37 @@ func resolveDBURL(dbMeta *metadata.DatabaseMetadata) (string, error) {
 	return dbURL, nil
 }
 
-func newPostgresConnector( //nolint:ireturn,nolintlint
+// newPostgresConnector returns the Postgres factory. Sources named in
+// sessionSettingsSources get postgres.WithSessionSettings on top of opts.
+func newPostgresConnector(sessionSettingsSources []string, opts ...postgres.Option) DBFactory {
+	return func(
+		ctx context.Context,
+		dbMeta *metadata.DatabaseMetadata,
+		inconsistencies *metadata.Inconsistencies,
+		logger *slog.Logger,
+	) (Connector, error) {
+		dbURL, err := resolveDBURL(dbMeta)
+		if err != nil {
+			return nil, fmt.Errorf("creating postgres connector for %s: %w", dbMeta.Name, err)
+		}
+
+		sourceOpts := opts
+		if slices.Contains(sessionSettingsSources, dbMeta.Name) {
+			sourceOpts = append(slices.Clone(opts), postgres.WithSessionSettings())
+		}
+
+		backend, err := postgres.New(ctx, dbURL, dbMeta, inconsistencies, logger, sourceOpts...)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to create postgres connector for %s: %w", dbMeta.Name, err,
+			)
+		}
+
+		return backend, nil
+	}
+}
+
+func newSQLiteConnector( //nolint:ireturn,nolintlint
 	ctx context.Context,
 	dbMeta *metadata.DatabaseMetadata,
 	inconsistencies *metadata.Inconsistencies,
@@ -350,20 +469,20 @@ func newPostgresConnector( //nolint:ireturn,nolintlint
 ) (Connector, error) {
 	dbURL, err := resolveDBURL(dbMeta)
 	if err != nil {
-		return nil, fmt.Errorf("creating postgres connector for %s: %w", dbMeta.Name, err)
+		return nil, fmt.Errorf("creating sqlite connector for %s: %w", dbMeta.Name, err)
 	}
 
-	backend, err := postgres.New(ctx, dbURL, dbMeta, inconsistencies, logger)
+	backend, err := sqlite.New(ctx, dbURL, dbMeta, inconsistencies, logger)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"failed to create postgres connector for %s: %w", dbMeta.Name, err,
+			"failed to create sqlite connector for %s: %w", dbMeta.Name, err,
 		)
 	}
 
 	return backend, nil
 }
 
-func newSQLiteConnector( //nolint:ireturn,nolintlint
+func newMySQLConnector( //nolint:ireturn,nolintlint
 	ctx context.Context,
 	dbMeta *metadata.DatabaseMetadata,
 	inconsistencies *metadata.Inconsistencies,
@@ -371,13 +490,13 @@ func newSQLiteConnector( //nolint:ireturn,nolintlint
 ) (Connector, error) {
 	dbURL, err := resolveDBURL(dbMeta)
 	if err != nil {
-		return nil, fmt.Errorf("creating sqlite connector for %s: %w", dbMeta.Name, err)
+		return nil, fmt.Errorf("creating mysql connector for %s: %w", dbMeta.Name, err)
 	}
 
-	backend, err := sqlite.New(ctx, dbURL, dbMeta, inconsistencies, logger)
+	backend, err := mysql.New(ctx, dbURL, dbMeta, inconsistencies, logger)
 	if err != nil {
 		return nil, fmt.Errorf(
-			"failed to create sqlite connector for %s: %w", dbMeta.Name, err,
+			"failed to create mysql connector for %s: %w", dbMeta.Name, err,
 		)
 	}
 
@@ -25,21 +25,23 @@ import (
 )
 
 // errFactoryBoom and errFactoryBang are test sentinel errors used to verify
-// error propagation from factory failures.
+// error propagation from factory failures; errStubSchema is returned by
+// stubConnector.GetSchema.
 var (
 	errFactoryBoom = errors.New("boom")
 	errFactoryBang = errors.New("bang")
+	errStubSchema  = errors.New("stub schema unavailable")
 )
 
 var update = flag.Bool("update", false, "update golden files") //nolint:gochecknoglobals
 
 // stubConnector is a no-op Connector used by the customization-failure tests
-// so applyCustomization is reached without needing a real factory. None of
-// its methods get called in those paths — applyCustomization rejects the
-// configuration before the connector is exercised.
+// so applyCustomization is reached without needing a real factory. Its
+// GetSchema fails, which makes newCustomizedConnector fail while customizing
+// the schema; none of its other methods get called in those paths.
 type stubConnector struct{}
 
-func (stubConnector) GetSchema() (map[string]*graph.Schema, error) { return nil, nil } //nolint:nilnil
+func (stubConnector) GetSchema() (map[string]*graph.Schema, error) { return nil, errStubSchema }
 
 func (stubConnector) Execute(
 	context.Context,
@@ -498,6 +500,75 @@ func TestBuildConnectorsFromMetadata_InjectedFactories(t *testing.T) {
 	}
 }
 
+// TestBuildConnectorsFromMetadata_WithConnectors verifies that sources
+// supplied through WithConnectors are registered without calling their
+// factory, and that supplied connectors the metadata no longer declares are
+// left out.
+func TestBuildConnectorsFromMetadata_WithConnectors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	keptConn := mock.NewMockConnector(ctrl)
+	keptConn.EXPECT().GetSchema().Return(map[string]*graph.Schema{
+		"admin": newMinimalSchema("users", "User"),
+	}, nil)
+
+	builtConn := mock.NewMockConnector(ctrl)
+	builtConn.EXPECT().GetSchema().Return(map[string]*graph.Schema{
+		"admin": newMinimalSchema("orders", "Order"),
+	}, nil)
+
+	var built []string
+
+	dbFactory := func(
+		_ context.Context,
+		dbMeta *metadata.DatabaseMetadata,
+		_ *metadata.Inconsistencies,
+		_ *slog.Logger,
+	) (connector.Connector, error) {
+		built = append(built, dbMeta.Name)
+
+		return builtConn, nil
+	}
+
+	meta := &metadata.Metadata{
+		Databases: []metadata.DatabaseMetadata{
+			{Name: "kept", Kind: "postgres", Configuration: metadata.DatabaseConfiguration{}, Tables: nil, Functions: nil},
+			{Name: "changed", Kind: "postgres", Configuration: metadata.DatabaseConfiguration{}, Tables: nil, Functions: nil},
+		},
+		RemoteSchemas: nil,
+	}
+
+	result, err := connector.BuildConnectorsFromMetadata(
+		t.Context(), meta, slog.Default(),
+		connector.WithDBFactories(map[string]connector.DBFactory{"postgres": dbFactory}),
+		connector.WithConnectors(map[string]connector.Connector{
+			"kept":    keptConn,
+			"dropped": mock.NewMockConnector(ctrl),
+		}),
+	)
+	if err != nil {
+		t.Fatalf("BuildConnectorsFromMetadata: %v", err)
+	}
+
+	if len(built) != 1 || built[0] != "changed" {
+		t.Errorf("db factory built %v, want [changed]", built)
+	}
+
+	if result.Connectors["kept"] != keptConn {
+		t.Error("expected the supplied connector stored under 'kept'")
+	}
+
+	if result.Connectors["changed"] != builtConn {
+		t.Error("expected the built connector stored under 'changed'")
+	}
+
+	if _, ok := result.Connectors["dropped"]; ok {
+		t.Error("expected the undeclared 'dropped' connector to be left out")
+	}
+}
+
 // TestBuildConnectorsFromMetadata_FactoryInconsistencies verifies that
 // factory failures from both paths are recorded as inconsistencies and skipped
 // rather than aborting the build.
@@ -568,10 +639,9 @@ func TestBuildConnectorsFromMetadata_FactoryInconsistencies(t *testing.T) {
 			wantSub:  "failed to create remote schema connector",
 		},
 		{
-			// The database factory returns a usable Connector, but the
-			// source metadata declares a per-type FieldNames customization
-			// that newCustomizedConnector rejects. The whole source is
-			// recorded as an inconsistency and dropped.
+			// The database factory returns a Connector whose schema cannot
+			// be read, so customizing it fails in newCustomizedConnector.
+			// The whole source is recorded as an inconsistency and dropped.
 			name: "db_customization_error",
 			meta: &metadata.Metadata{
 				RemoteSchemas: nil,
@@ -580,9 +650,7 @@ func TestBuildConnectorsFromMetadata_FactoryInconsistencies(t *testing.T) {
 						Name: "default",
 						Kind: "postgres",
 						Customization: metadata.Customization{
-							FieldNames: []metadata.FieldNameCustomization{
-								{ParentType: "users", Prefix: "x_"},
-							},
+							RootFieldsNamespace: "x",
 						},
 						Configuration: metadata.DatabaseConfiguration{},
 						Tables:        nil,
@@ -604,11 +672,11 @@ func TestBuildConnectorsFromMetadata_FactoryInconsistencies(t *testing.T) {
 			},
 			wantKind: metadata.InconsistencyKindDatabase,
 			wantName: "default",
-			wantSub:  "per-type field_names customization is not supported",
+			wantSub:  "getting schema to customize",
 		},
 		{
 			// Same trick on the remote-schema side: the factory returns a
-			// usable Connector but the customization config is rejected.
+			// Connector whose schema cannot be customized.
 			name: "remote_schema_customization_error",
 			meta: &metadata.Metadata{
 				Databases: nil,
@@ -617,9 +685,7 @@ func TestBuildConnectorsFromMetadata_FactoryInconsistencies(t *testing.T) {
 						Name: "rs",
 						Definition: metadata.RemoteSchemaDefinition{
 							Customization: metadata.Customization{
-								FieldNames: []metadata.FieldNameCustomization{
-									{ParentType: "Query", Prefix: "x_"},
-								},
+								RootFieldsNamespace: "x",
 							},
 						},
 						Comment:             "",
@@ -637,7 +703,7 @@ func TestBuildConnectorsFromMetadata_FactoryInconsistencies(t *testing.T) {
 			},
 			wantKind: metadata.InconsistencyKindRemoteSchema,
 			wantName: "rs",
-			wantSub:  "per-type field_names customization is not supported",
+			wantSub:  "getting schema to customize",
 		},
 	}
 
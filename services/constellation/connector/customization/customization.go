@@ -1,7 +1,7 @@
 // Package customization applies Hasura-style GraphQL schema customization
-// (root-field namespacing, root-field prefix/suffix, type renaming and
-// per-type field renaming) to a connector's schema, and reverses it on the
-// execution path.
+// (root-field namespacing, root-field prefix/suffix, type renaming, per-type
+// field renaming and the graphql-default naming convention) to a connector's
+// schema, and reverses it on the execution path.
 //
 // The package is deliberately connector-agnostic: it operates only on
 // graph.Schema and gqlparser operation/response values, driven by a normalized
@@ -24,6 +24,7 @@ import (
 
 	"github.com/nhost/nhost/services/constellation/graph"
 	"github.com/nhost/nhost/services/constellation/metadata"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 // builtinScalars are the GraphQL spec scalars that must never be renamed when
@@ -57,6 +58,11 @@ type renamer struct {
 	// in rewriteRoots, so rewriteDefinitionNames knows not to rename them again
 	// (they are minted already in final form).
 	createdWrappers map[string]struct{}
+	// scalars and outputEnums hold native type names the naming convention
+	// leaves alone: scalars keep their type name, and enums used as an output
+	// type keep their values (see casedEnumValue).
+	scalars     map[string]struct{}
+	outputEnums map[string]struct{}
 }
 
 func newRenamer(s *graph.Schema, cfg metadata.Customization, flavor Flavor) *renamer {
@@ -68,8 +74,10 @@ func newRenamer(s *graph.Schema, cfg metadata.Customization, flavor Flavor) *ren
 		addOwned(t.Name)
 	}
 
+	scalars := make(map[string]struct{}, len(s.Scalars))
 	for _, t := range s.Scalars {
 		addOwned(t.Name)
+		scalars[t.Name] = struct{}{}
 	}
 
 	for _, t := range s.Enums {
@@ -100,6 +108,8 @@ func newRenamer(s *graph.Schema, cfg metadata.Customization, flavor Flavor) *ren
 		fieldRules:      fieldRules,
 		noRename:        sharedTypeNames(s, flavor),
 		createdWrappers: make(map[string]struct{}),
+		scalars:         scalars,
+		outputEnums:     outputEnumNames(s),
 	}
 }
 
@@ -135,17 +145,18 @@ func sharedTypeNames(s *graph.Schema, flavor Flavor) map[string]struct{} {
 
 // typeName renames a type definition's own name. Mapping wins over
 // prefix/suffix. Names in noRename (shared types under the database flavor)
-// are returned unchanged.
+// skip the prefix/suffix. The naming convention applies on top of everything
+// but an explicit mapping.
 func (r *renamer) typeName(name string) string {
 	if _, ok := r.noRename[name]; ok {
-		return name
+		return r.casedTypeName(name, name)
 	}
 
 	if mapped, ok := r.cfg.TypeNamesMapping[name]; ok {
 		return mapped
 	}
 
-	return r.cfg.TypeNamesPrefix + name + r.cfg.TypeNamesSuffix
+	return r.casedTypeName(name, r.cfg.TypeNamesPrefix+name+r.cfg.TypeNamesSuffix)
 }
 
 // typeRef renames a type reference, leaving builtin scalars and any name the
@@ -179,6 +190,8 @@ func (r *renamer) renameTypeReference(t *graph.Type) {
 // fieldName renames a field on parentType (the field's original parent type
 // name). isRoot adds the root-field prefix/suffix on top of any field_names
 // rule, mirroring how a database namespace prefixes the wrapped root fields.
+// The naming convention is applied last, so a graphql-default source turns
+// "insert_users_one" into "insertUsersOne" after any prefix.
 func (r *renamer) fieldName(parentType, name string, isRoot bool) string {
 	out := name
 
@@ -194,7 +207,7 @@ func (r *renamer) fieldName(parentType, name string, isRoot bool) string {
 		out = r.cfg.RootFieldsPrefix + out + r.cfg.RootFieldsSuffix
 	}
 
-	return out
+	return r.casedFieldName(out)
 }
 
 // Flavor selects the source-specific naming Hasura applies to the namespace
@@ -233,6 +246,19 @@ type Customizer struct {
 	// reverse direction must treat such a fragment as root and strip the
 	// root-field prefix/suffix from its selections. Populated by Apply.
 	wrapperTypes map[string]struct{}
+	// fields maps a customized parent type (object, interface, or input
+	// object) and a customized field name to the native field name, its
+	// customized result type, and its arguments; enumValues maps a customized
+	// enum and value back to the native value; rootParents maps an operation
+	// kind to the customized type holding its root fields. They drive the
+	// per-field reversal of field names, argument names, and literal and
+	// variable values. Populated by Apply.
+	fields      map[string]map[string]fieldNames
+	enumValues  map[string]map[string]string
+	rootParents map[ast.Operation]string
+	// renamesArguments reports whether any argument, input field, or enum
+	// value was renamed, i.e. whether arguments and variables need reversing.
+	renamesArguments bool
 }
 
 // New returns a Customizer for cfg with the given source flavor. The returned
@@ -244,6 +270,9 @@ func New(cfg metadata.Customization, flavor Flavor) *Customizer {
 		typeForward:  make(map[string]string),
 		typeInverse:  make(map[string]string),
 		wrapperTypes: make(map[string]struct{}),
+		fields:       make(map[string]map[string]fieldNames),
+		enumValues:   make(map[string]map[string]string),
+		rootParents:  make(map[ast.Operation]string),
 	}
 }
 
@@ -277,6 +306,8 @@ func (c *Customizer) Apply(s *graph.Schema) *graph.Schema {
 
 	c.recordTypeMaps(r, rootNames)
 
+	natives := snapshotNativeNames(s)
+
 	// Pass A: rename every type reference, and rename field names on
 	// non-root object/interface types. Root types are deferred to pass B
 	// because their fields may be relocated under the namespace wrapper.
@@ -300,6 +331,8 @@ func (c *Customizer) Apply(s *graph.Schema) *graph.Schema {
 	// wrapper (which Apply mints from the root type name).
 	r.rewriteDefinitionNames(s, rootNames)
 
+	c.recordNames(s, natives)
+
 	return s
 }
 
@@ -341,6 +374,7 @@ func (r *renamer) rewriteReferencesAndFieldNames(
 
 			for _, a := range f.Arguments {
 				r.renameTypeReference(a.Type)
+				a.Name = r.casedFieldName(a.Name)
 			}
 
 			if !isRoot {
@@ -359,6 +393,7 @@ func (r *renamer) rewriteReferencesAndFieldNames(
 
 			for _, a := range f.Arguments {
 				r.renameTypeReference(a.Type)
+				a.Name = r.casedFieldName(a.Name)
 			}
 
 			f.Name = r.fieldName(iface.Name, f.Name, false)
@@ -372,6 +407,13 @@ func (r *renamer) rewriteReferencesAndFieldNames(
 	for _, in := range s.Inputs {
 		for _, f := range in.Fields {
 			r.renameTypeReference(f.Type)
+			f.Name = r.casedFieldName(f.Name)
+		}
+	}
+
+	for _, e := range s.Enums {
+		for _, v := range e.Values {
+			v.Name = r.casedEnumValue(e.Name, v.Name)
 		}
 	}
 
@@ -877,3 +877,133 @@ func TestForwardResultRawTypenameViaFragmentSpread(t *testing.T) {
 		t.Errorf("__typename = %v, want LeagueTeam (remapped via fragment spread)", got)
 	}
 }
+
+// fieldNamesCustomizer returns a Customizer with a type prefix and a
+// field_names rule on Team (prefix "team_", mapping name -> displayName),
+// primed via Apply so the renamed fields can be reversed.
+func fieldNamesCustomizer() *customization.Customizer {
+	c := customization.New(metadata.Customization{
+		TypeNamesPrefix: "League",
+		FieldNames: []metadata.FieldNameCustomization{
+			{
+				ParentType: "Team",
+				Prefix:     "team_",
+				Mapping:    map[string]string{"name": "displayName"},
+			},
+		},
+	}, customization.FlavorRemoteSchema)
+	c.Apply(newTestSchema())
+
+	return c
+}
+
+// fieldNamesOperation returns
+//
+//	query { teams { ...teamFields ... on LeagueTeam { label: displayName } __typename } }
+//	fragment teamFields on LeagueTeam { team_id }
+func fieldNamesOperation() (*ast.OperationDefinition, ast.FragmentDefinitionList) {
+	op := &ast.OperationDefinition{
+		Operation: ast.Query,
+		SelectionSet: ast.SelectionSet{
+			field("teams", ast.SelectionSet{
+				&ast.FragmentSpread{Name: "teamFields"},
+				&ast.InlineFragment{
+					TypeCondition: "LeagueTeam",
+					SelectionSet: ast.SelectionSet{
+						&ast.Field{Alias: "label", Name: "displayName"},
+					},
+				},
+				field("__typename", nil),
+			}),
+		},
+	}
+
+	fragments := ast.FragmentDefinitionList{
+		&ast.FragmentDefinition{
+			Name:          "teamFields",
+			TypeCondition: "LeagueTeam",
+			SelectionSet:  ast.SelectionSet{field("team_id", nil)},
+		},
+	}
+
+	return op, fragments
+}
+
+func TestReverseOperationReversesFieldNames(t *testing.T) {
+	t.Parallel()
+
+	op, fragments := fieldNamesOperation()
+
+	native, nativeFragments := fieldNamesCustomizer().ReverseOperation(op, fragments)
+
+	teams, ok := native.SelectionSet[0].(*ast.Field)
+	if !ok || teams.Name != "teams" || teams.Alias != "" {
+		t.Fatalf("root selection = %#v, want unaliased teams", native.SelectionSet[0])
+	}
+
+	if spread, ok := teams.SelectionSet[0].(*ast.FragmentSpread); !ok || spread.Name != "teamFields" {
+		t.Errorf("selection 0 = %#v, want spread of teamFields", teams.SelectionSet[0])
+	}
+
+	inline, ok := teams.SelectionSet[1].(*ast.InlineFragment)
+	if !ok || inline.TypeCondition != "Team" {
+		t.Fatalf("selection 1 = %#v, want inline fragment on Team", teams.SelectionSet[1])
+	}
+
+	// The client's alias wins over the customized name as the response key.
+	if label, ok := inline.SelectionSet[0].(*ast.Field); !ok || label.Name != "name" || label.Alias != "label" {
+		t.Errorf("inline field = %#v, want name aliased label", inline.SelectionSet[0])
+	}
+
+	if typename, ok := teams.SelectionSet[2].(*ast.Field); !ok ||
+		typename.Name != "__typename" || typename.Alias != "" {
+		t.Errorf("selection 2 = %#v, want unaliased __typename", teams.SelectionSet[2])
+	}
+
+	if len(nativeFragments) != 1 || nativeFragments[0].TypeCondition != "Team" {
+		t.Fatalf("fragments = %#v, want teamFields on Team", nativeFragments)
+	}
+
+	// Without a client alias the renamed field is aliased to its customized
+	// name so the response key is preserved.
+	id, ok := nativeFragments[0].SelectionSet[0].(*ast.Field)
+	if !ok || id.Name != "id" || id.Alias != "team_id" {
+		t.Errorf("fragment field = %#v, want id aliased team_id", nativeFragments[0].SelectionSet[0])
+	}
+}
+
+func TestForwardResultKeepsFieldNamesResponseKeys(t *testing.T) {
+	t.Parallel()
+
+	op, fragments := fieldNamesOperation()
+
+	// The connector answered the reversed operation, so its keys are the
+	// aliases ReverseOperation kept.
+	native := map[string]any{
+		"teams": jsontext.Value(`[{"team_id":"team-eng","label":"Engineering","__typename":"Team"}]`),
+	}
+
+	out := fieldNamesCustomizer().ForwardResult(native, op, fragments)
+
+	teams, ok := out["teams"].([]any)
+	if !ok || len(teams) != 1 {
+		t.Fatalf("teams not decoded into a one-element list: %#v", out["teams"])
+	}
+
+	want := map[string]any{
+		"team_id":    "team-eng",
+		"label":      "Engineering",
+		"__typename": "LeagueTeam",
+	}
+
+	first, ok := teams[0].(map[string]any)
+	if !ok || len(first) != len(want) {
+		t.Fatalf("team = %#v, want %#v", teams[0], want)
+	}
+
+	for key, value := range want {
+		if first[key] != value {
+			t.Errorf("team[%q] = %v, want %v", key, first[key], value)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package customization
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nhost/nhost/services/constellation/graph"
+	"github.com/nhost/nhost/services/constellation/metadata"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// graphQLDefault reports whether the graphql-default naming convention is in
+// effect: camelCase field and argument names, PascalCase type names, and
+// UPPER_CASE enum values.
+func (r *renamer) graphQLDefault() bool {
+	return r.cfg.NamingConvention == metadata.NamingConventionGraphQLDefault
+}
+
+// casedTypeName applies the naming convention to a (possibly already
+// prefixed/suffixed) type name. Scalars keep their names under every
+// convention, so client scalar mappings (uuid, timestamptz, jsonb, ...) stay
+// valid; native is the un-customized name used to recognise them.
+func (r *renamer) casedTypeName(native, name string) string {
+	if !r.graphQLDefault() {
+		return name
+	}
+
+	if _, isScalar := r.scalars[native]; isScalar {
+		return name
+	}
+
+	return pascalCase(name)
+}
+
+// casedFieldName applies the naming convention to a field or argument name.
+func (r *renamer) casedFieldName(name string) string {
+	if !r.graphQLDefault() {
+		return name
+	}
+
+	return camelCase(name)
+}
+
+// casedEnumValue applies the naming convention to a value of enum, which is
+// the enum's native name. Enums that appear as an output type (enum-table
+// columns) keep their values: those are row data returned verbatim by the
+// connector, so renaming them would make results disagree with the schema.
+func (r *renamer) casedEnumValue(enum, value string) string {
+	if !r.graphQLDefault() {
+		return value
+	}
+
+	if _, isOutput := r.outputEnums[enum]; isOutput {
+		return value
+	}
+
+	return upperSnakeCase(value)
+}
+
+// outputEnumNames returns the (native) names of the enums referenced as the
+// type of an object or interface field.
+func outputEnumNames(s *graph.Schema) map[string]struct{} {
+	enums := make(map[string]struct{}, len(s.Enums))
+	for _, e := range s.Enums {
+		enums[e.Name] = struct{}{}
+	}
+
+	out := make(map[string]struct{})
+
+	collect := func(fields []*graph.Field) {
+		for _, f := range fields {
+			name := namedType(f.Type)
+			if _, ok := enums[name]; ok {
+				out[name] = struct{}{}
+			}
+		}
+	}
+
+	for _, obj := range s.Types {
+		collect(obj.Fields)
+	}
+
+	for _, iface := range s.Interfaces {
+		collect(iface.Fields)
+	}
+
+	return out
+}
+
+// namedType returns the base named type of a (possibly list/non-null) type.
+func namedType(t *graph.Type) string {
+	for t != nil && t.Elem != nil {
+		t = t.Elem
+	}
+
+	if t == nil {
+		return ""
+	}
+
+	return t.NamedType
+}
+
+// camelCase converts a snake_case name to camelCase: "insert_users_one"
+// becomes "insertUsersOne". The first segment is kept as-is, so a name that
+// is already camelCase is unchanged. Names with a leading underscore
+// (operators such as _eq, _and, _set, and __typename) are left untouched.
+func camelCase(name string) string {
+	if name == "" || name[0] == '_' || !strings.Contains(name, "_") {
+		return name
+	}
+
+	parts := strings.Split(name, "_")
+
+	var b strings.Builder
+
+	b.Grow(len(name))
+	b.WriteString(parts[0])
+
+	for _, part := range parts[1:] {
+		b.WriteString(upperFirst(part))
+	}
+
+	return b.String()
+}
+
+// pascalCase converts a snake_case name to PascalCase: "users_bool_exp"
+// becomes "UsersBoolExp".
+func pascalCase(name string) string {
+	parts := strings.Split(name, "_")
+
+	var b strings.Builder
+
+	b.Grow(len(name))
+
+	for _, part := range parts {
+		b.WriteString(upperFirst(part))
+	}
+
+	return b.String()
+}
+
+// upperSnakeCase converts a snake_case or camelCase name to UPPER_SNAKE_CASE:
+// "asc_nulls_first" becomes "ASC_NULLS_FIRST" and "userId" becomes "USER_ID".
+func upperSnakeCase(name string) string {
+	var b strings.Builder
+
+	b.Grow(len(name) + len(name)/4) //nolint:mnd
+
+	prev := rune(0)
+	for _, r := range name {
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) {
+			b.WriteByte('_')
+		}
+
+		b.WriteRune(unicode.ToUpper(r))
+		prev = r
+	}
+
+	return b.String()
+}
+
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return s
+	}
+
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// fieldNames records, for one customized field, the native field name, the
+// customized name of its base result type, and its arguments keyed by
+// customized name. Input-object fields reuse it without arguments.
+type fieldNames struct {
+	native   string
+	typeName string
+	args     map[string]argumentNames
+}
+
+// argumentNames records an argument's native name and the customized name of
+// its base input type.
+type argumentNames struct {
+	native   string
+	typeName string
+}
+
+// nativeNames snapshots the native names of every field, argument, input field
+// and enum value before Apply renames them, keyed by definition pointer so the
+// names can be matched up again once the passes have run.
+type nativeNames struct {
+	fields      map[*graph.Field]string
+	arguments   map[*graph.Argument]string
+	inputFields map[*graph.InputField]string
+	enumValues  map[*graph.EnumValue]string
+}
+
+func snapshotNativeNames(s *graph.Schema) nativeNames {
+	natives := nativeNames{
+		fields:      make(map[*graph.Field]string),
+		arguments:   make(map[*graph.Argument]string),
+		inputFields: make(map[*graph.InputField]string),
+		enumValues:  make(map[*graph.EnumValue]string),
+	}
+
+	addFields := func(fields []*graph.Field) {
+		for _, f := range fields {
+			natives.fields[f] = f.Name
+			for _, a := range f.Arguments {
+				natives.arguments[a] = a.Name
+			}
+		}
+	}
+
+	for _, obj := range s.Types {
+		addFields(obj.Fields)
+	}
+
+	for _, iface := range s.Interfaces {
+		addFields(iface.Fields)
+	}
+
+	for _, in := range s.Inputs {
+		for _, f := range in.Fields {
+			natives.inputFields[f] = f.Name
+		}
+	}
+
+	for _, e := range s.Enums {
+		for _, v := range e.Values {
+			natives.enumValues[v] = v.Name
+		}
+	}
+
+	return natives
+}
+
+// recordNames walks the customized schema and records the customized->native
+// field, argument, input-field and enum-value names the reverse direction
+// needs. Definitions minted by Apply (the namespace field) have no native
+// name and map to themselves.
+func (c *Customizer) recordNames(s *graph.Schema, natives nativeNames) {
+	for _, obj := range s.Types {
+		c.recordFields(obj.Name, obj.Fields, natives)
+	}
+
+	for _, iface := range s.Interfaces {
+		c.recordFields(iface.Name, iface.Fields, natives)
+	}
+
+	for _, in := range s.Inputs {
+		byName := c.parentFields(in.Name)
+
+		for _, f := range in.Fields {
+			native := nativeOr(natives.inputFields, f, f.Name)
+			c.renamesArguments = c.renamesArguments || native != f.Name
+			byName[f.Name] = fieldNames{native: native, typeName: namedType(f.Type), args: nil}
+		}
+	}
+
+	for _, e := range s.Enums {
+		for _, v := range e.Values {
+			native := nativeOr(natives.enumValues, v, v.Name)
+			if native == v.Name {
+				continue
+			}
+
+			values, ok := c.enumValues[e.Name]
+			if !ok {
+				values = make(map[string]string, len(e.Values))
+				c.enumValues[e.Name] = values
+			}
+
+			values[v.Name] = native
+			c.renamesArguments = true
+		}
+	}
+
+	c.recordRootParents(s)
+}
+
+func (c *Customizer) recordFields(parent string, fields []*graph.Field, natives nativeNames) {
+	byName := c.parentFields(parent)
+
+	for _, f := range fields {
+		var args map[string]argumentNames
+		if len(f.Arguments) > 0 {
+			args = make(map[string]argumentNames, len(f.Arguments))
+		}
+
+		for _, a := range f.Arguments {
+			native := nativeOr(natives.arguments, a, a.Name)
+			c.renamesArguments = c.renamesArguments || native != a.Name
+			args[a.Name] = argumentNames{native: native, typeName: namedType(a.Type)}
+		}
+
+		byName[f.Name] = fieldNames{
+			native:   nativeOr(natives.fields, f, f.Name),
+			typeName: namedType(f.Type),
+			args:     args,
+		}
+	}
+}
+
+// nativeOr returns the snapshotted native name of def, or name when def was
+// minted by Apply and has none.
+func nativeOr[K comparable](natives map[K]string, def K, name string) string {
+	if native, ok := natives[def]; ok {
+		return native
+	}
+
+	return name
+}
+
+// parentFields returns the (created on demand) field map for a customized
+// parent type.
+func (c *Customizer) parentFields(parent string) map[string]fieldNames {
+	byName, ok := c.fields[parent]
+	if !ok {
+		byName = make(map[string]fieldNames)
+		c.fields[parent] = byName
+	}
+
+	return byName
+}
+
+// recordRootParents records the type whose fields each operation kind's root
+// selections are resolved against: the root operation type itself, or the
+// namespace wrapper when root fields are namespaced.
+func (c *Customizer) recordRootParents(s *graph.Schema) {
+	roots := []struct {
+		op   ast.Operation
+		name *string
+	}{
+		{op: ast.Query, name: s.QueryType},
+		{op: ast.Mutation, name: s.MutationType},
+		{op: ast.Subscription, name: s.SubscriptionType},
+	}
+
+	for _, root := range roots {
+		if root.name == nil {
+			continue
+		}
+
+		parent := *root.name
+		if c.cfg.RootFieldsNamespace != "" {
+			if ns, ok := c.fields[parent][c.cfg.RootFieldsNamespace]; ok {
+				parent = ns.typeName
+			}
+		}
+
+		c.rootParents[root.op] = parent
+	}
+}
@@ -0,0 +1,367 @@
+package customization_test
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/connector/customization"
+	"github.com/nhost/nhost/services/constellation/graph"
+	"github.com/nhost/nhost/services/constellation/metadata"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func objectField(name string, typ *graph.Type, args ...*graph.Argument) *graph.Field {
+	return &graph.Field{Name: name, Type: typ, Arguments: args}
+}
+
+func argument(name string, typ *graph.Type) *graph.Argument {
+	return &graph.Argument{Name: name, Type: typ}
+}
+
+func inputField(name string, typ *graph.Type) *graph.InputField {
+	return &graph.InputField{Name: name, Type: typ}
+}
+
+func enumType(name string, values ...string) *graph.EnumType {
+	enum := &graph.EnumType{Name: name}
+	for _, v := range values {
+		enum.Values = append(enum.Values, &graph.EnumValue{Name: v})
+	}
+
+	return enum
+}
+
+// newSnakeCaseSchema returns a miniature SQL source schema in Hasura's default
+// (snake_case) naming: a users table with a select, an insert_one mutation,
+// the order_by enum, and an enum-table enum (user_status_enum) used as the
+// type of an output column.
+func newSnakeCaseSchema() *graph.Schema {
+	queryName := "query_root"
+	mutationName := "mutation_root"
+
+	return &graph.Schema{
+		Types: []*graph.ObjectType{
+			{
+				Name: "query_root",
+				Fields: []*graph.Field{
+					objectField("users", graph.NewNonNullListType(graph.NewNonNullType("users")),
+						argument("where", graph.NewNamedType("users_bool_exp")),
+						argument("order_by", graph.NewListType(graph.NewNonNullType("users_order_by"))),
+						argument("distinct_on", graph.NewListType(graph.NewNonNullType("users_select_column"))),
+					),
+				},
+			},
+			{
+				Name: "mutation_root",
+				Fields: []*graph.Field{
+					objectField("insert_users_one", graph.NewNamedType("users"),
+						argument("object", graph.NewNonNullType("users_insert_input")),
+					),
+				},
+			},
+			{
+				Name: "users",
+				Fields: []*graph.Field{
+					objectField("user_id", graph.NewNonNullType("Int")),
+					objectField("created_at", graph.NewNamedType("timestamptz")),
+					objectField("status", graph.NewNamedType("user_status_enum")),
+				},
+			},
+		},
+		Scalars: []*graph.ScalarType{{Name: "timestamptz"}},
+		Enums: []*graph.EnumType{
+			enumType("order_by", "asc", "desc_nulls_last"),
+			enumType("users_select_column", "user_id", "created_at"),
+			enumType("user_status_enum", "active", "on_hold"),
+		},
+		Inputs: []*graph.InputObjectType{
+			{
+				Name: "users_bool_exp",
+				Fields: []*graph.InputField{
+					inputField("user_id", graph.NewNamedType("Int_comparison_exp")),
+					inputField("_and", graph.NewListType(graph.NewNonNullType("users_bool_exp"))),
+				},
+			},
+			{
+				Name:   "Int_comparison_exp",
+				Fields: []*graph.InputField{inputField("_eq", graph.NewNamedType("Int"))},
+			},
+			{
+				Name: "users_order_by",
+				Fields: []*graph.InputField{
+					inputField("created_at", graph.NewNamedType("order_by")),
+				},
+			},
+			{
+				Name: "users_insert_input",
+				Fields: []*graph.InputField{
+					inputField("user_id", graph.NewNamedType("Int")),
+					inputField("status", graph.NewNamedType("user_status_enum")),
+				},
+			},
+		},
+		QueryType:    &queryName,
+		MutationType: &mutationName,
+	}
+}
+
+func graphQLDefaultCustomizer() *customization.Customizer {
+	c := customization.New(metadata.Customization{
+		NamingConvention: metadata.NamingConventionGraphQLDefault,
+	}, customization.FlavorDatabase)
+	c.Apply(newSnakeCaseSchema())
+
+	return c
+}
+
+func enumValueNames(s *graph.Schema, name string) []string {
+	for _, e := range s.Enums {
+		if e.Name == name {
+			names := make([]string, 0, len(e.Values))
+			for _, v := range e.Values {
+				names = append(names, v.Name)
+			}
+
+			return names
+		}
+	}
+
+	return nil
+}
+
+func TestApplyGraphQLDefaultNamingConvention(t *testing.T) {
+	t.Parallel()
+
+	s := customization.New(metadata.Customization{
+		NamingConvention: metadata.NamingConventionGraphQLDefault,
+	}, customization.FlavorDatabase).Apply(newSnakeCaseSchema())
+
+	for _, name := range []string{
+		"query_root", "mutation_root", "Users", "UsersBoolExp", "UsersOrderBy",
+		"UsersInsertInput", "IntComparisonExp", "OrderBy", "UsersSelectColumn",
+		"UserStatusEnum", "timestamptz",
+	} {
+		if !hasTypeName(s, name) {
+			t.Errorf("missing type %q; names: %v", name, allTypeNames(s))
+		}
+	}
+
+	users := findField(findType(s, "query_root"), "users")
+	if users == nil {
+		t.Fatalf("users root field missing")
+	}
+
+	argNames := make([]string, 0, len(users.Arguments))
+	for _, a := range users.Arguments {
+		argNames = append(argNames, a.Name)
+	}
+
+	if want := []string{"where", "orderBy", "distinctOn"}; !slices.Equal(argNames, want) {
+		t.Errorf("users arguments = %v, want %v", argNames, want)
+	}
+
+	if findField(findType(s, "mutation_root"), "insertUsersOne") == nil {
+		t.Errorf("insert_users_one must be camelCased to insertUsersOne")
+	}
+
+	if got := findField(findType(s, "Users"), "userId"); got == nil {
+		t.Errorf("users.user_id must be camelCased to userId")
+	} else if baseTypeName(got.Type) != "Int" {
+		t.Errorf("userId type = %q, want Int", baseTypeName(got.Type))
+	}
+
+	if got := enumValueNames(s, "OrderBy"); !slices.Equal(got, []string{"ASC", "DESC_NULLS_LAST"}) {
+		t.Errorf("OrderBy values = %v, want upper-cased", got)
+	}
+
+	if got := enumValueNames(s, "UsersSelectColumn"); !slices.Equal(got, []string{"USER_ID", "CREATED_AT"}) {
+		t.Errorf("UsersSelectColumn values = %v, want upper-cased", got)
+	}
+
+	// user_status_enum is the type of an output column, so its values are row
+	// data and must keep their native spelling.
+	if got := enumValueNames(s, "UserStatusEnum"); !slices.Equal(got, []string{"active", "on_hold"}) {
+		t.Errorf("UserStatusEnum values = %v, want unchanged", got)
+	}
+
+	for _, in := range s.Inputs {
+		if in.Name != "UsersBoolExp" {
+			continue
+		}
+
+		names := []string{in.Fields[0].Name, in.Fields[1].Name}
+		if !slices.Equal(names, []string{"userId", "_and"}) {
+			t.Errorf("UsersBoolExp fields = %v, want [userId _and]", names)
+		}
+	}
+}
+
+func TestApplyHasuraDefaultNamingConventionIsNoOp(t *testing.T) {
+	t.Parallel()
+
+	cfg := metadata.Customization{NamingConvention: metadata.NamingConventionHasuraDefault}
+	if !cfg.IsZero() {
+		t.Fatalf("hasura-default alone must be the zero customization")
+	}
+
+	s := customization.New(cfg, customization.FlavorDatabase).Apply(newSnakeCaseSchema())
+	if findField(findType(s, "mutation_root"), "insert_users_one") == nil {
+		t.Errorf("hasura-default must keep snake_case root fields")
+	}
+}
+
+func TestReverseOperationGraphQLDefault(t *testing.T) {
+	t.Parallel()
+
+	// query {
+	//   users(
+	//     where: {userId: {_eq: 1}, _and: [{userId: {_eq: $id}}]}
+	//     orderBy: [{createdAt: DESC_NULLS_LAST}]
+	//     distinctOn: USER_ID
+	//   ) { userId createdAt ... on Users { id: userId } }
+	// }
+	op := &ast.OperationDefinition{
+		Operation: ast.Query,
+		SelectionSet: ast.SelectionSet{
+			&ast.Field{
+				Name: "users",
+				Arguments: ast.ArgumentList{
+					{Name: "where", Value: object(
+						child("userId", object(child("_eq", &ast.Value{Kind: ast.IntValue, Raw: "1"}))),
+						child("_and", list(object(
+							child("userId", object(child("_eq", &ast.Value{Kind: ast.Variable, Raw: "id"}))),
+						))),
+					)},
+					{Name: "orderBy", Value: list(object(
+						child("createdAt", &ast.Value{Kind: ast.EnumValue, Raw: "DESC_NULLS_LAST"}),
+					))},
+					{Name: "distinctOn", Value: &ast.Value{Kind: ast.EnumValue, Raw: "USER_ID"}},
+				},
+				SelectionSet: ast.SelectionSet{
+					field("userId", nil),
+					field("createdAt", nil),
+					&ast.InlineFragment{
+						TypeCondition: "Users",
+						SelectionSet:  ast.SelectionSet{&ast.Field{Alias: "id", Name: "userId"}},
+					},
+				},
+			},
+		},
+	}
+
+	native, _ := graphQLDefaultCustomizer().ReverseOperation(op, nil)
+
+	users, ok := native.SelectionSet[0].(*ast.Field)
+	if !ok || users.Name != "users" {
+		t.Fatalf("root selection = %#v, want users", native.SelectionSet[0])
+	}
+
+	if got := users.Arguments.ForName("order_by"); got == nil {
+		t.Fatalf("orderBy must reverse to order_by; got %v", users.Arguments)
+	} else if got.Value.String() != "[{created_at:desc_nulls_last}]" {
+		t.Errorf("order_by value = %s", got.Value.String())
+	}
+
+	if got := users.Arguments.ForName("where").Value.String(); got != "{user_id:{_eq:1},_and:[{user_id:{_eq:$id}}]}" {
+		t.Errorf("where value = %s", got)
+	}
+
+	if got := users.Arguments.ForName("distinct_on").Value.String(); got != "user_id" {
+		t.Errorf("distinct_on value = %s", got)
+	}
+
+	userID, _ := users.SelectionSet[0].(*ast.Field)
+	if userID.Name != "user_id" || userID.Alias != "userId" {
+		t.Errorf("userId reversed to %q alias %q, want user_id aliased userId", userID.Name, userID.Alias)
+	}
+
+	frag, _ := users.SelectionSet[2].(*ast.InlineFragment)
+	if frag.TypeCondition != "users" {
+		t.Errorf("type condition = %q, want users", frag.TypeCondition)
+	}
+
+	aliased, _ := frag.SelectionSet[0].(*ast.Field)
+	if aliased.Name != "user_id" || aliased.Alias != "id" {
+		t.Errorf("aliased field reversed to %q alias %q, want user_id aliased id", aliased.Name, aliased.Alias)
+	}
+
+	// The input operation is shared with the planner and must be untouched.
+	if op.SelectionSet[0].(*ast.Field).Arguments[1].Name != "orderBy" {
+		t.Errorf("ReverseOperation mutated its input")
+	}
+}
+
+func TestReverseVariablesGraphQLDefault(t *testing.T) {
+	t.Parallel()
+
+	// mutation ($object: UsersInsertInput!, $limit: Int) {
+	//   insertUsersOne(object: $object) { userId }
+	// }
+	op := &ast.OperationDefinition{
+		Operation: ast.Mutation,
+		VariableDefinitions: ast.VariableDefinitionList{
+			{Variable: "object", Type: &ast.Type{NamedType: "UsersInsertInput", NonNull: true}},
+			{Variable: "limit", Type: &ast.Type{NamedType: "Int"}},
+		},
+		SelectionSet: ast.SelectionSet{
+			&ast.Field{
+				Name: "insertUsersOne",
+				Arguments: ast.ArgumentList{
+					{Name: "object", Value: &ast.Value{Kind: ast.Variable, Raw: "object"}},
+				},
+				SelectionSet: ast.SelectionSet{field("userId", nil)},
+			},
+		},
+	}
+
+	c := graphQLDefaultCustomizer()
+
+	native, _ := c.ReverseOperation(op, nil)
+
+	insert, _ := native.SelectionSet[0].(*ast.Field)
+	if insert.Name != "insert_users_one" || insert.Alias != "insertUsersOne" {
+		t.Errorf("root field reversed to %q alias %q", insert.Name, insert.Alias)
+	}
+
+	if native.VariableDefinitions[0].Type.NamedType != "users_insert_input" {
+		t.Errorf("variable type = %q, want users_insert_input",
+			native.VariableDefinitions[0].Type.NamedType)
+	}
+
+	variables := map[string]any{
+		"object": map[string]any{"userId": float64(1), "status": "on_hold"},
+		"limit":  float64(10),
+	}
+
+	got := c.ReverseVariables(op, variables)
+
+	want := map[string]any{
+		"object": map[string]any{"user_id": float64(1), "status": "on_hold"},
+		"limit":  float64(10),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReverseVariables = %v, want %v", got, want)
+	}
+
+	if _, ok := variables["object"].(map[string]any)["userId"]; !ok {
+		t.Errorf("ReverseVariables mutated its input")
+	}
+}
+
+func object(children ...*ast.ChildValue) *ast.Value {
+	return &ast.Value{Kind: ast.ObjectValue, Children: children}
+}
+
+func list(values ...*ast.Value) *ast.Value {
+	children := make(ast.ChildValueList, len(values))
+	for i, v := range values {
+		children[i] = &ast.ChildValue{Value: v}
+	}
+
+	return &ast.Value{Kind: ast.ListValue, Children: children}
+}
+
+func child(name string, value *ast.Value) *ast.ChildValue {
+	return &ast.ChildValue{Name: name, Value: value}
+}
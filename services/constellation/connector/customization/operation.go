@@ -12,12 +12,14 @@ import (
 // nodes and never mutates the inputs (the planner shares them across
 // connectors).
 //
-// Two things are undone: the namespace wrapper (each root namespace field is
-// removed and its children lifted to the root) and type renaming (type
+// Three things are undone: the namespace wrapper (each root namespace field is
+// removed and its children lifted to the root), type renaming (type
 // conditions on fragments and named types in variable definitions are mapped
-// back to native names). Field-name reversal is applied for the root-field
-// prefix/suffix; per-type field_names reversal is not yet implemented (no
-// configuration in use exercises it).
+// back to native names), and field renaming. Field, argument, input-field and
+// enum-value names are mapped back through the names Apply recorded for the
+// selection's parent type, falling back to stripping the root-field
+// prefix/suffix for root fields Apply did not see. Variable values are
+// reversed separately, by ReverseVariables.
 func (c *Customizer) ReverseOperation(
 	op *ast.OperationDefinition,
 	fragments ast.FragmentDefinitionList,
@@ -31,8 +33,12 @@ func (c *Customizer) ReverseOperation(
 		Name:                op.Name,
 		VariableDefinitions: c.reverseVariableDefinitions(op.VariableDefinitions),
 		Directives:          op.Directives,
-		SelectionSet:        c.reverseRootSelections(op.SelectionSet, fragments),
-		Position:            op.Position,
+		SelectionSet: c.reverseRootSelections(
+			op.SelectionSet,
+			c.rootParents[op.Operation],
+			fragments,
+		),
+		Position: op.Position,
 	}
 
 	var rebuiltFragments ast.FragmentDefinitionList
@@ -46,6 +52,7 @@ func (c *Customizer) ReverseOperation(
 				Directives:         frag.Directives,
 				SelectionSet: c.reverseSelections(
 					frag.SelectionSet,
+					frag.TypeCondition,
 					c.fragmentCarriesRootFields(frag.TypeCondition),
 				),
 				Definition: frag.Definition,
@@ -218,18 +225,22 @@ func fieldResponseKey(field *ast.Field) string {
 // (mirroring how ForwardResult resolves them via fragments.ForName), falling
 // back to the spread's own validated Definition. Any root selection that does
 // not resolve to the namespace field is reversed in place without lifting.
+//
+// parent is the customized type holding the root fields (the namespace wrapper
+// when one is configured), used to look up their native names.
 func (c *Customizer) reverseRootSelections(
 	selections ast.SelectionSet,
+	parent string,
 	fragments ast.FragmentDefinitionList,
 ) ast.SelectionSet {
 	if c.cfg.RootFieldsNamespace == "" {
-		return c.reverseSelections(selections, true)
+		return c.reverseSelections(selections, parent, true)
 	}
 
 	var lifted ast.SelectionSet
 
 	for _, selection := range selections {
-		lifted = append(lifted, c.liftRootSelection(selection, fragments)...)
+		lifted = append(lifted, c.liftRootSelection(selection, parent, fragments)...)
 	}
 
 	return lifted
@@ -244,6 +255,7 @@ func (c *Customizer) reverseRootSelections(
 // lifting.
 func (c *Customizer) liftRootSelection(
 	selection ast.Selection,
+	parent string,
 	fragments ast.FragmentDefinitionList,
 ) ast.SelectionSet {
 	switch sel := selection.(type) {
@@ -251,25 +263,25 @@ func (c *Customizer) liftRootSelection(
 		if sel.Name == c.cfg.RootFieldsNamespace {
 			// The namespace field's children are the real root fields once
 			// lifted, so reverse them as root fields.
-			return c.reverseSelections(sel.SelectionSet, true)
+			return c.reverseSelections(sel.SelectionSet, parent, true)
 		}
 
-		return ast.SelectionSet{c.reverseSelection(sel, true)}
+		return ast.SelectionSet{c.reverseSelection(sel, parent, true)}
 	case *ast.InlineFragment:
 		if !c.selectionsContainNamespace(sel.SelectionSet, fragments) {
-			return ast.SelectionSet{c.reverseSelection(sel, true)}
+			return ast.SelectionSet{c.reverseSelection(sel, parent, true)}
 		}
 
-		return c.liftRootSelections(sel.SelectionSet, fragments)
+		return c.liftRootSelections(sel.SelectionSet, parent, fragments)
 	case *ast.FragmentSpread:
 		def := resolveFragment(sel, fragments)
 		if def == nil || !c.selectionsContainNamespace(def.SelectionSet, fragments) {
-			return ast.SelectionSet{c.reverseSelection(selection, true)}
+			return ast.SelectionSet{c.reverseSelection(selection, parent, true)}
 		}
 
-		return c.liftRootSelections(def.SelectionSet, fragments)
+		return c.liftRootSelections(def.SelectionSet, parent, fragments)
 	default:
-		return ast.SelectionSet{c.reverseSelection(selection, true)}
+		return ast.SelectionSet{c.reverseSelection(selection, parent, true)}
 	}
 }
 
@@ -277,11 +289,12 @@ func (c *Customizer) liftRootSelection(
 // flattening the namespace field's children to the root.
 func (c *Customizer) liftRootSelections(
 	selections ast.SelectionSet,
+	parent string,
 	fragments ast.FragmentDefinitionList,
 ) ast.SelectionSet {
 	var lifted ast.SelectionSet
 	for _, inner := range selections {
-		lifted = append(lifted, c.liftRootSelection(inner, fragments)...)
+		lifted = append(lifted, c.liftRootSelection(inner, parent, fragments)...)
 	}
 
 	return lifted
@@ -335,9 +348,12 @@ func resolveFragment(
 // fragment) and so carry the root-field prefix/suffix. It is threaded down so
 // that descending into a field's own selection set clears it: only genuine root
 // fields get the affix stripped, nested fields whose names happen to collide
-// with the affix are left untouched.
+// with the affix are left untouched. parent is the customized type the
+// selections are made on; it is empty when unknown, in which case field names
+// are kept as-is.
 func (c *Customizer) reverseSelections(
 	selections ast.SelectionSet,
+	parent string,
 	isRoot bool,
 ) ast.SelectionSet {
 	if selections == nil {
@@ -346,7 +362,7 @@ func (c *Customizer) reverseSelections(
 
 	rebuilt := make(ast.SelectionSet, len(selections))
 	for i, selection := range selections {
-		rebuilt[i] = c.reverseSelection(selection, isRoot)
+		rebuilt[i] = c.reverseSelection(selection, parent, isRoot)
 	}
 
 	return rebuilt
@@ -354,15 +370,29 @@ func (c *Customizer) reverseSelections(
 
 func (c *Customizer) reverseSelection( //nolint:ireturn,nolintlint
 	selection ast.Selection,
+	parent string,
 	isRoot bool,
 ) ast.Selection {
 	switch sel := selection.(type) {
 	case *ast.Field:
+		// Fields Apply never saw (e.g. __typename, or native fields the
+		// planner injects for joins) are not in the map and keep their name.
+		info, known := c.fields[parent][sel.Name]
+
 		nativeName := sel.Name
-		if isRoot {
+
+		switch {
+		case known:
+			nativeName = info.native
+		case isRoot:
 			nativeName = c.reverseRootFieldName(sel)
 		}
 
+		arguments := sel.Arguments
+		if known && c.renamesArguments {
+			arguments = c.reverseArguments(sel.Arguments, info.args)
+		}
+
 		// Preserve the client's response key: if the name changed and no
 		// explicit alias was given, alias the native field to the customized
 		// name so the connector returns data under the key the caller expects
@@ -375,9 +405,9 @@ func (c *Customizer) reverseSelection( //nolint:ireturn,nolintlint
 		return &ast.Field{ //nolint:exhaustruct
 			Alias:            alias,
 			Name:             nativeName,
-			Arguments:        sel.Arguments,
+			Arguments:        arguments,
 			Directives:       sel.Directives,
-			SelectionSet:     c.reverseSelections(sel.SelectionSet, false),
+			SelectionSet:     c.reverseSelections(sel.SelectionSet, info.typeName, false),
 			Definition:       sel.Definition,
 			ObjectDefinition: sel.ObjectDefinition,
 			Position:         sel.Position,
@@ -385,10 +415,15 @@ func (c *Customizer) reverseSelection( //nolint:ireturn,nolintlint
 	case *ast.InlineFragment:
 		// An inline fragment at the root level still selects root fields, so
 		// the root signal flows through it unchanged.
+		fragmentParent := parent
+		if sel.TypeCondition != "" {
+			fragmentParent = sel.TypeCondition
+		}
+
 		return &ast.InlineFragment{ //nolint:exhaustruct
 			TypeCondition:    c.reverseTypeName(sel.TypeCondition),
 			Directives:       sel.Directives,
-			SelectionSet:     c.reverseSelections(sel.SelectionSet, isRoot),
+			SelectionSet:     c.reverseSelections(sel.SelectionSet, fragmentParent, isRoot),
 			ObjectDefinition: sel.ObjectDefinition,
 			Position:         sel.Position,
 		}
@@ -478,7 +513,7 @@ func (c *Customizer) reverseVariableDefinitions(
 		rebuilt[i] = &ast.VariableDefinition{ //nolint:exhaustruct
 			Variable:     def.Variable,
 			Type:         c.reverseASTType(def.Type),
-			DefaultValue: def.DefaultValue,
+			DefaultValue: c.reverseValue(def.DefaultValue, typeNameOf(def.Type)),
 			Directives:   def.Directives,
 			Definition:   def.Definition,
 			Used:         def.Used,
@@ -4,6 +4,7 @@ import (
 	"encoding/json/jsontext"
 	json "encoding/json/v2"
 
+	"github.com/nhost/nhost/services/constellation/metadata"
 	"github.com/vektah/gqlparser/v2/ast"
 )
 
@@ -215,9 +216,11 @@ func (w *resultWalker) selectionsSelectTypename(selections ast.SelectionSet) boo
 
 // remapsTypeNames reports whether the customization renames any type, which is
 // what makes __typename re-mapping (and therefore decoding raw values)
-// necessary. Namespacing alone does not rename types.
+// necessary. Namespacing alone does not rename types; the graphql-default
+// naming convention does.
 func (c *Customizer) remapsTypeNames() bool {
-	return c.cfg.TypeNamesPrefix != "" ||
+	return c.cfg.NamingConvention == metadata.NamingConventionGraphQLDefault ||
+		c.cfg.TypeNamesPrefix != "" ||
 		c.cfg.TypeNamesSuffix != "" ||
 		len(c.cfg.TypeNamesMapping) > 0
 }
@@ -0,0 +1,183 @@
+package customization
+
+import (
+	"maps"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ReverseVariables maps the operation's variable values from customized input
+// field and enum value names back to native ones, mirroring what
+// ReverseOperation does for literal arguments. op is the client-facing
+// (customized) operation: each variable is reversed according to the
+// customized type it is declared with. It returns variables unchanged when
+// nothing was renamed, and a new map otherwise; the input is never mutated.
+func (c *Customizer) ReverseVariables(
+	op *ast.OperationDefinition,
+	variables map[string]any,
+) map[string]any {
+	if !c.enabled() || !c.renamesArguments || op == nil || len(variables) == 0 {
+		return variables
+	}
+
+	reversed := maps.Clone(variables)
+
+	for _, def := range op.VariableDefinitions {
+		if value, ok := variables[def.Variable]; ok {
+			reversed[def.Variable] = c.reverseVariableValue(value, typeNameOf(def.Type))
+		}
+	}
+
+	return reversed
+}
+
+// reverseVariableValue reverses one JSON-decoded variable value of the
+// customized input type typeName.
+func (c *Customizer) reverseVariableValue(value any, typeName string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		fields, ok := c.fields[typeName]
+		if !ok {
+			return v
+		}
+
+		reversed := make(map[string]any, len(v))
+		for name, child := range v {
+			info, ok := fields[name]
+			if !ok {
+				reversed[name] = child
+
+				continue
+			}
+
+			reversed[info.native] = c.reverseVariableValue(child, info.typeName)
+		}
+
+		return reversed
+	case []any:
+		reversed := make([]any, len(v))
+		for i, item := range v {
+			reversed[i] = c.reverseVariableValue(item, typeName)
+		}
+
+		return reversed
+	case string:
+		if native, ok := c.enumValues[typeName][v]; ok {
+			return native
+		}
+
+		return v
+	default:
+		return value
+	}
+}
+
+// reverseArguments returns a copy of arguments with names mapped back through
+// the field's recorded argument names and literal values reversed by type.
+// Arguments the field does not declare are kept as-is.
+func (c *Customizer) reverseArguments(
+	arguments ast.ArgumentList,
+	names map[string]argumentNames,
+) ast.ArgumentList {
+	if len(arguments) == 0 {
+		return arguments
+	}
+
+	rebuilt := make(ast.ArgumentList, len(arguments))
+	for i, arg := range arguments {
+		info, ok := names[arg.Name]
+		if !ok {
+			rebuilt[i] = arg
+
+			continue
+		}
+
+		rebuilt[i] = &ast.Argument{
+			Name:     info.native,
+			Value:    c.reverseValue(arg.Value, info.typeName),
+			Position: arg.Position,
+			Comment:  arg.Comment,
+		}
+	}
+
+	return rebuilt
+}
+
+// reverseValue returns a copy of a literal value of the customized input type
+// typeName with input-object field names and enum values mapped back to
+// native ones. Variables are left alone; their values are reversed by
+// ReverseVariables.
+func (c *Customizer) reverseValue(value *ast.Value, typeName string) *ast.Value {
+	if value == nil {
+		return nil
+	}
+
+	switch value.Kind {
+	case ast.ObjectValue:
+		fields := c.fields[typeName]
+
+		rebuilt := *value
+		rebuilt.Children = make(ast.ChildValueList, len(value.Children))
+
+		for i, child := range value.Children {
+			info, ok := fields[child.Name]
+			if !ok {
+				rebuilt.Children[i] = child
+
+				continue
+			}
+
+			rebuilt.Children[i] = &ast.ChildValue{
+				Name:     info.native,
+				Value:    c.reverseValue(child.Value, info.typeName),
+				Position: child.Position,
+				Comment:  child.Comment,
+			}
+		}
+
+		return &rebuilt
+	case ast.ListValue:
+		rebuilt := *value
+		rebuilt.Children = make(ast.ChildValueList, len(value.Children))
+
+		for i, child := range value.Children {
+			rebuilt.Children[i] = &ast.ChildValue{
+				Name:     child.Name,
+				Value:    c.reverseValue(child.Value, typeName),
+				Position: child.Position,
+				Comment:  child.Comment,
+			}
+		}
+
+		return &rebuilt
+	case ast.EnumValue:
+		native, ok := c.enumValues[typeName][value.Raw]
+		if !ok {
+			return value
+		}
+
+		rebuilt := *value
+		rebuilt.Raw = native
+
+		return &rebuilt
+	case ast.Variable, ast.IntValue, ast.FloatValue, ast.StringValue, ast.BlockValue,
+		ast.BooleanValue, ast.NullValue:
+		return value
+	default:
+		return value
+	}
+}
+
+// typeNameOf returns the base named type of a (possibly list/non-null) AST
+// type.
+func typeNameOf(t *ast.Type) string {
+	for t != nil && t.Elem != nil {
+		t = t.Elem
+	}
+
+	if t == nil {
+		return ""
+	}
+
+	return t.NamedType
+}
@@ -25,9 +25,8 @@ import (
 // GetTypeName, which this decorator delegates to inner unchanged), which this
 // decorator's schema renames. No metadata in use combines the two (the
 // namespaced remote schema declares no remote relationships), so it is left as
-// a follow-up. Unlike field_names -- rejected at construction because it lives
-// in the Customization config -- this combination cannot be guarded in
-// newCustomizedConnector: remote relationships live in
+// a follow-up. This combination cannot be guarded in newCustomizedConnector:
+// remote relationships live in
 // metadata.DatabaseMetadata.Tables[].RemoteRelationships /
 // RemoteSchemaMetadata.RemoteRelationships, neither of which is passed to the
 // constructor, and the *targeted* side (another source pointing at this one) is
@@ -74,27 +73,18 @@ func applyCustomization( //nolint:ireturn,nolintlint
 // customized per cfg. It customizes every role schema once at construction
 // (Apply clones, so the wrapped connector's schemas are untouched).
 //
-// Per-type field_names customization is rejected here: Apply renames such
-// fields in the forward schema, but the execution path (ReverseOperation /
-// ForwardResult) does not reverse them, so the customized schema would
-// advertise renamed fields while queries selecting them fail against the
-// wrapped connector. Failing at construction turns that silent runtime
-// breakage into a clear config-time error until reverse mapping is
-// implemented.
+// Every field rename -- per-type field_names as well as the graphql-default
+// naming convention, which also renames arguments and enum values -- is
+// reversed through the names Apply records (see
+// customization.Customizer.ReverseOperation/ReverseVariables), so operations
+// reach the wrapped connector with native names and the customized response
+// keys preserved as aliases.
 func newCustomizedConnector(
 	name string,
 	inner Connector,
 	cfg metadata.Customization,
 	flavor customization.Flavor,
 ) (*customizedConnector, error) {
-	if len(cfg.FieldNames) > 0 {
-		return nil, fmt.Errorf(
-			"%w: customizing connector %s: per-type field_names customization is not supported "+
-				"(the schema would advertise renamed fields that execution cannot reverse)",
-			ErrUnsupportedCustomization, name,
-		)
-	}
-
 	customizer := customization.New(cfg, flavor)
 
 	native, err := inner.GetSchema()
@@ -129,9 +119,10 @@ func (c *customizedConnector) Execute(
 	logger *slog.Logger,
 ) (map[string]any, error) {
 	nativeOp, nativeFragments := c.customizer.ReverseOperation(operation, fragments)
+	nativeVariables := c.customizer.ReverseVariables(operation, variables)
 
 	result, err := c.inner.Execute(
-		ctx, nativeOp, nativeFragments, variables, role, sessionVariables, logger,
+		ctx, nativeOp, nativeFragments, nativeVariables, role, sessionVariables, logger,
 	)
 
 	// Reshape any data the connector returned, including the partial data that
@@ -161,9 +152,10 @@ func (c *customizedConnector) ValidateOperation(
 	sessionVariables map[string]any,
 ) error {
 	nativeOp, nativeFragments := c.customizer.ReverseOperation(operation, fragments)
+	nativeVariables := c.customizer.ReverseVariables(operation, variables)
 
 	if err := c.inner.ValidateOperation(
-		nativeOp, nativeFragments, variables, role, sessionVariables,
+		nativeOp, nativeFragments, nativeVariables, role, sessionVariables,
 	); err != nil {
 		err = c.remapQueryValidationArgumentPath(err, operation, fragments)
 
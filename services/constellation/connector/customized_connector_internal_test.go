@@ -549,10 +549,9 @@ func TestCustomizedConnectorClose(t *testing.T) {
 // type comment says "no metadata in use combines the two": pairing a namespaced
 // source with a remote relationship is silently wrong.
 //
-// A construction-time guard mirroring the field_names check is NOT feasible:
-// newCustomizedConnector only receives (name, inner, cfg metadata.Customization,
-// flavor). cfg carries namespace/prefix/suffix/type-mapping/field_names only --
-// field_names CAN be rejected because it lives in cfg, but remote relationships
+// A construction-time guard is NOT feasible: newCustomizedConnector only
+// receives (name, inner, cfg metadata.Customization, flavor). cfg carries
+// namespace/prefix/suffix/type-mapping/field_names only -- remote relationships
 // live in dbMeta.Tables[].RemoteRelationships / rsMeta.RemoteRelationships,
 // which are never passed to the constructor. The constructor also cannot see the
 // targeted side (another source pointing AT this one); only the composer, which
@@ -622,27 +621,68 @@ func TestCustomizedConnectorRelationshipNamingDivergence(t *testing.T) {
 	}
 }
 
-func TestNewCustomizedConnectorRejectsFieldNames(t *testing.T) {
+// TestCustomizedConnectorExecuteFieldNames drives a per-type field_names
+// customization through the decorator: the client selects the renamed root and
+// object fields, the inner connector must receive the native names aliased to
+// the customized response keys, and the response comes back keyed by the
+// customized names without any remapping.
+func TestCustomizedConnectorExecuteFieldNames(t *testing.T) {
 	t.Parallel()
 
-	_, err := newCustomizedConnector(
+	inner := &fakeConnector{
+		schema: teamSchema(),
+		execData: map[string]any{
+			"nba_teams": []any{map[string]any{"teamId": "1"}},
+		},
+	}
+
+	conn, err := newCustomizedConnector(
 		"rs",
-		&fakeConnector{schema: teamSchema()},
+		inner,
 		metadata.Customization{
 			FieldNames: []metadata.FieldNameCustomization{
-				{
-					ParentType: "Team",
-					Mapping:    map[string]string{"name": "displayName"},
-				},
+				{ParentType: "Query", Prefix: "nba_"},
+				{ParentType: "Team", Mapping: map[string]string{"id": "teamId"}},
 			},
 		},
 		customization.FlavorRemoteSchema,
 	)
-	if err == nil {
-		t.Fatal("expected error for field_names customization, got nil")
+	if err != nil {
+		t.Fatalf("newCustomizedConnector: %v", err)
+	}
+
+	// query { nba_teams { teamId } }
+	op := &ast.OperationDefinition{
+		Operation: ast.Query,
+		SelectionSet: ast.SelectionSet{
+			&ast.Field{
+				Name:         "nba_teams",
+				SelectionSet: ast.SelectionSet{&ast.Field{Name: "teamId"}},
+			},
+		},
+	}
+
+	got, err := conn.Execute(t.Context(), op, nil, nil, metadata.RoleAdmin, nil, slog.Default())
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	teams, ok := inner.gotOp.SelectionSet[0].(*ast.Field)
+	if !ok || teams.Name != "teams" || teams.Alias != "nba_teams" {
+		t.Fatalf("root field = %#v, want teams aliased nba_teams", inner.gotOp.SelectionSet[0])
+	}
+
+	id, ok := teams.SelectionSet[0].(*ast.Field)
+	if !ok || id.Name != "id" || id.Alias != "teamId" {
+		t.Fatalf("team field = %#v, want id aliased teamId", teams.SelectionSet[0])
+	}
+
+	list, ok := got["nba_teams"].([]any)
+	if !ok || len(list) != 1 {
+		t.Fatalf("nba_teams = %#v, want a one-element list", got["nba_teams"])
 	}
 
-	if !strings.Contains(err.Error(), "field_names") {
-		t.Errorf("error should mention field_names, got: %v", err)
+	if team, _ := list[0].(map[string]any); team["teamId"] != "1" {
+		t.Errorf("team = %#v, want teamId 1", list[0])
 	}
 }
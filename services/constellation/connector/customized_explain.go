@@ -0,0 +1,49 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nhost/nhost/services/constellation/connector/explain"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Explain forwards the wrapped connector's explain capability. The operation
+// is reversed to native names exactly as in Execute, so the explained SQL is
+// the SQL Execute would run. Each field's response key is mapped back onto the
+// customized operation; a namespaced field is reported as "namespace.field".
+// It returns explain.ErrNotSupported when the wrapped connector cannot
+// explain (e.g. remote schemas).
+func (c *customizedConnector) Explain(
+	ctx context.Context,
+	operation *ast.OperationDefinition,
+	fragments ast.FragmentDefinitionList,
+	variables map[string]any,
+	role string,
+	sessionVariables map[string]any,
+) ([]explain.Field, error) {
+	inner, ok := c.inner.(explain.Explainer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", explain.ErrNotSupported, c.name)
+	}
+
+	nativeOp, nativeFragments := c.customizer.ReverseOperation(operation, fragments)
+	nativeVariables := c.customizer.ReverseVariables(operation, variables)
+
+	fields, err := inner.Explain(
+		ctx, nativeOp, nativeFragments, nativeVariables, role, sessionVariables,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("explaining customized connector %s: %w", c.name, err)
+	}
+
+	for i := range fields {
+		fields[i].Field = strings.ReplaceAll(
+			c.customizer.ForwardArgumentPath(fields[i].Field, operation, fragments),
+			".selectionSet.", ".",
+		)
+	}
+
+	return fields, nil
+}
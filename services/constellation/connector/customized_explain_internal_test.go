@@ -0,0 +1,95 @@
+package connector
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/connector/customization"
+	"github.com/nhost/nhost/services/constellation/connector/explain"
+	"github.com/nhost/nhost/services/constellation/metadata"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// explainingConnector is a fakeConnector that also implements
+// explain.Explainer, reporting one field per native root selection.
+type explainingConnector struct {
+	fakeConnector
+}
+
+func (e *explainingConnector) Explain(
+	_ context.Context,
+	operation *ast.OperationDefinition,
+	_ ast.FragmentDefinitionList,
+	_ map[string]any,
+	_ string,
+	_ map[string]any,
+) ([]explain.Field, error) {
+	e.gotOp = operation
+
+	fields := make([]explain.Field, 0, len(operation.SelectionSet))
+
+	for _, selection := range operation.SelectionSet {
+		field, _ := selection.(*ast.Field)
+		fields = append(fields, explain.Field{
+			Field: cmp.Or(field.Alias, field.Name),
+			SQL:   "SELECT 1",
+			Plan:  nil,
+		})
+	}
+
+	return fields, nil
+}
+
+func TestCustomizedConnectorExplain(t *testing.T) {
+	t.Parallel()
+
+	custom := metadata.Customization{
+		RootFieldsNamespace: "league",
+		TypeNamesPrefix:     "League",
+	}
+
+	t.Run("forwards and maps fields under the namespace", func(t *testing.T) {
+		t.Parallel()
+
+		inner := &explainingConnector{fakeConnector: fakeConnector{schema: teamSchema()}}
+
+		conn, err := newCustomizedConnector("default", inner, custom, customization.FlavorDatabase)
+		if err != nil {
+			t.Fatalf("newCustomizedConnector: %v", err)
+		}
+
+		fields, err := conn.Explain(
+			t.Context(), namespacedQueryOpWithTeamsField("squads", nil),
+			nil, nil, metadata.RoleAdmin, nil,
+		)
+		if err != nil {
+			t.Fatalf("Explain: %v", err)
+		}
+
+		assertReversedToNative(t, inner.gotOp)
+
+		if len(fields) != 1 || fields[0].Field != "league.squads" {
+			t.Errorf("fields = %+v, want one field reported as league.squads", fields)
+		}
+	})
+
+	t.Run("inner connector without explain", func(t *testing.T) {
+		t.Parallel()
+
+		conn, err := newCustomizedConnector(
+			"default", &fakeConnector{schema: teamSchema()}, custom, customization.FlavorDatabase,
+		)
+		if err != nil {
+			t.Fatalf("newCustomizedConnector: %v", err)
+		}
+
+		_, err = conn.Explain(
+			t.Context(), namespacedQueryOp(), nil, nil, metadata.RoleAdmin, nil,
+		)
+		if !errors.Is(err, explain.ErrNotSupported) {
+			t.Errorf("err = %v, want explain.ErrNotSupported", err)
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nhost/nhost/services/constellation/connector/runsql"
+)
+
+// RunSQL forwards the wrapped connector's run_sql capability. Customization
+// only renames the GraphQL surface, so the scripts and the tracked tables pass
+// through unchanged. It returns runsql.ErrNotSupported when the wrapped
+// connector cannot run SQL (e.g. remote schemas).
+func (c *customizedConnector) RunSQL(
+	ctx context.Context, scripts []runsql.Script, opts runsql.Options,
+) ([]*runsql.Result, error) {
+	inner, ok := c.inner.(runsql.Runner)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", runsql.ErrNotSupported, c.name)
+	}
+
+	results, err := inner.RunSQL(ctx, scripts, opts)
+	if err != nil {
+		return nil, fmt.Errorf("running sql on customized connector %s: %w", c.name, err)
+	}
+
+	return results, nil
+}
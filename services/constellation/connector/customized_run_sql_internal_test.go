@@ -0,0 +1,73 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/connector/customization"
+	"github.com/nhost/nhost/services/constellation/connector/runsql"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+// runningConnector is a fakeConnector that also implements runsql.Runner,
+// recording the scripts it was asked to run.
+type runningConnector struct {
+	fakeConnector
+
+	gotScripts []runsql.Script
+}
+
+func (r *runningConnector) RunSQL(
+	_ context.Context, scripts []runsql.Script, _ runsql.Options,
+) ([]*runsql.Result, error) {
+	r.gotScripts = scripts
+
+	return []*runsql.Result{{Rows: [][]string{{"?column?"}, {"1"}}, Changed: false}}, nil
+}
+
+func TestCustomizedConnectorRunSQL(t *testing.T) {
+	t.Parallel()
+
+	custom := metadata.Customization{
+		RootFieldsNamespace: "league",
+		TypeNamesPrefix:     "League",
+	}
+
+	t.Run("forwards the sql unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		inner := &runningConnector{fakeConnector: fakeConnector{schema: teamSchema()}}
+
+		conn, err := newCustomizedConnector("default", inner, custom, customization.FlavorDatabase)
+		if err != nil {
+			t.Fatalf("newCustomizedConnector: %v", err)
+		}
+
+		results, err := conn.RunSQL(t.Context(), []runsql.Script{{SQL: "SELECT 1"}}, runsql.Options{})
+		if err != nil {
+			t.Fatalf("RunSQL: %v", err)
+		}
+
+		if len(inner.gotScripts) != 1 || inner.gotScripts[0].SQL != "SELECT 1" ||
+			len(results) != 1 || len(results[0].Rows) != 2 {
+			t.Errorf("scripts = %+v, results = %+v; want the inner connector's result", inner.gotScripts, results)
+		}
+	})
+
+	t.Run("inner connector without run_sql", func(t *testing.T) {
+		t.Parallel()
+
+		conn, err := newCustomizedConnector(
+			"default", &fakeConnector{schema: teamSchema()}, custom, customization.FlavorDatabase,
+		)
+		if err != nil {
+			t.Fatalf("newCustomizedConnector: %v", err)
+		}
+
+		_, err = conn.RunSQL(t.Context(), []runsql.Script{{SQL: "SELECT 1"}}, runsql.Options{})
+		if !errors.Is(err, runsql.ErrNotSupported) {
+			t.Errorf("err = %v, want runsql.ErrNotSupported", err)
+		}
+	})
+}
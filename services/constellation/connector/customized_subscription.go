@@ -27,7 +27,8 @@ type subscriptionCapable interface {
 // capability, decorating the returned handler so subscription operations are
 // reversed to native names and streamed updates are reshaped back into
 // customized form. It returns nil when the wrapped connector does not serve
-// subscriptions (e.g. remote schemas), which buildState treats as "no handler".
+// subscriptions (e.g. the in-memory connector), which buildState treats as
+// "no handler".
 func (c *customizedConnector) NewSubscriptionHandler( //nolint:ireturn,nolintlint
 	pollingInterval time.Duration,
 	logger *slog.Logger,
@@ -66,6 +67,7 @@ func (h *customizedSubscriptionHandler) Start(
 	nativeReq := req
 	nativeReq.Operation = nativeOp
 	nativeReq.Fragments = nativeFragments
+	nativeReq.Variables = h.customizer.ReverseVariables(req.Operation, req.Variables)
 
 	innerCh, err := h.inner.Start(ctx, nativeReq, logger)
 	if err != nil {
@@ -9,8 +9,3 @@ var ErrUnsupportedDatabaseKind = errors.New("unsupported database kind")
 // ErrDatabaseURLNotSet is returned when a database's resolved connection URL
 // is empty after consulting metadata and environment variables.
 var ErrDatabaseURLNotSet = errors.New("database URL is not set")
-
-// ErrUnsupportedCustomization is returned when a connector customization
-// configuration uses a feature that is not yet supported by the execution
-// path (e.g. per-type field_names renames).
-var ErrUnsupportedCustomization = errors.New("unsupported customization")
@@ -0,0 +1,51 @@
+// Package explain defines the optional capability behind POST
+// /v1/graphql/explain: a connector that can describe, without executing it,
+// the SQL it would run for each root field of a query and the database's plan
+// for that SQL.
+//
+// SQL connectors implement [Explainer]; the customization decorator forwards
+// it, returning [ErrNotSupported] when its wrapped connector cannot explain.
+// The controller probes connectors for it by type assertion and rejects root
+// fields owned by connectors that do not support it (remote schemas).
+package explain
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	"errors"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ErrNotSupported is returned by an Explainer that forwards the capability
+// (the customization decorator) when the connector it wraps cannot explain.
+var ErrNotSupported = errors.New("connector does not support explain")
+
+// Field describes the SQL generated for one root field.
+type Field struct {
+	// Field is the root field's response key (its alias, or its name when
+	// no alias is set).
+	Field string
+	// SQL is the statement the connector would execute, with positional
+	// placeholders.
+	SQL string
+	// Plan is the database's plan for SQL, as JSON: the EXPLAIN (FORMAT
+	// JSON) document on PostgreSQL and MySQL, the EXPLAIN QUERY PLAN rows
+	// on SQLite.
+	Plan jsontext.Value
+}
+
+// Explainer is implemented by connectors that can explain a query operation.
+// Explain builds the same SQL Execute would for the supplied role and session
+// variables and asks the database to plan it, without running it. Fields are
+// returned in the order of the operation's root selections.
+type Explainer interface {
+	Explain(
+		ctx context.Context,
+		operation *ast.OperationDefinition,
+		fragments ast.FragmentDefinitionList,
+		variables map[string]any,
+		role string,
+		sessionVariables map[string]any,
+	) ([]Field, error)
+}
@@ -0,0 +1,306 @@
+// Package federation adds the Apollo Federation v2 subgraph surface to a
+// composed role schema: the @key directive on entity types, the _Any /
+// _Service / _Entity types, and the _service and _entities root fields.
+//
+// Entities are declared by connectors implementing [Provider]; the composer
+// binds each one to its connector and calls [Augment] on the merged schema
+// before validation. The two root fields have no owning connector: the
+// controller resolves _service from the role's validated schema via [SDL] and
+// dispatches _entities to the connector recorded in each [Target].
+package federation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nhost/nhost/services/constellation/graph"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+)
+
+const (
+	// ServiceField is the root field returning the subgraph SDL.
+	ServiceField = "_service"
+	// EntitiesField is the root field resolving entity representations.
+	EntitiesField = "_entities"
+	// RepresentationsArgument is the _entities argument carrying the
+	// representations the router wants resolved.
+	RepresentationsArgument = "representations"
+	// TypenameKey is the representation key naming the entity type.
+	TypenameKey = "__typename"
+	// EntityUnion is the union of every entity type, the element type of
+	// _entities.
+	EntityUnion = "_Entity"
+
+	anyScalar      = "_Any"
+	fieldSetScalar = "federation__FieldSet"
+	serviceType    = "_Service"
+	keyDirective   = "key"
+
+	// linkSchemaExtension opts the SDL into Federation v2 and imports the
+	// only federation directive Constellation emits.
+	linkSchemaExtension = `extend schema @link(url: "https://specs.apollo.dev/federation/v2.3", ` +
+		`import: ["@key"])`
+)
+
+// ErrReservedName is returned by [Augment] when the schema already defines
+// one of the names the federation surface needs, typically because a remote
+// schema is itself a federation subgraph.
+var ErrReservedName = errors.New("schema already defines a federation name")
+
+// Entity is a GraphQL object type a connector can resolve by key for one role.
+type Entity struct {
+	// TypeName is the GraphQL object type the @key directive is attached to.
+	TypeName string
+	// SelectField is the root query field that lists rows of TypeName and
+	// accepts a where argument; entity batches are fetched through it.
+	SelectField string
+	// KeyFields are the GraphQL field names forming the key, in key order.
+	KeyFields []string
+}
+
+// Target is an Entity bound to the connector that resolves it.
+type Target struct {
+	Entity
+
+	// Connector is the name of the connector owning TypeName and SelectField.
+	Connector string
+}
+
+// Provider is the optional interface a schema provider satisfies when some of
+// its types can be exposed as federation entities. Connectors that do not
+// implement it (remote schemas, customized sources) contribute no entities.
+type Provider interface {
+	// FederationEntities returns the entities role may resolve. Entities
+	// whose key fields the role cannot read must be omitted.
+	FederationEntities(role string) []Entity
+}
+
+// IsRootField reports whether name is one of the federation root fields.
+func IsRootField(name string) bool {
+	return name == ServiceField || name == EntitiesField
+}
+
+// Augment adds the federation surface for entities to schema. Entities whose
+// type is missing from schema are skipped; Augment is a no-op when none
+// remain, so roles without entities keep their schema unchanged. It returns
+// the entities that were applied, or [ErrReservedName] (leaving schema
+// untouched) when a federation name is already taken.
+//
+// Object types in a merged schema are shared with the connectors' own
+// schemas, so the types Augment changes are replaced by modified copies
+// rather than edited in place.
+func Augment(schema *graph.Schema, entities []Target) ([]Target, error) {
+	if len(entities) == 0 {
+		return nil, nil
+	}
+
+	if name, taken := reservedNameInUse(schema); taken {
+		return nil, fmt.Errorf("%w: %q", ErrReservedName, name)
+	}
+
+	applied := make([]Target, 0, len(entities))
+	members := make([]string, 0, len(entities))
+
+	for _, entity := range entities {
+		idx := slices.IndexFunc(schema.Types, func(t *graph.ObjectType) bool {
+			return t.Name == entity.TypeName
+		})
+		if idx < 0 {
+			continue
+		}
+
+		keyed := *schema.Types[idx]
+		keyed.Directives = append(slices.Clone(keyed.Directives), &graph.Directive{
+			Name: keyDirective,
+			Arguments: []*graph.DirectiveArgument{
+				{Name: "fields", Value: strings.Join(entity.KeyFields, " ")},
+			},
+		})
+		schema.Types[idx] = &keyed
+
+		applied = append(applied, entity)
+		members = append(members, entity.TypeName)
+	}
+
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	slices.Sort(members)
+	addDefinitions(schema, members)
+
+	return applied, nil
+}
+
+// reservedNameInUse reports the first federation type, directive, or query
+// root field name schema already defines.
+func reservedNameInUse(schema *graph.Schema) (string, bool) {
+	reserved := []string{anyScalar, fieldSetScalar, serviceType, EntityUnion}
+	queryType := queryTypeName(schema)
+
+	for _, t := range schema.Types {
+		if slices.Contains(reserved, t.Name) {
+			return t.Name, true
+		}
+
+		if t.Name != queryType {
+			continue
+		}
+
+		for _, f := range t.Fields {
+			if IsRootField(f.Name) {
+				return f.Name, true
+			}
+		}
+	}
+
+	for _, t := range schema.Scalars {
+		if slices.Contains(reserved, t.Name) {
+			return t.Name, true
+		}
+	}
+
+	for _, t := range schema.Unions {
+		if slices.Contains(reserved, t.Name) {
+			return t.Name, true
+		}
+	}
+
+	for _, d := range schema.Directives {
+		if d.Name == keyDirective {
+			return "@" + d.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// addDefinitions adds the federation scalars, types, directive, and root
+// fields to schema. members are the _Entity union members.
+func addDefinitions(schema *graph.Schema, members []string) {
+	schema.Scalars = append(schema.Scalars,
+		&graph.ScalarType{Name: anyScalar},      //nolint:exhaustruct
+		&graph.ScalarType{Name: fieldSetScalar}, //nolint:exhaustruct
+	)
+
+	schema.Types = append(schema.Types, &graph.ObjectType{ //nolint:exhaustruct
+		Name: serviceType,
+		Fields: []*graph.Field{
+			{Name: "sdl", Type: graph.NewNamedType("String")}, //nolint:exhaustruct
+		},
+	})
+
+	schema.Unions = append(schema.Unions, &graph.UnionType{ //nolint:exhaustruct
+		Name:  EntityUnion,
+		Types: members,
+	})
+
+	resolvableDefault := "true"
+
+	schema.Directives = append(schema.Directives, &graph.DirectiveDefinition{ //nolint:exhaustruct
+		Name: keyDirective,
+		Arguments: []*graph.Argument{
+			{Name: "fields", Type: graph.NewNonNullType(fieldSetScalar)}, //nolint:exhaustruct
+			{ //nolint:exhaustruct
+				Name:         "resolvable",
+				Type:         graph.NewNamedType("Boolean"),
+				DefaultValue: &resolvableDefault,
+			},
+		},
+		Locations:  []graph.DirectiveLocation{graph.LocationObject, graph.LocationInterface},
+		Repeatable: true,
+	})
+
+	queryType := queryTypeName(schema)
+	for i, t := range schema.Types {
+		if t.Name != queryType {
+			continue
+		}
+
+		root := *t
+		root.Fields = append(slices.Clone(t.Fields),
+			&graph.Field{ //nolint:exhaustruct
+				Name: ServiceField,
+				Type: graph.NewNonNullType(serviceType),
+			},
+			&graph.Field{ //nolint:exhaustruct
+				Name: EntitiesField,
+				Type: graph.NewNonNullListType(graph.NewNamedType(EntityUnion)),
+				Arguments: []*graph.Argument{
+					{ //nolint:exhaustruct
+						Name: RepresentationsArgument,
+						Type: graph.NewNonNullListType(graph.NewNonNullType(anyScalar)),
+					},
+				},
+			},
+		)
+		schema.Types[i] = &root
+	}
+}
+
+func queryTypeName(schema *graph.Schema) string {
+	if schema.QueryType != nil {
+		return *schema.QueryType
+	}
+
+	return "Query"
+}
+
+// SDL renders schema as the subgraph SDL returned by _service. The federation
+// additions themselves are left out, as the Federation spec requires, and the
+// document opens with the @link extension that marks it as Federation v2.
+func SDL(schema *ast.Schema) string {
+	if schema == nil {
+		return ""
+	}
+
+	stripped := *schema
+	stripped.Types = make(map[string]*ast.Definition, len(schema.Types))
+	stripped.Directives = make(map[string]*ast.DirectiveDefinition, len(schema.Directives))
+
+	for name, def := range schema.Types {
+		switch name {
+		case anyScalar, fieldSetScalar, serviceType, EntityUnion:
+			continue
+		}
+
+		if schema.Query != nil && name == schema.Query.Name {
+			def = withoutRootFields(def)
+			stripped.Query = def
+		}
+
+		stripped.Types[name] = def
+	}
+
+	for name, def := range schema.Directives {
+		if name != keyDirective {
+			stripped.Directives[name] = def
+		}
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(linkSchemaExtension)
+	sb.WriteString("\n\n")
+	formatter.NewFormatter(&sb).FormatSchema(&stripped)
+
+	return sb.String()
+}
+
+// withoutRootFields returns a copy of the query root definition without the
+// federation root fields and the __schema / __type fields the validator adds.
+func withoutRootFields(def *ast.Definition) *ast.Definition {
+	out := *def
+	out.Fields = make(ast.FieldList, 0, len(def.Fields))
+
+	for _, field := range def.Fields {
+		if !IsRootField(field.Name) && !strings.HasPrefix(field.Name, "__") {
+			out.Fields = append(out.Fields, field)
+		}
+	}
+
+	return &out
+}
@@ -0,0 +1,153 @@
+package federation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/connector/federation"
+	"github.com/nhost/nhost/services/constellation/connector/schemamerge"
+	"github.com/nhost/nhost/services/constellation/graph"
+)
+
+func testSchema() *graph.Schema {
+	return &graph.Schema{
+		Types: []*graph.ObjectType{
+			{
+				Name: "Query",
+				Fields: []*graph.Field{
+					{Name: "users", Type: graph.NewNonNullListType(graph.NewNonNullType("users"))},
+				},
+			},
+			{
+				Name: "users",
+				Fields: []*graph.Field{
+					{Name: "id", Type: graph.NewNonNullType("Int")},
+					{Name: "name", Type: graph.NewNamedType("String")},
+				},
+			},
+		},
+	}
+}
+
+func usersTarget() federation.Target {
+	return federation.Target{
+		Entity: federation.Entity{
+			TypeName:    "users",
+			SelectField: "users",
+			KeyFields:   []string{"id"},
+		},
+		Connector: "default",
+	}
+}
+
+func TestAugment(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchema()
+	original := schema.Types[1]
+
+	applied, err := federation.Augment(schema, []federation.Target{
+		usersTarget(),
+		{Entity: federation.Entity{TypeName: "missing", SelectField: "missing", KeyFields: []string{"id"}}},
+	})
+	if err != nil {
+		t.Fatalf("Augment() error = %v", err)
+	}
+
+	if len(applied) != 1 || applied[0].TypeName != "users" {
+		t.Fatalf("Augment() applied = %+v, want only users", applied)
+	}
+
+	if len(original.Directives) != 0 {
+		t.Errorf("Augment() modified the shared users type in place")
+	}
+
+	_, validated, err := schemamerge.BuildValidatedSchema(schema, "user")
+	if err != nil {
+		t.Fatalf("BuildValidatedSchema() error = %v", err)
+	}
+
+	users := validated.Types["users"]
+	if key := users.Directives.ForName("key"); key == nil || key.Arguments.ForName("fields").Value.Raw != "id" {
+		t.Errorf("users @key = %v, want fields: \"id\"", key)
+	}
+
+	for _, field := range []string{federation.ServiceField, federation.EntitiesField} {
+		if validated.Query.Fields.ForName(field) == nil {
+			t.Errorf("query root has no %s field", field)
+		}
+	}
+
+	if entity := validated.Types[federation.EntityUnion]; entity == nil ||
+		len(entity.Types) != 1 || entity.Types[0] != "users" {
+		t.Errorf("_Entity union = %v, want [users]", entity)
+	}
+}
+
+func TestAugmentNoEntities(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchema()
+
+	applied, err := federation.Augment(schema, nil)
+	if err != nil || applied != nil {
+		t.Fatalf("Augment() = %v, %v, want nil, nil", applied, err)
+	}
+
+	if len(schema.Scalars) != 0 || len(schema.Unions) != 0 || len(schema.Types[0].Fields) != 1 {
+		t.Errorf("Augment() changed a schema without entities")
+	}
+}
+
+func TestAugmentReservedName(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchema()
+	schema.Types[0].Fields = append(schema.Types[0].Fields, &graph.Field{
+		Name: federation.ServiceField,
+		Type: graph.NewNamedType("String"),
+	})
+
+	_, err := federation.Augment(schema, []federation.Target{usersTarget()})
+	if !errors.Is(err, federation.ErrReservedName) {
+		t.Fatalf("Augment() error = %v, want ErrReservedName", err)
+	}
+
+	if len(schema.Types[1].Directives) != 0 {
+		t.Errorf("Augment() modified the schema despite the error")
+	}
+}
+
+func TestSDL(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchema()
+	if _, err := federation.Augment(schema, []federation.Target{usersTarget()}); err != nil {
+		t.Fatalf("Augment() error = %v", err)
+	}
+
+	_, validated, err := schemamerge.BuildValidatedSchema(schema, "user")
+	if err != nil {
+		t.Fatalf("BuildValidatedSchema() error = %v", err)
+	}
+
+	sdl := federation.SDL(validated)
+
+	if !strings.HasPrefix(sdl, `extend schema @link(url: "https://specs.apollo.dev/federation/v2.3"`) {
+		t.Errorf("SDL() does not open with the @link extension:\n%s", sdl)
+	}
+
+	if !strings.Contains(sdl, `type users @key(fields: "id")`) {
+		t.Errorf("SDL() has no keyed users type:\n%s", sdl)
+	}
+
+	hidden := []string{
+		"_service", "_entities", "_Entity", "_Any", "_Service", "directive @key", "__schema",
+	}
+	for _, name := range hidden {
+		if strings.Contains(sdl, name) {
+			t.Errorf("SDL() contains %q:\n%s", name, sdl)
+		}
+	}
+}
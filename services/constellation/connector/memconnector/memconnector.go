@@ -262,7 +262,12 @@ func (c *connector) Execute(
 			)
 		}
 
-		result[field.Alias] = entry.response
+		key := field.Alias
+		if key == "" {
+			key = field.Name
+		}
+
+		result[key] = entry.response
 	}
 
 	return result, nil
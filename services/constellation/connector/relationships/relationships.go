@@ -43,10 +43,11 @@ type TypeNameResolver interface {
 // translates *metadata.Metadata into a []RelationshipSpec before calling
 // Inject; the spec carries no metadata-specific shape or magic strings.
 //
-// For a db→rs relationship, TargetIdentifier resolves to the remote schema's
-// root field type name, RemoteFieldName names the remote root field, and
-// BoundArguments lists which of that field's arguments are bound by the
-// relationship (and therefore hidden from the user-facing field).
+// For a db→rs or rs→rs relationship, TargetIdentifier resolves to the remote
+// schema's root field type name, RemoteFieldName names the remote root field,
+// and BoundArguments lists which of that field's arguments are bound by the
+// relationship (and therefore hidden from the user-facing field). The two
+// differ only in the source: a table type or a remote-schema type.
 //
 // For a db→db or rs→db relationship, TargetIdentifier is the source-local
 // table identifier (e.g., "public.users") that the target connector resolves
@@ -64,8 +65,8 @@ type RelationshipSpec struct {
 	TargetConnector string
 	// TargetIdentifier is the connector-local identifier of the target
 	// (e.g. "public.posts" for SQL, or the remote root field name for a
-	// db→rs relationship). It is fed to the target connector's GetTypeName
-	// to resolve the GraphQL type name.
+	// db→rs or rs→rs relationship). It is fed to the target connector's
+	// GetTypeName to resolve the GraphQL type name.
 	TargetIdentifier string
 	// IsArray is true for one-to-many / many-to-many relationships and false
 	// for object (one-to-one / many-to-one) relationships.
@@ -75,7 +76,7 @@ type RelationshipSpec struct {
 	// db→db relationships where the source connector can enforce SQL filters
 	// on the target side.
 	WithSQLArgs bool
-	// RemoteFieldName, when non-empty, marks this spec as a db→rs
+	// RemoteFieldName, when non-empty, marks this spec as a db→rs or rs→rs
 	// relationship. It names the root-Query field on the remote schema whose
 	// type and unmapped arguments are imported onto the injected field.
 	RemoteFieldName string
@@ -89,8 +90,8 @@ type RelationshipSpec struct {
 	// use the canonical "An array relationship" baked into the array-field
 	// builder. The composer sets ObjectDescription to "An object relationship"
 	// for db→db object relationships and "" for rs→db object relationships
-	// (matching Hasura's per-direction defaults). For db→rs the description
-	// is sourced from the remote schema, so this field is unused.
+	// (matching Hasura's per-direction defaults). For db→rs and rs→rs the
+	// description is sourced from the remote schema, so this field is unused.
 	ObjectDescription string
 }
 
@@ -246,10 +247,10 @@ func sqlListArgs(typeName string) []*graph.Argument {
 	}
 }
 
-// addRemoteSchemaRelFieldToSchemas adds a db→rs relationship field to every
-// role schema where both the source object type and the remote field are
-// accessible. The field's GraphQL type and description are imported from the
-// remote schema's matching root field, and arguments listed in
+// addRemoteSchemaRelFieldToSchemas adds a db→rs or rs→rs relationship field
+// to every role schema where both the source object type and the remote field
+// are accessible. The field's GraphQL type and description are imported from
+// the remote schema's matching root field, and arguments listed in
 // spec.BoundArguments (e.g., appID: $id) are stripped so only the unbound,
 // user-facing arguments (e.g., resolve: Boolean!) remain — matching Hasura's
 // behaviour.
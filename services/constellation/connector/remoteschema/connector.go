@@ -17,10 +17,12 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/nhost/nhost/services/constellation/graph"
 	"github.com/nhost/nhost/services/constellation/internal/requestcontext"
+	"github.com/nhost/nhost/services/constellation/internal/upload"
 	"github.com/nhost/nhost/services/constellation/metadata"
 	"github.com/vektah/gqlparser/v2/ast"
 )
@@ -64,6 +66,37 @@ type Connector struct {
 	schemas              map[string]*graph.Schema          // role -> schema
 	presets              map[string]map[string][]presetArg // role -> "TypeName.fieldName" -> presets
 	httpClient           *httpClient
+	// responseTransform, when set, rewrites operation responses before they
+	// are parsed. Introspection responses are never transformed.
+	responseTransform *responseTransform
+	// subscriptionTimeout bounds the upstream WebSocket handshake of a
+	// proxied subscription; it is the definition's timeout_seconds.
+	subscriptionTimeout time.Duration
+	// sdlHash digests the admin schema introspected at construction; the
+	// background re-introspection compares against it.
+	sdlHash string
+	// stopWatch and watchDone stop and await the background
+	// re-introspection; both are nil when it is not running.
+	stopWatch context.CancelFunc
+	watchDone chan struct{}
+}
+
+// Option configures optional behaviour of New.
+type Option func(*options)
+
+type options struct {
+	notify func(SchemaRefresh)
+}
+
+// WithSchemaRefreshes enables background re-introspection for remote schemas
+// whose metadata sets introspection_cache_ttl: every TTL the endpoint is
+// introspected again and the outcomes described on SchemaRefresh are reported
+// to notify, from the connector's own goroutine. Without this option the
+// schema is introspected only at construction.
+func WithSchemaRefreshes(notify func(SchemaRefresh)) Option {
+	return func(o *options) {
+		o.notify = notify
+	}
 }
 
 // New creates a new remote schema connector from metadata. The provided doer is
@@ -74,7 +107,13 @@ func New(
 	ctx context.Context,
 	meta *metadata.RemoteSchemaMetadata,
 	doer HTTPDoer,
+	opts ...Option,
 ) (*Connector, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	timeout := meta.Definition.TimeoutSeconds
 	if timeout <= 0 {
 		timeout = defaultTimeoutSeconds
@@ -121,16 +160,29 @@ func New(
 		return nil, fmt.Errorf("building role schemas for remote schema %s: %w", meta.Name, err)
 	}
 
+	reqTransform, err := newRequestTransform(meta.Definition.RequestTransform)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request_transform for remote schema %s: %w", meta.Name, err)
+	}
+
+	respTransform, err := newResponseTransform(meta.Definition.ResponseTransform)
+	if err != nil {
+		return nil, fmt.Errorf("invalid response_transform for remote schema %s: %w", meta.Name, err)
+	}
+
 	connector := &Connector{
 		name:                 meta.Name,
 		forwardClientHeaders: meta.Definition.ForwardClientHeaders,
 		schemas:              schemas,
 		presets:              presets,
 		httpClient: &httpClient{
-			url:     url,
-			headers: headers,
-			client:  doer,
+			url:       url,
+			headers:   headers,
+			client:    doer,
+			transform: reqTransform,
 		},
+		responseTransform:   respTransform,
+		subscriptionTimeout: time.Duration(timeout) * time.Second,
 	}
 
 	// Admin role always has full access via introspection.
@@ -140,6 +192,11 @@ func New(
 	}
 
 	connector.schemas[metadata.RoleAdmin] = adminSchema
+	connector.sdlHash = schemaHash(adminSchema)
+
+	if ttl := meta.Definition.IntrospectionCacheTTL; ttl > 0 && o.notify != nil {
+		connector.startIntrospectionWatch(ctx, time.Duration(ttl)*time.Second, o.notify)
+	}
 
 	return connector, nil
 }
@@ -258,10 +315,29 @@ func (c *Connector) roleRootTypeName(role string, operation ast.Operation) strin
 	return defaultRootTypeName(operation)
 }
 
-// Close releases connector-owned resources. Currently a no-op because the
-// remote schema connector borrows its HTTP transport from the caller and
-// holds no other state requiring shutdown.
-func (c *Connector) Close() {}
+// acceptsUploads reports whether the role's schema declares the multipart
+// request spec's Upload scalar.
+func (c *Connector) acceptsUploads(role string) bool {
+	schema := c.schemas[role]
+	if schema == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(schema.Scalars, func(s *graph.ScalarType) bool {
+		return s.Name == upload.ScalarName
+	})
+}
+
+// Close stops the background re-introspection, if running, and waits for it
+// to exit. The HTTP transport is borrowed from the caller and left open.
+func (c *Connector) Close() {
+	if c.stopWatch == nil {
+		return
+	}
+
+	c.stopWatch()
+	<-c.watchDone
+}
 
 // Execute forwards a GraphQL operation to the remote endpoint.
 // The planner handles relationship detection, AST transformation, and phantom field injection.
@@ -297,6 +373,7 @@ func (c *Connector) Execute(
 		ctx,
 		query,
 		variables,
+		role,
 		sessionVariables,
 		clientHeaders,
 		logger,
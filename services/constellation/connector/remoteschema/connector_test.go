@@ -770,7 +770,7 @@ func TestClose(t *testing.T) {
 
 	connector := newMockConnector(t, mockDoer)
 
-	// Close is a no-op, should not panic.
+	// No background re-introspection was started, so Close has nothing to stop.
 	connector.Close()
 
 	// Calling Close twice is also safe.
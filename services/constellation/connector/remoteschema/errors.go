@@ -17,6 +17,23 @@ var ErrRemoteStatus = errors.New("remote schema returned status")
 // response contains a non-empty top-level errors array.
 var ErrIntrospectionResponse = errors.New("introspection returned errors")
 
+// ErrTransformTemplateEngine is returned when a request or response transform
+// names a template_engine other than Kriti.
+var ErrTransformTemplateEngine = errors.New("unsupported transform template_engine")
+
+// ErrTransformMethod is returned when a request transform selects an HTTP
+// method other than GET, POST, PUT, PATCH or DELETE.
+var ErrTransformMethod = errors.New("unsupported transform method")
+
+// ErrTransformBodyAction is returned when a transform body uses an action the
+// transform does not support.
+var ErrTransformBodyAction = errors.New("unsupported transform body action")
+
+// ErrUploadWithTransform is returned when an operation carrying file uploads
+// targets a remote schema with a request transform, whose templates render
+// JSON bodies only.
+var ErrUploadWithTransform = errors.New("file uploads cannot be combined with a request_transform")
+
 // RemoteError is a single GraphQL error returned by a remote schema endpoint.
 // The fields mirror the GraphQL-over-HTTP wire format and are populated by
 // encoding/json/v2 when the remote endpoint responds with a `errors` array.
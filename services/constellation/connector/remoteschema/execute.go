@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"unicode/utf8"
 
+	"github.com/nhost/nhost/services/constellation/internal/upload"
 	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/formatter"
 	"github.com/vektah/gqlparser/v2/parser"
@@ -664,11 +665,13 @@ func buildQueryString(
 	return buf.String()
 }
 
-// executeRemoteQuery sends a GraphQL query to the remote endpoint.
+// executeRemoteQuery sends a GraphQL query to the remote endpoint, as a
+// multipart request when its variables carry file uploads.
 func (c *Connector) executeRemoteQuery(
 	ctx context.Context,
 	query string,
 	variables map[string]any,
+	role string,
 	sessionVariables map[string]any,
 	clientHeaders http.Header,
 	logger *slog.Logger,
@@ -678,14 +681,35 @@ func (c *Connector) executeRemoteQuery(
 		slog.String("query", query),
 	)
 
-	body, err := c.httpClient.do(ctx, graphQLRequest{
+	request := graphQLRequest{
 		Query:     query,
 		Variables: variables,
-	}, sessionVariables, clientHeaders)
+	}
+
+	var (
+		body []byte
+		err  error
+	)
+
+	// Uploads are only streamed to remotes that declare the scalar; any other
+	// remote cannot reference them and receives them as null.
+	if files := upload.Collect(variables); len(files) > 0 && c.acceptsUploads(role) {
+		body, err = c.httpClient.doUpload(ctx, request, files, sessionVariables, clientHeaders)
+	} else {
+		body, err = c.httpClient.do(ctx, request, sessionVariables, clientHeaders)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("sending GraphQL request: %w", err)
 	}
 
+	if c.responseTransform != nil {
+		body, err = c.responseTransform.render(body, sessionVariables)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var gqlResp graphQLResponse
 	if err := json.Unmarshal(
 		body, &gqlResp,
@@ -7,10 +7,14 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"strconv"
 	"strings"
 
 	oapimw "github.com/nhost/nhost/internal/lib/oapi/middleware"
+	"github.com/nhost/nhost/services/constellation/internal/upload"
 )
 
 // clientHeadersIgnored is the list of headers that must not be forwarded to
@@ -51,6 +55,8 @@ type httpClient struct {
 	url     string
 	headers map[string]string
 	client  HTTPDoer
+	// transform, when set, rewrites every request before it is sent.
+	transform *requestTransform
 }
 
 // applyClientHeaders forwards client headers to the request following the
@@ -88,11 +94,14 @@ func applyClientHeaders(req *http.Request, clientHeaders http.Header) {
 	}
 }
 
-// do executes an HTTP POST request with the given body and returns the response body.
-// Headers are applied in this priority order (highest first):
-// 1. Configured headers (from remote schema definition)
-// 2. Session variables as headers (x-hasura-*)
-// 3. Client headers (if forward_client_headers is enabled).
+// do sends the given body to the remote endpoint and returns the response body.
+// The body is sent as a JSON POST to the configured URL unless a request
+// transform rewrites it. Headers are applied in this priority order (highest
+// first):
+// 1. Request transform add_headers (after its remove_headers)
+// 2. Configured headers (from remote schema definition)
+// 3. Session variables as headers (x-hasura-*)
+// 4. Client headers (if forward_client_headers is enabled).
 func (h *httpClient) do(
 	ctx context.Context,
 	body any,
@@ -104,25 +113,146 @@ func (h *httpClient) do(
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(jsonBody))
+	out := outgoingRequest{
+		method:        http.MethodPost,
+		url:           h.url,
+		body:          jsonBody,
+		contentType:   "application/json",
+		addHeaders:    nil,
+		removeHeaders: nil,
+	}
+
+	if h.transform != nil {
+		out, err = h.transform.render(h.url, jsonBody, sessionVariables)
+		if err != nil {
+			return nil, fmt.Errorf("applying request_transform: %w", err)
+		}
+	}
+
+	req, err := newRequest(ctx, out, sessionVariables, clientHeaders, h.headers)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	if clientHeaders != nil {
-		applyClientHeaders(req, clientHeaders)
+	return h.send(ctx, req)
+}
+
+// doUpload sends body as a GraphQL multipart request
+// (https://github.com/jaydenseric/graphql-multipart-request-spec) carrying
+// files, which must be the uploads found in body's variables. File contents are
+// streamed from disk into the request body as it is sent. Headers follow
+// httpClient.do; request transforms render JSON bodies only, so they cannot be
+// combined with uploads.
+func (h *httpClient) doUpload(
+	ctx context.Context,
+	body graphQLRequest,
+	files []upload.Ref,
+	sessionVariables map[string]any,
+	clientHeaders http.Header,
+) ([]byte, error) {
+	if h.transform != nil {
+		return nil, ErrUploadWithTransform
 	}
 
-	for name, value := range sessionVariables {
-		req.Header.Set(name, fmt.Sprintf("%v", value))
+	// Uploads encode as null, which is what the spec expects in operations.
+	operations, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	pr, pw := io.Pipe()
+	defer pr.Close()
 
-	for name, value := range h.headers {
-		req.Header.Set(name, value)
+	form := multipart.NewWriter(pw)
+
+	req, err := newRequest(ctx, outgoingRequest{
+		method:        http.MethodPost,
+		url:           h.url,
+		body:          nil,
+		contentType:   form.FormDataContentType(),
+		addHeaders:    nil,
+		removeHeaders: nil,
+	}, sessionVariables, clientHeaders, h.headers)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = pr
+	req.ContentLength = -1
+
+	go func() {
+		pw.CloseWithError(writeUploadForm(form, operations, files))
+	}()
+
+	return h.send(ctx, req)
+}
+
+// writeUploadForm writes the operations field, the map field and one part per
+// file, named by its index, then closes the form.
+func writeUploadForm(form *multipart.Writer, operations []byte, files []upload.Ref) error {
+	fileMap := make(map[string][]string, len(files))
+	for i, ref := range files {
+		fileMap[strconv.Itoa(i)] = []string{ref.Path}
+	}
+
+	mapField, err := json.Marshal(fileMap, json.Deterministic(true))
+	if err != nil {
+		return fmt.Errorf("failed to marshal upload map: %w", err)
+	}
+
+	if err := form.WriteField("operations", string(operations)); err != nil {
+		return fmt.Errorf("writing operations field: %w", err)
+	}
+
+	if err := form.WriteField("map", string(mapField)); err != nil {
+		return fmt.Errorf("writing map field: %w", err)
+	}
+
+	for i, ref := range files {
+		if err := writeUploadPart(form, strconv.Itoa(i), ref.File); err != nil {
+			return err
+		}
+	}
+
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("closing upload form: %w", err)
+	}
+
+	return nil
+}
+
+func writeUploadPart(form *multipart.Writer, name string, file upload.File) error {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", multipart.FileContentDisposition(name, file.Filename))
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	header.Set("Content-Type", contentType)
+
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("writing upload %q: %w", file.Filename, err)
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer r.Close()
+
+	if _, err := io.Copy(part, r); err != nil {
+		return fmt.Errorf("writing upload %q: %w", file.Filename, err)
 	}
 
+	return nil
+}
+
+// send executes req and returns the response body, failing on a non-200
+// status.
+func (h *httpClient) send(ctx context.Context, req *http.Request) ([]byte, error) {
 	resp, err := h.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
@@ -141,7 +271,7 @@ func (h *httpClient) do(
 		oapimw.LoggerFromContext(ctx).ErrorContext(
 			ctx,
 			"remote schema returned non-200 status",
-			slog.String("url", h.url),
+			slog.String("url", req.URL.String()),
 			slog.Int("status", resp.StatusCode),
 			slog.String("body", string(respBody)),
 		)
@@ -151,3 +281,49 @@ func (h *httpClient) do(
 
 	return respBody, nil
 }
+
+// newRequest builds the HTTP request for out, applying headers in the
+// priority order documented on httpClient.do.
+func newRequest(
+	ctx context.Context,
+	out outgoingRequest,
+	sessionVariables map[string]any,
+	clientHeaders http.Header,
+	configured map[string]string,
+) (*http.Request, error) {
+	var body io.Reader
+	if out.body != nil {
+		body = bytes.NewReader(out.body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, out.method, out.url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if clientHeaders != nil {
+		applyClientHeaders(req, clientHeaders)
+	}
+
+	for name, value := range sessionVariables {
+		req.Header.Set(name, fmt.Sprintf("%v", value))
+	}
+
+	if out.contentType != "" {
+		req.Header.Set("Content-Type", out.contentType)
+	}
+
+	for name, value := range configured {
+		req.Header.Set(name, value)
+	}
+
+	for _, name := range out.removeHeaders {
+		req.Header.Del(name)
+	}
+
+	for name, value := range out.addHeaders {
+		req.Header.Set(name, value)
+	}
+
+	return req, nil
+}
@@ -2,10 +2,15 @@ package remoteschema
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/nhost/nhost/services/constellation/internal/upload"
 )
 
 func TestApplyClientHeaders(t *testing.T) {
@@ -141,3 +146,106 @@ func TestHTTPClient_HeaderPriority(t *testing.T) {
 		)
 	}
 }
+
+func TestHTTPClient_DoUploadStreamsMultipartRequest(t *testing.T) {
+	t.Parallel()
+
+	type received struct {
+		operations string
+		fileMap    string
+		filename   string
+		content    string
+	}
+
+	requests := make(chan received, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("parsing multipart request: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		file, header, err := r.FormFile("0")
+		if err != nil {
+			t.Errorf("reading file part: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+		defer file.Close()
+
+		content, _ := io.ReadAll(file)
+
+		requests <- received{
+			operations: r.FormValue("operations"),
+			fileMap:    r.FormValue("map"),
+			filename:   header.Filename,
+			content:    string(content),
+		}
+
+		if _, err := w.Write([]byte(`{"data":{"ok":true}}`)); err != nil {
+			t.Errorf("writing response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	file, err := upload.Spool(strings.NewReader("hello"), "hello.txt", "text/plain", 1024)
+	if err != nil {
+		t.Fatalf("spooling upload: %v", err)
+	}
+
+	t.Cleanup(func() { _ = file.Remove() })
+
+	client := &httpClient{
+		url:     server.URL,
+		headers: nil,
+		client:  &http.Client{Timeout: 60 * time.Second},
+	}
+
+	variables := map[string]any{"file": file}
+
+	body, err := client.doUpload(
+		context.Background(),
+		graphQLRequest{Query: "mutation ($file: Upload!) { ok(file: $file) }", Variables: variables},
+		upload.Collect(variables),
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(body) != `{"data":{"ok":true}}` {
+		t.Errorf("unexpected response body: %s", body)
+	}
+
+	got := <-requests
+
+	want := received{
+		operations: `{"query":"mutation ($file: Upload!) { ok(file: $file) }","variables":{"file":null}}`,
+		fileMap:    `{"0":["variables.file"]}`,
+		filename:   "hello.txt",
+		content:    "hello",
+	}
+	if got != want {
+		t.Errorf("remote received %+v, want %+v", got, want)
+	}
+}
+
+func TestHTTPClient_DoUploadRejectsRequestTransform(t *testing.T) {
+	t.Parallel()
+
+	client := &httpClient{
+		url:       "http://remote.test/graphql",
+		headers:   nil,
+		client:    &http.Client{Timeout: time.Second},
+		transform: &requestTransform{},
+	}
+
+	_, err := client.doUpload(context.Background(), graphQLRequest{}, nil, nil, nil)
+	if !errors.Is(err, ErrUploadWithTransform) {
+		t.Fatalf("expected ErrUploadWithTransform, got %v", err)
+	}
+}
@@ -2,13 +2,17 @@ package remoteschema
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json/jsontext"
 	json "encoding/json/v2"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/nhost/nhost/services/constellation/graph"
+	"github.com/vektah/gqlparser/v2/formatter"
 )
 
 const introspectionQuery = `
@@ -124,6 +128,91 @@ func Introspect(
 	})
 }
 
+// SchemaRefresh is the outcome of one background re-introspection of a remote
+// schema whose metadata sets introspection_cache_ttl (see WithSchemaRefreshes).
+// Changed reports that the remote SDL no longer matches the schema the
+// connector was built from, so the composed schemas are stale. Err reports
+// that re-introspection failed; the connector keeps serving its last good
+// schema. A refresh with neither set follows a failure and reports recovery.
+type SchemaRefresh struct {
+	Source  string
+	Changed bool
+	Err     error
+}
+
+// schemaHash digests schema's SDL so re-introspections can be compared
+// against the schema the connector was built from.
+func schemaHash(schema *graph.Schema) string {
+	var sdl strings.Builder
+	formatter.NewFormatter(&sdl).FormatSchemaDocument(schema.ToAST())
+
+	sum := sha256.Sum256([]byte(sdl.String()))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// startIntrospectionWatch runs watchIntrospection on its own goroutine until
+// Close. The goroutine outlives the construction ctx, keeping only its values.
+func (c *Connector) startIntrospectionWatch(
+	ctx context.Context,
+	interval time.Duration,
+	notify func(SchemaRefresh),
+) {
+	ctx, c.stopWatch = context.WithCancel(context.WithoutCancel(ctx))
+	c.watchDone = make(chan struct{})
+
+	go func() {
+		defer close(c.watchDone)
+
+		c.watchIntrospection(ctx, interval, notify)
+	}()
+}
+
+// watchIntrospection re-introspects the remote endpoint every interval until
+// ctx is cancelled. It reports every failure, every change, and the first
+// success after a failure; unchanged successes are not reported. A change is
+// reported again on every tick until the connector is replaced, so a rebuild
+// that could not be applied is retried.
+func (c *Connector) watchIntrospection(
+	ctx context.Context,
+	interval time.Duration,
+	notify func(SchemaRefresh),
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failing := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		refresh := SchemaRefresh{Source: c.name, Changed: false, Err: nil}
+
+		schema, err := c.introspectRemoteSchema(ctx)
+
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			refresh.Err = err
+		default:
+			refresh.Changed = schemaHash(schema) != c.sdlHash
+		}
+
+		if refresh.Err == nil && !refresh.Changed && !failing {
+			continue
+		}
+
+		failing = refresh.Err != nil
+
+		notify(refresh)
+	}
+}
+
 // introspectRemoteSchema fetches the schema from a remote GraphQL endpoint and returns a graph.Schema.
 func (c *Connector) introspectRemoteSchema(ctx context.Context) (*graph.Schema, error) {
 	return introspectViaHTTP(ctx, c.httpClient)
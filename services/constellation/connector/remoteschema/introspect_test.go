@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/nhost/nhost/services/constellation/connector/remoteschema"
 	"github.com/nhost/nhost/services/constellation/connector/remoteschema/mock"
@@ -166,3 +168,106 @@ func TestIntrospect_NilDoerUsesDefaultClient(t *testing.T) {
 		t.Fatal("expected non-nil schema from DefaultClient path")
 	}
 }
+
+// TestNew_SchemaRefreshes drives the background re-introspection enabled by
+// introspection_cache_ttl through a failure, the recovery that follows it and
+// a changed SDL. The TTL has one-second granularity, so the test takes a few
+// seconds of wall-clock time.
+func TestNew_SchemaRefreshes(t *testing.T) {
+	t.Parallel()
+
+	const (
+		serveOriginal int32 = iota
+		serveFailure
+		serveChanged
+	)
+
+	changedResponse := strings.Replace(
+		testIntrospectionResponse, `"name": "countries"`, `"name": "allCountries"`, 1,
+	)
+
+	var mode atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			switch mode.Load() {
+			case serveFailure:
+				w.WriteHeader(http.StatusBadGateway)
+			case serveChanged:
+				w.Header().Set("Content-Type", "application/json")
+				writeOrFail(t, w, []byte(changedResponse))
+			default:
+				w.Header().Set("Content-Type", "application/json")
+				writeOrFail(t, w, []byte(testIntrospectionResponse))
+			}
+		},
+	))
+	defer server.Close()
+
+	meta := newTestMetadata(server.URL, nil)
+	meta.Definition.IntrospectionCacheTTL = 1
+
+	refreshes := make(chan remoteschema.SchemaRefresh, 8)
+
+	conn, err := remoteschema.New(
+		context.Background(), meta, nil,
+		remoteschema.WithSchemaRefreshes(func(r remoteschema.SchemaRefresh) {
+			refreshes <- r
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	defer conn.Close()
+
+	next := func() remoteschema.SchemaRefresh {
+		t.Helper()
+
+		select {
+		case r := <-refreshes:
+			return r
+		case <-time.After(5 * time.Second):
+			t.Fatal("no schema refresh reported")
+
+			return remoteschema.SchemaRefresh{}
+		}
+	}
+
+	mode.Store(serveFailure)
+
+	if r := next(); r.Err == nil || r.Changed || r.Source != "test-remote" {
+		t.Fatalf("expected failure refresh for test-remote, got %+v", r)
+	}
+
+	mode.Store(serveOriginal)
+
+	if r := next(); r.Err != nil || r.Changed {
+		t.Fatalf("expected recovery refresh, got %+v", r)
+	}
+
+	mode.Store(serveChanged)
+
+	if r := next(); r.Err != nil || !r.Changed {
+		t.Fatalf("expected changed refresh, got %+v", r)
+	}
+}
+
+func TestNew_NoSchemaRefreshesWithoutTTL(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+	defer server.Close()
+
+	conn, err := remoteschema.New(
+		context.Background(), newTestMetadata(server.URL, nil), nil,
+		remoteschema.WithSchemaRefreshes(func(r remoteschema.SchemaRefresh) {
+			t.Errorf("unexpected refresh without introspection_cache_ttl: %+v", r)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	// Without a TTL no watcher is started, so Close has nothing to stop.
+	conn.Close()
+}
@@ -0,0 +1,510 @@
+package remoteschema
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nhost/nhost/services/constellation/internal/requestcontext"
+	"github.com/nhost/nhost/services/constellation/subscription"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// graphql-transport-ws message types used on the upstream connection
+// (https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md).
+const (
+	wsSubprotocol           = "graphql-transport-ws"
+	wsMessageConnectionInit = "connection_init"
+	wsMessageConnectionAck  = "connection_ack"
+	wsMessagePing           = "ping"
+	wsMessagePong           = "pong"
+	wsMessageSubscribe      = "subscribe"
+	wsMessageNext           = "next"
+	wsMessageError          = "error"
+	wsMessageComplete       = "complete"
+
+	// upstreamOperationID is the operation id used on the upstream socket.
+	// Each client subscription gets its own connection, so one id suffices.
+	upstreamOperationID = "1"
+
+	// subscriptionUpdateBuffer bounds how many upstream events are held for a
+	// slow consumer before the relay blocks reading the upstream socket.
+	subscriptionUpdateBuffer = 16
+
+	// upstreamCloseWait bounds the best-effort complete/close frames written
+	// when a subscription is stopped.
+	upstreamCloseWait = time.Second
+)
+
+// ErrSubscriptionHandlerShutdown is returned by Start once the handler has
+// been shut down (metadata reload or server stop).
+var ErrSubscriptionHandlerShutdown = errors.New("remote schema subscription handler is shut down")
+
+// ErrUpstreamHandshake is returned when the remote endpoint does not complete
+// the graphql-transport-ws handshake with a connection_ack.
+var ErrUpstreamHandshake = errors.New("remote schema did not acknowledge the subscription connection")
+
+// webSocketUpgradeHeaders are set by the dialer itself; forwarding a client
+// copy of them makes the handshake fail.
+var webSocketUpgradeHeaders = []string{ //nolint:gochecknoglobals
+	"Upgrade",
+	"Sec-Websocket-Key",
+	"Sec-Websocket-Version",
+	"Sec-Websocket-Extensions",
+	"Sec-Websocket-Protocol",
+}
+
+// wsMessage is a graphql-transport-ws protocol message.
+type wsMessage struct {
+	ID      string         `json:"id,omitempty"`
+	Type    string         `json:"type"`
+	Payload jsontext.Value `json:"payload,omitempty"`
+}
+
+// wsSubscribePayload is the payload of an upstream subscribe message.
+type wsSubscribePayload struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables,omitempty"`
+	OperationName string         `json:"operationName,omitempty"`
+}
+
+// wsNextPayload is the execution result carried by an upstream next message.
+type wsNextPayload struct {
+	Data   jsontext.Value `json:"data,omitempty"`
+	Errors []RemoteError  `json:"errors,omitempty"`
+}
+
+// NewSubscriptionHandler returns a handler that proxies subscriptions to the
+// remote endpoint over graphql-transport-ws. The polling interval is unused:
+// the remote server pushes its own events.
+func (c *Connector) NewSubscriptionHandler( //nolint:ireturn,nolintlint
+	_ time.Duration,
+	_ *slog.Logger,
+) subscription.Handler {
+	return &subscriptionHandler{
+		connector: c,
+		mu:        sync.Mutex{},
+		subs:      make(map[string]*remoteSubscription),
+		shutdown:  false,
+	}
+}
+
+// subscriptionHandler opens one upstream connection per client subscription
+// and relays its next/error/complete messages. Request and response
+// transforms are not applied: they describe HTTP requests.
+type subscriptionHandler struct {
+	connector *Connector
+
+	mu       sync.Mutex
+	subs     map[string]*remoteSubscription
+	shutdown bool
+}
+
+// remoteSubscription is one proxied subscription and its upstream socket.
+type remoteSubscription struct {
+	id   string
+	conn *websocket.Conn
+
+	writeMu  sync.Mutex
+	stopOnce sync.Once
+	// stop is closed when the subscription is stopped from our side; done is
+	// closed once the relay goroutine has exited and closed the update channel.
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Start dials the remote endpoint, completes the graphql-transport-ws
+// handshake and subscribes. Presets for the role are applied to the operation
+// and, when forward_client_headers is set, the client headers found on ctx are
+// forwarded, exactly as for queries. ctx bounds the handshake only.
+func (h *subscriptionHandler) Start(
+	ctx context.Context,
+	req subscription.Request,
+	logger *slog.Logger,
+) (<-chan subscription.Update, error) {
+	c := h.connector
+
+	if h.isShutdown() {
+		return nil, ErrSubscriptionHandlerShutdown
+	}
+
+	operation, fragments := applyPresetsToDocument(
+		req.Operation,
+		req.Fragments,
+		c.presets[req.Role],
+		req.SessionVariables,
+		c.roleRootTypeName(req.Role, ast.Subscription),
+	)
+
+	var clientHeaders http.Header
+	if c.forwardClientHeaders {
+		clientHeaders = requestcontext.ClientHeadersFromContext(ctx)
+	}
+
+	conn, err := c.dialSubscription(ctx, req.SessionVariables, clientHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to remote schema %s: %w", c.name, err)
+	}
+
+	sub := &remoteSubscription{
+		id:       req.ID,
+		conn:     conn,
+		writeMu:  sync.Mutex{},
+		stopOnce: sync.Once{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if err := sub.write(wsSubscribeMessage(buildQueryString(operation, fragments), req)); err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("subscribing on remote schema %s: %w", c.name, err)
+	}
+
+	h.mu.Lock()
+	if h.shutdown {
+		h.mu.Unlock()
+
+		_ = conn.Close()
+
+		return nil, ErrSubscriptionHandlerShutdown
+	}
+
+	h.subs[req.ID] = sub
+	h.mu.Unlock()
+
+	updates := make(chan subscription.Update, subscriptionUpdateBuffer)
+	go h.relay(sub, updates, logger)
+
+	return updates, nil
+}
+
+// Stop completes the upstream operation, closes the upstream socket and waits
+// for the update channel to be closed.
+func (h *subscriptionHandler) Stop(_ context.Context, subscriptionID string) {
+	h.mu.Lock()
+	sub := h.subs[subscriptionID]
+	delete(h.subs, subscriptionID)
+	h.mu.Unlock()
+
+	if sub != nil {
+		sub.close()
+	}
+}
+
+// Shutdown stops every subscription and rejects further Starts.
+func (h *subscriptionHandler) Shutdown(_ context.Context) {
+	h.mu.Lock()
+	h.shutdown = true
+	subs := h.subs
+	h.subs = make(map[string]*remoteSubscription)
+	h.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
+func (h *subscriptionHandler) isShutdown() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.shutdown
+}
+
+// relay reads the upstream socket until the remote completes or errors, the
+// socket fails, or the subscription is stopped, forwarding events to updates.
+// It owns updates and closes it on exit.
+func (h *subscriptionHandler) relay(
+	sub *remoteSubscription,
+	updates chan<- subscription.Update,
+	logger *slog.Logger,
+) {
+	defer close(sub.done)
+	defer close(updates)
+	defer h.forget(sub)
+	defer sub.conn.Close()
+
+	for {
+		_, data, err := sub.conn.ReadMessage()
+		if err != nil {
+			if !sub.stopped() {
+				sub.send(updates, subscription.NewUpdateError(
+					sub.id,
+					fmt.Errorf("reading from remote schema %s: %w", h.connector.name, err),
+				))
+			}
+
+			return
+		}
+
+		var msg wsMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			logger.Warn("ignoring malformed remote subscription message", slog.Any("error", err))
+
+			continue
+		}
+
+		if !h.handleMessage(sub, msg, updates) {
+			return
+		}
+	}
+}
+
+// handleMessage applies one upstream message and reports whether the relay
+// should keep reading.
+func (h *subscriptionHandler) handleMessage(
+	sub *remoteSubscription,
+	msg wsMessage,
+	updates chan<- subscription.Update,
+) bool {
+	switch msg.Type {
+	case wsMessagePing:
+		_ = sub.write(wsMessage{ID: "", Type: wsMessagePong, Payload: nil})
+
+		return true
+	case wsMessageNext:
+		return sub.send(updates, nextUpdate(sub.id, msg.Payload))
+	case wsMessageError:
+		var errs []RemoteError
+		if err := json.Unmarshal(msg.Payload, &errs); err != nil || len(errs) == 0 {
+			errs = []RemoteError{{Message: "remote schema subscription failed"}} //nolint:exhaustruct
+		}
+
+		sub.send(updates, subscription.NewUpdateError(sub.id, NewGraphQLError(errs)))
+
+		return false
+	case wsMessageComplete:
+		return false
+	default:
+		return true
+	}
+}
+
+// forget drops sub from the registry when the remote ends it on its own.
+func (h *subscriptionHandler) forget(sub *remoteSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subs[sub.id] == sub {
+		delete(h.subs, sub.id)
+	}
+}
+
+// nextUpdate converts an upstream execution result into an Update. A result
+// carrying errors is delivered as a (non-terminal) error update.
+func nextUpdate(id string, payload jsontext.Value) subscription.Update {
+	var result wsNextPayload
+	if err := json.Unmarshal(
+		payload, &result, jsontext.AllowDuplicateNames(true), jsontext.AllowInvalidUTF8(true),
+	); err != nil {
+		return subscription.NewUpdateError(id, fmt.Errorf("parsing remote subscription result: %w", err))
+	}
+
+	if len(result.Errors) > 0 {
+		return subscription.NewUpdateError(id, NewGraphQLError(result.Errors))
+	}
+
+	return subscription.NewUpdateData(id, result.Data)
+}
+
+func wsSubscribeMessage(query string, req subscription.Request) wsMessage {
+	payload, _ := json.Marshal(wsSubscribePayload{
+		Query:         query,
+		Variables:     req.Variables,
+		OperationName: req.OperationName,
+	})
+
+	return wsMessage{ID: upstreamOperationID, Type: wsMessageSubscribe, Payload: payload}
+}
+
+// send delivers update unless the subscription is stopped first, reporting
+// whether it was delivered. Events are never dropped: a slow consumer holds
+// back the upstream read instead.
+func (s *remoteSubscription) send(updates chan<- subscription.Update, update subscription.Update) bool {
+	select {
+	case updates <- update:
+		return true
+	case <-s.stop:
+		return false
+	}
+}
+
+func (s *remoteSubscription) write(msg wsMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encoding %s message: %w", msg.Type, err)
+	}
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := s.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		return fmt.Errorf("writing %s message: %w", msg.Type, err)
+	}
+
+	return nil
+}
+
+func (s *remoteSubscription) stopped() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// close completes the upstream operation, closes the socket and waits for the
+// relay to exit.
+func (s *remoteSubscription) close() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+
+		s.writeMu.Lock()
+		_ = s.conn.SetWriteDeadline(time.Now().Add(upstreamCloseWait))
+		s.writeMu.Unlock()
+
+		_ = s.write(wsMessage{ID: upstreamOperationID, Type: wsMessageComplete, Payload: nil})
+		_ = s.conn.Close()
+	})
+
+	<-s.done
+}
+
+// dialSubscription opens a graphql-transport-ws connection to the remote
+// endpoint and waits for its connection_ack. The headers a query would send
+// are set on the upgrade request and repeated in the connection_init payload,
+// where most GraphQL WebSocket servers read them.
+func (c *Connector) dialSubscription(
+	ctx context.Context,
+	sessionVariables map[string]any,
+	clientHeaders http.Header,
+) (*websocket.Conn, error) {
+	header, err := c.subscriptionHeaders(ctx, sessionVariables, clientHeaders)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &websocket.Dialer{ //nolint:exhaustruct
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: c.subscriptionTimeout,
+		Subprotocols:     []string{wsSubprotocol},
+	}
+
+	wsURL, err := webSocketURL(c.httpClient.url)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, wsURL, header)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("dialing: %w", err)
+	}
+
+	if err := handshake(conn, header, c.subscriptionTimeout); err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// subscriptionHeaders builds the upgrade request headers with the same
+// priority rules as an HTTP request (see httpClient.do).
+func (c *Connector) subscriptionHeaders(
+	ctx context.Context,
+	sessionVariables map[string]any,
+	clientHeaders http.Header,
+) (http.Header, error) {
+	req, err := newRequest(ctx, outgoingRequest{
+		method:        http.MethodGet,
+		url:           c.httpClient.url,
+		body:          nil,
+		contentType:   "",
+		addHeaders:    nil,
+		removeHeaders: webSocketUpgradeHeaders,
+	}, sessionVariables, clientHeaders, c.httpClient.headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return req.Header, nil
+}
+
+// handshake sends connection_init and waits up to timeout for connection_ack.
+func handshake(conn *websocket.Conn, header http.Header, timeout time.Duration) error {
+	initHeaders := make(map[string]string, len(header))
+	for name := range header {
+		initHeaders[name] = header.Get(name)
+	}
+
+	payload, _ := json.Marshal(map[string]any{"headers": initHeaders})
+
+	init, _ := json.Marshal(wsMessage{ID: "", Type: wsMessageConnectionInit, Payload: payload})
+	if err := conn.WriteMessage(websocket.TextMessage, init); err != nil {
+		return fmt.Errorf("writing connection_init: %w", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return fmt.Errorf("setting handshake deadline: %w", err)
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrUpstreamHandshake, err)
+		}
+
+		var msg wsMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return fmt.Errorf("%w: %w", ErrUpstreamHandshake, err)
+		}
+
+		switch msg.Type {
+		case wsMessageConnectionAck:
+			if err := conn.SetReadDeadline(time.Time{}); err != nil {
+				return fmt.Errorf("clearing handshake deadline: %w", err)
+			}
+
+			return nil
+		case wsMessagePing:
+			pong, _ := json.Marshal(wsMessage{ID: "", Type: wsMessagePong, Payload: nil})
+			if err := conn.WriteMessage(websocket.TextMessage, pong); err != nil {
+				return fmt.Errorf("writing pong: %w", err)
+			}
+		default:
+			return fmt.Errorf("%w: got %q", ErrUpstreamHandshake, msg.Type)
+		}
+	}
+}
+
+// webSocketURL maps the remote schema's http(s) URL to its ws(s) equivalent;
+// New has already rejected every other scheme.
+func webSocketURL(httpURL string) (string, error) {
+	parsed, err := url.Parse(httpURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing URL: %w", err)
+	}
+
+	if parsed.Scheme == "https" {
+		parsed.Scheme = "wss"
+	} else {
+		parsed.Scheme = "ws"
+	}
+
+	return parsed.String(), nil
+}
@@ -0,0 +1,288 @@
+package remoteschema
+
+import (
+	"context"
+	json "encoding/json/v2"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nhost/nhost/services/constellation/internal/requestcontext"
+	"github.com/nhost/nhost/services/constellation/subscription"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// fakeUpstream is a graphql-transport-ws server that records what it
+// receives and answers a subscribe with the scripted messages.
+type fakeUpstream struct {
+	t       *testing.T
+	script  []wsMessage
+	hold    bool // keep the socket open after the script instead of completing
+	header  chan http.Header
+	init    chan map[string]any
+	payload chan wsSubscribePayload
+	stopped chan string // message type received after subscribe
+}
+
+func newFakeUpstream(t *testing.T, script []wsMessage, hold bool) (*fakeUpstream, *httptest.Server) {
+	t.Helper()
+
+	up := &fakeUpstream{
+		t:       t,
+		script:  script,
+		hold:    hold,
+		header:  make(chan http.Header, 1),
+		init:    make(chan map[string]any, 1),
+		payload: make(chan wsSubscribePayload, 1),
+		stopped: make(chan string, 1),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(up.serve))
+	t.Cleanup(srv.Close)
+
+	return up, srv
+}
+
+func (up *fakeUpstream) serve(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{Subprotocols: []string{wsSubprotocol}}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		up.t.Errorf("upgrade: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	up.header <- r.Header
+
+	var init struct {
+		Payload map[string]any `json:"payload"`
+	}
+
+	_, data, _ := conn.ReadMessage()
+	_ = json.Unmarshal(data, &init)
+	up.init <- init.Payload
+
+	_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"connection_ack"}`))
+
+	var sub struct {
+		Payload wsSubscribePayload `json:"payload"`
+	}
+
+	_, data, _ = conn.ReadMessage()
+	_ = json.Unmarshal(data, &sub)
+	up.payload <- sub.Payload
+
+	for _, msg := range up.script {
+		out, _ := json.Marshal(msg)
+		_ = conn.WriteMessage(websocket.TextMessage, out)
+	}
+
+	if !up.hold {
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"id":"1","type":"complete"}`))
+		return
+	}
+
+	var msg wsMessage
+
+	_, data, err = conn.ReadMessage()
+	if err == nil {
+		_ = json.Unmarshal(data, &msg)
+	}
+
+	up.stopped <- msg.Type
+}
+
+func testSubscriptionConnector(url string, forwardClientHeaders bool) *Connector {
+	return &Connector{ //nolint:exhaustruct
+		name:                 "notifications",
+		forwardClientHeaders: forwardClientHeaders,
+		presets: map[string]map[string][]presetArg{
+			"user": {
+				"Subscription.notifications": {{
+					ArgumentName:    "userId",
+					Type:            ast.NamedType("String", nil),
+					TargetKind:      ast.Scalar,
+					SessionVariable: "x-hasura-user-id",
+				}},
+			},
+		},
+		httpClient: &httpClient{ //nolint:exhaustruct
+			url:     url,
+			headers: map[string]string{"x-api-key": "secret"},
+		},
+		subscriptionTimeout: 5 * time.Second,
+	}
+}
+
+func testSubscriptionRequest(t *testing.T, role string) subscription.Request {
+	t.Helper()
+
+	const query = `subscription OnNotification { notifications { id } }`
+
+	doc, err := parser.ParseQuery(&ast.Source{Name: "test", Input: query}) //nolint:exhaustruct
+	if err != nil {
+		t.Fatalf("parsing query: %v", err)
+	}
+
+	return subscription.Request{
+		ID:               "client-1",
+		QueryString:      query,
+		Operation:        doc.Operations[0],
+		Fragments:        nil,
+		OperationName:    "OnNotification",
+		Role:             role,
+		Variables:        map[string]any{"since": "yesterday"},
+		SessionVariables: map[string]any{"x-hasura-role": role, "x-hasura-user-id": "42"},
+	}
+}
+
+func receive(t *testing.T, updates <-chan subscription.Update) (subscription.Update, bool) {
+	t.Helper()
+
+	select {
+	case update, ok := <-updates:
+		return update, ok
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an update")
+
+		return subscription.Update{}, false
+	}
+}
+
+func TestSubscriptionHandlerRelaysUpstreamEvents(t *testing.T) {
+	t.Parallel()
+
+	up, srv := newFakeUpstream(t, []wsMessage{
+		{ID: "1", Type: wsMessagePing},
+		{ID: "1", Type: wsMessageNext, Payload: []byte(`{"data":{"notifications":{"id":1}}}`)},
+		{ID: "1", Type: wsMessageNext, Payload: []byte(`{"data":{"notifications":{"id":2}}}`)},
+	}, false)
+
+	handler := testSubscriptionConnector(srv.URL, true).NewSubscriptionHandler(0, slog.Default())
+
+	ctx := requestcontext.ClientHeadersToContext(
+		context.Background(), http.Header{"X-Client": {"web"}, "X-Hasura-Admin-Secret": {"nope"}},
+	)
+
+	updates, err := handler.Start(ctx, testSubscriptionRequest(t, "user"), slog.Default())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	header := <-up.header
+	for name, want := range map[string]string{
+		"X-Api-Key":        "secret",
+		"X-Client":         "web",
+		"X-Hasura-User-Id": "42",
+	} {
+		if got := header.Get(name); got != want {
+			t.Errorf("upgrade header %s = %q, want %q", name, got, want)
+		}
+	}
+
+	if got := header.Get("X-Hasura-Admin-Secret"); got != "" {
+		t.Errorf("admin secret was forwarded: %q", got)
+	}
+
+	if headers, _ := (<-up.init)["headers"].(map[string]any); headers["X-Api-Key"] != "secret" {
+		t.Errorf("connection_init headers = %v, want X-Api-Key", headers)
+	}
+
+	payload := <-up.payload
+	if !strings.Contains(payload.Query, `notifications(userId: "42")`) {
+		t.Errorf("upstream query %q lacks the preset argument", payload.Query)
+	}
+
+	if payload.OperationName != "OnNotification" || payload.Variables["since"] != "yesterday" {
+		t.Errorf("upstream payload = %+v", payload)
+	}
+
+	for _, want := range []string{`{"notifications":{"id":1}}`, `{"notifications":{"id":2}}`} {
+		update, ok := receive(t, updates)
+		if !ok || update.Error != nil || string(update.Data) != want {
+			t.Fatalf("update = %+v (open %v), want data %s", update, ok, want)
+		}
+	}
+
+	if update, ok := receive(t, updates); ok {
+		t.Fatalf("expected the channel to close on upstream complete, got %+v", update)
+	}
+}
+
+func TestSubscriptionHandlerUpstreamError(t *testing.T) {
+	t.Parallel()
+
+	_, srv := newFakeUpstream(t, []wsMessage{
+		{ID: "1", Type: wsMessageError, Payload: []byte(`[{"message":"not allowed"}]`)},
+	}, true)
+
+	handler := testSubscriptionConnector(srv.URL, false).NewSubscriptionHandler(0, slog.Default())
+
+	updates, err := handler.Start(context.Background(), testSubscriptionRequest(t, "admin"), slog.Default())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	update, _ := receive(t, updates)
+
+	var gqlErr *GraphQLError
+	if !errors.As(update.Error, &gqlErr) || gqlErr.Errors[0].Message != "not allowed" {
+		t.Fatalf("update error = %v, want the remote GraphQL error", update.Error)
+	}
+
+	if _, ok := receive(t, updates); ok {
+		t.Fatal("expected the channel to close after an upstream error")
+	}
+}
+
+func TestSubscriptionHandlerStopCompletesUpstream(t *testing.T) {
+	t.Parallel()
+
+	up, srv := newFakeUpstream(t, nil, true)
+
+	handler := testSubscriptionConnector(srv.URL, false).NewSubscriptionHandler(0, slog.Default())
+
+	updates, err := handler.Start(context.Background(), testSubscriptionRequest(t, "admin"), slog.Default())
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	<-up.payload
+
+	handler.Stop(context.Background(), "client-1")
+
+	if _, ok := <-updates; ok {
+		t.Fatal("expected Stop to close the update channel")
+	}
+
+	if got := <-up.stopped; got != wsMessageComplete {
+		t.Errorf("upstream received %q after Stop, want complete", got)
+	}
+
+	handler.Shutdown(context.Background())
+
+	_, err = handler.Start(context.Background(), testSubscriptionRequest(t, "admin"), slog.Default())
+	if !errors.Is(err, ErrSubscriptionHandlerShutdown) {
+		t.Errorf("Start after Shutdown = %v, want ErrSubscriptionHandlerShutdown", err)
+	}
+}
+
+func TestWebSocketURL(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		"http://remote:4000/graphql":  "ws://remote:4000/graphql",
+		"https://remote/v1/graphql?a": "wss://remote/v1/graphql?a",
+	} {
+		if got, err := webSocketURL(in); err != nil || got != want {
+			t.Errorf("webSocketURL(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+}
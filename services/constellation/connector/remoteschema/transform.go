@@ -0,0 +1,346 @@
+package remoteschema
+
+import (
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/nhost/nhost/internal/lib/jsontmpl"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+// templateEngineKriti is the only template_engine Hasura defines; an empty
+// template_engine means Kriti too.
+const templateEngineKriti = "Kriti"
+
+const (
+	bodyActionTransform = "transform"
+	bodyActionRemove    = "remove"
+	bodyActionForm      = "x_www_form_urlencoded"
+)
+
+// transformMethods are the HTTP methods a request transform may select.
+var transformMethods = []string{ //nolint:gochecknoglobals
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// outgoingRequest is the HTTP request httpClient.do sends: the configured
+// endpoint and JSON body, or whatever a request transform rendered from them.
+type outgoingRequest struct {
+	method        string
+	url           string
+	body          []byte
+	contentType   string
+	addHeaders    map[string]string
+	removeHeaders []string
+}
+
+// requestTransform is a validated request_transform. URL, header values, query
+// parameter values and form fields are string templates (Hasura's "unescaped"
+// templates): they are rendered as the contents of a Kriti string literal and
+// the resulting text is used as is. The body template is a full Kriti JSON
+// template.
+type requestTransform struct {
+	method              string
+	url                 string
+	queryParams         map[string]string
+	queryParamsTemplate string
+	addHeaders          map[string]string
+	removeHeaders       []string
+	body                *metadata.RemoteSchemaTransformBody
+}
+
+// responseTransform is a validated response_transform; template renders the
+// GraphQL response from the remote endpoint's decoded response body.
+type responseTransform struct {
+	template string
+}
+
+// newRequestTransform validates meta and every template it holds, so a
+// malformed template fails construction (and is reported as a metadata
+// inconsistency) instead of failing every request. Nil meta yields nil.
+func newRequestTransform(meta *metadata.RemoteSchemaRequestTransform) (*requestTransform, error) {
+	if meta == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	if err := validateTemplateEngine(meta.TemplateEngine); err != nil {
+		return nil, err
+	}
+
+	if meta.Method != "" && !slices.Contains(transformMethods, meta.Method) {
+		return nil, fmt.Errorf("%w: %q", ErrTransformMethod, meta.Method)
+	}
+
+	templates := map[string]string{"url": meta.URL, "query_params": meta.QueryParamsTemplate}
+	for name, tmpl := range meta.QueryParams {
+		templates["query_params."+name] = tmpl
+	}
+
+	for name, tmpl := range meta.AddHeaders {
+		templates["add_headers."+name] = tmpl
+	}
+
+	if err := validateStringTemplates(templates); err != nil {
+		return nil, err
+	}
+
+	if err := validateBody(meta.Body, bodyActionTransform, bodyActionRemove, bodyActionForm); err != nil {
+		return nil, fmt.Errorf("request_transform body: %w", err)
+	}
+
+	return &requestTransform{
+		method:              meta.Method,
+		url:                 meta.URL,
+		queryParams:         meta.QueryParams,
+		queryParamsTemplate: meta.QueryParamsTemplate,
+		addHeaders:          meta.AddHeaders,
+		removeHeaders:       meta.RemoveHeaders,
+		body:                meta.Body,
+	}, nil
+}
+
+// newResponseTransform validates meta. Nil meta, or one without a body, yields
+// nil: the response is used unchanged.
+func newResponseTransform(meta *metadata.RemoteSchemaResponseTransform) (*responseTransform, error) {
+	if meta == nil || meta.Body == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	if err := validateTemplateEngine(meta.TemplateEngine); err != nil {
+		return nil, err
+	}
+
+	if err := validateBody(meta.Body, bodyActionTransform); err != nil {
+		return nil, fmt.Errorf("response_transform body: %w", err)
+	}
+
+	return &responseTransform{template: meta.Body.Template}, nil
+}
+
+func validateTemplateEngine(engine string) error {
+	if engine != "" && engine != templateEngineKriti {
+		return fmt.Errorf("%w: %q", ErrTransformTemplateEngine, engine)
+	}
+
+	return nil
+}
+
+func validateStringTemplates(templates map[string]string) error {
+	for name, tmpl := range templates {
+		if tmpl == "" {
+			continue
+		}
+
+		if err := jsontmpl.Validate(stringTemplate(tmpl)); err != nil {
+			return fmt.Errorf("parsing %s template: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func validateBody(body *metadata.RemoteSchemaTransformBody, actions ...string) error {
+	if body == nil {
+		return nil
+	}
+
+	if !slices.Contains(actions, body.Action) {
+		return fmt.Errorf("%w: %q", ErrTransformBodyAction, body.Action)
+	}
+
+	switch body.Action {
+	case bodyActionTransform:
+		if err := jsontmpl.Validate(body.Template); err != nil {
+			return fmt.Errorf("parsing template: %w", err)
+		}
+	case bodyActionForm:
+		for name, tmpl := range body.FormTemplate {
+			if err := jsontmpl.Validate(stringTemplate(tmpl)); err != nil {
+				return fmt.Errorf("parsing form_template.%s template: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// stringTemplate turns an unescaped template such as
+// "{{$base_url}}/graphql" into the Kriti string literal it stands for.
+func stringTemplate(tmpl string) string {
+	return `"` + tmpl + `"`
+}
+
+// transformScope binds the variables request and response templates see.
+func transformScope(body jsontext.Value, sessionVariables map[string]any) jsontmpl.Scope {
+	if sessionVariables == nil {
+		sessionVariables = map[string]any{}
+	}
+
+	return jsontmpl.New().
+		WithVar("$body", body).
+		WithVar("$session_variables", sessionVariables)
+}
+
+// renderString renders an unescaped template to text. A template that
+// renders to a JSON string yields that string; any other JSON value yields
+// its JSON text.
+func renderString(tmpl string, scope jsontmpl.Scope) (string, error) {
+	out, err := jsontmpl.Render(stringTemplate(tmpl), scope)
+	if err != nil {
+		return "", fmt.Errorf("rendering template: %w", err)
+	}
+
+	var s string
+	if err := json.Unmarshal(out, &s); err == nil {
+		return s, nil
+	}
+
+	return string(out), nil
+}
+
+// render builds the outgoing request for a GraphQL request body sent to
+// baseURL. The rendered URL is subject to the same scheme/host check as the
+// configured one.
+func (t *requestTransform) render(
+	baseURL string, body []byte, sessionVariables map[string]any,
+) (outgoingRequest, error) {
+	scope := transformScope(body, sessionVariables).WithVar("$base_url", baseURL)
+
+	out := outgoingRequest{
+		method:        http.MethodPost,
+		url:           baseURL,
+		body:          body,
+		contentType:   "application/json",
+		addHeaders:    make(map[string]string, len(t.addHeaders)),
+		removeHeaders: t.removeHeaders,
+	}
+
+	if t.method != "" {
+		out.method = t.method
+	}
+
+	target, err := t.renderURL(baseURL, scope)
+	if err != nil {
+		return outgoingRequest{}, err
+	}
+
+	out.url = target
+
+	for name, tmpl := range t.addHeaders {
+		value, err := renderString(tmpl, scope)
+		if err != nil {
+			return outgoingRequest{}, fmt.Errorf("header %s: %w", name, err)
+		}
+
+		out.addHeaders[name] = value
+	}
+
+	if err := t.renderBody(&out, scope); err != nil {
+		return outgoingRequest{}, err
+	}
+
+	return out, nil
+}
+
+func (t *requestTransform) renderURL(baseURL string, scope jsontmpl.Scope) (string, error) {
+	target := baseURL
+
+	if t.url != "" {
+		rendered, err := renderString(t.url, scope)
+		if err != nil {
+			return "", fmt.Errorf("url: %w", err)
+		}
+
+		target = rendered
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return "", fmt.Errorf("parsing transformed URL: %w", err)
+	}
+
+	switch {
+	case t.queryParamsTemplate != "":
+		query, err := renderString(t.queryParamsTemplate, scope)
+		if err != nil {
+			return "", fmt.Errorf("query_params: %w", err)
+		}
+
+		parsed.RawQuery = query
+	case t.queryParams != nil:
+		query := make(url.Values, len(t.queryParams))
+
+		for name, tmpl := range t.queryParams {
+			value, err := renderString(tmpl, scope)
+			if err != nil {
+				return "", fmt.Errorf("query_params.%s: %w", name, err)
+			}
+
+			query.Set(name, value)
+		}
+
+		parsed.RawQuery = query.Encode()
+	}
+
+	target = parsed.String()
+	if err := validateRemoteURL(target); err != nil {
+		return "", fmt.Errorf("validating transformed URL: %w", err)
+	}
+
+	return target, nil
+}
+
+func (t *requestTransform) renderBody(out *outgoingRequest, scope jsontmpl.Scope) error {
+	if t.body == nil {
+		return nil
+	}
+
+	switch t.body.Action {
+	case bodyActionRemove:
+		out.body = nil
+		out.contentType = ""
+	case bodyActionForm:
+		form := make(url.Values, len(t.body.FormTemplate))
+
+		for name, tmpl := range t.body.FormTemplate {
+			value, err := renderString(tmpl, scope)
+			if err != nil {
+				return fmt.Errorf("form_template.%s: %w", name, err)
+			}
+
+			form.Set(name, value)
+		}
+
+		out.body = []byte(form.Encode())
+		out.contentType = "application/x-www-form-urlencoded"
+	default:
+		rendered, err := jsontmpl.Render(t.body.Template, scope)
+		if err != nil {
+			return fmt.Errorf("body: %w", err)
+		}
+
+		out.body = rendered
+	}
+
+	return nil
+}
+
+// render rewrites a response body. A body that is not JSON is bound to $body
+// as null.
+func (t *responseTransform) render(body []byte, sessionVariables map[string]any) ([]byte, error) {
+	value := jsontext.Value(body)
+	if !value.IsValid() {
+		value = jsontext.Value("null")
+	}
+
+	out, err := jsontmpl.Render(t.template, transformScope(value, sessionVariables))
+	if err != nil {
+		return nil, fmt.Errorf("rendering response_transform: %w", err)
+	}
+
+	return out, nil
+}
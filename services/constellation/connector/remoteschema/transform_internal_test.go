@@ -0,0 +1,136 @@
+package remoteschema
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+func TestRequestTransformRender(t *testing.T) {
+	t.Parallel()
+
+	const baseURL = "https://remote.example.com/graphql?keep=1"
+
+	body := []byte(`{"query":"{ me { id } }","variables":{"id":7}}`)
+	session := map[string]any{"x-hasura-user-id": "u1"}
+
+	tests := []struct {
+		name            string
+		meta            *metadata.RemoteSchemaRequestTransform
+		wantMethod      string
+		wantURL         string
+		wantBody        string
+		wantContentType string
+	}{
+		{
+			name:            "empty transform keeps the request",
+			meta:            &metadata.RemoteSchemaRequestTransform{},
+			wantMethod:      http.MethodPost,
+			wantURL:         baseURL,
+			wantBody:        string(body),
+			wantContentType: "application/json",
+		},
+		{
+			name: "query params template and remove body",
+			meta: &metadata.RemoteSchemaRequestTransform{
+				Method:              http.MethodGet,
+				QueryParamsTemplate: "user={{$session_variables['x-hasura-user-id']}}&id={{$body.variables.id}}",
+				Body:                &metadata.RemoteSchemaTransformBody{Action: bodyActionRemove},
+			},
+			wantMethod:      http.MethodGet,
+			wantURL:         "https://remote.example.com/graphql?user=u1&id=7",
+			wantBody:        "",
+			wantContentType: "",
+		},
+		{
+			name: "form body",
+			meta: &metadata.RemoteSchemaRequestTransform{
+				URL: "{{$base_url}}",
+				Body: &metadata.RemoteSchemaTransformBody{
+					Action: bodyActionForm,
+					FormTemplate: map[string]string{
+						"query": "{{$body.query}}",
+						"user":  "{{$session_variables['x-hasura-user-id']}}",
+					},
+				},
+			},
+			wantMethod:      http.MethodPost,
+			wantURL:         baseURL,
+			wantBody:        "query=%7B+me+%7B+id+%7D+%7D&user=u1",
+			wantContentType: "application/x-www-form-urlencoded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			transform, err := newRequestTransform(tt.meta)
+			if err != nil {
+				t.Fatalf("newRequestTransform: %v", err)
+			}
+
+			out, err := transform.render(baseURL, body, session)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+
+			if out.method != tt.wantMethod {
+				t.Errorf("method = %q, want %q", out.method, tt.wantMethod)
+			}
+
+			if out.url != tt.wantURL {
+				t.Errorf("url = %q, want %q", out.url, tt.wantURL)
+			}
+
+			if string(out.body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", out.body, tt.wantBody)
+			}
+
+			if out.contentType != tt.wantContentType {
+				t.Errorf("contentType = %q, want %q", out.contentType, tt.wantContentType)
+			}
+		})
+	}
+}
+
+func TestRequestTransformRender_RejectsRenderedURL(t *testing.T) {
+	t.Parallel()
+
+	transform, err := newRequestTransform(&metadata.RemoteSchemaRequestTransform{
+		URL: "file:///{{$session_variables['x-hasura-user-id']}}",
+	})
+	if err != nil {
+		t.Fatalf("newRequestTransform: %v", err)
+	}
+
+	if _, err := transform.render(
+		"https://remote.example.com", nil, map[string]any{"x-hasura-user-id": "etc/passwd"},
+	); err == nil {
+		t.Fatal("expected the rendered file:// URL to be rejected")
+	}
+}
+
+func TestResponseTransformRender_NonJSONBody(t *testing.T) {
+	t.Parallel()
+
+	transform, err := newResponseTransform(&metadata.RemoteSchemaResponseTransform{
+		Body: &metadata.RemoteSchemaTransformBody{
+			Action:   bodyActionTransform,
+			Template: `{"data": {"raw": {{$body}}}}`,
+		},
+	})
+	if err != nil {
+		t.Fatalf("newResponseTransform: %v", err)
+	}
+
+	out, err := transform.render([]byte("<html>oops</html>"), nil)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+
+	if string(out) != `{"data":{"raw":null}}` {
+		t.Errorf("render = %s, want $body bound to null", out)
+	}
+}
@@ -0,0 +1,183 @@
+package remoteschema_test
+
+import (
+	"context"
+	json "encoding/json/v2"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/connector/remoteschema"
+	"github.com/nhost/nhost/services/constellation/metadata"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// TestExecute_AppliesTransforms drives a request and a response transform end
+// to end: the request is re-addressed, re-parameterised, re-headered and its
+// body rebuilt from $body and $session_variables; the response body is
+// reshaped into a GraphQL response. Introspection goes through the request
+// transform too (it must reach the re-addressed endpoint) but its response is
+// never transformed.
+func TestExecute_AppliesTransforms(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2" {
+			t.Errorf("request not re-addressed: %s", r.URL)
+		}
+
+		var body struct {
+			Query      string         `json:"query"`
+			Extensions map[string]any `json:"extensions"`
+		}
+
+		if err := json.Unmarshal(readAllOrFail(t, r.Body), &body); err != nil {
+			t.Errorf("decoding transformed body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// Introspection carries no session variables.
+		if strings.Contains(body.Query, "__schema") {
+			writeOrFail(t, w, []byte(testIntrospectionResponse))
+
+			return
+		}
+
+		if got := r.URL.Query().Get("tenant"); got != "acme" {
+			t.Errorf("tenant query parameter = %q, want acme", got)
+		}
+
+		if got := r.Header.Get("X-Tenant"); got != "acme" {
+			t.Errorf("X-Tenant = %q, want acme", got)
+		}
+
+		if got := r.Header.Get("X-Hasura-Role"); got != "" {
+			t.Errorf("X-Hasura-Role = %q, want it removed", got)
+		}
+
+		if body.Extensions["tenant"] != "acme" {
+			t.Errorf("extensions = %v, want the tenant from $session_variables", body.Extensions)
+		}
+
+		writeOrFail(t, w, []byte(`{"result":{"test":"ok"}}`))
+	}))
+	defer server.Close()
+
+	meta := newTestMetadata(server.URL, nil)
+	meta.Definition.RequestTransform = &metadata.RemoteSchemaRequestTransform{
+		TemplateEngine:      "Kriti",
+		Method:              http.MethodPost,
+		URL:                 "{{$base_url}}/v2",
+		QueryParams:         map[string]string{"tenant": "{{$session_variables?['x-hasura-tenant-id']}}"},
+		QueryParamsTemplate: "",
+		AddHeaders:          map[string]string{"X-Tenant": "{{$session_variables?['x-hasura-tenant-id']}}"},
+		RemoveHeaders:       []string{"X-Hasura-Role"},
+		Body: &metadata.RemoteSchemaTransformBody{
+			Action:       "transform",
+			Template:     `{"query": {{$body.query}}, "extensions": {"tenant": {{$session_variables?['x-hasura-tenant-id']}}}}`,
+			FormTemplate: nil,
+		},
+	}
+	meta.Definition.ResponseTransform = &metadata.RemoteSchemaResponseTransform{
+		TemplateEngine: "",
+		Body: &metadata.RemoteSchemaTransformBody{
+			Action:       "transform",
+			Template:     `{"data": {{$body.result}}}`,
+			FormTemplate: nil,
+		},
+	}
+
+	connector, err := remoteschema.New(context.Background(), meta, nil)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	op := &ast.OperationDefinition{
+		Operation:    ast.Query,
+		SelectionSet: ast.SelectionSet{&ast.Field{Name: "test"}},
+	}
+
+	data, err := connector.Execute(
+		context.Background(), op, nil, nil, "user",
+		map[string]any{"x-hasura-role": "user", "x-hasura-tenant-id": "acme"},
+		slog.Default(),
+	)
+	if err != nil {
+		t.Fatalf("Execute() error: %v", err)
+	}
+
+	if data["test"] != "ok" {
+		t.Errorf("data = %v, want the transformed response", data)
+	}
+}
+
+func TestNew_RejectsInvalidTransforms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		request  *metadata.RemoteSchemaRequestTransform
+		response *metadata.RemoteSchemaResponseTransform
+		wantErr  error
+		wantMsg  string
+	}{
+		{
+			name:    "url template does not parse",
+			request: &metadata.RemoteSchemaRequestTransform{URL: "{{$base_url"},
+			wantMsg: "parsing url template",
+		},
+		{
+			name: "body template does not parse",
+			request: &metadata.RemoteSchemaRequestTransform{
+				Body: &metadata.RemoteSchemaTransformBody{Action: "transform", Template: `{"query": }`},
+			},
+			wantMsg: "request_transform body: parsing template",
+		},
+		{
+			name:    "unknown template engine",
+			request: &metadata.RemoteSchemaRequestTransform{TemplateEngine: "Go"},
+			wantErr: remoteschema.ErrTransformTemplateEngine,
+		},
+		{
+			name:    "unsupported method",
+			request: &metadata.RemoteSchemaRequestTransform{Method: "TRACE"},
+			wantErr: remoteschema.ErrTransformMethod,
+		},
+		{
+			name: "response body action other than transform",
+			response: &metadata.RemoteSchemaResponseTransform{
+				Body: &metadata.RemoteSchemaTransformBody{Action: "remove"},
+			},
+			wantErr: remoteschema.ErrTransformBodyAction,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The URL is never contacted: transforms are validated before
+			// the admin introspection request.
+			meta := newTestMetadata("http://remote.invalid/graphql", nil)
+			meta.Definition.RequestTransform = tt.request
+			meta.Definition.ResponseTransform = tt.response
+
+			_, err := remoteschema.New(context.Background(), meta, nil)
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+
+			if !strings.Contains(err.Error(), tt.wantMsg) || !strings.Contains(err.Error(), "_transform") {
+				t.Errorf("err = %v, want it to name the transform and contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Package runsql defines the optional capability behind the run_sql query of
+// POST /v2/query: a connector that can run raw SQL against its source and
+// report whether the SQL changed the definitions of the tables the metadata
+// tracks.
+//
+// SQL connectors implement [Runner] when their driver can run scripts
+// (PostgreSQL); the customization decorator forwards it. A run holds every
+// script of a request in one transaction, so a bulk either commits as a whole
+// or not at all. Both return
+// [ErrNotSupported] when the underlying connector or driver cannot. The
+// controller probes connectors for it by type assertion and, when a run
+// reports [Result.Changed], re-introspects the source.
+package runsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+// ErrNotSupported is returned by a Runner that forwards the capability (the
+// SQL connector, the customization decorator) when what it wraps cannot run
+// SQL.
+var ErrNotSupported = errors.New("connector does not support run_sql")
+
+// Options controls a single run.
+type Options struct {
+	// ReadOnly runs the scripts in a read-only transaction.
+	ReadOnly bool
+}
+
+// Script is one SQL script of a run.
+type Script struct {
+	// SQL may hold several statements.
+	SQL string
+	// Cascade allows the script to drop tracked tables. Without it a run
+	// whose script drops one is rolled back with a [*DependencyError].
+	Cascade bool
+	// Tracked lists the tables whose definitions are compared before and
+	// after the script, inside the run's transaction. Empty skips the check.
+	Tracked []metadata.TableSource
+}
+
+// Result is the outcome of one script of a run: the tuples returned by its
+// last statement, and whether it changed the tracked tables.
+type Result struct {
+	// Rows holds the header row followed by one row per tuple, every value
+	// rendered as text and NULL as "NULL". Nil when the last statement
+	// returned no tuples.
+	Rows [][]string
+	// Changed reports whether the script created, altered or dropped any of
+	// [Script.Tracked].
+	Changed bool
+}
+
+// DependencyError is returned when a script drops tracked tables without
+// [Script.Cascade]. The run is rolled back.
+type DependencyError struct {
+	// Script is the index of the script that dropped the tables.
+	Script int
+	Tables []metadata.TableSource
+}
+
+func (e *DependencyError) Error() string {
+	names := make([]string, 0, len(e.Tables))
+	for _, t := range e.Tables {
+		names = append(names, fmt.Sprintf("table %q.%q", t.Schema, t.Name))
+	}
+
+	return "cannot drop due to the following dependent objects : " + strings.Join(names, ", ")
+}
+
+// ExecutionError is returned when the database rejects a script itself, as
+// opposed to a failure to reach the database or to commit. The run is rolled
+// back.
+type ExecutionError struct {
+	// Script is the index of the script the database rejected.
+	Script int
+	// Code is the database's error code (the SQLSTATE on PostgreSQL).
+	Code string
+	Err  error
+}
+
+func (e *ExecutionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// Runner is implemented by connectors that can run raw SQL against their
+// source. The scripts run in order in one transaction that is committed only
+// if every statement of every script succeeds; the results are in the order
+// of the scripts.
+type Runner interface {
+	RunSQL(ctx context.Context, scripts []Script, opts Options) ([]*Result, error)
+}
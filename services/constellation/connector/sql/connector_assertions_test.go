@@ -2,7 +2,10 @@ package sql_test
 
 import (
 	"github.com/nhost/nhost/services/constellation/connector"
+	"github.com/nhost/nhost/services/constellation/connector/explain"
+	"github.com/nhost/nhost/services/constellation/connector/federation"
 	"github.com/nhost/nhost/services/constellation/connector/groupedaggregate"
+	"github.com/nhost/nhost/services/constellation/connector/runsql"
 	csql "github.com/nhost/nhost/services/constellation/connector/sql"
 )
 
@@ -10,8 +13,9 @@ import (
 // contracts the rest of the system depends on by type-assertion. A signature
 // drift on either interface becomes a build failure here, at the
 // implementation site, instead of a silent ok=false on the consumer side
-// (controller/resolver/aggregate_resolver.go and the controller's
-// subscriptionCapableConnector probe).
+// (controller/resolver/aggregate_resolver.go, the controller's
+// subscriptionCapableConnector probe, the composer's federation probe, and the
+// controller's explain and run_sql probes).
 //
 // These assertions live in an external test file because the production
 // package cannot import "connector" without creating an import cycle
@@ -19,4 +23,7 @@ import (
 var (
 	_ connector.Connector       = (*csql.Connector)(nil)
 	_ groupedaggregate.Executor = (*csql.Connector)(nil)
+	_ federation.Provider       = (*csql.Connector)(nil)
+	_ explain.Explainer         = (*csql.Connector)(nil)
+	_ runsql.Runner             = (*csql.Connector)(nil)
 )
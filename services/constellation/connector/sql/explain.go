@@ -0,0 +1,41 @@
+package sql //nolint:revive,nolintlint // package name "sql" shadows database/sql; see sql.go for the rationale.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/nhost/nhost/services/constellation/connector/explain"
+)
+
+// Explain builds the SQL Execute would run for operation and returns each root
+// field's statement with the driver's plan for it, satisfying
+// explain.Explainer. Nothing is executed: BuildQuery is side-effect free and
+// the driver only asks the database to plan each statement.
+func (c *Connector) Explain(
+	ctx context.Context,
+	operation *ast.OperationDefinition,
+	fragments ast.FragmentDefinitionList,
+	variables map[string]any,
+	role string,
+	sessionVariables map[string]any,
+) ([]explain.Field, error) {
+	operations, err := c.roots.BuildQuery(operation, fragments, variables, role, sessionVariables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	fields := make([]explain.Field, 0, len(operations))
+
+	for _, op := range operations {
+		plan, err := c.driver.ExplainOperation(ctx, op)
+		if err != nil {
+			return nil, fmt.Errorf("failed to explain %s: %w", op.Name, err)
+		}
+
+		fields = append(fields, explain.Field{Field: op.Name, SQL: op.SQL, Plan: plan})
+	}
+
+	return fields, nil
+}
@@ -13,11 +13,11 @@ import (
 var ErrInvalidArgument = errors.New("invalid argument")
 
 // ErrUnsupportedAggregateOrderBy is wrapped when a requested aggregate
-// order_by function cannot be rendered faithfully by the active backend. It
-// currently fires for the stddev/variance family on SQLite, which has no native
-// stddev/variance aggregate; emulating it with the one-pass sum-of-squares
-// identity is numerically unstable and would order rows differently from
-// PostgreSQL/Hasura, so the ordering is rejected rather than silently wrong.
+// order_by function cannot be rendered faithfully by the active backend: the
+// stddev/variance family on a backend that reports no stable variance ordering.
+// Emulating those with the one-pass sum-of-squares identity is numerically
+// unstable and would order rows differently from PostgreSQL/Hasura, so the
+// ordering is rejected rather than silently wrong.
 var ErrUnsupportedAggregateOrderBy = errors.New("unsupported aggregate order_by")
 
 // distinctOnOrderByMismatchMessage is the client-facing validation message
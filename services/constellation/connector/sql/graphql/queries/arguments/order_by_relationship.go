@@ -76,9 +76,9 @@ func appendRelationshipOrderBy(
 }
 
 // buildRelationshipOrderItems builds ordering terms for an object relationship.
-// Each leaf column produces one ORDER BY term rendering a correlated scalar
-// subquery; nested object relationships and aggregates recurse, wrapping the
-// inner expression in this relationship's subquery.
+// Each leaf column or vector distance produces one ORDER BY term rendering a
+// correlated scalar subquery; nested object relationships and aggregates
+// recurse, wrapping the inner expression in this relationship's subquery.
 func buildRelationshipOrderItems(
 	rel Relationship,
 	target Table,
@@ -112,6 +112,23 @@ func buildRelationshipOrderItems(
 			continue
 		}
 
+		if column := vectorDistanceColumn(target, child.Name); column != nil {
+			item, err := parseVectorDistanceOrderBy(target, column, child.Value, alias)
+			if err != nil {
+				return nil, err
+			}
+
+			items = append(items, OrderByItem{
+				Column: "",
+				term: newRelationshipOrderTerm(
+					rel, target, parentSource, alias, item.term, role, sessionVariables,
+				),
+				Direction: item.Direction,
+			})
+
+			continue
+		}
+
 		nested, err := buildNestedRelationshipOrderItems(
 			target, child, alias, role, sessionVariables, gen,
 		)
@@ -318,8 +335,8 @@ var aggregateOrderByFuncs = map[string]string{
 }
 
 // varianceOrderByFuncs is the subset of aggregateOrderByFuncs whose result is
-// only well-defined for ordering on backends with native, numerically stable
-// stddev/variance aggregates. Backends without them (SQLite) gate these via
+// only well-defined for ordering on backends with numerically stable
+// stddev/variance aggregates. Backends without them gate these via
 // dialect.SupportsStableVarianceOrderBy and the ordering is rejected.
 //
 //nolint:gochecknoglobals // immutable lookup table.
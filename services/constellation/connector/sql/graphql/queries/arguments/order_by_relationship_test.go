@@ -17,6 +17,21 @@ import (
 // SupportsStableVarianceOrderBy, so the concrete value is preferable to a mock.
 func sqliteDialect() dialect.Dialect { return &dialect.SQLiteDialect{} }
 
+// unstableVarianceDialect is a SQLite dialect reporting no stable variance
+// ordering, standing in for a backend that emulates stddev/variance with the
+// one-pass identity. The variance gate only reads
+// SupportsStableVarianceOrderBy, so overriding it on a real dialect is
+// preferable to a mock here too.
+type unstableVarianceDialect struct {
+	dialect.SQLiteDialect
+}
+
+func (d *unstableVarianceDialect) SupportsStableVarianceOrderBy() bool { return false }
+
+func unstableDialect() dialect.Dialect {
+	return &unstableVarianceDialect{SQLiteDialect: dialect.SQLiteDialect{}}
+}
+
 // aggregateOrderByTable wires the mocks for an array-relationship aggregate
 // order_by of the shape {employees_aggregate: {<fn>: {joined_at: asc}}} against
 // a target table using the given dialect, and returns the parent table to pass
@@ -81,20 +96,20 @@ func aggregateOrderByValue(fn string) *ast.Value {
 // TestParseOrderBy_AggregateVarianceGate locks the runtime backstop in
 // buildAggregateColumnOrderItems: an array-relationship aggregate order_by over
 // a stddev/variance function is rejected on a backend whose dialect reports
-// SupportsStableVarianceOrderBy() == false (SQLite), but renders the expected
-// correlated aggregate subquery on a backend that supports it (PostgreSQL).
+// SupportsStableVarianceOrderBy() == false, but renders the expected correlated
+// aggregate subquery on backends that support it (PostgreSQL, SQLite).
 //
-// Schema gating normally hides these fields on SQLite, so this is the only
-// coverage of the defensive ErrUnsupportedAggregateOrderBy path. It also pins
-// the gated set to the variance family: a non-variance aggregate (avg) is not
-// rejected on SQLite.
+// Schema gating normally hides these fields on such a backend, so this is the
+// only coverage of the defensive ErrUnsupportedAggregateOrderBy path. It also
+// pins the gated set to the variance family: a non-variance aggregate (avg) is
+// not rejected.
 func TestParseOrderBy_AggregateVarianceGate(t *testing.T) {
 	t.Parallel()
 
-	t.Run("stddev rejected on SQLite", func(t *testing.T) {
+	t.Run("stddev rejected without stable variance ordering", func(t *testing.T) {
 		t.Parallel()
 
-		tbl := aggregateOrderByTable(t, sqliteDialect(), false)
+		tbl := aggregateOrderByTable(t, unstableDialect(), false)
 
 		_, err := arguments.ParseOrderBy(
 			tbl, aggregateOrderByValue("stddev"), nil, "admin", nil, `"public"."departments"`,
@@ -104,10 +119,10 @@ func TestParseOrderBy_AggregateVarianceGate(t *testing.T) {
 		}
 	})
 
-	t.Run("variance rejected on SQLite", func(t *testing.T) {
+	t.Run("variance rejected without stable variance ordering", func(t *testing.T) {
 		t.Parallel()
 
-		tbl := aggregateOrderByTable(t, sqliteDialect(), false)
+		tbl := aggregateOrderByTable(t, unstableDialect(), false)
 
 		_, err := arguments.ParseOrderBy(
 			tbl, aggregateOrderByValue("variance"), nil, "admin", nil, `"public"."departments"`,
@@ -117,18 +132,18 @@ func TestParseOrderBy_AggregateVarianceGate(t *testing.T) {
 		}
 	})
 
-	t.Run("non-variance aggregate allowed on SQLite", func(t *testing.T) {
+	t.Run("non-variance aggregate allowed without stable variance ordering", func(t *testing.T) {
 		t.Parallel()
 
-		// avg is not in varianceOrderByFuncs, so the gate must not fire even on
-		// SQLite. This pins the gated set to exactly the variance family.
-		tbl := aggregateOrderByTable(t, sqliteDialect(), true)
+		// avg is not in varianceOrderByFuncs, so the gate must not fire. This
+		// pins the gated set to exactly the variance family.
+		tbl := aggregateOrderByTable(t, unstableDialect(), true)
 
 		items, err := arguments.ParseOrderBy(
 			tbl, aggregateOrderByValue("avg"), nil, "admin", nil, `"public"."departments"`,
 		)
 		if err != nil {
-			t.Fatalf("avg ordering must be allowed on SQLite, got %v", err)
+			t.Fatalf("avg ordering must be allowed, got %v", err)
 		}
 
 		if len(items) != 1 {
@@ -162,4 +177,21 @@ func TestParseOrderBy_AggregateVarianceGate(t *testing.T) {
 			t.Errorf("rendered SQL\n got = %q\nwant = %q", got, want)
 		}
 	})
+
+	t.Run("stddev renders aggregate subquery on SQLite", func(t *testing.T) {
+		t.Parallel()
+
+		tbl := aggregateOrderByTable(t, sqliteDialect(), true)
+
+		items, err := arguments.ParseOrderBy(
+			tbl, aggregateOrderByValue("stddev"), nil, "admin", nil, `"public"."departments"`,
+		)
+		if err != nil {
+			t.Fatalf("stddev ordering must be allowed on SQLite, got %v", err)
+		}
+
+		if len(items) != 1 {
+			t.Fatalf("expected 1 order_by item, got %d", len(items))
+		}
+	})
 }
@@ -0,0 +1,140 @@
+package arguments
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/values"
+	"github.com/nhost/nhost/services/constellation/connector/sql/pgtypes"
+)
+
+// vectorDistanceSuffix is appended to a vector column's GraphQL name to form
+// its distance ordering field (`<col>_distance: vector_distance_order_by`).
+const vectorDistanceSuffix = "_distance"
+
+// vectorDistanceOrderTerm orders by the pgvector distance between a column and
+// a bound vector: ("t"."col" <-> $N::vector). Ordering by this expression with
+// a LIMIT is the shape Postgres serves from an HNSW or IVFFlat index.
+type vectorDistanceOrderTerm struct {
+	qualifier string
+	column    string
+	metric    dialect.VectorMetric
+	to        any
+	dialect   dialect.Dialect
+}
+
+func (term *vectorDistanceOrderTerm) writeExpr(
+	b *strings.Builder, params []any, paramIndex int,
+) ([]any, int, error) {
+	to, err := values.CoerceSQLValue(pgtypes.Vector, term.to)
+	if err != nil {
+		return nil, 0, fmt.Errorf("coercing vector distance to: %w", err)
+	}
+
+	var column strings.Builder
+	core.WriteQualifiedColumn(&column, term.qualifier, term.column)
+
+	term.dialect.WriteVectorDistance(
+		b, term.metric, column.String(), term.dialect.Placeholder(paramIndex),
+	)
+
+	return append(params, to), paramIndex + 1, nil
+}
+
+// vectorDistanceColumn resolves a `<col>_distance` order_by field to its vector
+// column. It returns nil when the field does not name one, so the caller falls
+// through to relationship ordering; a relationship of the same name wins, as
+// the schema omits the distance field on such a collision.
+func vectorDistanceColumn(t Table, fieldName string) *core.Column {
+	name, ok := strings.CutSuffix(fieldName, vectorDistanceSuffix)
+	if !ok || t.Relationship(fieldName) != nil {
+		return nil
+	}
+
+	column := t.ColumnFromGraphqlName(name)
+	if column == nil || !pgtypes.IsVector(column.SQLType) {
+		return nil
+	}
+
+	return column
+}
+
+// parseVectorDistanceOrderBy parses a vector_distance_order_by input
+// ({to, metric = l2, direction = asc}) into an ordering item. qualifier is the
+// relationship subquery alias, or "" for the queried table itself.
+func parseVectorDistanceOrderBy(
+	t Table,
+	column *core.Column,
+	value *ast.Value,
+	qualifier string,
+) (OrderByItem, error) {
+	d := t.Dialect()
+	if !d.SupportsVector() {
+		return OrderByItem{}, fmt.Errorf( //nolint:exhaustruct
+			"%w: vector distance ordering is not supported by this database", ErrInvalidArgument,
+		)
+	}
+
+	if value.Kind != ast.ObjectValue {
+		return OrderByItem{}, fmt.Errorf( //nolint:exhaustruct
+			"%w: order_by on %s%s must be an object",
+			ErrInvalidArgument, column.GraphqlName, vectorDistanceSuffix,
+		)
+	}
+
+	term := &vectorDistanceOrderTerm{
+		qualifier: qualifier,
+		column:    column.SQLName,
+		metric:    dialect.VectorMetricL2,
+		to:        nil,
+		dialect:   d,
+	}
+	direction := core.OrderAsc
+
+	for _, child := range value.Children {
+		var err error
+
+		switch child.Name {
+		case "to":
+			term.to, err = values.ResolveASTValue(child.Value, nil)
+		case "metric":
+			term.metric, err = vectorDistanceMetric(child.Value)
+		case "direction":
+			direction, err = orderByDirection(child.Value)
+		default:
+			err = fmt.Errorf(
+				"%w: unknown vector distance order_by field %s", ErrInvalidArgument, child.Name,
+			)
+		}
+
+		if err != nil {
+			return OrderByItem{}, err //nolint:exhaustruct
+		}
+	}
+
+	if term.to == nil {
+		return OrderByItem{}, fmt.Errorf( //nolint:exhaustruct
+			"%w: vector distance order_by requires to", ErrInvalidArgument,
+		)
+	}
+
+	return OrderByItem{Column: "", term: term, Direction: direction}, nil
+}
+
+// vectorDistanceMetric resolves a vector_distance_metric enum value.
+func vectorDistanceMetric(value *ast.Value) (dialect.VectorMetric, error) {
+	if value.Kind == ast.EnumValue {
+		switch metric := dialect.VectorMetric(value.Raw); metric {
+		case dialect.VectorMetricL2, dialect.VectorMetricCosine, dialect.VectorMetricInnerProduct:
+			return metric, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"%w: unknown vector distance metric %q", ErrInvalidArgument, value.Raw,
+	)
+}
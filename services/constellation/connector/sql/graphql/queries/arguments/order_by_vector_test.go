@@ -0,0 +1,112 @@
+package arguments_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/arguments"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/arguments/mock"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+)
+
+// vectorOrderByTable wires the mocks for an order_by of the shape
+// {embedding_distance: {...}} on a table with a vector column embedding.
+func vectorOrderByTable(t *testing.T, d dialect.Dialect) arguments.Table {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	tbl := mock.NewMockTable(ctrl)
+
+	tbl.EXPECT().ColumnFromGraphqlName("embedding_distance").Return(nil)
+	tbl.EXPECT().Relationship("embedding_distance").Return(nil)
+	tbl.EXPECT().
+		ColumnFromGraphqlName("embedding").
+		Return(newColumn("embedding", "embedding", "vector"))
+	tbl.EXPECT().Dialect().Return(d)
+
+	return tbl
+}
+
+func TestParseOrderBy_VectorDistance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		value      *ast.Value
+		variables  map[string]any
+		wantSQL    string
+		wantParams []any
+	}{
+		{
+			name: "defaults to ascending l2",
+			value: objectValue(child("embedding_distance", objectValue(
+				child("to", stringValue("[1,2,3]")),
+			))),
+			wantSQL:    `ORDER BY ("embedding" <-> $1::vector) ASC`,
+			wantParams: []any{"[1,2,3]"},
+		},
+		{
+			name: "metric, direction and a nested variable",
+			value: objectValue(child("embedding_distance", objectValue(
+				child("to", &ast.Value{Kind: ast.Variable, Raw: "vec"}),
+				child("metric", enumValue("cosine")),
+				child("direction", enumValue("desc")),
+			))),
+			variables:  map[string]any{"vec": []any{float64(1), 0.5}},
+			wantSQL:    `ORDER BY ("embedding" <=> $1::vector) DESC`,
+			wantParams: []any{"[1,0.5]"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			items, err := arguments.ParseOrderBy(
+				vectorOrderByTable(t, pgDialect()), tc.value, tc.variables, "admin", nil, "",
+			)
+			if err != nil {
+				t.Fatalf("ParseOrderBy: %v", err)
+			}
+
+			ob := &arguments.OrderBy{Items: items}
+
+			var b strings.Builder
+
+			params, _, err := ob.WriteSQL(&b, nil, 1)
+			if err != nil {
+				t.Fatalf("WriteSQL: %v", err)
+			}
+
+			if got := b.String(); got != tc.wantSQL {
+				t.Errorf("rendered SQL\n got = %q\nwant = %q", got, tc.wantSQL)
+			}
+
+			if !slices.Equal(params, tc.wantParams) {
+				t.Errorf("params = %v, want %v", params, tc.wantParams)
+			}
+		})
+	}
+}
+
+// TestParseOrderBy_VectorDistanceRejectedOnSQLite pins the dialect gate: the
+// schema never advertises <col>_distance on SQLite, and the parser refuses it.
+func TestParseOrderBy_VectorDistanceRejectedOnSQLite(t *testing.T) {
+	t.Parallel()
+
+	value := objectValue(child("embedding_distance", objectValue(
+		child("to", stringValue("[1,2,3]")),
+	)))
+
+	_, err := arguments.ParseOrderBy(
+		vectorOrderByTable(t, sqliteDialect()), value, nil, "admin", nil, "",
+	)
+	if !errors.Is(err, arguments.ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument, got %v", err)
+	}
+}
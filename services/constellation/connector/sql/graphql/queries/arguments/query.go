@@ -2,6 +2,8 @@ package arguments
 
 import (
 	"fmt"
+	"math"
+	"slices"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
@@ -90,7 +92,7 @@ func ParseQuery(
 		return nil, nil, nil, err
 	}
 
-	return whereClause, modifiers, dOn, nil
+	return whereClause, adaptModifiersToDialect(t, modifiers), dOn, nil
 }
 
 func appendOrderByModifier(modifiers []QueryModifier, items []OrderByItem) []QueryModifier {
@@ -98,7 +100,48 @@ func appendOrderByModifier(modifiers []QueryModifier, items []OrderByItem) []Que
 		return modifiers
 	}
 
-	return append(modifiers, &OrderBy{Items: items})
+	return append(modifiers, &OrderBy{Items: items, nullsAsSortKey: false})
+}
+
+// adaptModifiersToDialect fits the parsed modifiers to the table's backend when
+// its ORDER BY and LIMIT grammar is narrower than PostgreSQL's. An ORDER BY
+// switches to an
+// explicit null sort key when the dialect has no NULLS FIRST / NULLS LAST, and
+// a LIMIT as large as the backend accepts is added ahead of the OFFSET when
+// the dialect needs one to accompany an OFFSET or an ORDER BY. For PostgreSQL
+// the modifiers are returned unchanged.
+func adaptModifiersToDialect(t Table, modifiers []QueryModifier) []QueryModifier {
+	if len(modifiers) == 0 {
+		return modifiers
+	}
+
+	d := t.Dialect()
+
+	var (
+		hasLimit, hasOffset, hasOrderBy bool
+		limitAt                         = len(modifiers)
+	)
+
+	for i, m := range modifiers {
+		switch m := m.(type) {
+		case *OrderBy:
+			hasOrderBy = true
+			m.nullsAsSortKey = !d.SupportsNullsOrdering()
+		case *Limit:
+			hasLimit = true
+		case *Offset:
+			hasOffset = true
+			limitAt = i
+		}
+	}
+
+	needsLimit := (hasOffset && d.RequiresLimitWithOffset()) ||
+		(hasOrderBy && d.RequiresLimitWithOrderBy())
+	if hasLimit || !needsLimit {
+		return modifiers
+	}
+
+	return slices.Insert(modifiers, limitAt, QueryModifier(&Limit{Value: math.MaxInt64}))
 }
 
 // validateDistinctOnOrderBy enforces PostgreSQL's rule that the DISTINCT ON
@@ -144,7 +187,9 @@ func validateDistinctOnOrderBy(
 			}
 		}
 
-		return append([]QueryModifier{&OrderBy{Items: prefix}}, modifiers...), nil
+		return append(
+			[]QueryModifier{&OrderBy{Items: prefix, nullsAsSortKey: false}}, modifiers...,
+		), nil
 	}
 
 	// An order_by was supplied; its leading prefix must contain exactly the
@@ -284,7 +329,7 @@ func ParseOrderBy(
 	sessionVariables map[string]any,
 	parentSource string,
 ) ([]OrderByItem, error) {
-	value, err := values.ResolveVariable(value, variables)
+	value, err := values.ResolveVariables(value, variables)
 	if err != nil {
 		return nil, fmt.Errorf("resolving order_by: %w", err)
 	}
@@ -318,11 +363,12 @@ func ParseOrderBy(
 }
 
 // appendOrderByObject parses one order_by object and appends each entry to
-// orderBy. A field is dispatched as a scalar column, an object-relationship
+// orderBy. A field is dispatched as a scalar column, a vector distance
+// (`<col>_distance: vector_distance_order_by`), an object-relationship
 // ordering (`<rel>: <target>_order_by`), or an array-relationship aggregate
-// ordering (`<rel>_aggregate: <target>_aggregate_order_by`). The latter two
+// ordering (`<rel>_aggregate: <target>_aggregate_order_by`). The last two
 // emit correlated-subquery ordering terms; everything else errors, matching the
-// schema, which only advertises those three shapes.
+// schema, which only advertises those four shapes.
 func appendOrderByObject(
 	t Table,
 	orderBy []OrderByItem,
@@ -348,6 +394,17 @@ func appendOrderByObject(
 			continue
 		}
 
+		if column := vectorDistanceColumn(t, field.Name); column != nil {
+			item, err := parseVectorDistanceOrderBy(t, column, field.Value, "")
+			if err != nil {
+				return nil, err
+			}
+
+			orderBy = append(orderBy, item)
+
+			continue
+		}
+
 		items, err := appendRelationshipOrderBy(
 			t, field, parentSource, role, sessionVariables, gen,
 		)
@@ -37,6 +37,10 @@ type OrderByItem struct {
 // OrderBy represents an ORDER BY clause.
 type OrderBy struct {
 	Items []OrderByItem
+	// nullsAsSortKey renders each item as "(expr) IS NULL <dir>, expr <dir>"
+	// for backends without NULLS FIRST / NULLS LAST, reproducing PostgreSQL's
+	// null placement. Set by ParseQuery from the table's dialect.
+	nullsAsSortKey bool
 }
 
 // WriteSQL writes the ORDER BY clause to the SQL builder, threading params for
@@ -58,18 +62,62 @@ func (o *OrderBy) WriteSQL(
 
 		var err error
 
+		if o.nullsAsSortKey {
+			params, paramIndex, err = o.Items[i].writeNullsSortKey(b, params, paramIndex)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+
 		params, paramIndex, err = o.Items[i].writeExpr(b, params, paramIndex)
 		if err != nil {
 			return nil, 0, err
 		}
 
 		b.WriteByte(' ')
-		b.WriteString(o.Items[i].Direction.SQL())
+
+		if o.nullsAsSortKey {
+			b.WriteString(o.Items[i].baseDirectionSQL())
+		} else {
+			b.WriteString(o.Items[i].Direction.SQL())
+		}
+	}
+
+	return params, paramIndex, nil
+}
+
+// writeNullsSortKey writes "(expr) IS NULL ASC, " or "(expr) IS NULL DESC, ",
+// the leading key that sorts NULLs last or first the way PostgreSQL does for
+// the item's direction. A relationship term is rendered twice, so its
+// parameters are appended twice in order.
+func (item OrderByItem) writeNullsSortKey(
+	b *strings.Builder, params []any, paramIndex int,
+) ([]any, int, error) {
+	b.WriteByte('(')
+
+	params, paramIndex, err := item.writeExpr(b, params, paramIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if item.Direction.NullsFirst() {
+		b.WriteString(") IS NULL DESC, ")
+	} else {
+		b.WriteString(") IS NULL ASC, ")
 	}
 
 	return params, paramIndex, nil
 }
 
+// baseDirectionSQL returns the direction without its NULLS clause.
+func (item OrderByItem) baseDirectionSQL() string {
+	if item.Direction.IsDescending() {
+		return core.OrderDesc.SQL()
+	}
+
+	return core.OrderAsc.SQL()
+}
+
 // writeExpr writes a single ORDER BY expression (column or relationship term).
 func (item OrderByItem) writeExpr(
 	b *strings.Builder, params []any, paramIndex int,
@@ -540,6 +540,7 @@ func TestParseQuery(t *testing.T) {
 
 		ctrl := gomock.NewController(t)
 		tbl := mock.NewMockTable(ctrl)
+		tbl.EXPECT().Dialect().Return(pgDialect())
 
 		// Order-by + distinct-on lookups. distinct_on must be the leading
 		// order_by column (Hasura's rule) or ParseQuery rejects, so both
@@ -614,6 +615,7 @@ func TestParseQuery(t *testing.T) {
 		// behaviour: zero is still appended.
 		ctrl := gomock.NewController(t)
 		tbl := mock.NewMockTable(ctrl)
+		tbl.EXPECT().Dialect().Return(pgDialect())
 
 		args := ast.ArgumentList{
 			&ast.Argument{Name: "limit", Value: intValue("0")},
@@ -886,6 +888,7 @@ func TestParseQueryDistinctOnOrderByValidation(t *testing.T) {
 
 		ctrl := gomock.NewController(t)
 		tbl := mock.NewMockTable(ctrl)
+		tbl.EXPECT().Dialect().Return(pgDialect())
 
 		tbl.EXPECT().ColumnFromGraphqlName("locale").
 			Return(newColumn("locale", "locale", "text"))
@@ -910,6 +913,7 @@ func TestParseQueryDistinctOnOrderByValidation(t *testing.T) {
 
 		ctrl := gomock.NewController(t)
 		tbl := mock.NewMockTable(ctrl)
+		tbl.EXPECT().Dialect().Return(pgDialect())
 
 		// order_by: [{name: asc}, {budget: desc}], distinct_on: [name, budget].
 		tbl.EXPECT().ColumnFromGraphqlName("name").
@@ -955,6 +959,7 @@ func TestParseQueryDistinctOnOrderByValidation(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			tbl := mock.NewMockTable(ctrl)
+			tbl.EXPECT().Dialect().Return(pgDialect())
 
 			// PostgreSQL/Hasura require the first N order_by columns to be the
 			// distinct_on columns, but those first N columns may be permuted.
@@ -997,6 +1002,78 @@ func TestParseQueryDistinctOnOrderByValidation(t *testing.T) {
 		})
 }
 
+// TestParseQueryAdaptsModifiersToDialect pins the rewrites ParseQuery applies
+// for backends without NULLS FIRST / NULLS LAST or a bare OFFSET: the null
+// placement becomes an explicit leading sort key and an unbounded LIMIT is
+// inserted ahead of the OFFSET. PostgreSQL keeps the modifiers as parsed.
+func TestParseQueryAdaptsModifiersToDialect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		dialect dialect.Dialect
+		want    string
+	}{
+		{
+			name:    "postgres",
+			dialect: pgDialect(),
+			want:    `ORDER BY "score" DESC NULLS LAST | OFFSET 5`,
+		},
+		{
+			name:    "sqlite",
+			dialect: sqliteDialect(),
+			want:    `ORDER BY "score" DESC NULLS LAST | LIMIT 9223372036854775807 | OFFSET 5`,
+		},
+		{
+			name:    "mysql",
+			dialect: dialect.NewMySQLDialect(),
+			want: `ORDER BY ("score") IS NULL ASC, "score" DESC | ` +
+				`LIMIT 9223372036854775807 | OFFSET 5`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			tbl := mock.NewMockTable(ctrl)
+
+			tbl.EXPECT().ColumnFromGraphqlName("score").
+				Return(newColumn("score", "score", "int"))
+			tbl.EXPECT().Dialect().Return(tt.dialect)
+
+			args := ast.ArgumentList{
+				&ast.Argument{
+					Name:  "order_by",
+					Value: objectValue(child("score", enumValue("desc_nulls_last"))),
+				},
+				&ast.Argument{Name: "offset", Value: intValue("5")},
+			}
+
+			_, mods, _, err := arguments.ParseQuery(tbl, args, nil, "user", nil, "")
+			if err != nil {
+				t.Fatalf("ParseQuery: %v", err)
+			}
+
+			parts := make([]string, 0, len(mods))
+
+			for _, m := range mods {
+				var b strings.Builder
+				if _, _, err := m.WriteSQL(&b, nil, 1); err != nil {
+					t.Fatalf("WriteSQL: %v", err)
+				}
+
+				parts = append(parts, b.String())
+			}
+
+			if got := strings.Join(parts, " | "); got != tt.want {
+				t.Errorf("modifiers:\n got  %s\n want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseQueryDistinctOnEmptyOrderBySynthesizesOrderBy(t *testing.T) {
 	t.Parallel()
 
@@ -1024,6 +1101,7 @@ func TestParseQueryDistinctOnEmptyOrderBySynthesizesOrderBy(t *testing.T) {
 
 			ctrl := gomock.NewController(t)
 			tbl := mock.NewMockTable(ctrl)
+			tbl.EXPECT().Dialect().Return(pgDialect())
 
 			tbl.EXPECT().ColumnFromGraphqlName("locale").
 				Return(newColumn("locale", "locale", "text"))
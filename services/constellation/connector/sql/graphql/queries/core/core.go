@@ -45,6 +45,28 @@ type SQLOperation struct {
 	// JSON results under this operation's Name. This is used by update_many so
 	// later updates observe earlier ones, matching Hasura's sequential semantics.
 	Sequential []SQLOperation `json:",omitempty"`
+	// Stages is non-empty for a mutation whose dialect cannot run
+	// data-modifying CTEs (SQLite). Each stage is one former CTE, executed in
+	// order into a temporary table named after it; SQL and Parameters then hold
+	// only the final statement that reads those tables.
+	Stages []SQLStage `json:",omitempty"`
+}
+
+// SQLStage is one statement of a staged mutation. Drivers execute it and keep
+// its rows in a temporary table called Name so later stages and the final
+// statement can read it the way they read the CTE it replaces.
+type SQLStage struct {
+	// Name is the unquoted name of the temporary table holding the rows.
+	Name string
+	// SQL is a SELECT, or an INSERT/UPDATE/DELETE ... RETURNING statement when
+	// Modifying is set.
+	SQL string
+	// Parameters is the positional argument list bound to SQL.
+	Parameters []any
+	// Modifying reports whether SQL is a DML statement. SQLite cannot feed
+	// RETURNING rows into another statement, so drivers copy them into the
+	// temporary table themselves instead of running CREATE TABLE ... AS.
+	Modifying bool
 }
 
 // StreamCursorInfo carries metadata for a single cursor column on a stream
@@ -73,8 +95,9 @@ type StreamCursorInfo struct {
 // clauses by callers that bypass the argument parsers.
 //
 // UnmarshalJSON requires a pointer receiver to mutate; the read-only methods
-// (SQL, IsDescending, MarshalJSON) keep value receivers because they don't,
-// mirroring the standard library convention for JSON-marshalable scalar types.
+// (SQL, IsDescending, NullsFirst, MarshalJSON) keep value receivers because
+// they don't, mirroring the standard library convention for JSON-marshalable
+// scalar types.
 //
 //nolint:recvcheck // mixed receivers are intentional; see godoc above.
 type OrderDirection int
@@ -128,6 +151,21 @@ func (d OrderDirection) IsDescending() bool {
 	}
 }
 
+// NullsFirst reports whether PostgreSQL places NULLs first for the direction:
+// explicitly for the NULLS FIRST variants, and by default for plain DESC, since
+// PostgreSQL sorts NULL above every other value. Backends without NULLS
+// FIRST / NULLS LAST use it to emulate PostgreSQL's placement.
+func (d OrderDirection) NullsFirst() bool {
+	switch d {
+	case OrderDesc, OrderAscNullsFirst, OrderDescNullsFirst:
+		return true
+	case OrderAsc, OrderAscNullsLast, OrderDescNullsLast:
+		return false
+	default:
+		return false
+	}
+}
+
 // MarshalJSON marshals the direction as its SQL fragment (e.g. "ASC NULLS
 // FIRST"). The textual form keeps JSON golden files human-readable and stable
 // across re-orderings of the constants.
@@ -1,12 +1,14 @@
 // Package dialect abstracts SQL syntax differences between database backends.
-// The Dialect interface defines the contract; PostgresDialect and SQLiteDialect
-// provide the two concrete implementations. Consumers (query builders, filters)
-// depend on the interface; concrete drivers (connector/sql/postgres, sqlite)
-// construct the implementation values.
+// The Dialect interface defines the contract; PostgresDialect, SQLiteDialect,
+// and MySQLDialect provide the concrete implementations. Consumers (query
+// builders, filters) depend on the interface; concrete drivers
+// (connector/sql/postgres, sqlite, mysql) construct the implementation values.
 package dialect
 
 import (
 	"strings"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
 )
 
 // SpatialPredicate identifies the fixed PostGIS boolean predicate a spatial
@@ -24,6 +26,17 @@ const (
 	SpatialPredicate3DIntersects SpatialPredicate = "3d_intersects"
 )
 
+// VectorMetric identifies the pgvector distance a vector comparison or
+// ordering uses. Values are internal constants matching the GraphQL
+// vector_distance_metric enum.
+type VectorMetric string
+
+const (
+	VectorMetricL2           VectorMetric = "l2"
+	VectorMetricCosine       VectorMetric = "cosine"
+	VectorMetricInnerProduct VectorMetric = "inner_product"
+)
+
 //go:generate mockgen -package mock -destination mock/dialect.go . Dialect
 
 // Dialect abstracts SQL syntax differences between database backends.
@@ -128,6 +141,11 @@ type Dialect interface { //nolint:interfacebloat
 	// PostgreSQL: coalesce(json_agg("x"), '[]')    SQLite: coalesce(json_group_array("x"), '[]')
 	CoalesceJSONArray(alias string) string
 
+	// CoalesceJSONAgg wraps a JSON array aggregation expression (as built by
+	// JSONAggQuotedAlias or JSONAggRawExpr) with coalesce to default to empty array.
+	// PostgreSQL: coalesce(expr, '[]')    SQLite: coalesce(expr, '[]')
+	CoalesceJSONAgg(aggExpr string) string
+
 	// JSONBuildObject returns the function name for building JSON objects.
 	// PostgreSQL: json_build_object    SQLite: json_object
 	JSONBuildObject() string
@@ -169,9 +187,27 @@ type Dialect interface { //nolint:interfacebloat
 	// SupportsDistinctOn returns whether DISTINCT ON is available.
 	SupportsDistinctOn() bool
 
-	// ThrowError returns SQL to raise an error.
-	// PostgreSQL: constellation_throw_error('msg', 'code')
-	// SQLite:     uses a subquery approach
+	// SupportsNullsOrdering returns whether ORDER BY accepts NULLS FIRST /
+	// NULLS LAST. When it does not (MySQL), order_by emulates PostgreSQL's
+	// null placement with a leading "expr IS NULL" sort key.
+	SupportsNullsOrdering() bool
+
+	// RequiresLimitWithOffset returns whether OFFSET is only valid after a
+	// LIMIT clause. SQLite and MySQL reject a bare OFFSET, so an offset without
+	// a limit is rendered with the largest LIMIT the backend accepts.
+	RequiresLimitWithOffset() bool
+
+	// RequiresLimitWithOrderBy returns whether an ORDER BY inside a derived
+	// table or CTE is only honoured when the same query block has a LIMIT.
+	// MySQL discards such an ORDER BY when it merges the derived table into
+	// an aggregating outer query, which would lose the order of JSON arrays.
+	RequiresLimitWithOrderBy() bool
+
+	// ThrowError returns a scalar expression that raises an error when it is
+	// evaluated. Both drivers install a constellation_throw_error function on
+	// every connection.
+	// PostgreSQL: (SELECT 0 FROM (SELECT constellation_throw_error('msg', 'code')) x)
+	// SQLite:     constellation_throw_error('msg', 'code')
 	ThrowError(message, code string) string
 
 	// MaterializedCTE returns "AS MATERIALIZED" or "AS" depending on support.
@@ -188,6 +224,23 @@ type Dialect interface { //nolint:interfacebloat
 	// SupportsJSONB returns whether JSONB operators (@>, <@, ?, ?&, ?|) are available.
 	SupportsJSONB() bool
 
+	// SupportsLtree returns whether the ltree operators (@>, <@, ~, ?, @) are
+	// available on ltree columns.
+	SupportsLtree() bool
+
+	// SupportsFullTextSearch returns whether tsvector columns can be matched
+	// with @@ websearch_to_tsquery.
+	SupportsFullTextSearch() bool
+
+	// SupportsVector returns whether pgvector distance operators are available
+	// on vector columns.
+	SupportsVector() bool
+
+	// WriteVectorDistance writes the distance between a vector expression and
+	// a placeholder bound to the vector's text form.
+	// PostgreSQL: leftExpr <-> $N::vector (<=> cosine, <#> negative inner product)
+	WriteVectorDistance(b *strings.Builder, metric VectorMetric, leftExpr, placeholder string)
+
 	// SupportsFunctions returns whether tracked SQL functions are available.
 	SupportsFunctions() bool
 
@@ -208,22 +261,24 @@ type Dialect interface { //nolint:interfacebloat
 
 	// SupportsStableVarianceOrderBy reports whether the backend can order an
 	// array-relationship aggregate by a stddev/variance function with a result
-	// numerically faithful to PostgreSQL's, so the row ordering matches. SQLite
-	// has no native stddev/variance aggregate and the one-pass identity that
-	// would emulate them suffers catastrophic cancellation for large, close
-	// values (it can even go negative), inverting the ordering versus
-	// PostgreSQL/Hasura. When this returns false the caller rejects such
-	// orderings rather than returning a silently wrong order.
+	// numerically faithful to PostgreSQL's, so the row ordering matches. The
+	// one-pass sum-of-squares identity suffers catastrophic cancellation for
+	// large, close values (it can even go negative), inverting the ordering
+	// versus PostgreSQL/Hasura, so a backend emulating the functions that way
+	// must return false and the caller rejects such orderings rather than
+	// returning a silently wrong order.
 	SupportsStableVarianceOrderBy() bool
 
-	// SupportsVarianceAggregates reports whether the backend has native
-	// stddev/variance aggregate functions (stddev, stddev_pop, stddev_samp,
-	// var_pop, var_samp, variance) usable in an aggregate selection. PostgreSQL
-	// does; SQLite (go-sqlite3) does not, so emitting STDDEV(...) etc. fails at
-	// execution with an opaque "no such function" error. Schema generation gates
-	// the corresponding aggregate fields on this, and the aggregate-selection
-	// builder rejects them as a defensive backstop for callers that bypass schema
-	// validation. avg and sum are native everywhere and are never gated here.
+	// SupportsVarianceAggregates reports whether the backend has stddev/variance
+	// aggregate functions (stddev, stddev_pop, stddev_samp, var_pop, var_samp,
+	// variance) usable in an aggregate selection. PostgreSQL and MySQL have them
+	// natively; SQLite gets them from Go aggregates the sqlite driver registers
+	// on every connection. Without them emitting STDDEV(...) etc. fails at
+	// execution with an opaque "no such function" error, so schema generation
+	// gates the corresponding aggregate fields on this, and the
+	// aggregate-selection builder rejects them as a defensive backstop for
+	// callers that bypass schema validation. avg and sum are native everywhere
+	// and are never gated here.
 	SupportsVarianceAggregates() bool
 
 	// BoolAndFunc returns the name of the aggregate that is true iff every
@@ -267,6 +322,28 @@ type Dialect interface { //nolint:interfacebloat
 	// SupportsUpsertUpdateAction.
 	WriteUpsertUpdateAction(b *strings.Builder)
 
+	// SupportsMultiplexedSubscriptions reports whether a subscription cohort
+	// can be polled with one multiplexed statement that unnests every
+	// subscriber's variables (see package multiplexed). PostgreSQL can; the
+	// other backends keep the subscription's own statement and poll it once
+	// per subscriber with that subscriber's variables bound.
+	SupportsMultiplexedSubscriptions() bool
+
+	// SupportsDataModifyingCTEs reports whether INSERT/UPDATE/DELETE ...
+	// RETURNING may appear inside a WITH clause. PostgreSQL allows it; SQLite
+	// does not, so the mutation builders hand each CTE over as a separate stage
+	// (see core.SQLStage) the driver executes one at a time inside its
+	// transaction.
+	SupportsDataModifyingCTEs() bool
+
+	// WriteReturningAll writes the RETURNING list a mutation uses to hand every
+	// table column to the statements that consume its rows.
+	// PostgreSQL: *    SQLite: +"a" AS "a", +"b" AS "b"
+	// SQLite's unary plus leaves the value untouched but drops the column's
+	// declared type, so go-sqlite3 returns the stored value instead of converting
+	// DATETIME or BOOLEAN columns into Go types before the driver stages it.
+	WriteReturningAll(b *strings.Builder, columns []string)
+
 	// WriteOnConflictTarget writes the conflict-target clause of an INSERT ...
 	// ON CONFLICT statement, up to (but not including) the DO NOTHING / DO UPDATE
 	// action. The two backends diverge irreconcilably here:
@@ -279,6 +356,12 @@ type Dialect interface { //nolint:interfacebloat
 	// identifies conflicts by column list, while PostgreSQL names the constraint.
 	RequiresOnConflictTargetColumns() bool
 
+	// RequiresUpsertSourceFilter reports whether an INSERT ... SELECT followed
+	// by ON CONFLICT needs a WHERE clause of its own. SQLite otherwise parses
+	// the upsert's ON as a join constraint on the last FROM term; PostgreSQL
+	// accepts either form.
+	RequiresUpsertSourceFilter() bool
+
 	// SQLite has no "ON CONSTRAINT <name>" form, so callers must supply the
 	// constraint's columns; PostgreSQL ignores them and names the constraint.
 	// conflictColumns are already-resolved SQL column names; they are emitted as
@@ -315,3 +398,29 @@ func writeExpressionList(b *strings.Builder, expressions []string) {
 		b.WriteString(expr)
 	}
 }
+
+// writePlaceholderList writes `source.column<operator>?, ?, ...)` with one "?"
+// per value, for the backends that expand arrays into positional placeholders.
+// operator carries the opening parenthesis (" IN (" or " NOT IN (").
+func writePlaceholderList(
+	b *strings.Builder, source, sqlName, operator string,
+	values []any, params []any, paramIndex int,
+) ([]any, int) {
+	core.WriteQualifiedColumn(b, source, sqlName)
+	b.WriteString(operator)
+
+	for i, v := range values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString("?")
+
+		params = append(params, v)
+		paramIndex++
+	}
+
+	b.WriteString(")")
+
+	return params, paramIndex
+}
@@ -83,6 +83,20 @@ func (mr *MockDialectMockRecorder) CoalesceJSONArray(alias any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CoalesceJSONArray", reflect.TypeOf((*MockDialect)(nil).CoalesceJSONArray), alias)
 }
 
+// CoalesceJSONAgg mocks base method.
+func (m *MockDialect) CoalesceJSONAgg(aggExpr string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CoalesceJSONAgg", aggExpr)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// CoalesceJSONAgg indicates an expected call of CoalesceJSONAgg.
+func (mr *MockDialectMockRecorder) CoalesceJSONAgg(aggExpr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CoalesceJSONAgg", reflect.TypeOf((*MockDialect)(nil).CoalesceJSONAgg), aggExpr)
+}
+
 // EmptyJSONArray mocks base method.
 func (m *MockDialect) EmptyJSONArray() string {
 	m.ctrl.T.Helper()
@@ -195,6 +209,34 @@ func (mr *MockDialectMockRecorder) Placeholder(paramIndex any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Placeholder", reflect.TypeOf((*MockDialect)(nil).Placeholder), paramIndex)
 }
 
+// RequiresLimitWithOffset mocks base method.
+func (m *MockDialect) RequiresLimitWithOffset() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequiresLimitWithOffset")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RequiresLimitWithOffset indicates an expected call of RequiresLimitWithOffset.
+func (mr *MockDialectMockRecorder) RequiresLimitWithOffset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiresLimitWithOffset", reflect.TypeOf((*MockDialect)(nil).RequiresLimitWithOffset))
+}
+
+// RequiresLimitWithOrderBy mocks base method.
+func (m *MockDialect) RequiresLimitWithOrderBy() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequiresLimitWithOrderBy")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RequiresLimitWithOrderBy indicates an expected call of RequiresLimitWithOrderBy.
+func (mr *MockDialectMockRecorder) RequiresLimitWithOrderBy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiresLimitWithOrderBy", reflect.TypeOf((*MockDialect)(nil).RequiresLimitWithOrderBy))
+}
+
 // RequiresOnConflictTargetColumns mocks base method.
 func (m *MockDialect) RequiresOnConflictTargetColumns() bool {
 	m.ctrl.T.Helper()
@@ -209,6 +251,20 @@ func (mr *MockDialectMockRecorder) RequiresOnConflictTargetColumns() *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiresOnConflictTargetColumns", reflect.TypeOf((*MockDialect)(nil).RequiresOnConflictTargetColumns))
 }
 
+// RequiresUpsertSourceFilter mocks base method.
+func (m *MockDialect) RequiresUpsertSourceFilter() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequiresUpsertSourceFilter")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// RequiresUpsertSourceFilter indicates an expected call of RequiresUpsertSourceFilter.
+func (mr *MockDialectMockRecorder) RequiresUpsertSourceFilter() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiresUpsertSourceFilter", reflect.TypeOf((*MockDialect)(nil).RequiresUpsertSourceFilter))
+}
+
 // SpatialCastExpression mocks base method.
 func (m *MockDialect) SpatialCastExpression(expr, fromSQLType, toSQLType string) string {
 	m.ctrl.T.Helper()
@@ -265,6 +321,20 @@ func (mr *MockDialectMockRecorder) SupportsArrays() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsArrays", reflect.TypeOf((*MockDialect)(nil).SupportsArrays))
 }
 
+// SupportsDataModifyingCTEs mocks base method.
+func (m *MockDialect) SupportsDataModifyingCTEs() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsDataModifyingCTEs")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsDataModifyingCTEs indicates an expected call of SupportsDataModifyingCTEs.
+func (mr *MockDialectMockRecorder) SupportsDataModifyingCTEs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsDataModifyingCTEs", reflect.TypeOf((*MockDialect)(nil).SupportsDataModifyingCTEs))
+}
+
 // SupportsDistinctOn mocks base method.
 func (m *MockDialect) SupportsDistinctOn() bool {
 	m.ctrl.T.Helper()
@@ -279,6 +349,20 @@ func (mr *MockDialectMockRecorder) SupportsDistinctOn() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsDistinctOn", reflect.TypeOf((*MockDialect)(nil).SupportsDistinctOn))
 }
 
+// SupportsFullTextSearch mocks base method.
+func (m *MockDialect) SupportsFullTextSearch() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsFullTextSearch")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsFullTextSearch indicates an expected call of SupportsFullTextSearch.
+func (mr *MockDialectMockRecorder) SupportsFullTextSearch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsFullTextSearch", reflect.TypeOf((*MockDialect)(nil).SupportsFullTextSearch))
+}
+
 // SupportsFunctions mocks base method.
 func (m *MockDialect) SupportsFunctions() bool {
 	m.ctrl.T.Helper()
@@ -321,6 +405,48 @@ func (mr *MockDialectMockRecorder) SupportsLateral() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsLateral", reflect.TypeOf((*MockDialect)(nil).SupportsLateral))
 }
 
+// SupportsLtree mocks base method.
+func (m *MockDialect) SupportsLtree() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsLtree")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsLtree indicates an expected call of SupportsLtree.
+func (mr *MockDialectMockRecorder) SupportsLtree() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsLtree", reflect.TypeOf((*MockDialect)(nil).SupportsLtree))
+}
+
+// SupportsMultiplexedSubscriptions mocks base method.
+func (m *MockDialect) SupportsMultiplexedSubscriptions() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsMultiplexedSubscriptions")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsMultiplexedSubscriptions indicates an expected call of SupportsMultiplexedSubscriptions.
+func (mr *MockDialectMockRecorder) SupportsMultiplexedSubscriptions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsMultiplexedSubscriptions", reflect.TypeOf((*MockDialect)(nil).SupportsMultiplexedSubscriptions))
+}
+
+// SupportsNullsOrdering mocks base method.
+func (m *MockDialect) SupportsNullsOrdering() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsNullsOrdering")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsNullsOrdering indicates an expected call of SupportsNullsOrdering.
+func (mr *MockDialectMockRecorder) SupportsNullsOrdering() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsNullsOrdering", reflect.TypeOf((*MockDialect)(nil).SupportsNullsOrdering))
+}
+
 // SupportsRegex mocks base method.
 func (m *MockDialect) SupportsRegex() bool {
 	m.ctrl.T.Helper()
@@ -391,6 +517,20 @@ func (mr *MockDialectMockRecorder) SupportsVarianceAggregates() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsVarianceAggregates", reflect.TypeOf((*MockDialect)(nil).SupportsVarianceAggregates))
 }
 
+// SupportsVector mocks base method.
+func (m *MockDialect) SupportsVector() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportsVector")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// SupportsVector indicates an expected call of SupportsVector.
+func (mr *MockDialectMockRecorder) SupportsVector() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportsVector", reflect.TypeOf((*MockDialect)(nil).SupportsVector))
+}
+
 // TableRef mocks base method.
 func (m *MockDialect) TableRef(schema, table string) string {
 	m.ctrl.T.Helper()
@@ -626,6 +766,18 @@ func (mr *MockDialectMockRecorder) WriteOnConflictTarget(b, constraintName, conf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteOnConflictTarget", reflect.TypeOf((*MockDialect)(nil).WriteOnConflictTarget), b, constraintName, conflictColumns)
 }
 
+// WriteReturningAll mocks base method.
+func (m *MockDialect) WriteReturningAll(b *strings.Builder, columns []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WriteReturningAll", b, columns)
+}
+
+// WriteReturningAll indicates an expected call of WriteReturningAll.
+func (mr *MockDialectMockRecorder) WriteReturningAll(b, columns any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteReturningAll", reflect.TypeOf((*MockDialect)(nil).WriteReturningAll), b, columns)
+}
+
 // WriteSpatialArrayIn mocks base method.
 func (m *MockDialect) WriteSpatialArrayIn(b *strings.Builder, source, sqlName, sqlType string, values, params []any, paramIndex int) ([]any, int) {
 	m.ctrl.T.Helper()
@@ -721,3 +873,15 @@ func (mr *MockDialectMockRecorder) WriteUpsertUpdateAction(b any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteUpsertUpdateAction", reflect.TypeOf((*MockDialect)(nil).WriteUpsertUpdateAction), b)
 }
+
+// WriteVectorDistance mocks base method.
+func (m *MockDialect) WriteVectorDistance(b *strings.Builder, metric dialect.VectorMetric, leftExpr, placeholder string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WriteVectorDistance", b, metric, leftExpr, placeholder)
+}
+
+// WriteVectorDistance indicates an expected call of WriteVectorDistance.
+func (mr *MockDialectMockRecorder) WriteVectorDistance(b, metric, leftExpr, placeholder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteVectorDistance", reflect.TypeOf((*MockDialect)(nil).WriteVectorDistance), b, metric, leftExpr, placeholder)
+}
@@ -0,0 +1,415 @@
+package dialect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+)
+
+var errMySQLUpsertUnsupported = errors.New("mysql sources do not support insert mutations")
+
+// MySQLDialect implements Dialect for MySQL 8.0.14 and later. The generated
+// SQL quotes identifiers with double quotes, so the driver runs every
+// statement with the ANSI_QUOTES SQL mode. MySQL sources are read-only: the
+// introspector reports every relation as neither insertable nor updatable,
+// so the mutation-only methods below are never reached.
+type MySQLDialect struct{}
+
+// Compile-time assertion that MySQLDialect satisfies Dialect; a missing
+// method becomes a build error instead of a runtime "method not found" panic.
+var _ Dialect = (*MySQLDialect)(nil)
+
+// NewMySQLDialect returns a MySQLDialect ready for use. Prefer the
+// constructor over literal initialisation so future configuration fields can
+// be added without breaking call sites.
+func NewMySQLDialect() *MySQLDialect {
+	return &MySQLDialect{}
+}
+
+// Placeholder ignores the parameter index because MySQL uses positional "?"
+// markers, like SQLite.
+func (d *MySQLDialect) Placeholder(_ int) string {
+	return "?"
+}
+
+// TypeCast returns the placeholder unchanged: MySQL converts bound values to
+// the compared column's type and has no ::type syntax.
+func (d *MySQLDialect) TypeCast(placeholder string, _ string) string {
+	return placeholder
+}
+
+// WriteArrayIn expands the values into "col IN (?, ?, ...)" because MySQL has
+// no array type. An empty list is rendered as "1 = 0", the never-matching
+// predicate, since MySQL rejects "IN ()".
+func (d *MySQLDialect) WriteArrayIn(
+	b *strings.Builder, source, sqlName, _ string,
+	values []any, params []any, paramIndex int,
+) ([]any, int) {
+	if len(values) == 0 {
+		b.WriteString("1 = 0")
+		return params, paramIndex
+	}
+
+	return writePlaceholderList(b, source, sqlName, " IN (", values, params, paramIndex)
+}
+
+// WriteArrayNotIn is the inverse of WriteArrayIn; an empty list excludes
+// nothing and is rendered as "1 = 1".
+func (d *MySQLDialect) WriteArrayNotIn(
+	b *strings.Builder, source, sqlName, _ string,
+	values []any, params []any, paramIndex int,
+) ([]any, int) {
+	if len(values) == 0 {
+		b.WriteString("1 = 1")
+		return params, paramIndex
+	}
+
+	return writePlaceholderList(b, source, sqlName, " NOT IN (", values, params, paramIndex)
+}
+
+func (d *MySQLDialect) SupportsSpatialTypes() bool {
+	return false
+}
+
+func (d *MySQLDialect) SpatialOutputExpression(expr, _ string) string {
+	return expr
+}
+
+func (d *MySQLDialect) SpatialValueExpression(placeholder, sqlType string) string {
+	return d.TypeCast(placeholder, sqlType)
+}
+
+func (d *MySQLDialect) SpatialCastExpression(expr, _, _ string) string {
+	return expr
+}
+
+func (d *MySQLDialect) WriteSpatialPredicate(
+	_ *strings.Builder,
+	_ SpatialPredicate,
+	_, _ string,
+) {
+	panic(
+		"dialect: WriteSpatialPredicate called on MySQLDialect; gate with SupportsSpatialTypes()",
+	)
+}
+
+func (d *MySQLDialect) WriteSpatialDWithinPredicate(
+	_ *strings.Builder,
+	_ bool,
+	_, _, _, _ string,
+	_ *string,
+) {
+	panic(
+		"dialect: WriteSpatialDWithinPredicate called on MySQLDialect; gate with SupportsSpatialTypes()",
+	)
+}
+
+func (d *MySQLDialect) WriteSpatialArrayIn(
+	b *strings.Builder, source, sqlName, sqlType string,
+	values []any, params []any, paramIndex int,
+) ([]any, int) {
+	return d.WriteArrayIn(b, source, sqlName, sqlType, values, params, paramIndex)
+}
+
+func (d *MySQLDialect) WriteSpatialArrayNotIn(
+	b *strings.Builder, source, sqlName, sqlType string,
+	values []any, params []any, paramIndex int,
+) ([]any, int) {
+	return d.WriteArrayNotIn(b, source, sqlName, sqlType, values, params, paramIndex)
+}
+
+func (d *MySQLDialect) WriteSpatialArrayInExpression(
+	_ *strings.Builder, _, _ string,
+	_ []any, _ []any, _ int,
+) ([]any, int) {
+	panic(
+		"dialect: WriteSpatialArrayInExpression called on MySQLDialect; gate with SupportsSpatialTypes()",
+	)
+}
+
+func (d *MySQLDialect) WriteSpatialArrayNotInExpression(
+	_ *strings.Builder, _, _ string,
+	_ []any, _ []any, _ int,
+) ([]any, int) {
+	panic(
+		"dialect: WriteSpatialArrayNotInExpression called on MySQLDialect; gate with SupportsSpatialTypes()",
+	)
+}
+
+// JSONAggQuotedAlias aggregates with JSON_ARRAYAGG. The aggregated column is
+// the JSON value a nested JSON_OBJECT produced, so no json() re-parse is
+// needed the way SQLite's text-typed JSON requires.
+func (d *MySQLDialect) JSONAggQuotedAlias(alias string) string {
+	return "JSON_ARRAYAGG(" + core.QuoteIdentifier(alias) + ")"
+}
+
+func (d *MySQLDialect) JSONAggRawExpr(expr string) string {
+	return "JSON_ARRAYAGG(" + expr + ")"
+}
+
+// CoalesceJSONArray defaults to JSON_ARRAY() rather than a '[]' literal:
+// coalescing a JSON value with a string yields a string, which JSON_OBJECT
+// would then embed as "[]" instead of an empty array.
+func (d *MySQLDialect) CoalesceJSONArray(alias string) string {
+	return "coalesce(JSON_ARRAYAGG(" + core.QuoteIdentifier(alias) + "), JSON_ARRAY())"
+}
+
+// CoalesceJSONAgg defaults to JSON_ARRAY() for the same reason as
+// CoalesceJSONArray.
+func (d *MySQLDialect) CoalesceJSONAgg(aggExpr string) string {
+	return "coalesce(" + aggExpr + ", JSON_ARRAY())"
+}
+
+func (d *MySQLDialect) JSONBuildObject() string {
+	return "JSON_OBJECT"
+}
+
+func (d *MySQLDialect) ToJSON(expr string) string {
+	return "CAST(" + expr + " AS JSON)"
+}
+
+func (d *MySQLDialect) EmptyJSONArray() string {
+	return "JSON_ARRAY()"
+}
+
+// TableRef qualifies the table with its database: a MySQL source's metadata
+// schema is the database the table lives in.
+func (d *MySQLDialect) TableRef(schema, table string) string {
+	return core.QuoteIdentifier(schema) + "." + core.QuoteIdentifier(table)
+}
+
+// SupportsLateral returns false. MySQL 8.0.14 has LATERAL derived tables, but
+// the correlated-subquery form the builders use for SQLite also runs there
+// and keeps a single code path for the backends without PostgreSQL's
+// row_to_json.
+func (d *MySQLDialect) SupportsLateral() bool {
+	return false
+}
+
+// Like compares binary strings so _like stays case-sensitive under the
+// case-insensitive collations MySQL uses by default.
+func (d *MySQLDialect) Like() string {
+	return "LIKE BINARY"
+}
+
+func (d *MySQLDialect) NotLike() string {
+	return "NOT LIKE BINARY"
+}
+
+func (d *MySQLDialect) WriteILikeCondition(
+	b *strings.Builder, source, column, placeholder string,
+) {
+	b.WriteString("LOWER(")
+	core.WriteQualifiedColumn(b, source, column)
+	b.WriteString(") LIKE LOWER(")
+	b.WriteString(placeholder)
+	b.WriteByte(')')
+}
+
+func (d *MySQLDialect) WriteNotILikeCondition(
+	b *strings.Builder, source, column, placeholder string,
+) {
+	b.WriteString("LOWER(")
+	core.WriteQualifiedColumn(b, source, column)
+	b.WriteString(") NOT LIKE LOWER(")
+	b.WriteString(placeholder)
+	b.WriteByte(')')
+}
+
+func (d *MySQLDialect) SupportsRegex() bool {
+	return false
+}
+
+func (d *MySQLDialect) SupportsDistinctOn() bool {
+	return false
+}
+
+// SupportsNullsOrdering returns false: MySQL has no NULLS FIRST / NULLS LAST
+// and always sorts NULLs as the smallest value.
+func (d *MySQLDialect) SupportsNullsOrdering() bool { return false }
+
+// RequiresLimitWithOffset returns true: MySQL only accepts OFFSET after a
+// LIMIT.
+func (d *MySQLDialect) RequiresLimitWithOffset() bool { return true }
+
+// RequiresLimitWithOrderBy returns true: the optimizer drops the ORDER BY of
+// a derived table it merges into an aggregating query, and a LIMIT is what
+// keeps the derived table from being merged.
+func (d *MySQLDialect) RequiresLimitWithOrderBy() bool { return true }
+
+// SupportsMultiplexedSubscriptions returns false: the multiplexed statement
+// unnests PostgreSQL arrays and reads subscriber variables with the #>> JSON
+// operator.
+func (d *MySQLDialect) SupportsMultiplexedSubscriptions() bool { return false }
+
+// ThrowError is only used by mutation builders, which MySQL sources never
+// expose.
+func (d *MySQLDialect) ThrowError(_, _ string) string {
+	panic("dialect: ThrowError called on MySQLDialect; MySQL sources are read-only")
+}
+
+func (d *MySQLDialect) MaterializedCTE() string {
+	return "AS"
+}
+
+// WriteArrayContains is unreachable on MySQL: array operators are gated by
+// SupportsArrays().
+func (d *MySQLDialect) WriteArrayContains(_ *strings.Builder, _, _ string) {
+	panic("dialect: WriteArrayContains called on MySQLDialect; gate with SupportsArrays()")
+}
+
+// WriteArrayContainedIn is unreachable on MySQL for the same reason as
+// WriteArrayContains.
+func (d *MySQLDialect) WriteArrayContainedIn(_ *strings.Builder, _, _ string) {
+	panic(
+		"dialect: WriteArrayContainedIn called on MySQLDialect; gate with SupportsArrays()",
+	)
+}
+
+func (d *MySQLDialect) SupportsJSONB() bool {
+	return false
+}
+
+func (d *MySQLDialect) SupportsLtree() bool {
+	return false
+}
+
+func (d *MySQLDialect) SupportsFullTextSearch() bool {
+	return false
+}
+
+func (d *MySQLDialect) SupportsVector() bool {
+	return false
+}
+
+func (d *MySQLDialect) WriteVectorDistance(_ *strings.Builder, _ VectorMetric, _, _ string) {
+	panic("dialect: WriteVectorDistance called on MySQLDialect; gate with SupportsVector()")
+}
+
+func (d *MySQLDialect) SupportsFunctions() bool {
+	return false
+}
+
+func (d *MySQLDialect) SupportsArrays() bool {
+	return false
+}
+
+// WriteCountAggregate counts multiple columns through a JSON_ARRAY tuple.
+// MySQL's own COUNT(a, b) form skips rows where any column is NULL, while
+// PostgreSQL's row constructor is non-null whenever the row exists; the
+// JSON array keeps the PostgreSQL semantics.
+func (d *MySQLDialect) WriteCountAggregate(
+	b *strings.Builder, distinct bool, expressions []string,
+) {
+	b.WriteString("COUNT(")
+
+	if len(expressions) == 0 {
+		b.WriteByte('*')
+		b.WriteByte(')')
+
+		return
+	}
+
+	if distinct {
+		b.WriteString("DISTINCT ")
+	}
+
+	if len(expressions) == 1 {
+		b.WriteByte('(')
+		b.WriteString(expressions[0])
+		b.WriteString("))")
+
+		return
+	}
+
+	b.WriteString("JSON_ARRAY(")
+	writeExpressionList(b, expressions)
+	b.WriteString("))")
+}
+
+// WriteAggregateOrderByExpr writes the native aggregate call: MySQL has the
+// whole avg/sum/min/max and stddev/variance family under PostgreSQL's names.
+func (d *MySQLDialect) WriteAggregateOrderByExpr(
+	b *strings.Builder, function string, expression string,
+) {
+	b.WriteString(strings.ToUpper(function))
+	b.WriteByte('(')
+	b.WriteString(expression)
+	b.WriteByte(')')
+}
+
+// SupportsStableVarianceOrderBy returns true: MySQL's stddev/variance
+// aggregates are computed natively, not through the sum-of-squares identity.
+func (d *MySQLDialect) SupportsStableVarianceOrderBy() bool { return true }
+
+func (d *MySQLDialect) SupportsVarianceAggregates() bool { return true }
+
+// BoolAndFunc returns min: MySQL stores booleans as TINYINT(1) 0/1, over which
+// min() matches bool_and.
+func (d *MySQLDialect) BoolAndFunc() string { return "min" }
+
+// BoolOrFunc returns max over the same 0/1 storage.
+func (d *MySQLDialect) BoolOrFunc() string { return "max" }
+
+func (d *MySQLDialect) WriteJSONRowPrefix(b *strings.Builder) {
+	b.WriteString("JSON_OBJECT(")
+}
+
+func (d *MySQLDialect) WriteJSONRowColumn(b *strings.Builder, alias, expr string) {
+	b.WriteByte('\'')
+	b.WriteString(alias)
+	b.WriteString("', ")
+	b.WriteString(expr)
+}
+
+func (d *MySQLDialect) WriteJSONRowSuffix(b *strings.Builder, outputAlias string) {
+	b.WriteString(") AS ")
+	core.WriteQuotedIdentifier(b, outputAlias)
+}
+
+func (d *MySQLDialect) WriteJSONRowSuffixNoAlias(b *strings.Builder) {
+	b.WriteString(")")
+}
+
+func (d *MySQLDialect) SupportsUpsertUpdateAction() bool { return false }
+
+func (d *MySQLDialect) WriteUpsertUpdateAction(_ *strings.Builder) {
+	panic(
+		"dialect: WriteUpsertUpdateAction called on MySQLDialect; gate with SupportsUpsertUpdateAction",
+	)
+}
+
+func (d *MySQLDialect) SupportsDataModifyingCTEs() bool { return false }
+
+func (d *MySQLDialect) WriteReturningAll(_ *strings.Builder, _ []string) {
+	panic("dialect: WriteReturningAll called on MySQLDialect; MySQL sources are read-only")
+}
+
+func (d *MySQLDialect) RequiresOnConflictTargetColumns() bool { return true }
+
+func (d *MySQLDialect) RequiresUpsertSourceFilter() bool { return false }
+
+// WriteOnConflictTarget always fails: MySQL sources expose no insert
+// mutations, and MySQL's ON DUPLICATE KEY UPDATE cannot name a target.
+func (d *MySQLDialect) WriteOnConflictTarget(
+	_ *strings.Builder, constraintName string, _ []string,
+) error {
+	return fmt.Errorf("%w (constraint %q)", errMySQLUpsertUnsupported, constraintName)
+}
+
+// WriteGroupKeysFrom renders the grouped-key derived table as a UNION ALL of
+// single-row SELECTs, the same shape SQLite uses; each value is bound through
+// a placeholder.
+//
+//	(SELECT ? AS "_join_key" UNION ALL SELECT ? UNION ALL ...) AS "__cs_grp_keys"
+func (d *MySQLDialect) WriteGroupKeysFrom(
+	b *strings.Builder,
+	keysAlias, colAlias, sqlType string,
+	values []any, params []any, paramIndex int,
+) ([]any, int) {
+	return NewSQLiteDialect().WriteGroupKeysFrom(
+		b, keysAlias, colAlias, sqlType, values, params, paramIndex,
+	)
+}
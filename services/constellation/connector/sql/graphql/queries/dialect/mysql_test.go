@@ -0,0 +1,203 @@
+package dialect_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+)
+
+func TestMySQLDialect_PlaceholderAndTypeCast(t *testing.T) {
+	t.Parallel()
+
+	d := dialect.NewMySQLDialect()
+
+	if got := d.Placeholder(3); got != "?" {
+		t.Fatalf("Placeholder(3) = %q, want ?", got)
+	}
+
+	if got := d.TypeCast("?", "uuid"); got != "?" {
+		t.Fatalf("TypeCast = %q, want ?", got)
+	}
+}
+
+func TestMySQLDialect_WriteArrayIn(t *testing.T) {
+	t.Parallel()
+
+	d := dialect.NewMySQLDialect()
+
+	var b strings.Builder
+
+	params, idx := d.WriteArrayIn(&b, `"t"`, "id", "int", []any{1, 2}, []any{"x"}, 2)
+
+	if got := b.String(); got != `"t"."id" IN (?, ?)` {
+		t.Fatalf("WriteArrayIn = %q", got)
+	}
+
+	if len(params) != 3 || idx != 4 {
+		t.Fatalf("params = %v, idx = %d; want 3 params, idx 4", params, idx)
+	}
+
+	b.Reset()
+
+	_, _ = d.WriteArrayNotIn(&b, `"t"`, "id", "int", nil, nil, 1)
+
+	if got := b.String(); got != "1 = 1" {
+		t.Fatalf("WriteArrayNotIn(empty) = %q, want 1 = 1", got)
+	}
+}
+
+func TestMySQLDialect_JSONHelpers(t *testing.T) {
+	t.Parallel()
+
+	d := dialect.NewMySQLDialect()
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"JSONAggQuotedAlias", d.JSONAggQuotedAlias("a"), `JSON_ARRAYAGG("a")`},
+		{"JSONAggRawExpr", d.JSONAggRawExpr(`"t"."x"`), `JSON_ARRAYAGG("t"."x")`},
+		{
+			"CoalesceJSONArray defaults to a JSON value",
+			d.CoalesceJSONArray("a"),
+			`coalesce(JSON_ARRAYAGG("a"), JSON_ARRAY())`,
+		},
+		{
+			"CoalesceJSONAgg defaults to a JSON value",
+			d.CoalesceJSONAgg(`JSON_ARRAYAGG("a")`),
+			`coalesce(JSON_ARRAYAGG("a"), JSON_ARRAY())`,
+		},
+		{"JSONBuildObject", d.JSONBuildObject(), "JSON_OBJECT"},
+		{"EmptyJSONArray", d.EmptyJSONArray(), "JSON_ARRAY()"},
+		{"TableRef", d.TableRef("shop", "orders"), `"shop"."orders"`},
+		{"Like", d.Like(), "LIKE BINARY"},
+		{"NotLike", d.NotLike(), "NOT LIKE BINARY"},
+		{"MaterializedCTE", d.MaterializedCTE(), "AS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.got != tt.want {
+				t.Fatalf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMySQLDialect_Capabilities(t *testing.T) {
+	t.Parallel()
+
+	d := dialect.NewMySQLDialect()
+
+	tests := map[string]struct {
+		got  bool
+		want bool
+	}{
+		"SupportsLateral":                  {d.SupportsLateral(), false},
+		"SupportsRegex":                    {d.SupportsRegex(), false},
+		"SupportsDistinctOn":               {d.SupportsDistinctOn(), false},
+		"SupportsNullsOrdering":            {d.SupportsNullsOrdering(), false},
+		"RequiresLimitWithOffset":          {d.RequiresLimitWithOffset(), true},
+		"RequiresLimitWithOrderBy":         {d.RequiresLimitWithOrderBy(), true},
+		"SupportsMultiplexedSubscriptions": {d.SupportsMultiplexedSubscriptions(), false},
+		"SupportsJSONB":                    {d.SupportsJSONB(), false},
+		"SupportsFunctions":                {d.SupportsFunctions(), false},
+		"SupportsArrays":                   {d.SupportsArrays(), false},
+		"SupportsSpatialTypes":             {d.SupportsSpatialTypes(), false},
+		"SupportsVarianceAggregates":       {d.SupportsVarianceAggregates(), true},
+		"SupportsStableVarianceOrderBy":    {d.SupportsStableVarianceOrderBy(), true},
+		"SupportsUpsertUpdateAction":       {d.SupportsUpsertUpdateAction(), false},
+		"SupportsDataModifyingCTEs":        {d.SupportsDataModifyingCTEs(), false},
+	}
+
+	for name, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestMySQLDialect_WriteCountAggregate(t *testing.T) {
+	t.Parallel()
+
+	d := dialect.NewMySQLDialect()
+
+	tests := []struct {
+		name        string
+		distinct    bool
+		expressions []string
+		want        string
+	}{
+		{name: "star", distinct: false, expressions: nil, want: `COUNT(*)`},
+		{
+			name:        "single distinct",
+			distinct:    true,
+			expressions: []string{`"t"."id"`},
+			want:        `COUNT(DISTINCT ("t"."id"))`,
+		},
+		{
+			name:        "multi keeps rows with NULL columns",
+			distinct:    true,
+			expressions: []string{`"t"."role"`, `"t"."active"`},
+			want:        `COUNT(DISTINCT JSON_ARRAY("t"."role", "t"."active"))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var b strings.Builder
+			d.WriteCountAggregate(&b, tt.distinct, tt.expressions)
+
+			if got := b.String(); got != tt.want {
+				t.Fatalf("WriteCountAggregate = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMySQLDialect_JSONRow(t *testing.T) {
+	t.Parallel()
+
+	d := dialect.NewMySQLDialect()
+
+	var b strings.Builder
+
+	d.WriteJSONRowPrefix(&b)
+	d.WriteJSONRowColumn(&b, "id", `"t"."id"`)
+	d.WriteJSONRowSuffix(&b, "user")
+
+	const want = `JSON_OBJECT('id', "t"."id") AS "user"`
+	if got := b.String(); got != want {
+		t.Fatalf("JSONRow:\n got  %q\n want %q", got, want)
+	}
+}
+
+func TestMySQLDialect_MutationHooksAreUnavailable(t *testing.T) {
+	t.Parallel()
+
+	d := dialect.NewMySQLDialect()
+
+	var b strings.Builder
+
+	if err := d.WriteOnConflictTarget(&b, "orders_pkey", []string{"id"}); err == nil {
+		t.Fatal("WriteOnConflictTarget: expected error, got nil")
+	}
+
+	if b.Len() != 0 {
+		t.Fatalf("WriteOnConflictTarget wrote %q on error", b.String())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("ThrowError: expected panic")
+		}
+	}()
+
+	_ = d.ThrowError("oops", "ZZ901")
+}
@@ -266,6 +266,10 @@ func (d *PostgresDialect) CoalesceJSONArray(alias string) string {
 	return "coalesce(json_agg(" + core.QuoteIdentifier(alias) + "), '[]')"
 }
 
+func (d *PostgresDialect) CoalesceJSONAgg(aggExpr string) string {
+	return "coalesce(" + aggExpr + ", '[]')"
+}
+
 func (d *PostgresDialect) JSONBuildObject() string {
 	return "json_build_object"
 }
@@ -320,12 +324,13 @@ func (d *PostgresDialect) SupportsDistinctOn() bool {
 
 // ThrowError calls the constellation_throw_error PL/pgSQL function (installed by
 // the connector) so the error surfaces with both a message and a SQLSTATE-style
-// code; SQLite has no equivalent custom-function story and uses RAISE(ABORT).
+// code. The function returns void, so the call is wrapped in a subquery that
+// yields an integer.
 func (d *PostgresDialect) ThrowError(message, code string) string {
 	message = strings.ReplaceAll(message, "'", "''")
 	code = strings.ReplaceAll(code, "'", "''")
 
-	return "constellation_throw_error('" + message + "', '" + code + "')"
+	return "(SELECT 0 FROM (SELECT constellation_throw_error('" + message + "', '" + code + "')) x)"
 }
 
 func (d *PostgresDialect) MaterializedCTE() string {
@@ -351,6 +356,46 @@ func (d *PostgresDialect) SupportsJSONB() bool {
 	return true
 }
 
+func (d *PostgresDialect) SupportsLtree() bool {
+	return true
+}
+
+func (d *PostgresDialect) SupportsFullTextSearch() bool {
+	return true
+}
+
+func (d *PostgresDialect) SupportsVector() bool {
+	return true
+}
+
+// WriteVectorDistance uses pgvector's distance operators rather than its
+// l2_distance/cosine_distance functions: only the operators are indexable by
+// HNSW and IVFFlat indexes.
+func (d *PostgresDialect) WriteVectorDistance(
+	b *strings.Builder, metric VectorMetric, leftExpr, placeholder string,
+) {
+	b.WriteByte('(')
+	b.WriteString(leftExpr)
+	b.WriteByte(' ')
+	b.WriteString(postgresVectorDistanceOperator(metric))
+	b.WriteByte(' ')
+	b.WriteString(d.TypeCast(placeholder, "vector"))
+	b.WriteByte(')')
+}
+
+func postgresVectorDistanceOperator(metric VectorMetric) string {
+	switch metric {
+	case VectorMetricL2:
+		return "<->"
+	case VectorMetricCosine:
+		return "<=>"
+	case VectorMetricInnerProduct:
+		return "<#>"
+	default:
+		panic("dialect: unknown vector metric " + string(metric))
+	}
+}
+
 func (d *PostgresDialect) SupportsFunctions() bool {
 	return true
 }
@@ -407,8 +452,24 @@ func (d *PostgresDialect) WriteUpsertUpdateAction(b *strings.Builder) {
 	b.WriteString("(xmax <> 0)")
 }
 
+func (d *PostgresDialect) SupportsDataModifyingCTEs() bool { return true }
+
+func (d *PostgresDialect) SupportsNullsOrdering() bool { return true }
+
+func (d *PostgresDialect) RequiresLimitWithOffset() bool { return false }
+
+func (d *PostgresDialect) RequiresLimitWithOrderBy() bool { return false }
+
+func (d *PostgresDialect) SupportsMultiplexedSubscriptions() bool { return true }
+
+func (d *PostgresDialect) WriteReturningAll(b *strings.Builder, _ []string) {
+	b.WriteByte('*')
+}
+
 func (d *PostgresDialect) RequiresOnConflictTargetColumns() bool { return false }
 
+func (d *PostgresDialect) RequiresUpsertSourceFilter() bool { return false }
+
 // WriteOnConflictTarget names the constraint directly: PostgreSQL supports the
 // "ON CONFLICT ON CONSTRAINT <name>" form, which targets a specific unique or
 // primary-key constraint by name. The conflictColumns argument is unused here —
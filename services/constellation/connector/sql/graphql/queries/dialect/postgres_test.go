@@ -237,6 +237,7 @@ func TestPostgresDialect_JSONHelpers(t *testing.T) {
 		{"JSONAggQuotedAlias quotes alias", d.JSONAggQuotedAlias("a"), `json_agg("a")`},
 		{"JSONAggRawExpr raw", d.JSONAggRawExpr(`"t"."x"`), `json_agg("t"."x")`},
 		{"CoalesceJSONArray", d.CoalesceJSONArray("a"), `coalesce(json_agg("a"), '[]')`},
+		{"CoalesceJSONAgg", d.CoalesceJSONAgg(`json_agg("a")`), `coalesce(json_agg("a"), '[]')`},
 		{"JSONBuildObject", d.JSONBuildObject(), "json_build_object"},
 		{"ToJSON", d.ToJSON("x"), "to_jsonb(x)"},
 		{"EmptyJSONArray", d.EmptyJSONArray(), "'[]'::json"},
@@ -295,15 +296,18 @@ func TestPostgresDialect_Capabilities(t *testing.T) {
 	d := &dialect.PostgresDialect{}
 
 	tests := map[string]bool{
-		"SupportsLateral":            d.SupportsLateral(),
-		"SupportsRegex":              d.SupportsRegex(),
-		"SupportsDistinctOn":         d.SupportsDistinctOn(),
-		"SupportsJSONB":              d.SupportsJSONB(),
-		"SupportsFunctions":          d.SupportsFunctions(),
-		"SupportsArrays":             d.SupportsArrays(),
-		"SupportsSpatialTypes":       d.SupportsSpatialTypes(),
-		"SupportsVarianceAggregates": d.SupportsVarianceAggregates(),
-		"SupportsUpsertUpdateAction": d.SupportsUpsertUpdateAction(),
+		"SupportsLateral":                  d.SupportsLateral(),
+		"SupportsRegex":                    d.SupportsRegex(),
+		"SupportsDistinctOn":               d.SupportsDistinctOn(),
+		"SupportsJSONB":                    d.SupportsJSONB(),
+		"SupportsFunctions":                d.SupportsFunctions(),
+		"SupportsArrays":                   d.SupportsArrays(),
+		"SupportsSpatialTypes":             d.SupportsSpatialTypes(),
+		"SupportsVarianceAggregates":       d.SupportsVarianceAggregates(),
+		"SupportsUpsertUpdateAction":       d.SupportsUpsertUpdateAction(),
+		"SupportsDataModifyingCTEs":        d.SupportsDataModifyingCTEs(),
+		"SupportsNullsOrdering":            d.SupportsNullsOrdering(),
+		"SupportsMultiplexedSubscriptions": d.SupportsMultiplexedSubscriptions(),
 	}
 
 	for name, got := range tests {
@@ -311,6 +315,14 @@ func TestPostgresDialect_Capabilities(t *testing.T) {
 			t.Errorf("%s = false, want true (Postgres supports all)", name)
 		}
 	}
+
+	if d.RequiresLimitWithOffset() || d.RequiresLimitWithOrderBy() {
+		t.Error("Postgres accepts OFFSET and ORDER BY without LIMIT")
+	}
+
+	if d.RequiresUpsertSourceFilter() {
+		t.Error("Postgres parses INSERT ... SELECT ... ON CONFLICT without a WHERE")
+	}
 }
 
 func TestPostgresDialect_BoolFuncs(t *testing.T) {
@@ -353,13 +365,13 @@ func TestPostgresDialect_ThrowError(t *testing.T) {
 			name:    "plain",
 			message: "oops",
 			code:    "ERR_X",
-			want:    `constellation_throw_error('oops', 'ERR_X')`,
+			want:    `(SELECT 0 FROM (SELECT constellation_throw_error('oops', 'ERR_X')) x)`,
 		},
 		{
 			name:    "escapes apostrophes on both arguments",
 			message: "it's bad",
 			code:    "code's",
-			want:    `constellation_throw_error('it''s bad', 'code''s')`,
+			want:    `(SELECT 0 FROM (SELECT constellation_throw_error('it''s bad', 'code''s')) x)`,
 		},
 	}
 
@@ -548,6 +560,18 @@ func TestPostgresDialect_WriteUpsertUpdateAction(t *testing.T) {
 	}
 }
 
+func TestPostgresDialect_WriteReturningAll(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+
+	(&dialect.PostgresDialect{}).WriteReturningAll(&b, []string{"id", "created_at"})
+
+	if got := b.String(); got != "*" {
+		t.Fatalf("WriteReturningAll = %q, want *", got)
+	}
+}
+
 // TestPostgresDialect_WriteSpatialPredicate pins the SpatialPredicate -> ST_*
 // function mapping and the two-argument call shape. A wrong entry here (e.g.
 // Touches resolving to ST_Within) would otherwise only surface in the
@@ -53,23 +53,7 @@ func (d *SQLiteDialect) WriteArrayIn(
 		return params, paramIndex
 	}
 
-	core.WriteQualifiedColumn(b, source, sqlName)
-	b.WriteString(" IN (")
-
-	for i, v := range values {
-		if i > 0 {
-			b.WriteString(", ")
-		}
-
-		b.WriteString("?")
-
-		params = append(params, v)
-		paramIndex++
-	}
-
-	b.WriteString(")")
-
-	return params, paramIndex
+	return writePlaceholderList(b, source, sqlName, " IN (", values, params, paramIndex)
 }
 
 // WriteArrayNotIn is the inverse of WriteArrayIn. The empty-values case
@@ -85,23 +69,7 @@ func (d *SQLiteDialect) WriteArrayNotIn(
 		return params, paramIndex
 	}
 
-	core.WriteQualifiedColumn(b, source, sqlName)
-	b.WriteString(" NOT IN (")
-
-	for i, v := range values {
-		if i > 0 {
-			b.WriteString(", ")
-		}
-
-		b.WriteString("?")
-
-		params = append(params, v)
-		paramIndex++
-	}
-
-	b.WriteString(")")
-
-	return params, paramIndex
+	return writePlaceholderList(b, source, sqlName, " NOT IN (", values, params, paramIndex)
 }
 
 func (d *SQLiteDialect) SupportsSpatialTypes() bool {
@@ -192,6 +160,10 @@ func (d *SQLiteDialect) CoalesceJSONArray(alias string) string {
 	return "coalesce(json_group_array(json(" + core.QuoteIdentifier(alias) + ")), '[]')"
 }
 
+func (d *SQLiteDialect) CoalesceJSONAgg(aggExpr string) string {
+	return "coalesce(" + aggExpr + ", '[]')"
+}
+
 func (d *SQLiteDialect) JSONBuildObject() string {
 	return "json_object"
 }
@@ -253,12 +225,17 @@ func (d *SQLiteDialect) SupportsDistinctOn() bool {
 	return false
 }
 
-// ThrowError synthesises a (SELECT RAISE(ABORT, ...)) expression: RAISE is only
-// legal inside a trigger body, so wrapping it in a scalar subquery lets us drop
-// it into the same positions where Postgres calls constellation_throw_error.
-// SQLite's RAISE has no error-code argument, so the code is intentionally ignored.
-func (d *SQLiteDialect) ThrowError(message, _ string) string {
-	return "(SELECT RAISE(ABORT, '" + strings.ReplaceAll(message, "'", "''") + "'))"
+// ThrowError calls the constellation_throw_error function the sqlite driver
+// registers on every connection. RAISE cannot be used instead: SQLite rejects
+// it outside a trigger body when the statement is prepared, even in a CASE
+// branch that never runs. The function returns an integer and is called
+// directly: wrapped in a subquery, SQLite drops the unused column and never
+// evaluates it.
+func (d *SQLiteDialect) ThrowError(message, code string) string {
+	message = strings.ReplaceAll(message, "'", "''")
+	code = strings.ReplaceAll(code, "'", "''")
+
+	return "constellation_throw_error('" + message + "', '" + code + "')"
 }
 
 func (d *SQLiteDialect) MaterializedCTE() string {
@@ -285,6 +262,22 @@ func (d *SQLiteDialect) SupportsJSONB() bool {
 	return false
 }
 
+func (d *SQLiteDialect) SupportsLtree() bool {
+	return false
+}
+
+func (d *SQLiteDialect) SupportsFullTextSearch() bool {
+	return false
+}
+
+func (d *SQLiteDialect) SupportsVector() bool {
+	return false
+}
+
+func (d *SQLiteDialect) WriteVectorDistance(_ *strings.Builder, _ VectorMetric, _, _ string) {
+	panic("dialect: WriteVectorDistance called on SQLiteDialect; gate with SupportsVector()")
+}
+
 func (d *SQLiteDialect) SupportsFunctions() bool {
 	return false
 }
@@ -326,45 +319,26 @@ func (d *SQLiteDialect) WriteCountAggregate(
 	b.WriteString("))")
 }
 
-// WriteAggregateOrderByExpr writes SQLite-supported aggregate expressions for
-// array-relationship aggregate order_by. SQLite has no stddev/variance aggregate
-// functions; the stddev/variance family is rejected upstream (gated by
-// SupportsStableVarianceOrderBy) because the one-pass identity that would
-// emulate them is numerically unstable and inverts the ordering for large,
-// close values. Only avg/sum/min/max reach this method.
+// WriteAggregateOrderByExpr writes the aggregate call for array-relationship
+// aggregate order_by. avg/sum/min/max are SQLite built-ins; the stddev/variance
+// family resolves to the Go aggregates the sqlite driver registers on every
+// connection under PostgreSQL's names (see connector/sql/sqlite).
 func (d *SQLiteDialect) WriteAggregateOrderByExpr(
 	b *strings.Builder, function string, expression string,
 ) {
-	switch function {
-	case "avg":
-		writeSQLiteUnaryAggregate(b, "AVG", expression)
-	case "max":
-		writeSQLiteUnaryAggregate(b, "MAX", expression)
-	case "min":
-		writeSQLiteUnaryAggregate(b, "MIN", expression)
-	case "sum":
-		writeSQLiteUnaryAggregate(b, "SUM", expression)
-	default:
-		// The caller validates the function name and rejects unsupported ones
-		// before reaching the dialect; keep the fallback syntactically obvious if
-		// a new schema field is added without extending this switch.
-		writeSQLiteUnaryAggregate(b, strings.ToUpper(function), expression)
-	}
+	writeSQLiteUnaryAggregate(b, strings.ToUpper(function), expression)
 }
 
-// SupportsStableVarianceOrderBy returns false: SQLite has no native
-// stddev/variance aggregate, and the one-pass sum-of-squares identity that would
-// emulate them loses all precision (and can go negative) for large, close
-// values, so the row ordering would diverge from PostgreSQL/Hasura. The caller
-// rejects such orderings instead of returning a silently wrong order.
-func (d *SQLiteDialect) SupportsStableVarianceOrderBy() bool { return false }
+// SupportsStableVarianceOrderBy returns true: the registered stddev/variance
+// aggregates use Welford's online algorithm rather than the one-pass
+// sum-of-squares identity, so they stay faithful to PostgreSQL for large,
+// close values and the row ordering matches.
+func (d *SQLiteDialect) SupportsStableVarianceOrderBy() bool { return true }
 
-// SupportsVarianceAggregates returns false: go-sqlite3 has no stddev/variance
-// aggregate functions, so STDDEV(...)/VARIANCE(...) etc. would fail at execution
-// with an opaque "no such function" error. Schema generation therefore omits the
-// stddev/variance aggregate fields for SQLite and the selection builder rejects
-// them; avg/sum/min/max/count remain native and unaffected.
-func (d *SQLiteDialect) SupportsVarianceAggregates() bool { return false }
+// SupportsVarianceAggregates returns true: the sqlite driver registers Go
+// stddev, stddev_pop, stddev_samp, var_pop, var_samp and variance aggregates on
+// every connection, so STDDEV(...) etc. resolve as on PostgreSQL.
+func (d *SQLiteDialect) SupportsVarianceAggregates() bool { return true }
 
 func (d *SQLiteDialect) SupportsUpsertUpdateAction() bool { return false }
 
@@ -374,8 +348,50 @@ func (d *SQLiteDialect) WriteUpsertUpdateAction(_ *strings.Builder) {
 	)
 }
 
+// SupportsDataModifyingCTEs returns false: SQLite only accepts RETURNING on a
+// top-level statement, never inside a WITH clause.
+func (d *SQLiteDialect) SupportsDataModifyingCTEs() bool { return false }
+
+// SupportsNullsOrdering returns true: SQLite accepts NULLS FIRST / NULLS LAST
+// since 3.30.
+func (d *SQLiteDialect) SupportsNullsOrdering() bool { return true }
+
+// RequiresLimitWithOffset returns true: SQLite's grammar only accepts OFFSET
+// as part of a LIMIT clause.
+func (d *SQLiteDialect) RequiresLimitWithOffset() bool { return true }
+
+func (d *SQLiteDialect) RequiresLimitWithOrderBy() bool { return false }
+
+// SupportsMultiplexedSubscriptions returns false: the multiplexed statement
+// unnests PostgreSQL arrays and reads subscriber variables with the #>> JSON
+// operator, neither of which SQLite has.
+func (d *SQLiteDialect) SupportsMultiplexedSubscriptions() bool { return false }
+
+// WriteReturningAll lists every column as a unary-plus expression. A bare
+// column (or *) carries its declared type into the result set, and go-sqlite3
+// converts DATETIME text into time.Time and BOOLEAN integers into bool; the
+// staged driver would then write those conversions back instead of the stored
+// values.
+func (d *SQLiteDialect) WriteReturningAll(b *strings.Builder, columns []string) {
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteByte('+')
+		core.WriteQuotedIdentifier(b, column)
+		b.WriteString(" AS ")
+		core.WriteQuotedIdentifier(b, column)
+	}
+}
+
 func (d *SQLiteDialect) RequiresOnConflictTargetColumns() bool { return true }
 
+// RequiresUpsertSourceFilter returns true: in "INSERT ... SELECT ... FROM t ON
+// CONFLICT" SQLite reads ON as the join constraint of t, so the SELECT gets an
+// always-true WHERE first.
+func (d *SQLiteDialect) RequiresUpsertSourceFilter() bool { return true }
+
 // WriteOnConflictTarget lists the constraint's columns: SQLite has no
 // "ON CONFLICT ON CONSTRAINT <name>" form, so it identifies the conflict target
 // by its index columns ("ON CONFLICT (\"col1\", \"col2\")"). When
@@ -149,6 +149,11 @@ func TestSQLiteDialect_JSONHelpers(t *testing.T) {
 			d.CoalesceJSONArray("a"),
 			`coalesce(json_group_array(json("a")), '[]')`,
 		},
+		{
+			"CoalesceJSONAgg",
+			d.CoalesceJSONAgg(`json_group_array("a")`),
+			`coalesce(json_group_array("a"), '[]')`,
+		},
 		{"JSONBuildObject", d.JSONBuildObject(), "json_object"},
 		{"ToJSON", d.ToJSON("x"), "json(x)"},
 		{"EmptyJSONArray", d.EmptyJSONArray(), "json_array()"},
@@ -208,15 +213,17 @@ func TestSQLiteDialect_Capabilities(t *testing.T) {
 	d := &dialect.SQLiteDialect{}
 
 	tests := map[string]bool{
-		"SupportsLateral":            d.SupportsLateral(),
-		"SupportsRegex":              d.SupportsRegex(),
-		"SupportsDistinctOn":         d.SupportsDistinctOn(),
-		"SupportsJSONB":              d.SupportsJSONB(),
-		"SupportsFunctions":          d.SupportsFunctions(),
-		"SupportsArrays":             d.SupportsArrays(),
-		"SupportsSpatialTypes":       d.SupportsSpatialTypes(),
-		"SupportsVarianceAggregates": d.SupportsVarianceAggregates(),
-		"SupportsUpsertUpdateAction": d.SupportsUpsertUpdateAction(),
+		"SupportsLateral":                  d.SupportsLateral(),
+		"SupportsRegex":                    d.SupportsRegex(),
+		"SupportsDistinctOn":               d.SupportsDistinctOn(),
+		"SupportsJSONB":                    d.SupportsJSONB(),
+		"SupportsFunctions":                d.SupportsFunctions(),
+		"SupportsArrays":                   d.SupportsArrays(),
+		"SupportsSpatialTypes":             d.SupportsSpatialTypes(),
+		"SupportsUpsertUpdateAction":       d.SupportsUpsertUpdateAction(),
+		"SupportsDataModifyingCTEs":        d.SupportsDataModifyingCTEs(),
+		"SupportsMultiplexedSubscriptions": d.SupportsMultiplexedSubscriptions(),
+		"RequiresLimitWithOrderBy":         d.RequiresLimitWithOrderBy(),
 	}
 
 	for name, got := range tests {
@@ -224,6 +231,22 @@ func TestSQLiteDialect_Capabilities(t *testing.T) {
 			t.Errorf("%s = true, want false (SQLite supports none)", name)
 		}
 	}
+
+	if !d.SupportsNullsOrdering() {
+		t.Error("SupportsNullsOrdering = false, want true (SQLite 3.30+)")
+	}
+
+	if !d.SupportsVarianceAggregates() {
+		t.Error("SupportsVarianceAggregates = false, want true (registered by the sqlite driver)")
+	}
+
+	if !d.RequiresLimitWithOffset() {
+		t.Error("RequiresLimitWithOffset = false, want true (OFFSET is part of LIMIT)")
+	}
+
+	if !d.RequiresUpsertSourceFilter() {
+		t.Error("RequiresUpsertSourceFilter = false, want true (ON would join the FROM term)")
+	}
 }
 
 func TestSQLiteDialect_SpatialExpressionsAreIdentity(t *testing.T) {
@@ -436,16 +459,16 @@ func TestSQLiteDialect_ThrowError(t *testing.T) {
 		want    string
 	}{
 		{
-			name:    "plain (code is ignored)",
+			name:    "plain",
 			message: "oops",
-			code:    "ignored",
-			want:    `(SELECT RAISE(ABORT, 'oops'))`,
+			code:    "ZZ901",
+			want:    `constellation_throw_error('oops', 'ZZ901')`,
 		},
 		{
 			name:    "escapes apostrophes in message",
 			message: "it's bad",
 			code:    "",
-			want:    `(SELECT RAISE(ABORT, 'it''s bad'))`,
+			want:    `constellation_throw_error('it''s bad', '')`,
 		},
 	}
 
@@ -577,9 +600,8 @@ func TestSQLiteDialect_CountAndAggregateOrderBy(t *testing.T) {
 		})
 	}
 
-	// Only avg/sum/min/max reach the dialect; the stddev/variance family is
-	// rejected upstream (SupportsStableVarianceOrderBy returns false) because the
-	// one-pass identity that would emulate it is numerically unstable.
+	// The stddev/variance family renders under PostgreSQL's names, resolved by
+	// the Go aggregates the sqlite driver registers on every connection.
 	aggregateTests := []struct {
 		function string
 		want     string
@@ -588,6 +610,10 @@ func TestSQLiteDialect_CountAndAggregateOrderBy(t *testing.T) {
 		{function: "sum", want: `SUM("t"."score")`},
 		{function: "min", want: `MIN("t"."score")`},
 		{function: "max", want: `MAX("t"."score")`},
+		{function: "stddev", want: `STDDEV("t"."score")`},
+		{function: "stddev_pop", want: `STDDEV_POP("t"."score")`},
+		{function: "var_samp", want: `VAR_SAMP("t"."score")`},
+		{function: "variance", want: `VARIANCE("t"."score")`},
 	}
 
 	for _, tt := range aggregateTests {
@@ -603,8 +629,8 @@ func TestSQLiteDialect_CountAndAggregateOrderBy(t *testing.T) {
 		})
 	}
 
-	if d.SupportsStableVarianceOrderBy() {
-		t.Fatal("SupportsStableVarianceOrderBy = true, want false for SQLite")
+	if !d.SupportsStableVarianceOrderBy() {
+		t.Fatal("SupportsStableVarianceOrderBy = false, want true for SQLite")
 	}
 }
 
@@ -844,12 +870,26 @@ func TestSQLiteDialect_WriteOnConflictTarget(t *testing.T) {
 	}
 }
 
+// TestSQLiteDialect_WriteReturningAll pins the unary-plus RETURNING list that
+// keeps go-sqlite3 from converting declared DATETIME/BOOLEAN columns.
+func TestSQLiteDialect_WriteReturningAll(t *testing.T) {
+	t.Parallel()
+
+	var b strings.Builder
+
+	(&dialect.SQLiteDialect{}).WriteReturningAll(&b, []string{"id", `we"ird`})
+
+	const want = `+"id" AS "id", +"we""ird" AS "we""ird"`
+	if got := b.String(); got != want {
+		t.Fatalf("WriteReturningAll:\n got  %q\n want %q", got, want)
+	}
+}
+
 // TestSQLiteDialect_WriteOnConflictTarget_Prepares proves the rendered conflict
 // target is valid SQLite by preparing a real INSERT ... ON CONFLICT (...) DO
-// UPDATE against an in-memory database. This is the executable backstop for the
-// conflict-target rendering; it deliberately uses a plain INSERT, not the
-// data-modifying-CTE wrapper Constellation emits, because SQLite cannot parse
-// "WITH ... AS (INSERT ...)" at all (a separate, broader limitation).
+// UPDATE against an in-memory database. It isolates the conflict-target
+// rendering with a plain INSERT; the staged mutation tests in package queries
+// execute the full upsert statements.
 func TestSQLiteDialect_WriteOnConflictTarget_Prepares(t *testing.T) {
 	t.Parallel()
 
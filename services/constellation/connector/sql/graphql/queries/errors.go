@@ -63,7 +63,7 @@ var (
 	// ErrUnsupportedVarianceAggregate is wrapped when an aggregate selection
 	// requests a stddev/variance-family function (stddev, stddev_pop,
 	// stddev_samp, var_pop, var_samp, variance) on a backend that has no such
-	// aggregate function (SQLite). Schema generation already omits these fields,
+	// aggregate function. Schema generation already omits these fields,
 	// so a schema-validated request never reaches the builder; this guards
 	// callers that bypass validation, turning what would be an opaque "no such
 	// function" execution error into a clear typed error that the HTTP layer can
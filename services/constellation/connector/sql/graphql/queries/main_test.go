@@ -153,6 +153,12 @@ func normalizeOperation(op *core.SQLOperation) {
 	for i := range op.Sequential {
 		normalizeOperation(&op.Sequential[i])
 	}
+
+	for i := range op.Stages {
+		for j := range op.Stages[i].Parameters {
+			op.Stages[i].Parameters[j] = normalizeValue(op.Stages[i].Parameters[j])
+		}
+	}
 }
 
 func execureOperation(
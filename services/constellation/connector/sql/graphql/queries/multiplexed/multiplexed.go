@@ -73,6 +73,76 @@ func Multiplex(op core.SQLOperation) (string, []any, error) {
 	return buildMultiplexedSQL(op.Name, innerSQL), staticParams, nil
 }
 
+// Validate reports whether op can be executed once per subscriber by binding
+// its markers directly (see BindParams), the path used by dialects that cannot
+// express the multiplexed envelope. Like Multiplex it returns
+// ErrSessionVarInMultiElementArray when a session-variable marker is trapped
+// inside a multi-element array parameter.
+func Validate(op core.SQLOperation) error {
+	for i, param := range op.Parameters {
+		if _, ok := extractSessionVarName(param); ok {
+			continue
+		}
+
+		if containsSessionVarMarker(param) {
+			return fmt.Errorf("%w: parameter %d", ErrSessionVarInMultiElementArray, i+1)
+		}
+	}
+
+	return nil
+}
+
+// BindParams resolves the markers in params for the subscriber at
+// subscriberIndex, producing a parameter list that can be bound to the
+// operation's SQL as-is. Session variables take the subscriber's value (as
+// text, matching the `#>>` extraction of the multiplexed form), cursor markers
+// take the current value from cursorValues, and a function session_argument
+// takes the subscriber's whole session as a JSON object. Static parameters are
+// passed through unchanged.
+func BindParams(
+	params []any,
+	subscriberIndex int,
+	sessionVarArrays map[string][]any,
+	cursorValues map[string]any,
+) []any {
+	bound := make([]any, len(params))
+
+	for i, param := range params {
+		if varName, ok := extractSessionVarName(param); ok {
+			bound[i] = subscriberSessionValue(subscriberIndex, sessionVarArrays[varName])
+
+			continue
+		}
+
+		switch v := param.(type) {
+		case core.CursorValue:
+			bound[i] = cursorValues[v.ColumnName]
+		case core.FunctionSessionArgument:
+			var sb strings.Builder
+
+			sb.WriteString("{")
+			writeSessionVarsJSON(&sb, subscriberIndex, sessionVarArrays)
+			sb.WriteString("}")
+
+			bound[i] = sb.String()
+		default:
+			bound[i] = param
+		}
+	}
+
+	return bound
+}
+
+// subscriberSessionValue returns the subscriber's session-variable value as
+// text, or nil when the subscriber did not send it.
+func subscriberSessionValue(subscriberIndex int, values []any) any {
+	if subscriberIndex >= len(values) || values[subscriberIndex] == nil {
+		return nil
+	}
+
+	return fmt.Sprintf("%v", values[subscriberIndex])
+}
+
 // rewriteMultiplexedSQL converts a regular core.SQLOperation into its inner
 // multiplexed form: the SQL with session, cursor, and function session_argument
 // parameter references rewritten to JSON path expressions, and the residual
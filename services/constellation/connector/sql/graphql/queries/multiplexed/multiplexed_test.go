@@ -492,6 +492,101 @@ func TestPrepareParams(t *testing.T) {
 	}
 }
 
+func TestBindParams(t *testing.T) {
+	t.Parallel()
+
+	params := []any{
+		core.SessionVarValue{Name: "x-hasura-user-id"},
+		[]any{core.SessionVarValue{Name: "x-hasura-org-id"}},
+		core.CursorValue{ColumnName: "id", Value: 1},
+		core.FunctionSessionArgument{SQLType: "json"},
+		"static",
+	}
+	sessionVarArrays := map[string][]any{
+		"x-hasura-user-id": {"user-a", 7},
+		"x-hasura-org-id":  {"org-a"},
+	}
+	cursorValues := map[string]any{"id": 42}
+
+	tests := []struct {
+		name            string
+		subscriberIndex int
+		want            []any
+	}{
+		{
+			name:            "first subscriber",
+			subscriberIndex: 0,
+			want: []any{
+				"user-a", "org-a", 42,
+				`{"x-hasura-org-id":"org-a","x-hasura-user-id":"user-a"}`, "static",
+			},
+		},
+		{
+			name:            "missing values bind NULL and numbers bind as text",
+			subscriberIndex: 1,
+			want: []any{
+				"7", nil, 42,
+				`{"x-hasura-org-id":null,"x-hasura-user-id":"7"}`, "static",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := multiplexed.BindParams(params, tc.subscriberIndex, sessionVarArrays, cursorValues)
+
+			// The session JSON's key order follows map iteration; compare it
+			// decoded.
+			gotSession, wantSession := decodeObject(t, got[3]), decodeObject(t, tc.want[3])
+			if diff := cmp.Diff(wantSession, gotSession); diff != "" {
+				t.Errorf("session argument mismatch (-want +got):\n%s", diff)
+			}
+
+			got[3], tc.want[3] = nil, nil
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("BindParams mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func decodeObject(t *testing.T, v any) map[string]any {
+	t.Helper()
+
+	s, ok := v.(string)
+	if !ok {
+		t.Fatalf("expected a JSON string, got %T", v)
+	}
+
+	var out map[string]any
+	if err := json.Unmarshal([]byte(s), &out); err != nil {
+		t.Fatalf("unmarshal %q: %v", s, err)
+	}
+
+	return out
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	ok := core.SQLOperation{Parameters: []any{
+		core.SessionVarValue{Name: "x-hasura-user-id"},
+		[]any{"a", "b"},
+	}}
+	if err := multiplexed.Validate(ok); err != nil {
+		t.Fatalf("Validate: unexpected error %v", err)
+	}
+
+	trapped := core.SQLOperation{Parameters: []any{
+		[]any{core.SessionVarValue{Name: "x-hasura-user-id"}, "literal"},
+	}}
+	if err := multiplexed.Validate(trapped); !errors.Is(err, multiplexed.ErrSessionVarInMultiElementArray) {
+		t.Fatalf("Validate error = %v, want ErrSessionVarInMultiElementArray", err)
+	}
+}
+
 func TestExtractInitialCursorValues(t *testing.T) {
 	t.Parallel()
 
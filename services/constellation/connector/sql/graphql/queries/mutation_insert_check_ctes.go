@@ -94,7 +94,7 @@ func (t *table) buildCheckConstraintWhereClause(
 // tableSubs redirects relationship-EXISTS subqueries that target a table
 // currently being inserted into to its parent CTE (nested array-rel inserts).
 func (t *table) buildCheckConstraintCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	checkCTEName string,
 	insertObj arguments.InsertObject,
 	nestedFKIndex arguments.NestedFKSources,
@@ -104,11 +104,11 @@ func (t *table) buildCheckConstraintCTE(
 	params []any,
 	paramIndex int,
 ) ([]any, int, bool, error) {
-	b.WriteString(checkCTEName)
-	b.WriteString(" AS (SELECT * FROM (SELECT ") //nolint:unqueryvet
+	b.openCTE(checkCTEName)
+	b.WriteString("SELECT * FROM (SELECT ") //nolint:unqueryvet
 
 	paramIndex, fromCTEs := t.buildCheckConstraintSelectClause(
-		b, insertObj, nestedFKIndex, paramIndex,
+		&b.Builder, insertObj, nestedFKIndex, paramIndex,
 	)
 
 	// Add NULL columns for any columns referenced by the permission check
@@ -117,10 +117,10 @@ func (t *table) buildCheckConstraintCTE(
 	// so the permission predicate sees the real id.
 	fromCTEs = appendUniqueCTENames(
 		fromCTEs,
-		t.appendMissingPermissionColumns(b, insertObj, nestedFKIndex, role),
+		t.appendMissingPermissionColumns(&b.Builder, insertObj, nestedFKIndex, role),
 	)
 
-	writeFromCTEs(b, fromCTEs)
+	writeFromCTEs(&b.Builder, fromCTEs)
 
 	b.WriteString(") AS data WHERE ")
 
@@ -130,7 +130,7 @@ func (t *table) buildCheckConstraintCTE(
 	)
 
 	params, paramIndex, hasCheckPermissions, err = t.buildCheckConstraintWhereClause(
-		b,
+		&b.Builder,
 		role,
 		sessionVariables,
 		tableSubs,
@@ -141,7 +141,8 @@ func (t *table) buildCheckConstraintCTE(
 		return nil, 0, false, err
 	}
 
-	b.WriteString("), ")
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	return params, paramIndex, hasCheckPermissions, nil
 }
@@ -151,7 +152,7 @@ func (t *table) buildCheckConstraintCTE(
 // cteName should be the base name (e.g., "mutation_result") or empty for multi-row case.
 // expectedCount is the number of rows we expect to pass the permission check.
 func (t *table) buildCheckCountCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	checkCTEName string,
 	expectedCount int,
@@ -163,22 +164,24 @@ func (t *table) buildCheckCountCTE(
 		checkCountCTEName = "check_count"
 	}
 
-	b.WriteString(checkCountCTEName)
-	b.WriteString(" AS (SELECT ")
+	b.openCTE(checkCountCTEName)
+	b.WriteString("SELECT ")
 	b.WriteString("CASE WHEN (SELECT COUNT(*) FROM ")
 	b.WriteString(checkCTEName)
 	b.WriteString(") >= ")
 	b.WriteString(strconv.Itoa(expectedCount))
 	b.WriteByte(' ')
 	b.WriteString("THEN 1 ")
-	b.WriteString("ELSE (SELECT 0 FROM (SELECT ")
+	b.WriteString("ELSE ")
 	b.WriteString(t.dialect.ThrowError(errMsgInsertPermissionFailed, errCodePermissionDenied))
-	b.WriteString(") x) END AS status), ")
+	b.WriteString(" END AS status")
+	b.closeCTEWithoutParams()
+	b.WriteString(", ")
 }
 
 // buildSingleInsertCTEPreCheck builds a single-row insert using the pre-mutation permission check.
 func (t *table) buildSingleInsertCTEPreCheck( //nolint:funlen // Linear SQL CTE template.
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	insertObj arguments.InsertObject,
 	onConflict *arguments.OnConflict,
@@ -268,7 +271,7 @@ func (t *table) buildSingleInsertCTEPreCheck( //nolint:funlen // Linear SQL CTE
 // sees the just-inserted rows. nil/empty for top-level inserts; populated for
 // nested-insert children that key off a parent CTE.
 func (t *table) buildSingleInsertCTEPostCheck( //nolint:funlen // Linear SQL CTE template.
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	insertObj arguments.InsertObject,
 	onConflict *arguments.OnConflict,
@@ -281,16 +284,18 @@ func (t *table) buildSingleInsertCTEPostCheck( //nolint:funlen // Linear SQL CTE
 ) ([]any, int, error) {
 	dataCTEName := "check_" + cteName
 
-	b.WriteString(dataCTEName)
-	b.WriteString(" AS (SELECT * FROM (SELECT ") //nolint:unqueryvet
+	b.openCTE(dataCTEName)
+	b.WriteString("SELECT * FROM (SELECT ") //nolint:unqueryvet
 
 	var fromCTEs []string
 
 	paramIndex, fromCTEs = t.buildCheckConstraintSelectClause(
-		b, insertObj, nestedFKIndex, paramIndex,
+		&b.Builder, insertObj, nestedFKIndex, paramIndex,
 	)
-	writeFromCTEs(b, fromCTEs)
-	b.WriteString(") AS data WHERE true), ")
+	writeFromCTEs(&b.Builder, fromCTEs)
+	b.WriteString(") AS data WHERE true")
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	plan := t.prepareUpsertUpdateCheckPlan(
 		b,
@@ -307,27 +312,29 @@ func (t *table) buildSingleInsertCTEPostCheck( //nolint:funlen // Linear SQL CTE
 	rawCTEName := "_" + cteName
 	columnNames := insertObj.ColumnNames()
 
-	b.WriteString(rawCTEName)
-	b.WriteString(" AS (INSERT INTO ")
+	b.openModifyingCTE(rawCTEName)
+	b.WriteString("INSERT INTO ")
 	b.WriteString(t.tableFromClause())
 
-	t.buildInsertColumnsClause(b, columnNames)
-	t.buildInsertSelectClause(b, columnNames, dataCTEName, nestedFKIndex)
-	t.buildInsertFromClause(b, dataCTEName, nestedFKIndex)
+	t.buildInsertColumnsClause(&b.Builder, columnNames)
+	t.buildInsertSelectClause(&b.Builder, columnNames, dataCTEName, nestedFKIndex)
+	t.buildInsertFromClause(&b.Builder, dataCTEName, nestedFKIndex)
+	t.writeUpsertSourceFilter(&b.Builder, onConflict)
 
 	if onConflict != nil {
 		var err error
 
 		params, paramIndex, err = t.writeOnConflictSQL(
-			b, onConflict, role, sessionVariables, params, paramIndex,
+			&b.Builder, onConflict, role, sessionVariables, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	t.writeInsertReturning(b, plan)
-	b.WriteString("), ")
+	t.writeInsertReturning(&b.Builder, plan)
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	postCheckSourceCTEName := appendUpsertInsertedRowsCTE(b, plan, rawCTEName)
 	postCheckName := cteName + "_post_check"
@@ -522,7 +529,7 @@ func insertPresentColumns(
 // (see buildCheckConstraintWhereClause) and is required for nested
 // array-relationship children whose check column is defaulted-and-absent.
 func (t *table) buildPostCheckCTEWithName(
-	b *strings.Builder,
+	b *mutationBuilder,
 	checkName string,
 	rawCTEName string,
 	tableSubs where.TableSubstitutions,
@@ -535,13 +542,13 @@ func (t *table) buildPostCheckCTEWithName(
 		return params, paramIndex, nil
 	}
 
-	b.WriteString(checkName)
-	b.WriteString(" AS (SELECT CASE WHEN (SELECT COUNT(*) FROM ")
+	b.openCTE(checkName)
+	b.WriteString("SELECT CASE WHEN (SELECT COUNT(*) FROM ")
 	b.WriteString(rawCTEName)
 	b.WriteString(" WHERE ")
 
 	params, paramIndex, _, err := t.permissions.WriteInsertCheckSubstituted(
-		b, role, sessionVariables, params, paramIndex, rawCTEName, tableSubs,
+		&b.Builder, role, sessionVariables, params, paramIndex, rawCTEName, tableSubs,
 	)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to write post-check permission: %w", err)
@@ -549,9 +556,10 @@ func (t *table) buildPostCheckCTEWithName(
 
 	b.WriteString(") = (SELECT COUNT(*) FROM ")
 	b.WriteString(rawCTEName)
-	b.WriteString(") THEN 1 ELSE (SELECT 0 FROM (SELECT ")
+	b.WriteString(") THEN 1 ELSE ")
 	b.WriteString(t.dialect.ThrowError(errMsgInsertPermissionFailed, errCodePermissionDenied))
-	b.WriteString(") x) END AS status)")
+	b.WriteString(" END AS status")
+	b.closeCTE(params)
 
 	return params, paramIndex, nil
 }
@@ -560,7 +568,7 @@ func (t *table) buildPostCheckCTEWithName(
 // "post_check". Top-level callers pass tableSubs=nil because there is no
 // parent CTE in flight to substitute into.
 func (t *table) buildPostCheckCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	rawCTEName string,
 	role string,
 	sessionVariables map[string]any,
@@ -846,7 +854,7 @@ func collectFKSourceCTEs(columns []string, nestedFKIndex arguments.NestedFKSourc
 // to substitute into relationship-EXISTS subqueries, so tableSubs is always
 // nil here.
 func (t *table) buildCheckMutationResultCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	insertObjs []arguments.InsertObject,
 	allColumns []string,
 	columnToValue []map[string]any,
@@ -857,11 +865,11 @@ func (t *table) buildCheckMutationResultCTE(
 	paramIndex int,
 ) ([]any, int, bool, error) {
 	checkCTEName := "check_mutation_result"
-	b.WriteString(checkCTEName)
-	b.WriteString(" AS (SELECT * FROM (") //nolint:unqueryvet
+	b.openCTE(checkCTEName)
+	b.WriteString("SELECT * FROM (") //nolint:unqueryvet
 
 	params, paramIndex = t.buildUnionAllSelect(
-		b, insertObjs, allColumns, columnToValue, nestedFKIndex, params, paramIndex,
+		&b.Builder, insertObjs, allColumns, columnToValue, nestedFKIndex, params, paramIndex,
 	)
 
 	b.WriteString(") AS data WHERE ")
@@ -872,7 +880,7 @@ func (t *table) buildCheckMutationResultCTE(
 	)
 
 	params, paramIndex, hasCheckPermissions, err = t.buildCheckConstraintWhereClause(
-		b,
+		&b.Builder,
 		role,
 		sessionVariables,
 		nil,
@@ -883,14 +891,15 @@ func (t *table) buildCheckMutationResultCTE(
 		return nil, 0, false, err
 	}
 
-	b.WriteString("), ")
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	return params, paramIndex, hasCheckPermissions, nil
 }
 
 // buildMutationResultInsertCTE builds the final mutation_result INSERT CTE.
 func (t *table) buildMutationResultInsertCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	allColumns []string,
 	nestedFKIndex arguments.NestedFKSources,
@@ -906,31 +915,33 @@ func (t *table) buildMutationResultInsertCTE(
 
 	finalColumns := insertColumnsWithNestedFK(allColumns, nestedFKIndex)
 
-	b.WriteString(cteName)
-	b.WriteString(" AS (INSERT INTO ")
+	b.openModifyingCTE(cteName)
+	b.WriteString("INSERT INTO ")
 	b.WriteString(t.tableFromClause())
 
-	t.buildInsertColumnsClause(b, finalColumns)
-	t.buildInsertSelectClause(b, finalColumns, checkCTEName, nestedFKIndex)
-	t.buildInsertFromClause(b, checkCTEName, nestedFKIndex)
+	t.buildInsertColumnsClause(&b.Builder, finalColumns)
+	t.buildInsertSelectClause(&b.Builder, finalColumns, checkCTEName, nestedFKIndex)
+	t.buildInsertFromClause(&b.Builder, checkCTEName, nestedFKIndex)
 
 	if hasCheckPermissions {
 		b.WriteString(" WHERE (SELECT status FROM check_count) = 1")
+	} else {
+		t.writeUpsertSourceFilter(&b.Builder, onConflict)
 	}
 
 	if onConflict != nil {
 		var err error
 
 		params, paramIndex, err = t.writeOnConflictSQL(
-			b, onConflict, role, sessionVariables, params, paramIndex,
+			&b.Builder, onConflict, role, sessionVariables, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	t.writeInsertReturning(b, plan)
-	b.WriteByte(')')
+	t.writeInsertReturning(&b.Builder, plan)
+	b.closeCTE(params)
 
 	return params, paramIndex, nil
 }
@@ -940,7 +951,7 @@ func (t *table) buildMutationResultInsertCTE(
 // post-INSERT evaluation (see requiresPostInsertCheck): the check predicate is
 // validated against the input data subquery before the INSERT runs.
 func (t *table) buildInsertMutationCTEPreCheck( //nolint:funlen // Linear SQL CTE template.
-	b *strings.Builder,
+	b *mutationBuilder,
 	insertObjs []arguments.InsertObject,
 	allColumns []string,
 	columnToValue []map[string]any,
@@ -1028,7 +1039,7 @@ func (t *table) buildInsertMutationCTEPreCheck( //nolint:funlen // Linear SQL CT
 //	post_check AS (validate inserted rows pass permission filter against real data),
 //	mutation_result AS (SELECT visible columns FROM _mutation_result WHERE post_check passes)
 func (t *table) buildInsertMutationCTEPostCheck( //nolint:funlen // Linear SQL CTE template.
-	b *strings.Builder,
+	b *mutationBuilder,
 	insertObjs []arguments.InsertObject,
 	allColumns []string,
 	columnToValue []map[string]any,
@@ -1041,13 +1052,16 @@ func (t *table) buildInsertMutationCTEPostCheck( //nolint:funlen // Linear SQL C
 ) ([]any, int, error) {
 	finalColumns := insertColumnsWithNestedFK(allColumns, nestedFKIndex)
 
-	b.WriteString("insert_data AS (SELECT * FROM (") //nolint:unqueryvet
+	b.openCTE("insert_data")
+	b.WriteString("SELECT * FROM (") //nolint:unqueryvet
 
 	params, paramIndex = t.buildUnionAllSelect(
-		b, insertObjs, finalColumns, columnToValue, nestedFKIndex, params, paramIndex,
+		&b.Builder, insertObjs, finalColumns, columnToValue, nestedFKIndex, params, paramIndex,
 	)
 
-	b.WriteString(") AS data), ")
+	b.WriteString(") AS data")
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	plan := t.prepareUpsertUpdateCheckPlan(
 		b,
@@ -1061,26 +1075,29 @@ func (t *table) buildInsertMutationCTEPostCheck( //nolint:funlen // Linear SQL C
 		true,
 	)
 
-	b.WriteString("_mutation_result AS (INSERT INTO ")
+	b.openModifyingCTE("_mutation_result")
+	b.WriteString("INSERT INTO ")
 	b.WriteString(t.tableFromClause())
 
-	t.buildInsertColumnsClause(b, finalColumns)
-	t.buildInsertSelectClause(b, finalColumns, "insert_data", nestedFKIndex)
-	t.buildInsertFromClause(b, "insert_data", nestedFKIndex)
+	t.buildInsertColumnsClause(&b.Builder, finalColumns)
+	t.buildInsertSelectClause(&b.Builder, finalColumns, "insert_data", nestedFKIndex)
+	t.buildInsertFromClause(&b.Builder, "insert_data", nestedFKIndex)
+	t.writeUpsertSourceFilter(&b.Builder, onConflict)
 
 	if onConflict != nil {
 		var err error
 
 		params, paramIndex, err = t.writeOnConflictSQL(
-			b, onConflict, role, sessionVariables, params, paramIndex,
+			&b.Builder, onConflict, role, sessionVariables, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	t.writeInsertReturning(b, plan)
-	b.WriteString("), ")
+	t.writeInsertReturning(&b.Builder, plan)
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	postCheckSourceCTEName := appendUpsertInsertedRowsCTE(b, plan, "_mutation_result")
 
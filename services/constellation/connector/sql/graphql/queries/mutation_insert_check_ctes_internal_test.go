@@ -559,7 +559,7 @@ func TestBuildSingleInsertCTEPreCheckUpsertAppliesUpdatePermissions(t *testing.T
 		Where:          nil,
 	}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	params, paramIndex, err := tbl.buildSingleInsertCTEPreCheck(
 		&b,
@@ -686,7 +686,7 @@ func buildUpsertMarkerCollisionSQL(
 		Where:          nil,
 	}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	params, _, err := tbl.buildSingleInsertCTEPreCheck(
 		&b,
@@ -755,7 +755,7 @@ func TestBuildSingleInsertCTEPreCheckUpsertNullsNotDistinctConflictUsesNullSafeM
 		Where:          nil,
 	}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	_, _, err := tbl.buildSingleInsertCTEPreCheck(
 		&b,
@@ -861,7 +861,7 @@ func TestBuildInsertMutationCTEPreCheckUpsertAppliesUpdatePermissions(t *testing
 	nestedFKColumns := map[string]struct{}(nil)
 	allColumns, columnToValue := tbl.collectAllColumns(objs, nestedFKColumns)
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	params, paramIndex, err := tbl.buildInsertMutationCTEPreCheck(
 		&b,
@@ -971,7 +971,7 @@ func TestBuildInsertMutationCTEPreCheckUpsertNoMarkerDetectsSourceDuplicates(t *
 
 	allColumns, columnToValue := tbl.collectAllColumns(objs, nil)
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	_, _, err := tbl.buildInsertMutationCTEPreCheck(
 		&b,
@@ -1047,7 +1047,7 @@ func TestBuildSingleInsertCTEPreCheckUpsertUndetectableConflictCoversAllRows(t *
 		Where:          nil,
 	}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	_, _, err := tbl.buildSingleInsertCTEPreCheck(
 		&b,
@@ -1120,7 +1120,7 @@ func TestBuildSingleInsertCTEPreCheckUpsertDetectsParentSourcedFKConflict(t *tes
 		"organization_id": {CTEName: "mutation_result", ColumnName: "id"},
 	}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	params, paramIndex, err := tbl.buildSingleInsertCTEPreCheck(
 		&b,
@@ -1227,7 +1227,7 @@ func TestBuildPartitionedNestedArrayCTEPreCheckUpsertAppliesUpdatePermissions(t
 	parentCTENames := partitionedParentCTENames(2)
 	nestedFKIndex := arguments.NestedFKSources{"note_id": {ColumnName: "id"}}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	params, paramIndex, err := tbl.buildPartitionedNestedArrayCTE(
 		&b,
@@ -1337,7 +1337,7 @@ func TestBuildPartitionedNestedArrayCTEPreCheckUpsertNoMarkerDetectsSourceDuplic
 	}
 	nestedFKIndex := arguments.NestedFKSources{"note_id": {ColumnName: "id"}}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	_, _, err := tbl.buildPartitionedNestedArrayCTE(
 		&b,
@@ -1384,7 +1384,7 @@ func TestBuildSingleInsertCTEPreCheckNoPermissions(t *testing.T) {
 		insertCol(nameCol, "alice"),
 	}}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	params, paramIndex, err := tbl.buildSingleInsertCTEPreCheck(
 		&b, "mutation_result", obj, nil, nil, nil, nil, 1, "user", nil,
@@ -1440,7 +1440,7 @@ func TestBuildSingleInsertCTEPreCheckWithPermissions(t *testing.T) {
 		insertCol(nameCol, "alice"),
 	}}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	params, _, err := tbl.buildSingleInsertCTEPreCheck(
 		&b, "mutation_result", obj, nil, nil, nil, nil, 1, "user", nil,
@@ -1505,7 +1505,7 @@ func TestBuildSingleInsertCTEPostCheckShape(t *testing.T) {
 		insertCol(nameCol, "alice"),
 	}}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	_, _, err := tbl.buildSingleInsertCTEPostCheck(
 		&b, "mutation_result", obj, nil, nil, nil, nil, 1, "user",
@@ -1594,7 +1594,7 @@ func TestBuildSingleInsertCTEPostCheckUpsertANDsUpdateAndInsertPostChecks(t *tes
 		t.Fatalf("fixture must take the post-check path (generated insert-check column)")
 	}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	params, paramIndex, err := tbl.buildSingleInsertCTEPostCheck(
 		&b,
@@ -1692,7 +1692,7 @@ func TestBuildSingleInsertCTEPostCheckUpsertScopesInsertCheckWithoutUpdateCheck(
 		Where:          nil,
 	}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	params, paramIndex, err := tbl.buildSingleInsertCTEPostCheck(
 		&b,
@@ -1790,7 +1790,7 @@ func TestBuildInsertMutationCTEPostCheckUpsertNoMarkerChecksSourceDuplicates(t *
 
 	allColumns, columnToValue := tbl.collectAllColumns(objs, nil)
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	_, _, err := tbl.buildInsertMutationCTEPostCheck(
 		&b,
@@ -1858,7 +1858,7 @@ func TestRequiresPostInsertCheckBranching(t *testing.T) {
 
 		presentCols := insertPresentColumns([]arguments.InsertObject{obj}, nil)
 		if tbl.requiresPostInsertCheck("user", presentCols, nil) {
-			var b strings.Builder
+			var b mutationBuilder
 
 			_, _, err := tbl.buildSingleInsertCTEPostCheck(
 				&b, "mutation_result", obj, nil, nil, nil, nil, 1, "user", nil,
@@ -1867,7 +1867,7 @@ func TestRequiresPostInsertCheckBranching(t *testing.T) {
 			return b.String(), err
 		}
 
-		var b strings.Builder
+		var b mutationBuilder
 
 		_, _, err := tbl.buildSingleInsertCTEPreCheck(
 			&b, "mutation_result", obj, nil, nil, nil, nil, 1, "user", nil,
@@ -2070,7 +2070,7 @@ func TestBuildPostCheckCTEWithNameThreadsSubs(t *testing.T) {
 	)
 
 	build := func(subs where.TableSubstitutions) string {
-		var b strings.Builder
+		var b mutationBuilder
 
 		_, _, err := tbl.buildPostCheckCTEWithName(
 			&b, "post_check", "_mutation_result", subs, "user",
@@ -2130,7 +2130,7 @@ func TestBuildSingleInsertCTEPostCheckPropagatesSubs(t *testing.T) {
 	}}
 
 	build := func(subs where.TableSubstitutions) string {
-		var b strings.Builder
+		var b mutationBuilder
 
 		_, _, err := tbl.buildSingleInsertCTEPostCheck(
 			&b, "mutation_result", obj, nil, nil, subs, nil, 1, "user",
@@ -2255,7 +2255,7 @@ func TestBuildSingleInsertCTEPreCheckAggregateRelationshipCheck(t *testing.T) {
 
 	subs := where.TableSubstitutions{`"public"."user_departments"`: "mutation_result"}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	_, _, err := tbl.buildSingleInsertCTEPreCheck(
 		&b, "mutation_result", obj, nil, nil, subs, nil, 1, "user", nil,
@@ -2323,7 +2323,7 @@ func TestBuildSingleInsertCTEPreCheckAggregateRelationshipNoSubs(t *testing.T) {
 		insertCol(col("name", "text", false), "research"),
 	}}
 
-	var b strings.Builder
+	var b mutationBuilder
 
 	_, _, err := tbl.buildSingleInsertCTEPreCheck(
 		&b, "mutation_result", obj, nil, nil, nil, nil, 1, "user", nil,
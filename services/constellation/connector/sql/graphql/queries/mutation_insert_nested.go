@@ -70,7 +70,7 @@ func bareNestedCTENameForInsert(nested *arguments.NestedInsert) string {
 // alias for any deeper nested insert reached by the recursion. Callers that
 // want the historical `nested_<rel>` naming pass bareNestedCTENameForInsert.
 func buildNestedInsertCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	ni *arguments.NestedInsert,
 	cteName string,
 	childNamer nestedCTENamer,
@@ -147,7 +147,7 @@ func buildNestedInsertCTE(
 // buildSingleNestedInsertCTE emits the single-row nested INSERT path, used for
 // object relationships and array relationships with exactly one row.
 func buildSingleNestedInsertCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	target *table,
 	cteName string,
 	ni *arguments.NestedInsert,
@@ -177,7 +177,7 @@ func buildSingleNestedInsertCTE(
 // names line up with the single-row nested path and with downstream consumers
 // that key off `nested_<rel>`.
 func (t *table) buildMultiNestedInsertCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	insertObjs []arguments.InsertObject,
 	onConflict *arguments.OnConflict,
@@ -209,7 +209,7 @@ func (t *table) buildMultiNestedInsertCTE(
 }
 
 func (t *table) buildMultiNestedInsertCTEPreCheck( //nolint:funlen // Linear SQL CTE template.
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	insertObjs []arguments.InsertObject,
 	allColumns []string,
@@ -227,23 +227,24 @@ func (t *table) buildMultiNestedInsertCTEPreCheck( //nolint:funlen // Linear SQL
 
 	dataColumns := t.extendWithPermissionColumns(finalColumns, role)
 
-	b.WriteString(checkCTEName)
-	b.WriteString(" AS (SELECT * FROM (") //nolint:unqueryvet
+	b.openCTE(checkCTEName)
+	b.WriteString("SELECT * FROM (") //nolint:unqueryvet
 
 	params, paramIndex = t.buildUnionAllSelect(
-		b, insertObjs, dataColumns, columnToValue, nestedFKIndex, params, paramIndex,
+		&b.Builder, insertObjs, dataColumns, columnToValue, nestedFKIndex, params, paramIndex,
 	)
 
 	b.WriteString(") AS data WHERE ")
 
 	params, paramIndex, hasCheckPermissions, err := t.buildCheckConstraintWhereClause(
-		b, role, sessionVariables, tableSubs, params, paramIndex,
+		&b.Builder, role, sessionVariables, tableSubs, params, paramIndex,
 	)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	b.WriteString("), ")
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	if hasCheckPermissions {
 		t.buildCheckCountCTE(b, cteName, checkCTEName, len(insertObjs))
@@ -263,26 +264,26 @@ func (t *table) buildMultiNestedInsertCTEPreCheck( //nolint:funlen // Linear SQL
 
 	rawCTEName := rawCTENameForUpsertUpdateCheck(cteName, plan)
 
-	b.WriteString(rawCTEName)
-	b.WriteString(" AS (INSERT INTO ")
+	b.openModifyingCTE(rawCTEName)
+	b.WriteString("INSERT INTO ")
 	b.WriteString(t.tableFromClause())
 
-	t.buildInsertColumnsClause(b, finalColumns)
-	t.buildInsertSelectClause(b, finalColumns, checkCTEName, nestedFKIndex)
-	t.buildInsertFromClause(b, checkCTEName, nestedFKIndex)
-	t.buildInsertWhereClause(b, cteName, hasCheckPermissions)
+	t.buildInsertColumnsClause(&b.Builder, finalColumns)
+	t.buildInsertSelectClause(&b.Builder, finalColumns, checkCTEName, nestedFKIndex)
+	t.buildInsertFromClause(&b.Builder, checkCTEName, nestedFKIndex)
+	t.buildInsertWhereClause(&b.Builder, cteName, hasCheckPermissions, onConflict)
 
 	if onConflict != nil {
 		params, paramIndex, err = t.writeOnConflictSQL(
-			b, onConflict, role, sessionVariables, params, paramIndex,
+			&b.Builder, onConflict, role, sessionVariables, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	t.writeInsertReturning(b, plan)
-	b.WriteByte(')')
+	t.writeInsertReturning(&b.Builder, plan)
+	b.closeCTE(params)
 
 	params, paramIndex, err = t.appendUpsertUpdateCheckAndFinalCTE(
 		b, cteName, rawCTEName, plan, role, sessionVariables, params, paramIndex,
@@ -301,7 +302,7 @@ func (t *table) buildMultiNestedInsertCTEPreCheck( //nolint:funlen // Linear SQL
 // target a sibling in-flight CTE (typically the parent's mutation_result) so
 // they read the just-inserted rows instead of the underlying empty table.
 func (t *table) buildMultiNestedInsertCTEPostCheck( //nolint:funlen // Linear SQL CTE template.
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	insertObjs []arguments.InsertObject,
 	allColumns []string,
@@ -320,14 +321,16 @@ func (t *table) buildMultiNestedInsertCTEPostCheck( //nolint:funlen // Linear SQ
 
 	finalColumns := insertColumnsWithNestedFK(allColumns, nestedFKIndex)
 
-	b.WriteString(dataCTEName)
-	b.WriteString(" AS (SELECT * FROM (") //nolint:unqueryvet
+	b.openCTE(dataCTEName)
+	b.WriteString("SELECT * FROM (") //nolint:unqueryvet
 
 	params, paramIndex = t.buildUnionAllSelect(
-		b, insertObjs, finalColumns, columnToValue, nestedFKIndex, params, paramIndex,
+		&b.Builder, insertObjs, finalColumns, columnToValue, nestedFKIndex, params, paramIndex,
 	)
 
-	b.WriteString(") AS data), ")
+	b.WriteString(") AS data")
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	plan := t.prepareUpsertUpdateCheckPlan(
 		b,
@@ -341,27 +344,29 @@ func (t *table) buildMultiNestedInsertCTEPostCheck( //nolint:funlen // Linear SQ
 		true,
 	)
 
-	b.WriteString(rawCTEName)
-	b.WriteString(" AS (INSERT INTO ")
+	b.openModifyingCTE(rawCTEName)
+	b.WriteString("INSERT INTO ")
 	b.WriteString(t.tableFromClause())
 
-	t.buildInsertColumnsClause(b, finalColumns)
-	t.buildInsertSelectClause(b, finalColumns, dataCTEName, nestedFKIndex)
-	t.buildInsertFromClause(b, dataCTEName, nestedFKIndex)
+	t.buildInsertColumnsClause(&b.Builder, finalColumns)
+	t.buildInsertSelectClause(&b.Builder, finalColumns, dataCTEName, nestedFKIndex)
+	t.buildInsertFromClause(&b.Builder, dataCTEName, nestedFKIndex)
+	t.writeUpsertSourceFilter(&b.Builder, onConflict)
 
 	if onConflict != nil {
 		var err error
 
 		params, paramIndex, err = t.writeOnConflictSQL(
-			b, onConflict, role, sessionVariables, params, paramIndex,
+			&b.Builder, onConflict, role, sessionVariables, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	t.writeInsertReturning(b, plan)
-	b.WriteString("), ")
+	t.writeInsertReturning(&b.Builder, plan)
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	postCheckSourceCTEName := appendUpsertInsertedRowsCTE(b, plan, rawCTEName)
 
@@ -409,15 +414,15 @@ func (t *table) buildNestedInsertCTEs(
 	sessionVariables map[string]any,
 	params []any,
 	paramIndex int,
-) (string, []any, int, error) {
+) (*mutationBuilder, []any, int, error) {
+	var cteSQL mutationBuilder
+
 	if len(insertObjs) == 0 || !hasObjectNestedInserts(insertObjs) {
-		return "", params, paramIndex, nil
+		return &cteSQL, params, paramIndex, nil
 	}
 
 	multiParent := len(insertObjs) > 1
 
-	var cteSQL strings.Builder
-
 	var objectNamers []*partitionedObjectCTENameAllocator
 	if multiParent {
 		objectNamers = newPartitionedObjectCTENameAllocators(insertObjs, "", nil)
@@ -459,7 +464,7 @@ func (t *table) buildNestedInsertCTEs(
 				params, paramIndex, role, sessionVariables,
 			)
 			if err != nil {
-				return "", nil, 0, fmt.Errorf(
+				return nil, nil, 0, fmt.Errorf(
 					"failed to build CTE for %s: %w",
 					nested.RelationshipName,
 					err,
@@ -468,7 +473,7 @@ func (t *table) buildNestedInsertCTEs(
 		}
 	}
 
-	return cteSQL.String(), params, paramIndex, nil
+	return &cteSQL, params, paramIndex, nil
 }
 
 // buildArrayNestedInsertCTEs builds CTEs for array-relationship nested
@@ -495,9 +500,11 @@ func (t *table) buildArrayNestedInsertCTEs(
 	sessionVariables map[string]any,
 	params []any,
 	paramIndex int,
-) (string, []any, int, error) {
+) (*mutationBuilder, []any, int, error) {
+	var cteSQL mutationBuilder
+
 	if len(insertObjs) == 0 {
-		return "", params, paramIndex, nil
+		return &cteSQL, params, paramIndex, nil
 	}
 
 	if len(insertObjs) > 1 {
@@ -507,10 +514,9 @@ func (t *table) buildArrayNestedInsertCTEs(
 	}
 
 	if len(insertObjs[0].NestedInserts) == 0 {
-		return "", params, paramIndex, nil
+		return &cteSQL, params, paramIndex, nil
 	}
 
-	var cteSQL strings.Builder
 	for i := range insertObjs[0].NestedInserts {
 		nested := &insertObjs[0].NestedInserts[i]
 		if !nested.IsArrayRelationship {
@@ -525,7 +531,7 @@ func (t *table) buildArrayNestedInsertCTEs(
 			params, paramIndex, role, sessionVariables,
 		)
 		if err != nil {
-			return "", nil, 0, fmt.Errorf(
+			return nil, nil, 0, fmt.Errorf(
 				"failed to build CTE for %s: %w",
 				nested.RelationshipName,
 				err,
@@ -533,7 +539,7 @@ func (t *table) buildArrayNestedInsertCTEs(
 		}
 	}
 
-	return cteSQL.String(), params, paramIndex, nil
+	return &cteSQL, params, paramIndex, nil
 }
 
 func hasArrayNestedInserts(insertObjs []arguments.InsertObject) bool {
@@ -672,7 +678,7 @@ func partitionedParentCTENames(count int) []string {
 // empty for array-only inserts (array-rel FKs live on the child, not the
 // parent).
 func (t *table) buildPartitionedParentInsertMutationCTEBody(
-	b *strings.Builder,
+	b *mutationBuilder,
 	insertObjs []arguments.InsertObject,
 	nestedFKIndexes []arguments.NestedFKSources,
 	onConflict *arguments.OnConflict,
@@ -705,17 +711,18 @@ func (t *table) buildPartitionedParentInsertMutationCTEBody(
 		}
 	}
 
-	b.WriteString(", mutation_result AS (")
+	b.WriteString(", ")
+	b.openCTE("mutation_result")
 
 	for i := range insertObjs {
 		if i > 0 {
 			b.WriteString(" UNION ALL ")
 		}
 
-		t.writePartitionedParentProjection(b, partitionedParentCTEName(i))
+		t.writePartitionedParentProjection(&b.Builder, partitionedParentCTEName(i))
 	}
 
-	b.WriteByte(')')
+	b.closeCTEWithoutParams()
 
 	return params, paramIndex, nil
 }
@@ -1001,8 +1008,8 @@ func (t *table) buildPartitionedArrayNestedInsertCTEs(
 	sessionVariables map[string]any,
 	params []any,
 	paramIndex int,
-) (string, []any, int, error) {
-	var cteSQL strings.Builder
+) (*mutationBuilder, []any, int, error) {
+	var cteSQL mutationBuilder
 
 	params, paramIndex, err := t.buildPartitionedArrayNestedInsertCTEsWithParents(
 		&cteSQL,
@@ -1017,14 +1024,14 @@ func (t *table) buildPartitionedArrayNestedInsertCTEs(
 		paramIndex,
 	)
 	if err != nil {
-		return "", nil, 0, err
+		return nil, nil, 0, err
 	}
 
-	return cteSQL.String(), params, paramIndex, nil
+	return &cteSQL, params, paramIndex, nil
 }
 
 func (t *table) buildPartitionedArrayNestedInsertCTEsWithParents(
-	b *strings.Builder,
+	b *mutationBuilder,
 	insertObjs []arguments.InsertObject,
 	parentCTENames []string,
 	parentUnionCTEName string,
@@ -1069,7 +1076,7 @@ func nestedInsertTargetTable(ni *arguments.NestedInsert) (*table, error) {
 }
 
 func (t *table) buildPartitionedArrayNestedInsertGroup(
-	b *strings.Builder,
+	b *mutationBuilder,
 	g *partitionedNestedGroup,
 	parentUnionCTEName string,
 	tableSubs where.TableSubstitutions,
@@ -1103,7 +1110,7 @@ func (t *table) buildPartitionedArrayNestedInsertGroup(
 }
 
 func (t *table) buildPartitionedNestedArrayGroupWithNestedInserts(
-	b *strings.Builder,
+	b *mutationBuilder,
 	g *partitionedNestedGroup,
 	childSubs where.TableSubstitutions,
 	role string,
@@ -1145,7 +1152,7 @@ func (t *table) buildPartitionedNestedArrayGroupWithNestedInserts(
 }
 
 func (t *table) buildFlatPartitionedNestedArrayGroup(
-	b *strings.Builder,
+	b *mutationBuilder,
 	g *partitionedNestedGroup,
 	childSubs where.TableSubstitutions,
 	role string,
@@ -1179,7 +1186,7 @@ func partitionedNestedRowCTEName(cteName string, rowIdx int) string {
 }
 
 func (t *table) buildPartitionedNestedArrayRowsCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	template *arguments.NestedInsert,
 	insertObjs []arguments.InsertObject,
@@ -1228,18 +1235,17 @@ func (t *table) buildPartitionedNestedArrayRowsCTE(
 	}
 
 	b.WriteString(", ")
-	b.WriteString(cteName)
-	b.WriteString(" AS (")
+	b.openCTE(cteName)
 
 	for i, rowCTEName := range rowCTENames {
 		if i > 0 {
 			b.WriteString(" UNION ALL ")
 		}
 
-		t.writePartitionedParentProjection(b, rowCTEName)
+		t.writePartitionedParentProjection(&b.Builder, rowCTEName)
 	}
 
-	b.WriteByte(')')
+	b.closeCTEWithoutParams()
 
 	return rowCTENames, params, paramIndex, nil
 }
@@ -1247,7 +1253,7 @@ func (t *table) buildPartitionedNestedArrayRowsCTE(
 // buildPartitionedNestedArrayCTE dispatches the partitioned shape to the
 // pre-check or post-check sibling, mirroring buildMultiNestedInsertCTE.
 func (t *table) buildPartitionedNestedArrayCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	insertObjs []arguments.InsertObject,
 	parentCTENames []string,
@@ -1325,7 +1331,7 @@ func insertColumnsWithNestedFK(
 // but each UNION branch sources FK columns from its matching parent CTE
 // instead of cross-joining the whole mutation_result CTE.
 func (t *table) buildPartitionedNestedArrayCTEPreCheck( //nolint:funlen // Linear SQL CTE template.
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	insertObjs []arguments.InsertObject,
 	parentCTENames []string,
@@ -1344,24 +1350,25 @@ func (t *table) buildPartitionedNestedArrayCTEPreCheck( //nolint:funlen // Linea
 	finalColumns := insertColumnsWithNestedFK(allColumns, nestedFKIndex)
 	dataColumns := t.extendWithPermissionColumns(finalColumns, role)
 
-	b.WriteString(checkCTEName)
-	b.WriteString(" AS (SELECT * FROM (") //nolint:unqueryvet
+	b.openCTE(checkCTEName)
+	b.WriteString("SELECT * FROM (") //nolint:unqueryvet
 
 	params, paramIndex = t.buildPartitionedUnionAllSelect(
-		b, insertObjs, parentCTENames, dataColumns, columnToValue,
+		&b.Builder, insertObjs, parentCTENames, dataColumns, columnToValue,
 		nestedFKIndex, params, paramIndex,
 	)
 
 	b.WriteString(") AS data WHERE ")
 
 	params, paramIndex, hasCheckPermissions, err := t.buildCheckConstraintWhereClause(
-		b, role, sessionVariables, tableSubs, params, paramIndex,
+		&b.Builder, role, sessionVariables, tableSubs, params, paramIndex,
 	)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	b.WriteString("), ")
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	if hasCheckPermissions {
 		t.buildCheckCountCTE(b, cteName, checkCTEName, len(insertObjs))
@@ -1381,26 +1388,26 @@ func (t *table) buildPartitionedNestedArrayCTEPreCheck( //nolint:funlen // Linea
 
 	rawCTEName := rawCTENameForUpsertUpdateCheck(cteName, plan)
 
-	b.WriteString(rawCTEName)
-	b.WriteString(" AS (INSERT INTO ")
+	b.openModifyingCTE(rawCTEName)
+	b.WriteString("INSERT INTO ")
 	b.WriteString(t.tableFromClause())
 
-	t.buildInsertColumnsClause(b, finalColumns)
-	t.buildInsertSelectClause(b, finalColumns, checkCTEName, nil)
-	t.buildInsertFromClause(b, checkCTEName, nil)
-	t.buildInsertWhereClause(b, cteName, hasCheckPermissions)
+	t.buildInsertColumnsClause(&b.Builder, finalColumns)
+	t.buildInsertSelectClause(&b.Builder, finalColumns, checkCTEName, nil)
+	t.buildInsertFromClause(&b.Builder, checkCTEName, nil)
+	t.buildInsertWhereClause(&b.Builder, cteName, hasCheckPermissions, onConflict)
 
 	if onConflict != nil {
 		params, paramIndex, err = t.writeOnConflictSQL(
-			b, onConflict, role, sessionVariables, params, paramIndex,
+			&b.Builder, onConflict, role, sessionVariables, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	t.writeInsertReturning(b, plan)
-	b.WriteByte(')')
+	t.writeInsertReturning(&b.Builder, plan)
+	b.closeCTE(params)
 
 	params, paramIndex, err = t.appendUpsertUpdateCheckAndFinalCTE(
 		b, cteName, rawCTEName, plan, role, sessionVariables, params, paramIndex,
@@ -1417,7 +1424,7 @@ func (t *table) buildPartitionedNestedArrayCTEPreCheck( //nolint:funlen // Linea
 // buildMultiNestedInsertCTEPostCheck but with the same partitioning treatment
 // as the pre-check sibling.
 func (t *table) buildPartitionedNestedArrayCTEPostCheck( //nolint:funlen // Linear SQL CTE template.
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	insertObjs []arguments.InsertObject,
 	parentCTENames []string,
@@ -1437,15 +1444,17 @@ func (t *table) buildPartitionedNestedArrayCTEPostCheck( //nolint:funlen // Line
 
 	finalColumns := insertColumnsWithNestedFK(allColumns, nestedFKIndex)
 
-	b.WriteString(dataCTEName)
-	b.WriteString(" AS (SELECT * FROM (") //nolint:unqueryvet
+	b.openCTE(dataCTEName)
+	b.WriteString("SELECT * FROM (") //nolint:unqueryvet
 
 	params, paramIndex = t.buildPartitionedUnionAllSelect(
-		b, insertObjs, parentCTENames, finalColumns, columnToValue,
+		&b.Builder, insertObjs, parentCTENames, finalColumns, columnToValue,
 		nestedFKIndex, params, paramIndex,
 	)
 
-	b.WriteString(") AS data), ")
+	b.WriteString(") AS data")
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	plan := t.prepareUpsertUpdateCheckPlan(
 		b,
@@ -1459,27 +1468,29 @@ func (t *table) buildPartitionedNestedArrayCTEPostCheck( //nolint:funlen // Line
 		true,
 	)
 
-	b.WriteString(rawCTEName)
-	b.WriteString(" AS (INSERT INTO ")
+	b.openModifyingCTE(rawCTEName)
+	b.WriteString("INSERT INTO ")
 	b.WriteString(t.tableFromClause())
 
-	t.buildInsertColumnsClause(b, finalColumns)
-	t.buildInsertSelectClause(b, finalColumns, dataCTEName, nil)
-	t.buildInsertFromClause(b, dataCTEName, nil)
+	t.buildInsertColumnsClause(&b.Builder, finalColumns)
+	t.buildInsertSelectClause(&b.Builder, finalColumns, dataCTEName, nil)
+	t.buildInsertFromClause(&b.Builder, dataCTEName, nil)
+	t.writeUpsertSourceFilter(&b.Builder, onConflict)
 
 	if onConflict != nil {
 		var err error
 
 		params, paramIndex, err = t.writeOnConflictSQL(
-			b, onConflict, role, sessionVariables, params, paramIndex,
+			&b.Builder, onConflict, role, sessionVariables, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	t.writeInsertReturning(b, plan)
-	b.WriteString("), ")
+	t.writeInsertReturning(&b.Builder, plan)
+	b.closeCTE(params)
+	b.WriteString(", ")
 
 	postCheckSourceCTEName := appendUpsertInsertedRowsCTE(b, plan, rawCTEName)
 
@@ -618,11 +618,13 @@ func TestBuildNestedInsertCTEsAvoidsObjectRelationshipDescendantCollision(t *tes
 		},
 	}
 
-	got, _, _, err := tbl.buildNestedInsertCTEs(insertObjs, "admin", nil, nil, 1)
+	nested, _, _, err := tbl.buildNestedInsertCTEs(insertObjs, "admin", nil, nil, 1)
 	if err != nil {
 		t.Fatalf("buildNestedInsertCTEs(): %v", err)
 	}
 
+	got := nested.String()
+
 	wantAliases := []string{
 		"nested_owner_0",
 		"nested_owner_1",
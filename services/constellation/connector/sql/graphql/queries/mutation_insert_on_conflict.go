@@ -211,7 +211,7 @@ func (t *table) upsertConflictDetectionColumns(
 }
 
 func (t *table) prepareUpsertUpdateCheckPlan(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	sourceCTEName string,
 	onConflict *arguments.OnConflict,
@@ -253,7 +253,7 @@ func rawCTENameForUpsertUpdateCheck(cteName string, plan upsertUpdateCheckPlan)
 }
 
 func (t *table) writeInsertReturning(b *strings.Builder, plan upsertUpdateCheckPlan) {
-	b.WriteString(" RETURNING *")
+	t.writeReturningAll(b)
 
 	if !plan.useUpdateActionColumn {
 		return
@@ -284,7 +284,7 @@ func (t *table) upsertUpdateActionColumnAlias() string {
 }
 
 func appendUpsertInsertedRowsCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	plan upsertUpdateCheckPlan,
 	rawCTEName string,
 ) string {
@@ -292,25 +292,26 @@ func appendUpsertInsertedRowsCTE(
 		return rawCTEName
 	}
 
-	b.WriteString(plan.insertedRowsCTEName)
-	b.WriteString(" AS (SELECT * FROM ") //nolint:unqueryvet
+	b.openCTE(plan.insertedRowsCTEName)
+	b.WriteString("SELECT * FROM ") //nolint:unqueryvet
 	b.WriteString(rawCTEName)
 	b.WriteString(" WHERE ")
 
 	if plan.useUpdateActionColumn {
-		writeUpsertUpdatedColumnPredicate(b, rawCTEName, plan.updateActionColumn, false)
+		writeUpsertUpdatedColumnPredicate(&b.Builder, rawCTEName, plan.updateActionColumn, false)
 	} else {
 		b.WriteString("NOT ")
-		writeConflictKeyExists(b, plan.conflictCTEName, rawCTEName, plan)
+		writeConflictKeyExists(&b.Builder, plan.conflictCTEName, rawCTEName, plan)
 	}
 
-	b.WriteString("), ")
+	b.closeCTEWithoutParams()
+	b.WriteString(", ")
 
 	return plan.insertedRowsCTEName
 }
 
 func (t *table) writeUpsertConflictKeysCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	plan upsertUpdateCheckPlan,
 ) {
 	if !plan.enabled || !plan.canDetectConflicts {
@@ -319,10 +320,10 @@ func (t *table) writeUpsertConflictKeysCTE(
 
 	targetAlias := core.QuoteIdentifier(plan.conflictCTEName + "_target")
 
-	b.WriteString(plan.conflictCTEName)
-	b.WriteString(" AS (SELECT ")
+	b.openCTE(plan.conflictCTEName)
+	b.WriteString("SELECT ")
 
-	writeConflictKeySelectList(b, targetAlias, plan.conflictColumns)
+	writeConflictKeySelectList(&b.Builder, targetAlias, plan.conflictColumns)
 
 	b.WriteString(" FROM ")
 	b.WriteString(t.tableFromClause())
@@ -332,43 +333,45 @@ func (t *table) writeUpsertConflictKeysCTE(
 	b.WriteString(plan.sourceCTEName)
 	b.WriteString(" WHERE ")
 	writeConflictKeyMatch(
-		b,
+		&b.Builder,
 		targetAlias,
 		plan.sourceCTEName,
 		plan.conflictColumns,
 		plan.conflictNullsNotDistinct,
 	)
-	b.WriteString("))")
+	b.WriteByte(')')
+	b.closeCTEWithoutParams()
 }
 
 func writeUpsertSourceConflictKeysCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	plan upsertUpdateCheckPlan,
 ) {
 	if !plan.enabled || !plan.canDetectConflicts || !plan.detectSourceConflicts {
 		return
 	}
 
-	b.WriteString(plan.sourceConflictCTEName)
-	b.WriteString(" AS (SELECT ")
+	b.openCTE(plan.sourceConflictCTEName)
+	b.WriteString("SELECT ")
 
-	writeConflictKeySelectList(b, plan.sourceCTEName, plan.conflictColumns)
+	writeConflictKeySelectList(&b.Builder, plan.sourceCTEName, plan.conflictColumns)
 
 	b.WriteString(" FROM ")
 	b.WriteString(plan.sourceCTEName)
 
 	if !plan.conflictNullsNotDistinct {
 		b.WriteString(" WHERE ")
-		writeConflictKeyNotNull(b, plan.sourceCTEName, plan.conflictColumns)
+		writeConflictKeyNotNull(&b.Builder, plan.sourceCTEName, plan.conflictColumns)
 	}
 
 	b.WriteString(" GROUP BY ")
-	writeConflictKeyColumnList(b, plan.sourceCTEName, plan.conflictColumns)
-	b.WriteString(" HAVING COUNT(*) > 1)")
+	writeConflictKeyColumnList(&b.Builder, plan.sourceCTEName, plan.conflictColumns)
+	b.WriteString(" HAVING COUNT(*) > 1")
+	b.closeCTEWithoutParams()
 }
 
 func (t *table) appendUpsertUpdatePostCheckCTEs(
-	b *strings.Builder,
+	b *mutationBuilder,
 	plan upsertUpdateCheckPlan,
 	rawCTEName string,
 	role string,
@@ -399,7 +402,7 @@ func (t *table) appendUpsertUpdatePostCheckCTEs(
 }
 
 func (t *table) appendUpsertUpdateCheckAndFinalCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	rawCTEName string,
 	plan upsertUpdateCheckPlan,
@@ -461,69 +464,69 @@ func hasStatusCheckNames(checkNames []string) bool {
 // deliberately leaves the CTE unfiltered so the UPDATE check runs fail-closed
 // against every RETURNING row.
 func (t *table) writeUpsertUpdatedRowsCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	plan upsertUpdateCheckPlan,
 	rawCTEName string,
 ) {
-	b.WriteString(plan.updatedRowsCTEName)
-	b.WriteString(" AS (SELECT * FROM ") //nolint:unqueryvet
+	b.openCTE(plan.updatedRowsCTEName)
+	b.WriteString("SELECT * FROM ") //nolint:unqueryvet
 	b.WriteString(rawCTEName)
 
 	if plan.useUpdateActionColumn {
 		b.WriteString(" WHERE ")
-		writeUpsertUpdatedColumnPredicate(b, rawCTEName, plan.updateActionColumn, true)
+		writeUpsertUpdatedColumnPredicate(&b.Builder, rawCTEName, plan.updateActionColumn, true)
 	} else if plan.canDetectConflicts {
 		b.WriteString(" WHERE ")
-		writeConflictKeyExists(b, plan.conflictCTEName, rawCTEName, plan)
+		writeConflictKeyExists(&b.Builder, plan.conflictCTEName, rawCTEName, plan)
 
 		if plan.detectSourceConflicts {
 			b.WriteString(" OR ")
-			writeConflictKeyExists(b, plan.sourceConflictCTEName, rawCTEName, plan)
+			writeConflictKeyExists(&b.Builder, plan.sourceConflictCTEName, rawCTEName, plan)
 		}
 	}
 
-	b.WriteByte(')')
+	b.closeCTEWithoutParams()
 }
 
 func writeCTESelectAllWithStatusChecks(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	sourceCTEName string,
 	checkNames ...string,
 ) {
-	b.WriteString(cteName)
-	b.WriteString(" AS (SELECT * FROM ") //nolint:unqueryvet
+	b.openCTE(cteName)
+	b.WriteString("SELECT * FROM ") //nolint:unqueryvet
 	b.WriteString(sourceCTEName)
 
-	writeStatusCheckWhere(b, checkNames)
+	writeStatusCheckWhere(&b.Builder, checkNames)
 
-	b.WriteByte(')')
+	b.closeCTEWithoutParams()
 }
 
 func writeCTESelectColumnsWithStatusChecks(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	sourceCTEName string,
 	columns []*core.Column,
 	checkNames ...string,
 ) {
-	b.WriteString(cteName)
-	b.WriteString(" AS (SELECT ")
+	b.openCTE(cteName)
+	b.WriteString("SELECT ")
 
 	for i, column := range columns {
 		if i > 0 {
 			b.WriteString(", ")
 		}
 
-		core.WriteQualifiedColumn(b, sourceCTEName, column.SQLName)
+		core.WriteQualifiedColumn(&b.Builder, sourceCTEName, column.SQLName)
 	}
 
 	b.WriteString(" FROM ")
 	b.WriteString(sourceCTEName)
 
-	writeStatusCheckWhere(b, checkNames)
+	writeStatusCheckWhere(&b.Builder, checkNames)
 
-	b.WriteByte(')')
+	b.closeCTEWithoutParams()
 }
 
 func writeUpsertUpdatedColumnPredicate(
@@ -0,0 +1,501 @@
+package queries_test
+
+import (
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+	"github.com/nhost/nhost/services/constellation/connector/sql/sqlite"
+	"github.com/nhost/nhost/services/constellation/internal/lib/testdb"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+const sqliteUsersDDL = `CREATE TABLE users (
+	id text NOT NULL PRIMARY KEY,
+	user_id text NOT NULL,
+	username text NOT NULL,
+	bio text NOT NULL,
+	status text NOT NULL
+);
+CREATE UNIQUE INDEX users_username_key ON users(username);`
+
+const sqliteAuthorsDDL = `CREATE TABLE authors (
+	id integer PRIMARY KEY,
+	name text NOT NULL,
+	created_at datetime NOT NULL DEFAULT '2024-01-02T03:04:05Z'
+);
+CREATE TABLE articles (
+	id integer PRIMARY KEY,
+	author_id integer NOT NULL REFERENCES authors(id),
+	title text NOT NULL,
+	published boolean NOT NULL DEFAULT 0
+);`
+
+type sqliteMutationEnv struct {
+	client *sqlite.Client
+	roots  queries.Roots
+}
+
+func newSQLiteMutationEnv(
+	t *testing.T, md *metadata.DatabaseMetadata, ddl string, seeds ...string,
+) sqliteMutationEnv {
+	t.Helper()
+
+	sqlite.FlattenMetadata(md)
+
+	client := testdb.NewSQLite(t, ddl, seeds...)
+
+	objects, err := client.Introspect(t.Context(), md)
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+
+	roots, _, err := queries.BuildRoots(objects, md, &dialect.SQLiteDialect{})
+	if err != nil {
+		t.Fatalf("BuildRoots: %v", err)
+	}
+
+	return sqliteMutationEnv{client: client, roots: roots}
+}
+
+func (e sqliteMutationEnv) build(
+	t *testing.T, query, role string, session map[string]any,
+) []core.SQLOperation {
+	t.Helper()
+
+	doc, gqlErr := parser.ParseQuery(&ast.Source{Input: query})
+	if gqlErr != nil {
+		t.Fatalf("ParseQuery: %v", gqlErr)
+	}
+
+	operations, err := e.roots.BuildQuery(doc.Operations[0], doc.Fragments, nil, role, session)
+	if err != nil {
+		t.Fatalf("BuildQuery: %v", err)
+	}
+
+	return operations
+}
+
+func (e sqliteMutationEnv) execute(
+	t *testing.T, query, role string, session map[string]any,
+) (map[string]any, error) {
+	t.Helper()
+
+	return e.client.ExecuteOperations( //nolint:wrapcheck
+		t.Context(), e.build(t, query, role, session), slog.New(slog.DiscardHandler),
+	)
+}
+
+func (e sqliteMutationEnv) mustExecute(
+	t *testing.T, query, role string, session map[string]any,
+) map[string]any {
+	t.Helper()
+
+	results, err := e.execute(t, query, role, session)
+	if err != nil {
+		t.Fatalf("ExecuteOperations: %v", err)
+	}
+
+	decoded := make(map[string]any, len(results))
+
+	for name, result := range results {
+		value, ok := result.(jsontext.Value)
+		if !ok {
+			t.Fatalf("%s result = %T, want jsontext.Value", name, result)
+		}
+
+		var payload any
+		if err := json.Unmarshal(value, &payload); err != nil {
+			t.Fatalf("unmarshal %s: %v", name, err)
+		}
+
+		decoded[name] = payload
+	}
+
+	return decoded
+}
+
+func (e sqliteMutationEnv) read(t *testing.T, query string) map[string]any {
+	t.Helper()
+
+	return e.mustExecute(t, query, "admin", nil)
+}
+
+func authorsMetadata() *metadata.DatabaseMetadata {
+	return &metadata.DatabaseMetadata{
+		Name: "default",
+		Kind: "sqlite",
+		Tables: []metadata.TableMetadata{
+			{
+				Table: metadata.TableSource{Schema: "main", Name: "authors"},
+				ArrayRelationships: []metadata.ArrayRelationship{
+					{
+						Name: "articles",
+						Using: metadata.RelationshipUsing{
+							ForeignKeyConstraint: &metadata.ForeignKeyConstraint{
+								Columns: []string{"author_id"},
+								Table:   metadata.TableSource{Schema: "main", Name: "articles"},
+							},
+						},
+					},
+				},
+			},
+			{
+				Table: metadata.TableSource{Schema: "main", Name: "articles"},
+				ObjectRelationships: []metadata.ObjectRelationship{
+					{
+						Name:  "author",
+						Using: metadata.RelationshipUsing{ForeignKeyColumns: []string{"author_id"}},
+					},
+				},
+				InsertPermissions: []metadata.InsertPermission{
+					{
+						Role: "user",
+						Permission: metadata.InsertPermissionConfig{
+							Columns: []string{"id", "author_id", "title", "published"},
+							Check:   map[string]any{"published": map[string]any{"_eq": false}},
+						},
+					},
+				},
+				SelectPermissions: []metadata.SelectPermission{
+					{
+						Role: "user",
+						Permission: metadata.SelectPermissionConfig{
+							Columns: []string{"id", "title", "published"},
+							Filter:  map[string]any{},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// TestSQLiteUpsertExecutes runs an on_conflict upsert end to end. SQLite has
+// no "ON CONFLICT ON CONSTRAINT <name>" form, so the staged INSERT must target
+// the conflict by column list; the named UNIQUE INDEX gives a deterministic
+// constraint enum value equal to the index name.
+func TestSQLiteUpsertExecutes(t *testing.T) {
+	t.Parallel()
+
+	env := newSQLiteMutationEnv(
+		t, usersMetadata(), sqliteUsersDDL,
+		`INSERT INTO users VALUES ('id-A', 'user-A', 'alice', 'old bio', 'pending');`,
+	)
+
+	const mutation = `
+		mutation {
+		  insert_users_one(
+		    object: {
+		      id: "id-B"
+		      user_id: "user-A"
+		      username: "alice"
+		      bio: "updated bio"
+		      status: "pending"
+		    }
+		    on_conflict: {
+		      constraint: users_username_key
+		      update_columns: [bio, status]
+		    }
+		  ) {
+		    username
+		    bio
+		  }
+		}`
+
+	session := map[string]any{"x-hasura-user-id": "user-A"}
+
+	operations := env.build(t, mutation, "user", session)
+
+	var upsert string
+
+	for _, stage := range operations[0].Stages {
+		if stage.Modifying {
+			upsert = stage.SQL
+		}
+	}
+
+	if !strings.Contains(upsert, `ON CONFLICT ("username") DO UPDATE`) ||
+		strings.Contains(upsert, "ON CONSTRAINT") {
+		t.Errorf("SQLite upsert must target the conflict by column list; got SQL:\n%s", upsert)
+	}
+
+	got := env.mustExecute(t, mutation, "user", session)
+
+	want := map[string]any{
+		"insert_users_one": map[string]any{"username": "alice", "bio": "updated bio"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestSQLiteNestedInsertExecutes covers object- and array-relationship inserts.
+// SQLite has no boolean storage class, so "published" reads back as 0 or 1.
+func TestSQLiteNestedInsertExecutes(t *testing.T) {
+	t.Parallel()
+
+	env := newSQLiteMutationEnv(t, authorsMetadata(), sqliteAuthorsDDL)
+
+	got := env.mustExecute(t, `
+		mutation {
+		  insert_authors(objects: [
+		    {name: "Ada", articles: {data: [{title: "Engines"}, {title: "Notes"}]}}
+		    {name: "Grace", articles: {data: [{title: "Compilers", published: true}]}}
+		  ]) {
+		    affected_rows
+		    returning {
+		      name
+		      created_at
+		      articles(order_by: {title: asc}) { title published }
+		    }
+		  }
+		  insert_articles_one(
+		    object: {title: "Solo", author: {data: {name: "Linus"}}}
+		  ) {
+		    title
+		    author { name }
+		  }
+		}`, "admin", nil)
+
+	want := map[string]any{
+		"insert_authors": map[string]any{
+			"affected_rows": float64(5),
+			"returning": []any{
+				map[string]any{
+					"name":       "Ada",
+					"created_at": "2024-01-02T03:04:05Z",
+					"articles": []any{
+						map[string]any{"title": "Engines", "published": float64(0)},
+						map[string]any{"title": "Notes", "published": float64(0)},
+					},
+				},
+				map[string]any{
+					"name":       "Grace",
+					"created_at": "2024-01-02T03:04:05Z",
+					"articles": []any{
+						map[string]any{"title": "Compilers", "published": float64(1)},
+					},
+				},
+			},
+		},
+		"insert_articles_one": map[string]any{
+			"title":  "Solo",
+			"author": map[string]any{"name": "Linus"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result mismatch (-want +got):\n%s", diff)
+	}
+
+	stored := env.read(t, `query { articles_aggregate { aggregate { count } } }`)
+
+	wantStored := map[string]any{
+		"articles_aggregate": map[string]any{"aggregate": map[string]any{"count": float64(4)}},
+	}
+	if diff := cmp.Diff(wantStored, stored); diff != "" {
+		t.Errorf("stored rows mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestSQLitePermissionCheckRollsBackMutation asserts a failing insert check
+// aborts the request and rolls back the mutations staged before it.
+func TestSQLitePermissionCheckRollsBackMutation(t *testing.T) {
+	t.Parallel()
+
+	env := newSQLiteMutationEnv(
+		t, authorsMetadata(), sqliteAuthorsDDL,
+		`INSERT INTO authors (id, name) VALUES (1, 'Ada');`,
+	)
+
+	_, err := env.execute(t, `
+		mutation {
+		  ok: insert_articles_one(object: {author_id: 1, title: "Draft"}) { id }
+		  denied: insert_articles(objects: [
+		    {author_id: 1, title: "Fine"}
+		    {author_id: 1, title: "Leak", published: true}
+		  ]) { affected_rows }
+		}`, "user", nil)
+	if err == nil || !strings.Contains(err.Error(), "check constraint of an insert/update permission has failed") {
+		t.Fatalf("ExecuteOperations error = %v, want insert permission check failure", err)
+	}
+
+	stored := env.read(t, `query { articles_aggregate { aggregate { count } } }`)
+
+	want := map[string]any{
+		"articles_aggregate": map[string]any{"aggregate": map[string]any{"count": float64(0)}},
+	}
+	if diff := cmp.Diff(want, stored); diff != "" {
+		t.Errorf("a failed check must roll back every mutation (-want +got):\n%s", diff)
+	}
+}
+
+func TestSQLiteUpdateAndDeleteExecute(t *testing.T) {
+	t.Parallel()
+
+	env := newSQLiteMutationEnv(
+		t, authorsMetadata(), sqliteAuthorsDDL,
+		`INSERT INTO authors (id, name, created_at) VALUES
+			(1, 'Ada', '1843-10-01 00:00:00'), (2, 'Grace', '1952-05-01 00:00:00');
+		 INSERT INTO articles (id, author_id, title) VALUES
+			(1, 1, 'Engines'), (2, 1, 'Notes'), (3, 2, 'Compilers');`,
+	)
+
+	got := env.mustExecute(t, `
+		mutation {
+		  update_articles_many(updates: [
+		    {where: {author_id: {_eq: 1}}, _set: {published: true}}
+		    {where: {published: {_eq: true}}, _set: {title: "Published"}}
+		  ]) {
+		    affected_rows
+		  }
+		  update_authors_by_pk(pk_columns: {id: 2}, _set: {name: "Grace Hopper"}) {
+		    name
+		    created_at
+		  }
+		  delete_articles(where: {author_id: {_eq: 2}}) {
+		    affected_rows
+		    returning { title author { name } }
+		  }
+		}`, "admin", nil)
+
+	want := map[string]any{
+		"update_articles_many": []any{
+			map[string]any{"affected_rows": float64(2)},
+			map[string]any{"affected_rows": float64(2)},
+		},
+		"update_authors_by_pk": map[string]any{
+			"name":       "Grace Hopper",
+			"created_at": "1952-05-01 00:00:00",
+		},
+		"delete_articles": map[string]any{
+			"affected_rows": float64(1),
+			"returning": []any{
+				map[string]any{
+					"title":  "Compilers",
+					"author": map[string]any{"name": "Grace Hopper"},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result mismatch (-want +got):\n%s", diff)
+	}
+}
+
+const sqliteItemsDDL = `CREATE TABLE items (
+	id integer PRIMARY KEY,
+	price integer NOT NULL,
+	qty integer NOT NULL DEFAULT 1,
+	total integer GENERATED ALWAYS AS (price * qty) STORED,
+	label text GENERATED ALWAYS AS ('item ' || id) VIRTUAL
+);
+CREATE VIEW item_prices AS SELECT id, price, qty FROM items;
+CREATE TRIGGER item_prices_insert INSTEAD OF INSERT ON item_prices BEGIN
+	INSERT INTO items (id, price, qty) VALUES (NEW.id, NEW.price, coalesce(NEW.qty, 1));
+END;
+CREATE TRIGGER item_prices_update INSTEAD OF UPDATE ON item_prices BEGIN
+	UPDATE items SET price = NEW.price, qty = NEW.qty WHERE id = OLD.id;
+END;
+CREATE TRIGGER item_prices_delete INSTEAD OF DELETE ON item_prices BEGIN
+	DELETE FROM items WHERE id = OLD.id;
+END;`
+
+func itemsMetadata() *metadata.DatabaseMetadata {
+	return &metadata.DatabaseMetadata{
+		Name: "default",
+		Kind: "sqlite",
+		Tables: []metadata.TableMetadata{
+			{Table: metadata.TableSource{Schema: "main", Name: "items"}},
+			{Table: metadata.TableSource{Schema: "main", Name: "item_prices"}},
+		},
+	}
+}
+
+// TestSQLiteGeneratedColumnsExecute writes a table with STORED and VIRTUAL
+// generated columns: the generated values are left to SQLite and read back
+// from RETURNING after both the insert and the update.
+func TestSQLiteGeneratedColumnsExecute(t *testing.T) {
+	t.Parallel()
+
+	env := newSQLiteMutationEnv(t, itemsMetadata(), sqliteItemsDDL)
+
+	got := env.mustExecute(t, `
+		mutation {
+		  insert_items_one(object: {id: 1, price: 3, qty: 2}) {
+		    total
+		    label
+		  }
+		  update_items_by_pk(pk_columns: {id: 1}, _set: {qty: 5}) {
+		    total
+		  }
+		}`, "admin", nil)
+
+	want := map[string]any{
+		"insert_items_one":   map[string]any{"total": float64(6), "label": "item 1"},
+		"update_items_by_pk": map[string]any{"total": float64(15)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestSQLiteInsteadOfTriggerViewExecutes writes through a view backed by
+// INSTEAD OF triggers. SQLite runs the trigger for each row and RETURNING
+// reports the row as written to the view.
+func TestSQLiteInsteadOfTriggerViewExecutes(t *testing.T) {
+	t.Parallel()
+
+	env := newSQLiteMutationEnv(
+		t, itemsMetadata(), sqliteItemsDDL,
+		`INSERT INTO items (id, price, qty) VALUES (1, 10, 1);`,
+	)
+
+	got := env.mustExecute(t, `
+		mutation {
+		  insert_item_prices(objects: [{id: 2, price: 4, qty: 3}]) {
+		    affected_rows
+		    returning { id price }
+		  }
+		  update_item_prices(where: {id: {_eq: 2}}, _set: {price: 9}) {
+		    affected_rows
+		    returning { id price }
+		  }
+		  delete_item_prices(where: {id: {_eq: 1}}) {
+		    affected_rows
+		  }
+		}`, "admin", nil)
+
+	want := map[string]any{
+		"insert_item_prices": map[string]any{
+			"affected_rows": float64(1),
+			"returning":     []any{map[string]any{"id": float64(2), "price": float64(4)}},
+		},
+		"update_item_prices": map[string]any{
+			"affected_rows": float64(1),
+			"returning":     []any{map[string]any{"id": float64(2), "price": float64(9)}},
+		},
+		"delete_item_prices": map[string]any{"affected_rows": float64(1)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result mismatch (-want +got):\n%s", diff)
+	}
+
+	got = env.read(t, `query { items { id total } }`)
+
+	want = map[string]any{
+		"items": []any{map[string]any{"id": float64(2), "total": float64(27)}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("base table mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,142 @@
+package queries
+
+import (
+	"strings"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+)
+
+// mutationBuilder is the builder mutation SQL is written to. Besides the
+// text, it records every top-level CTE of the statement's WITH clause, so a
+// dialect without data-modifying CTEs can run each of them as a separate
+// statement (see table.mutationOperation) without parsing the SQL back.
+//
+// CTEs are opened with openCTE or openModifyingCTE, which write "name AS (",
+// and closed with closeCTE or closeCTEWithoutParams, which write ")".
+// Parameters are appended in the order their placeholders appear in the text,
+// so a CTE owns the parameters appended since the previous CTE was closed.
+type mutationBuilder struct {
+	strings.Builder
+
+	ctes []mutationCTE
+}
+
+// mutationCTE is a CTE recorded by mutationBuilder.
+type mutationCTE struct {
+	name      string
+	modifying bool
+	// start and end delimit the CTE body, between its parentheses.
+	start int
+	end   int
+	// params is the length of the parameter list when the CTE was closed, or
+	// noCTEParams when the CTE has no placeholders.
+	params int
+}
+
+const noCTEParams = -1
+
+// openCTE writes the head of a CTE whose body is a SELECT.
+func (b *mutationBuilder) openCTE(name string) {
+	b.open(name, false)
+}
+
+// openModifyingCTE writes the head of a CTE whose body is an INSERT, UPDATE or
+// DELETE ... RETURNING statement.
+func (b *mutationBuilder) openModifyingCTE(name string) {
+	b.open(name, true)
+}
+
+func (b *mutationBuilder) open(name string, modifying bool) {
+	b.WriteString(name)
+	b.WriteString(" AS (")
+	b.ctes = append(b.ctes, mutationCTE{
+		name:      name,
+		modifying: modifying,
+		start:     b.Len(),
+		end:       0,
+		params:    0,
+	})
+}
+
+// closeCTE closes the CTE opened last; params is the parameter list with the
+// CTE's own parameters appended.
+func (b *mutationBuilder) closeCTE(params []any) {
+	cte := &b.ctes[len(b.ctes)-1]
+	cte.end = b.Len()
+	cte.params = len(params)
+
+	b.WriteByte(')')
+}
+
+// closeCTEWithoutParams closes the CTE opened last, which has no placeholders.
+func (b *mutationBuilder) closeCTEWithoutParams() {
+	cte := &b.ctes[len(b.ctes)-1]
+	cte.end = b.Len()
+	cte.params = noCTEParams
+
+	b.WriteByte(')')
+}
+
+// writeCTEs appends the SQL and the CTEs written to other, which was built
+// with the same parameter list as b.
+func (b *mutationBuilder) writeCTEs(other *mutationBuilder) {
+	offset := b.Len()
+
+	b.WriteString(other.String())
+
+	for _, cte := range other.ctes {
+		cte.start += offset
+		cte.end += offset
+		b.ctes = append(b.ctes, cte)
+	}
+}
+
+// mutationOperation returns the operation for the mutation SQL written to b.
+// Dialects that cannot run data-modifying CTEs (see
+// dialect.Dialect.SupportsDataModifyingCTEs) get every CTE as a stage the
+// driver executes into a temporary table of the same name, and the statement
+// after the WITH clause as the operation's SQL: it reads the temporary tables
+// exactly as it read the CTEs.
+func (t *table) mutationOperation(
+	name string,
+	b *mutationBuilder,
+	params []any,
+) core.SQLOperation {
+	op := core.SQLOperation{
+		Name:          name,
+		SQL:           b.String(),
+		Parameters:    params,
+		StreamCursors: nil,
+		Sequential:    nil,
+		Stages:        nil,
+	}
+
+	if t.dialect.SupportsDataModifyingCTEs() || len(b.ctes) == 0 {
+		return op
+	}
+
+	sql := op.SQL
+	first := 0
+
+	op.Stages = make([]core.SQLStage, len(b.ctes))
+	for i, cte := range b.ctes {
+		last := cte.params
+		if last == noCTEParams {
+			last = first
+		}
+
+		op.Stages[i] = core.SQLStage{
+			Name:       cte.name,
+			SQL:        sql[cte.start:cte.end],
+			Parameters: params[first:last:last],
+			Modifying:  cte.modifying,
+		}
+		first = last
+	}
+
+	tail := b.ctes[len(b.ctes)-1].end + len(")")
+	op.SQL = strings.TrimLeft(sql[tail:], " ")
+	op.Parameters = params[first:]
+
+	return op
+}
@@ -0,0 +1,142 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+)
+
+// writeStagedTestMutation writes a two-CTE mutation whose text contains
+// placeholder and parenthesis characters inside literals, identifiers and
+// comments, none of which may affect how it is staged.
+func writeStagedTestMutation(b *mutationBuilder) []any {
+	params := []any{"a"}
+
+	b.WriteString("WITH ")
+	b.openCTE("insert_data")
+	b.WriteString(`SELECT ? AS "a?", '?(' AS "b" /* ) ? */`)
+	b.closeCTE(params)
+	b.WriteString(", ")
+	b.openModifyingCTE("mutation_result")
+	b.WriteString(`INSERT INTO "t" ("a?") SELECT "a?" FROM insert_data RETURNING *`)
+	b.closeCTEWithoutParams()
+	b.WriteString(" SELECT json_object('n', (SELECT COUNT(*) FROM mutation_result), 'x', ?)")
+
+	return append(params, "x")
+}
+
+func TestMutationOperation(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		dialect dialect.Dialect
+		want    core.SQLOperation
+	}{
+		{
+			name:    "data-modifying CTEs keep one statement",
+			dialect: &dialect.PostgresDialect{},
+			want: core.SQLOperation{
+				Name: "m",
+				SQL: `WITH insert_data AS (SELECT ? AS "a?", '?(' AS "b" /* ) ? */), ` +
+					`mutation_result AS (INSERT INTO "t" ("a?") SELECT "a?" FROM insert_data RETURNING *) ` +
+					`SELECT json_object('n', (SELECT COUNT(*) FROM mutation_result), 'x', ?)`,
+				Parameters:    []any{"a", "x"},
+				StreamCursors: nil,
+				Sequential:    nil,
+				Stages:        nil,
+			},
+		},
+		{
+			name:    "CTEs become stages",
+			dialect: &dialect.SQLiteDialect{},
+			want: core.SQLOperation{
+				Name:          "m",
+				SQL:           "SELECT json_object('n', (SELECT COUNT(*) FROM mutation_result), 'x', ?)",
+				Parameters:    []any{"x"},
+				StreamCursors: nil,
+				Sequential:    nil,
+				Stages: []core.SQLStage{
+					{
+						Name:       "insert_data",
+						SQL:        `SELECT ? AS "a?", '?(' AS "b" /* ) ? */`,
+						Parameters: []any{"a"},
+						Modifying:  false,
+					},
+					{
+						Name:       "mutation_result",
+						SQL:        `INSERT INTO "t" ("a?") SELECT "a?" FROM insert_data RETURNING *`,
+						Parameters: []any{},
+						Modifying:  true,
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tbl := newTable("main", "t", tc.dialect)
+
+			var b mutationBuilder
+
+			params := writeStagedTestMutation(&b)
+
+			got := tbl.mutationOperation("m", &b, params)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("mutationOperation() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMutationBuilderWriteCTEs(t *testing.T) {
+	t.Parallel()
+
+	tbl := newTable("main", "t", &dialect.SQLiteDialect{})
+
+	var nested mutationBuilder
+
+	nested.openModifyingCTE("nested_author")
+	nested.WriteString("INSERT INTO authors (name) VALUES (?) RETURNING *")
+	nested.closeCTE([]any{"Ada"})
+
+	var b mutationBuilder
+
+	b.WriteString("WITH ")
+	b.writeCTEs(&nested)
+	b.WriteString(", ")
+	b.openModifyingCTE("mutation_result")
+	b.WriteString("INSERT INTO articles (author_id, title) SELECT id, ? FROM nested_author RETURNING *")
+	b.closeCTE([]any{"Ada", "Notes"})
+	b.WriteString(" SELECT COUNT(*) FROM mutation_result")
+
+	got := tbl.mutationOperation("m", &b, []any{"Ada", "Notes"})
+
+	want := []core.SQLStage{
+		{
+			Name:       "nested_author",
+			SQL:        "INSERT INTO authors (name) VALUES (?) RETURNING *",
+			Parameters: []any{"Ada"},
+			Modifying:  true,
+		},
+		{
+			Name:       "mutation_result",
+			SQL:        "INSERT INTO articles (author_id, title) SELECT id, ? FROM nested_author RETURNING *",
+			Parameters: []any{"Notes"},
+			Modifying:  true,
+		},
+	}
+	if diff := cmp.Diff(want, got.Stages); diff != "" {
+		t.Errorf("Stages mismatch (-want +got):\n%s", diff)
+	}
+
+	if got.SQL != "SELECT COUNT(*) FROM mutation_result" {
+		t.Errorf("SQL = %q", got.SQL)
+	}
+}
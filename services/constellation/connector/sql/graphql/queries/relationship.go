@@ -495,7 +495,7 @@ func buildJoinCondition(
 		// Reverse: FK lives on the target table. Pair each FK column on the
 		// target with the matching column on the parent via the introspected
 		// FK metadata of the target table.
-		return buildReverseJoin(using, fkColumns, objects)
+		return buildReverseJoin(using, fkColumns, parentTable, objects)
 	case isArray:
 		// Array forward (rare): treat each FK column as pointing at the
 		// parent's matching primary key column. The introspection emitter
@@ -527,15 +527,17 @@ func buildJoinCondition(
 }
 
 // pairForwardColumns returns the parent-side and target-side column lists for
-// a forward FK relationship. Each entry in fkColumns is matched against the
-// parent table's introspected ForeignKeys; the target column is read off the
-// matching entry.
+// a forward FK relationship. fkColumns is resolved against the parent table's
+// introspected foreign keys as a whole (see
+// (*introspection.Table).ForwardForeignKey), so each column of a composite key
+// is paired through the constraint that covers all of them; the target column
+// is read off the matching entry.
 //
 // Callers are expected to validate the target table's existence (and, in the
-// forward branch, that every fkColumn agrees on the same target) before
-// invoking this function — typically via getRelationshipTarget /
-// (*introspection.Table).LookupForwardFKTarget. An unmatched fkColumn would
-// emit a pair of (parentCol, "") whose downstream rendering through
+// forward branch, that the columns resolve to one target) before invoking
+// this function — typically via getRelationshipTarget /
+// (*introspection.Table).LookupForwardFKTarget. An unresolved fkColumns
+// pairs each parent column with "", whose downstream rendering through
 // core.WriteQualifiedColumn / core.WriteQuotedIdentifier is malformed SQL
 // (an empty quoted identifier `""`), so reaching that state indicates a
 // metadata/introspection invariant violation rather than a graceful
@@ -548,22 +550,11 @@ func pairForwardColumns(
 		return nil, nil
 	}
 
-	parentCols := make([]string, 0, len(fkColumns))
-	targetCols := make([]string, 0, len(fkColumns))
-
-	for _, col := range fkColumns {
-		parentCols = append(parentCols, col)
-
-		var matched string
-
-		for _, fk := range parentTable.ForeignKeys {
-			if fk.ColumnName == col {
-				matched = fk.ForeignColumnName
-				break
-			}
-		}
+	parentCols := append([]string(nil), fkColumns...)
+	targetCols := make([]string, len(fkColumns))
 
-		targetCols = append(targetCols, matched)
+	for i, fk := range parentTable.ForwardForeignKey(fkColumns) {
+		targetCols[i] = fk.ForeignColumnName
 	}
 
 	return parentCols, targetCols
@@ -571,16 +562,18 @@ func pairForwardColumns(
 
 // buildReverseJoin pairs reverse-FK columns: the columns named in
 // ForeignKeyConstraint.Columns live on the target table; their counterparts
-// on the parent are read from the target table's introspected ForeignKeys
-// (those whose ForeignTable/ForeignSchema point back at the parent). Returns
-// errRelationshipReverseFKColumnUnmatched when a configured FK column has no
-// corresponding introspection entry — emitting an empty parent column there
-// would render as `"alias".""` and fail at execution time, so the caller
-// surfaces the inconsistency at construction time and reconcile drops the
-// relationship.
+// on the parent are read from the target table's introspected foreign key
+// over those columns that references the parent (a composite key is matched
+// as a whole, see (*introspection.Table).ReferencingForeignKey). A nil
+// parentTable accepts a foreign key to any table. Returns
+// errRelationshipReverseFKColumnUnmatched when no such foreign key exists —
+// emitting an empty parent column there would render as `"alias".""` and
+// fail at execution time, so the caller surfaces the inconsistency at
+// construction time and reconcile drops the relationship.
 func buildReverseJoin(
 	using metadata.RelationshipUsing,
 	fkColumns []string,
+	parentTable *introspection.Table,
 	objects *introspection.Objects,
 ) ([]string, []string, []string, bool, error) {
 	if using.ForeignKeyConstraint == nil || len(fkColumns) == 0 {
@@ -601,35 +594,30 @@ func buildReverseJoin(
 			)
 	}
 
-	parentCols := make([]string, 0, len(fkColumns))
-	targetCols := make([]string, 0, len(fkColumns))
-
-	for _, col := range fkColumns {
-		var matched string
-
-		for _, fk := range targetTable.ForeignKeys {
-			if fk.ColumnName == col {
-				matched = fk.ForeignColumnName
-				break
-			}
-		}
+	var fks []introspection.ForeignKey
+	if parentTable != nil {
+		fks = targetTable.ReferencingForeignKey(fkColumns, parentTable.Schema, parentTable.Name)
+	} else {
+		fks = targetTable.ForwardForeignKey(fkColumns)
+	}
 
-		if matched == "" {
-			return nil, nil, nil, true,
-				fmt.Errorf(
-					"%w: %s.%s.%s",
-					errRelationshipReverseFKColumnUnmatched,
-					targetSchema,
-					targetTableName,
-					col,
-				)
-		}
+	if fks == nil {
+		return nil, nil, nil, true,
+			fmt.Errorf(
+				"%w: %s.%s.%s",
+				errRelationshipReverseFKColumnUnmatched,
+				targetSchema,
+				targetTableName,
+				strings.Join(fkColumns, ","),
+			)
+	}
 
-		parentCols = append(parentCols, matched)
-		targetCols = append(targetCols, col)
+	parentCols := make([]string, 0, len(fkColumns))
+	for _, fk := range fks {
+		parentCols = append(parentCols, fk.ForeignColumnName)
 	}
 
-	return fkColumns, parentCols, targetCols, true, nil
+	return fkColumns, parentCols, append([]string(nil), fkColumns...), true, nil
 }
 
 // getRelationshipTarget resolves the schema-qualified name of the relationship
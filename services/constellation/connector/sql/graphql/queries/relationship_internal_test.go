@@ -3,6 +3,7 @@ package queries
 import (
 	"errors"
 	"maps"
+	"slices"
 	"strings"
 	"testing"
 
@@ -293,6 +294,7 @@ func TestBuildReverseJoinUnmatchedColumn(t *testing.T) {
 				IsView:            false,
 				IsInsertable:      true,
 				IsUpdatable:       true,
+				IsDeletable:       true,
 			},
 		},
 	}
@@ -310,7 +312,7 @@ func TestBuildReverseJoinUnmatchedColumn(t *testing.T) {
 	}
 
 	fk, parentCols, targetCols, _, err := buildReverseJoin(
-		using, []string{"user_id"}, objects,
+		using, []string{"user_id"}, usersTable(), objects,
 	)
 	if err == nil {
 		t.Fatal("buildReverseJoin: expected error for unmatched column, got nil")
@@ -360,6 +362,7 @@ func TestBuildReverseJoinMatchedColumn(t *testing.T) {
 				IsView:            false,
 				IsInsertable:      true,
 				IsUpdatable:       true,
+				IsDeletable:       true,
 			},
 		},
 	}
@@ -377,7 +380,7 @@ func TestBuildReverseJoinMatchedColumn(t *testing.T) {
 	}
 
 	_, parentCols, targetCols, isReversed, err := buildReverseJoin(
-		using, []string{"user_id"}, objects,
+		using, []string{"user_id"}, usersTable(), objects,
 	)
 	if err != nil {
 		t.Fatalf("buildReverseJoin: unexpected error: %v", err)
@@ -490,3 +493,136 @@ func TestBuildJoinConditionForSelection_PercentInColumnName(t *testing.T) {
 		})
 	}
 }
+
+func usersTable() *introspection.Table {
+	return &introspection.Table{Schema: "public", Name: "users"}
+}
+
+// tenantScopedObjects returns tenant-scoped tables whose foreign keys all
+// start with tenant_id: orders references both customers and products through
+// composite keys, with the products key listed first so a column-by-column
+// lookup of tenant_id would land on the wrong target.
+func tenantScopedObjects() *introspection.Objects {
+	objects := introspection.NewObjects()
+	objects.Schemas["public"] = &introspection.Schema{
+		Tables: map[string]*introspection.Table{
+			"customers": {Schema: "public", Name: "customers"},
+			"products":  {Schema: "public", Name: "products"},
+			"orders": {
+				Schema: "public",
+				Name:   "orders",
+				ForeignKeys: []introspection.ForeignKey{
+					{
+						ConstraintName: "orders_product_fkey", ColumnName: "tenant_id",
+						ForeignSchema: "public", ForeignTable: "products", ForeignColumnName: "tenant_id",
+					},
+					{
+						ConstraintName: "orders_product_fkey", ColumnName: "product_id",
+						ForeignSchema: "public", ForeignTable: "products", ForeignColumnName: "id",
+					},
+					{
+						ConstraintName: "orders_customer_fkey", ColumnName: "tenant_id",
+						ForeignSchema: "public", ForeignTable: "customers", ForeignColumnName: "tenant_id",
+					},
+					{
+						ConstraintName: "orders_customer_fkey", ColumnName: "customer_id",
+						ForeignSchema: "public", ForeignTable: "customers", ForeignColumnName: "id",
+					},
+				},
+			},
+		},
+	}
+
+	return objects
+}
+
+func TestBuildJoinConditionCompositeForeignKey(t *testing.T) {
+	t.Parallel()
+
+	objects := tenantScopedObjects()
+	orders, _ := objects.GetTable("public", "orders")
+	customers, _ := objects.GetTable("public", "customers")
+
+	tests := []struct {
+		name         string
+		using        metadata.RelationshipUsing
+		isArray      bool
+		parent       *introspection.Table
+		wantParent   []string
+		wantTarget   []string
+		wantReversed bool
+	}{
+		{
+			name: "forward object relationship",
+			using: metadata.RelationshipUsing{
+				ForeignKeyColumns: []string{"tenant_id", "customer_id"},
+			},
+			parent:       orders,
+			wantParent:   []string{"tenant_id", "customer_id"},
+			wantTarget:   []string{"tenant_id", "id"},
+			wantReversed: false,
+		},
+		{
+			name: "reverse array relationship",
+			using: metadata.RelationshipUsing{
+				ForeignKeyConstraint: &metadata.ForeignKeyConstraint{
+					Columns: []string{"customer_id", "tenant_id"},
+					Table:   metadata.TableSource{Schema: "public", Name: "orders"},
+				},
+			},
+			isArray:      true,
+			parent:       customers,
+			wantParent:   []string{"id", "tenant_id"},
+			wantTarget:   []string{"customer_id", "tenant_id"},
+			wantReversed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, parentCols, targetCols, reversed, err := buildJoinCondition(
+				tt.using, tt.isArray, tt.parent, objects,
+			)
+			if err != nil {
+				t.Fatalf("buildJoinCondition: unexpected error: %v", err)
+			}
+
+			if !slices.Equal(parentCols, tt.wantParent) {
+				t.Errorf("parentCols = %v, want %v", parentCols, tt.wantParent)
+			}
+
+			if !slices.Equal(targetCols, tt.wantTarget) {
+				t.Errorf("targetCols = %v, want %v", targetCols, tt.wantTarget)
+			}
+
+			if reversed != tt.wantReversed {
+				t.Errorf("reversed = %v, want %v", reversed, tt.wantReversed)
+			}
+		})
+	}
+}
+
+// TestBuildReverseJoinRejectsForeignKeyToOtherTable pins that a reverse
+// relationship only pairs through a foreign key referencing the parent: the
+// orders columns (tenant_id, product_id) form a key to products, so they
+// cannot back a relationship from customers.
+func TestBuildReverseJoinRejectsForeignKeyToOtherTable(t *testing.T) {
+	t.Parallel()
+
+	objects := tenantScopedObjects()
+	customers, _ := objects.GetTable("public", "customers")
+
+	using := metadata.RelationshipUsing{
+		ForeignKeyConstraint: &metadata.ForeignKeyConstraint{
+			Columns: []string{"tenant_id", "product_id"},
+			Table:   metadata.TableSource{Schema: "public", Name: "orders"},
+		},
+	}
+
+	_, _, _, _, err := buildReverseJoin(using, using.ForeignKeyConstraint.Columns, customers, objects)
+	if !errors.Is(err, errRelationshipReverseFKColumnUnmatched) {
+		t.Errorf("expected errRelationshipReverseFKColumnUnmatched, got %v", err)
+	}
+}
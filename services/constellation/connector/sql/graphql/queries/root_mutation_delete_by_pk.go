@@ -2,7 +2,6 @@ package queries
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
@@ -39,32 +38,21 @@ func (t *table) buildMutationDeleteByPkSQL(
 		return core.SQLOperation{}, fmt.Errorf("failed to parse selection set: %w", err)
 	}
 
-	b := getBuilder()
+	var b mutationBuilder
 
 	params, err := t.buildDeleteByPkSQL(
-		b, field, whereClause, columns, relationships,
+		&b, field, whereClause, columns, relationships,
 		fragments, variables, role, sessionVariables, roots,
 	)
 	if err != nil {
-		putBuilder(b)
-
 		return core.SQLOperation{}, fmt.Errorf("failed to build DELETE BY PK SQL: %w", err)
 	}
 
-	sql := b.String()
-	putBuilder(b)
-
-	return core.SQLOperation{
-		Name:          alias,
-		SQL:           sql,
-		Parameters:    params,
-		StreamCursors: nil,
-		Sequential:    nil,
-	}, nil
+	return t.mutationOperation(alias, &b, params), nil
 }
 
 func (t *table) buildDeleteByPkSQL(
-	b *strings.Builder,
+	b *mutationBuilder,
 	field *ast.Field,
 	whereClause where.Clause,
 	columns []columnSelection,
@@ -101,7 +89,7 @@ func (t *table) buildDeleteByPkSQL(
 	b.WriteString(" ")
 
 	params, err = t.buildFinalSelect(
-		b,
+		&b.Builder,
 		columns,
 		relationships,
 		nil,
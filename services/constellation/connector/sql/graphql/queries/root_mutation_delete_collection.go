@@ -2,7 +2,6 @@ package queries
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
@@ -43,10 +42,10 @@ func (t *table) buildMutationDeleteCollectionSQL(
 		return core.SQLOperation{}, fmt.Errorf("failed to parse selection set: %w", err)
 	}
 
-	b := getBuilder()
+	var b mutationBuilder
 
 	params, err := t.buildDeleteCollectionSQL(
-		b,
+		&b,
 		whereClause,
 		selection,
 		fragments,
@@ -56,27 +55,16 @@ func (t *table) buildMutationDeleteCollectionSQL(
 		roots,
 	)
 	if err != nil {
-		putBuilder(b)
-
 		return core.SQLOperation{}, fmt.Errorf("failed to build DELETE SQL: %w", err)
 	}
 
-	sql := b.String()
-	putBuilder(b)
-
-	return core.SQLOperation{
-		Name:          alias,
-		SQL:           sql,
-		Parameters:    params,
-		StreamCursors: nil,
-		Sequential:    nil,
-	}, nil
+	return t.mutationOperation(alias, &b, params), nil
 }
 
 // buildDeleteCTEBody builds just the CTE body for a DELETE (without "WITH " prefix).
 // Returns: "cteName AS (DELETE FROM ... WHERE ... RETURNING *)".
 func (t *table) buildDeleteCTEBody(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	whereClause where.Clause,
 	role string,
@@ -84,8 +72,8 @@ func (t *table) buildDeleteCTEBody(
 	params []any,
 	paramIndex int,
 ) ([]any, int, error) {
-	b.WriteString(cteName)
-	b.WriteString(" AS (DELETE FROM ")
+	b.openModifyingCTE(cteName)
+	b.WriteString("DELETE FROM ")
 	b.WriteString(t.tableFromClause())
 	b.WriteString(" WHERE ")
 
@@ -94,7 +82,7 @@ func (t *table) buildDeleteCTEBody(
 	// Apply the user's WHERE clause
 	if len(whereClause) > 0 {
 		params, paramIndex, err = whereClause.WriteCondition(
-			b, t.tableName, params, paramIndex,
+			&b.Builder, t.tableName, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to write WHERE clause: %w", err)
@@ -108,7 +96,7 @@ func (t *table) buildDeleteCTEBody(
 		b.WriteString(" AND (")
 
 		params, paramIndex, _, err = t.permissions.WriteDeleteFilter(
-			b, params, paramIndex, role, sessionVariables, t.tableName,
+			&b.Builder, params, paramIndex, role, sessionVariables, t.tableName,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to apply delete permissions: %w", err)
@@ -117,14 +105,15 @@ func (t *table) buildDeleteCTEBody(
 		b.WriteString(")")
 	}
 
-	b.WriteString(" RETURNING *)")
+	t.writeReturningAll(&b.Builder)
+	b.closeCTE(params)
 
 	return params, paramIndex, nil
 }
 
 // buildDeleteCollectionSQL builds the complete DELETE query with CTEs for permissions.
 func (t *table) buildDeleteCollectionSQL(
-	b *strings.Builder,
+	b *mutationBuilder,
 	whereClause where.Clause,
 	selection mutationSelection,
 	fragments ast.FragmentDefinitionList,
@@ -156,7 +145,7 @@ func (t *table) buildDeleteCollectionSQL(
 	b.WriteString(" ")
 
 	params, _, err = selection.WriteSQL(
-		b, fragments, variables, role, sessionVariables, roots, params, paramIndex,
+		&b.Builder, fragments, variables, role, sessionVariables, roots, params, paramIndex,
 	)
 	if err != nil {
 		return nil, err
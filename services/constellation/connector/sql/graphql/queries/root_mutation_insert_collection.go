@@ -37,10 +37,10 @@ func (t *table) buildMutationInsertCollectionSQL(
 		return core.SQLOperation{}, fmt.Errorf("failed to parse selection set: %w", err)
 	}
 
-	b := getBuilder()
+	var b mutationBuilder
 
 	params, err := t.buildInsertCollectionSQL(
-		b,
+		&b,
 		insertObjs,
 		onConflict,
 		selection,
@@ -51,27 +51,16 @@ func (t *table) buildMutationInsertCollectionSQL(
 		roots,
 	)
 	if err != nil {
-		putBuilder(b)
-
 		return core.SQLOperation{}, fmt.Errorf("failed to build insert query: %w", err)
 	}
 
-	sql := b.String()
-	putBuilder(b)
-
-	return core.SQLOperation{
-		Name:          alias,
-		SQL:           sql,
-		Parameters:    params,
-		StreamCursors: nil,
-		Sequential:    nil,
-	}, nil
+	return t.mutationOperation(alias, &b, params), nil
 }
 
 // buildInsertCollectionSQL reuses buildInsertMutationCTE and wraps it with the
 // {affected_rows, returning} response selection.
 func (t *table) buildInsertCollectionSQL(
-	b *strings.Builder,
+	b *mutationBuilder,
 	insertObjs []arguments.InsertObject,
 	onConflict *arguments.OnConflict,
 	selection mutationSelection,
@@ -84,7 +73,8 @@ func (t *table) buildInsertCollectionSQL(
 	params := make([]any, 0, len(insertObjs)*8) //nolint:mnd
 	paramIndex := 1
 
-	cteSQL, params, paramIndex, nestedCTERefs, err := t.buildInsertMutationCTE(
+	params, paramIndex, nestedCTERefs, err := t.buildInsertMutationCTE(
+		b,
 		insertObjs,
 		onConflict,
 		role,
@@ -96,7 +86,6 @@ func (t *table) buildInsertCollectionSQL(
 		return nil, err
 	}
 
-	b.WriteString(cteSQL)
 	b.WriteString(" ")
 
 	// The two force-ref sites cover non-overlapping selection shapes, not
@@ -118,7 +107,7 @@ func (t *table) buildInsertCollectionSQL(
 	}
 
 	params, _, err = selection.WriteSQL(
-		b,
+		&b.Builder,
 		fragments,
 		variables,
 		role,
@@ -222,19 +211,33 @@ func (t *table) buildInsertWhereClause(
 	b *strings.Builder,
 	cteName string,
 	hasCheckPermissions bool,
+	onConflict *arguments.OnConflict,
 ) {
-	if hasCheckPermissions {
-		checkCountCTEName := cteName + "_check_count"
+	if !hasCheckPermissions {
+		t.writeUpsertSourceFilter(b, onConflict)
 
-		b.WriteString(" WHERE (SELECT status FROM ")
-		b.WriteString(checkCountCTEName)
-		b.WriteString(") = 1")
+		return
+	}
+
+	checkCountCTEName := cteName + "_check_count"
+
+	b.WriteString(" WHERE (SELECT status FROM ")
+	b.WriteString(checkCountCTEName)
+	b.WriteString(") = 1")
+}
+
+// writeUpsertSourceFilter writes an always-true WHERE for an INSERT ... SELECT
+// that has no filter of its own but is followed by ON CONFLICT, on dialects
+// that need one (see dialect.Dialect.RequiresUpsertSourceFilter).
+func (t *table) writeUpsertSourceFilter(b *strings.Builder, onConflict *arguments.OnConflict) {
+	if onConflict != nil && t.dialect.RequiresUpsertSourceFilter() {
+		b.WriteString(" WHERE true")
 	}
 }
 
 // buildInsertCTE builds the INSERT INTO ... RETURNING CTE.
 func (t *table) buildInsertCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	checkCountBaseName string,
 	insertObj arguments.InsertObject,
@@ -248,30 +251,30 @@ func (t *table) buildInsertCTE(
 	params []any,
 	paramIndex int,
 ) ([]any, int, error) {
-	b.WriteString(cteName)
-	b.WriteString(" AS (INSERT INTO ")
+	b.openModifyingCTE(cteName)
+	b.WriteString("INSERT INTO ")
 	b.WriteString(t.tableFromClause())
 
 	columnNames := insertObj.ColumnNames()
 
-	t.buildInsertColumnsClause(b, columnNames)
-	t.buildInsertSelectClause(b, columnNames, checkCTEName, nestedFKIndex)
-	t.buildInsertFromClause(b, checkCTEName, nestedFKIndex)
-	t.buildInsertWhereClause(b, checkCountBaseName, hasCheckPermissions)
+	t.buildInsertColumnsClause(&b.Builder, columnNames)
+	t.buildInsertSelectClause(&b.Builder, columnNames, checkCTEName, nestedFKIndex)
+	t.buildInsertFromClause(&b.Builder, checkCTEName, nestedFKIndex)
+	t.buildInsertWhereClause(&b.Builder, checkCountBaseName, hasCheckPermissions, onConflict)
 
 	if onConflict != nil {
 		var err error
 
 		params, paramIndex, err = t.writeOnConflictSQL(
-			b, onConflict, role, sessionVariables, params, paramIndex,
+			&b.Builder, onConflict, role, sessionVariables, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, err
 		}
 	}
 
-	t.writeInsertReturning(b, plan)
-	b.WriteByte(')')
+	t.writeInsertReturning(&b.Builder, plan)
+	b.closeCTE(params)
 
 	return params, paramIndex, nil
 }
@@ -285,7 +288,7 @@ func (t *table) buildInsertCTE(
 // subqueries that target a table being inserted into in the same statement to
 // its parent CTE — nil for top-level inserts, populated for nested ones.
 func (t *table) buildSingleInsertCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	insertObj arguments.InsertObject,
 	onConflict *arguments.OnConflict,
@@ -313,36 +316,36 @@ func (t *table) buildSingleInsertCTE(
 // buildInsertMutationCTE builds the complete WITH clause including all CTEs up to and including mutation_result.
 // This is shared by both insert_one and insert (multiple rows). Dispatches to
 // the pre-check or post-check path the same way buildSingleInsertCTE does.
-// Returns the CTE SQL, updated params, paramIndex, nested-insert CTE refs, and error.
+// Writes the WITH clause to b and returns updated params, paramIndex,
+// nested-insert CTE refs, and error.
 func (t *table) buildInsertMutationCTE(
+	b *mutationBuilder,
 	insertObjs []arguments.InsertObject,
 	onConflict *arguments.OnConflict,
 	role string,
 	sessionVariables map[string]any,
 	params []any,
 	paramIndex int,
-) (string, []any, int, nestedInsertCTERefs, error) {
-	var b strings.Builder
-
+) ([]any, int, nestedInsertCTERefs, error) {
 	b.WriteString("WITH ")
 
 	nestedCTESQL, params, paramIndex, err := t.buildNestedInsertCTEs(
 		insertObjs, role, sessionVariables, params, paramIndex,
 	)
 	if err != nil {
-		return "", nil, 0, nestedInsertCTERefs{}, err
+		return nil, 0, nestedInsertCTERefs{}, err
 	}
 
-	if nestedCTESQL != "" {
-		b.WriteString(nestedCTESQL)
+	if nestedCTESQL.Len() > 0 {
+		b.writeCTEs(nestedCTESQL)
 		b.WriteString(", ")
 	}
 
 	params, paramIndex, err = t.buildParentInsertMutationCTEBody(
-		&b, insertObjs, onConflict, role, sessionVariables, params, paramIndex,
+		b, insertObjs, onConflict, role, sessionVariables, params, paramIndex,
 	)
 	if err != nil {
-		return "", nil, 0, nestedInsertCTERefs{}, err
+		return nil, 0, nestedInsertCTERefs{}, err
 	}
 
 	// Array-relationship nested CTEs reference columns from mutation_result, so
@@ -351,20 +354,20 @@ func (t *table) buildInsertMutationCTE(
 		insertObjs, role, sessionVariables, params, paramIndex,
 	)
 	if err != nil {
-		return "", nil, 0, nestedInsertCTERefs{}, err
+		return nil, 0, nestedInsertCTERefs{}, err
 	}
 
-	if arrayNestedSQL != "" {
+	if arrayNestedSQL.Len() > 0 {
 		b.WriteString(", ")
-		b.WriteString(arrayNestedSQL)
+		b.writeCTEs(arrayNestedSQL)
 	}
 
 	nestedCTERefs, err := t.buildNestedCTERefs(insertObjs)
 	if err != nil {
-		return "", nil, 0, nestedInsertCTERefs{}, err
+		return nil, 0, nestedInsertCTERefs{}, err
 	}
 
-	return b.String(), params, paramIndex, nestedCTERefs, nil
+	return params, paramIndex, nestedCTERefs, nil
 }
 
 // buildParentInsertMutationCTEBody emits the parent INSERT CTE(s) up to and
@@ -377,7 +380,7 @@ func (t *table) buildInsertMutationCTE(
 // per-parent parent CTE rather than a single mutation_result cross-joined onto
 // every row. All other inserts use the shared single-body path.
 func (t *table) buildParentInsertMutationCTEBody(
-	b *strings.Builder,
+	b *mutationBuilder,
 	insertObjs []arguments.InsertObject,
 	onConflict *arguments.OnConflict,
 	role string,
@@ -433,7 +436,7 @@ func sortedNestedCTENames(names []string) []string {
 // be validated against the inserted row (see requiresPostInsertCheck) or the
 // pre-check path otherwise.
 func (t *table) buildInsertMutationCTEBody(
-	b *strings.Builder,
+	b *mutationBuilder,
 	insertObjs []arguments.InsertObject,
 	allColumns []string,
 	columnToValue []map[string]any,
@@ -2,7 +2,6 @@ package queries
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
@@ -38,10 +37,10 @@ func (t *table) buildMutationInsertOneSQL(
 		return core.SQLOperation{}, fmt.Errorf("failed to parse selection set: %w", err)
 	}
 
-	b := getBuilder()
+	var b mutationBuilder
 
 	params, err := t.buildInsertSQL(
-		b,
+		&b,
 		insertObj,
 		onConflict,
 		columns,
@@ -54,26 +53,15 @@ func (t *table) buildMutationInsertOneSQL(
 		rootFieldName(field),
 	)
 	if err != nil {
-		putBuilder(b)
-
 		return core.SQLOperation{}, fmt.Errorf("failed to build insert query: %w", err)
 	}
 
-	sql := b.String()
-	putBuilder(b)
-
-	return core.SQLOperation{
-		Name:          alias,
-		SQL:           sql,
-		Parameters:    params,
-		StreamCursors: nil,
-		Sequential:    nil,
-	}, nil
+	return t.mutationOperation(alias, &b, params), nil
 }
 
 // buildInsertSQL builds the complete INSERT query with CTEs for permissions.
 func (t *table) buildInsertSQL(
-	b *strings.Builder,
+	b *mutationBuilder,
 	insertObj arguments.InsertObject,
 	onConflict *arguments.OnConflict,
 	columns []columnSelection,
@@ -89,7 +77,8 @@ func (t *table) buildInsertSQL(
 	paramIndex := 1
 
 	// Build the mutation CTEs
-	cteSQL, params, paramIndex, nestedCTERefs, err := t.buildInsertMutationCTE(
+	params, paramIndex, nestedCTERefs, err := t.buildInsertMutationCTE(
+		b,
 		[]arguments.InsertObject{insertObj},
 		onConflict,
 		role,
@@ -101,12 +90,11 @@ func (t *table) buildInsertSQL(
 		return nil, err
 	}
 
-	b.WriteString(cteSQL)
 	b.WriteString(" ")
 
 	// Build the final SELECT with field selection.
 	params, err = t.buildFinalSelect(
-		b,
+		&b.Builder,
 		columns,
 		relationships,
 		nestedCTERefs.direct,
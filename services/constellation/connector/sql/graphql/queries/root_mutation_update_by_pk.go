@@ -2,7 +2,6 @@ package queries
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
@@ -38,32 +37,21 @@ func (t *table) buildMutationUpdateByPkSQL(
 		return core.SQLOperation{}, fmt.Errorf("failed to parse selection set: %w", err)
 	}
 
-	b := getBuilder()
+	var b mutationBuilder
 
 	params, err := t.buildUpdateByPkSQL(
-		b, update, columns, relationships, fragments, variables, role, sessionVariables, roots,
+		&b, update, columns, relationships, fragments, variables, role, sessionVariables, roots,
 		rootFieldName(field),
 	)
 	if err != nil {
-		putBuilder(b)
-
 		return core.SQLOperation{}, fmt.Errorf("failed to build UPDATE BY PK SQL: %w", err)
 	}
 
-	sql := b.String()
-	putBuilder(b)
-
-	return core.SQLOperation{
-		Name:          alias,
-		SQL:           sql,
-		Parameters:    params,
-		StreamCursors: nil,
-		Sequential:    nil,
-	}, nil
+	return t.mutationOperation(alias, &b, params), nil
 }
 
 func (t *table) buildUpdateByPkSQL(
-	b *strings.Builder,
+	b *mutationBuilder,
 	update arguments.Update,
 	columns []columnSelection,
 	relationships []relationshipSelection,
@@ -102,7 +90,7 @@ func (t *table) buildUpdateByPkSQL(
 	// Build SELECT for single row (reusing buildFinalSelect from insert_one)
 	// This returns row_to_json for the single updated object
 	params, err = t.buildFinalSelect(
-		b,
+		&b.Builder,
 		columns,
 		relationships,
 		nil, // no nested selection CTEs
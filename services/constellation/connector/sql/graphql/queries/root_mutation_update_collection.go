@@ -2,7 +2,6 @@ package queries
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
@@ -36,11 +35,11 @@ func (t *table) buildMutationUpdateSQL( //nolint:dupl
 		return core.SQLOperation{}, fmt.Errorf("failed to parse selection set: %w", err)
 	}
 
-	b := getBuilder()
+	var b mutationBuilder
 
 	// Build the UPDATE SQL with CTEs and permissions
 	params, err := t.buildUpdateCollectionSQL(
-		b,
+		&b,
 		updateObj,
 		selection,
 		fragments,
@@ -50,21 +49,10 @@ func (t *table) buildMutationUpdateSQL( //nolint:dupl
 		roots,
 	)
 	if err != nil {
-		putBuilder(b)
-
 		return core.SQLOperation{}, fmt.Errorf("failed to build UPDATE SQL: %w", err)
 	}
 
-	sql := b.String()
-	putBuilder(b)
-
-	return core.SQLOperation{
-		Name:          alias,
-		SQL:           sql,
-		Parameters:    params,
-		StreamCursors: nil,
-		Sequential:    nil,
-	}, nil
+	return t.mutationOperation(alias, &b, params), nil
 }
 
 // buildUpdateCTEBody builds the CTE(s) exposing the updated rows under cteName
@@ -81,7 +69,7 @@ func (t *table) buildMutationUpdateSQL( //nolint:dupl
 //	cteName_post_check AS (validate all rows pass the check),
 //	cteName AS (SELECT * FROM _cteName WHERE cteName_post_check passes)
 func (t *table) buildUpdateCTEBody(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	updateObj arguments.Update,
 	role string,
@@ -116,12 +104,13 @@ func (t *table) buildUpdateCTEBody(
 	}
 
 	b.WriteString(", ")
-	b.WriteString(cteName)
-	b.WriteString(" AS (SELECT * FROM ") //nolint:unqueryvet
+	b.openCTE(cteName)
+	b.WriteString("SELECT * FROM ") //nolint:unqueryvet
 	b.WriteString(rawCTEName)
 	b.WriteString(" WHERE (SELECT status FROM ")
 	b.WriteString(postCheckName)
-	b.WriteString(") = 1)")
+	b.WriteString(") = 1")
+	b.closeCTEWithoutParams()
 
 	return params, paramIndex, nil
 }
@@ -130,7 +119,7 @@ func (t *table) buildUpdateCTEBody(
 // applying the update SET/WHERE and the role's row-level update filter. It is
 // the raw UPDATE step shared by both the checked and unchecked update paths.
 func (t *table) writeUpdateStatementCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	cteName string,
 	updateObj arguments.Update,
 	role string,
@@ -138,12 +127,12 @@ func (t *table) writeUpdateStatementCTE(
 	params []any,
 	paramIndex int,
 ) ([]any, int, error) {
-	b.WriteString(cteName)
-	b.WriteString(" AS (UPDATE ")
+	b.openModifyingCTE(cteName)
+	b.WriteString("UPDATE ")
 	b.WriteString(t.tableFromClause())
 	b.WriteString(" SET ")
 
-	params, paramIndex = updateObj.WriteSQL(b, params, paramIndex, t.dialect)
+	params, paramIndex = updateObj.WriteSQL(&b.Builder, params, paramIndex, t.dialect)
 
 	b.WriteString(" WHERE ")
 
@@ -151,7 +140,7 @@ func (t *table) writeUpdateStatementCTE(
 
 	if len(updateObj.Where) > 0 {
 		params, paramIndex, err = updateObj.Where.WriteCondition(
-			b, t.tableName, params, paramIndex,
+			&b.Builder, t.tableName, params, paramIndex,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to write WHERE clause: %w", err)
@@ -165,7 +154,7 @@ func (t *table) writeUpdateStatementCTE(
 		b.WriteString(" AND (")
 
 		params, paramIndex, _, err = t.permissions.WriteUpdateFilter(
-			b, params, paramIndex, role, sessionVariables, t.tableName,
+			&b.Builder, params, paramIndex, role, sessionVariables, t.tableName,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to apply update permissions: %w", err)
@@ -174,7 +163,8 @@ func (t *table) writeUpdateStatementCTE(
 		b.WriteString(")")
 	}
 
-	b.WriteString(" RETURNING *)")
+	t.writeReturningAll(&b.Builder)
+	b.closeCTE(params)
 
 	return params, paramIndex, nil
 }
@@ -186,7 +176,7 @@ func (t *table) writeUpdateStatementCTE(
 // otherwise raises the same dialect error as the insert post-check, aborting
 // the whole mutation (all-or-nothing). Mirrors buildPostCheckCTEWithName.
 func (t *table) buildUpdatePostCheckCTE(
-	b *strings.Builder,
+	b *mutationBuilder,
 	checkName string,
 	rawCTEName string,
 	role string,
@@ -194,13 +184,13 @@ func (t *table) buildUpdatePostCheckCTE(
 	params []any,
 	paramIndex int,
 ) ([]any, int, error) {
-	b.WriteString(checkName)
-	b.WriteString(" AS (SELECT CASE WHEN (SELECT COUNT(*) FROM ")
+	b.openCTE(checkName)
+	b.WriteString("SELECT CASE WHEN (SELECT COUNT(*) FROM ")
 	b.WriteString(rawCTEName)
 	b.WriteString(" WHERE ")
 
 	params, paramIndex, _, err := t.permissions.WriteUpdateCheck(
-		b, role, sessionVariables, params, paramIndex, rawCTEName,
+		&b.Builder, role, sessionVariables, params, paramIndex, rawCTEName,
 	)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to write update post-check permission: %w", err)
@@ -208,9 +198,10 @@ func (t *table) buildUpdatePostCheckCTE(
 
 	b.WriteString(") = (SELECT COUNT(*) FROM ")
 	b.WriteString(rawCTEName)
-	b.WriteString(") THEN 1 ELSE (SELECT 0 FROM (SELECT ")
+	b.WriteString(") THEN 1 ELSE ")
 	b.WriteString(t.dialect.ThrowError(errMsgInsertPermissionFailed, errCodePermissionDenied))
-	b.WriteString(") x) END AS status)")
+	b.WriteString(" END AS status")
+	b.closeCTE(params)
 
 	return params, paramIndex, nil
 }
@@ -218,7 +209,7 @@ func (t *table) buildUpdatePostCheckCTE(
 // buildUpdateCollectionSQL builds the complete UPDATE query with CTEs for permissions.
 // This follows the same pattern as buildInsertManySQL but for UPDATE operations.
 func (t *table) buildUpdateCollectionSQL(
-	b *strings.Builder,
+	b *mutationBuilder,
 	updateObj arguments.Update,
 	selection mutationSelection,
 	fragments ast.FragmentDefinitionList,
@@ -250,7 +241,7 @@ func (t *table) buildUpdateCollectionSQL(
 	b.WriteString(" ")
 
 	params, _, err = selection.WriteSQL(
-		b,
+		&b.Builder,
 		fragments,
 		variables,
 		role,
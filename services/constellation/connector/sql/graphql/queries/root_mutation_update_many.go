@@ -37,10 +37,10 @@ func (t *table) buildMutationUpdateManySQL(
 	sequential := make([]core.SQLOperation, len(updates))
 
 	for i := range updates {
-		b := getBuilder()
+		var b mutationBuilder
 
 		params, buildErr := t.buildUpdateCollectionSQL(
-			b,
+			&b,
 			updates[i],
 			selection,
 			fragments,
@@ -50,22 +50,12 @@ func (t *table) buildMutationUpdateManySQL(
 			roots,
 		)
 		if buildErr != nil {
-			putBuilder(b)
-
 			return core.SQLOperation{}, fmt.Errorf(
 				"failed to build UPDATE MANY SQL for index %d: %w", i, buildErr,
 			)
 		}
 
-		sequential[i] = core.SQLOperation{
-			Name:          fmt.Sprintf("%s[%d]", alias, i),
-			SQL:           b.String(),
-			Parameters:    params,
-			StreamCursors: nil,
-			Sequential:    nil,
-		}
-
-		putBuilder(b)
+		sequential[i] = t.mutationOperation(fmt.Sprintf("%s[%d]", alias, i), &b, params)
 	}
 
 	return core.SQLOperation{
@@ -74,5 +64,6 @@ func (t *table) buildMutationUpdateManySQL(
 		Parameters:    nil,
 		StreamCursors: nil,
 		Sequential:    sequential,
+		Stages:        nil,
 	}, nil
 }
@@ -55,6 +55,7 @@ func (t *table) buildQueryAggregateSQL(
 		Parameters:    params,
 		StreamCursors: nil,
 		Sequential:    nil,
+		Stages:        nil,
 	}, nil
 }
 
@@ -296,9 +297,9 @@ func (t *table) writeAggregateNodes(
 
 	b.WriteByte('\'')
 	b.WriteString(responseName)
-	b.WriteString("', (SELECT coalesce(")
-	b.WriteString(jsonAggExpr)
-	b.WriteString(", '[]') FROM (")
+	b.WriteString("', (SELECT ")
+	b.WriteString(t.dialect.CoalesceJSONAgg(jsonAggExpr))
+	b.WriteString(" FROM (")
 
 	var err error
 
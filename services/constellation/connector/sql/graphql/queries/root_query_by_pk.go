@@ -72,6 +72,7 @@ func (t *table) buildQueryByPkSQL(
 		Parameters:    params,
 		StreamCursors: nil,
 		Sequential:    nil,
+		Stages:        nil,
 	}, nil
 }
 
@@ -53,6 +53,7 @@ func (t *table) buildQueryCollectionSQL(
 		Parameters:    params,
 		StreamCursors: nil,
 		Sequential:    nil,
+		Stages:        nil,
 	}, nil
 }
 
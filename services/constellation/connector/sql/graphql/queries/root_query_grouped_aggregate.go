@@ -155,6 +155,7 @@ func (t *table) BuildGroupedAggregateSQL(
 		Parameters:    params,
 		StreamCursors: nil,
 		Sequential:    nil,
+		Stages:        nil,
 	}, nil
 }
 
@@ -9,7 +9,6 @@ import (
 
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/arguments"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
-	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/multiplexed"
 )
 
 // buildSubscriptionStreamSQL builds multiplexed SQL for a streaming subscription.
@@ -73,10 +72,11 @@ func (t *table) buildSubscriptionStreamSQL(
 		Parameters:    params,
 		StreamCursors: streamCursorInfos(streamArgs.Cursors),
 		Sequential:    nil,
+		Stages:        nil,
 	}
 
 	// Convert to multiplexed and build final SQL
-	op.SQL, op.Parameters, err = multiplexed.Multiplex(op)
+	op.SQL, op.Parameters, err = subscriptionSQL(t.dialect, op)
 	if err != nil {
 		return core.SQLOperation{}, fmt.Errorf(
 			"failed to multiplex stream subscription SQL: %w",
@@ -174,7 +174,8 @@ func (t *table) buildStreamQuerySQL( //nolint:cyclop,funlen,gocognit,gocyclo,mai
 	argumentPath string,
 	streamArgs arguments.Stream,
 ) ([]any, int, error) {
-	// Note: Remote relationships are not supported in subscriptions and are ignored
+	// Note: remote relationships never reach here; the controller replaces them
+	// with their join columns and resolves them on each batch.
 	columns, relationships, err := t.astToQuerySelection(field, fragments)
 	if err != nil {
 		return nil, 0, err
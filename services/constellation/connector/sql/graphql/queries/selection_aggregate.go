@@ -15,10 +15,11 @@ import (
 const typenameField = "__typename"
 
 // varianceAggregateFuncs is the subset of aggregate-selection functions backed
-// by a native stddev/variance SQL aggregate. Backends without them (SQLite) gate
-// these via dialect.SupportsVarianceAggregates: schema generation omits the
-// fields and the selection builder rejects them. avg/sum/max/min are absent
-// because they are native on every supported backend.
+// by a stddev/variance SQL aggregate (native, or registered by the driver on
+// SQLite). Backends without them gate these via
+// dialect.SupportsVarianceAggregates: schema generation omits the fields and the
+// selection builder rejects them. avg/sum/max/min are absent because they are
+// native on every supported backend.
 //
 //nolint:gochecknoglobals // immutable lookup table.
 var varianceAggregateFuncs = map[string]bool{
@@ -532,7 +533,7 @@ func (t *table) appendAggregateField(
 	case "sum", "avg", "max", "min", "stddev", "stddev_pop",
 		"stddev_samp", "var_pop", "var_samp", "variance":
 		// The stddev/variance family is rejected on backends without those
-		// aggregate functions (SQLite). Schema generation already omits these
+		// aggregate functions. Schema generation already omits these
 		// fields, so a schema-validated request never reaches this branch; this
 		// is a defensive backstop for callers that bypass validation, converting
 		// an opaque "no such function" execution error into a clear typed error.
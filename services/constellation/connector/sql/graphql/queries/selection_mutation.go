@@ -85,8 +85,13 @@ func (s mutationSelection) WriteSQLWithCTE(
 		return nil, 0, err
 	}
 
+	if hasReturning && s.dialect.SupportsLateral() {
+		// Closes the derived "_e" table writeReturningLateral opens.
+		b.WriteString(") AS \"_e\"")
+	}
+
 	if hasReturning {
-		b.WriteString(") AS \"_e\"))")
+		b.WriteString("))")
 	} else {
 		b.WriteString(")")
 	}
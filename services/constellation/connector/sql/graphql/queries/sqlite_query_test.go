@@ -491,11 +491,10 @@ func TestBuildSelectionSQL_SQLite(t *testing.T) { //nolint:paralleltest
 			},
 		},
 		{
-			// SQLite has no native stddev/variance aggregate, and the one-pass
-			// identity that would emulate it is numerically unstable (it inverts
-			// the ordering for large, close values), so such orderings are
-			// rejected instead of returning a silently wrong order.
-			name: "order_by array relationship aggregate stddev rejected",
+			// stddev/variance resolve to the Go aggregates the sqlite driver
+			// registers, which are numerically stable, so ordering by them is
+			// accepted as on PostgreSQL.
+			name: "order_by array relationship aggregate stddev",
 			query: query{
 				Query: `
 					query {
@@ -505,10 +504,9 @@ func TestBuildSelectionSQL_SQLite(t *testing.T) { //nolint:paralleltest
 					}`,
 				Role: "admin",
 			},
-			expectError: arguments.ErrUnsupportedAggregateOrderBy,
 		},
 		{
-			name: "order_by array relationship aggregate variance rejected",
+			name: "order_by array relationship aggregate variance",
 			query: query{
 				Query: `
 					query {
@@ -518,7 +516,6 @@ func TestBuildSelectionSQL_SQLite(t *testing.T) { //nolint:paralleltest
 					}`,
 				Role: "admin",
 			},
-			expectError: arguments.ErrUnsupportedAggregateOrderBy,
 		},
 	}
 
@@ -699,12 +696,10 @@ func TestAggregateBuildQuery_SQLite(t *testing.T) { //nolint:paralleltest
 			},
 		},
 		{
-			// go-sqlite3 has no stddev/variance aggregate functions, so emitting
-			// STDDEV(...) etc. would fail at execution with an opaque "no such
-			// function" error. Schema generation omits these fields (see
-			// TestGenerateForRole_SQLite); the builder rejects them as a backstop
-			// for callers that bypass schema validation.
-			name: "stddev aggregate rejected",
+			// go-sqlite3 has no stddev/variance aggregate functions; the sqlite
+			// driver registers Go ones under PostgreSQL's names, so the SQL is the
+			// same as on PostgreSQL.
+			name: "stddev aggregate",
 			query: query{
 				Query: `
 					query {
@@ -717,10 +712,9 @@ func TestAggregateBuildQuery_SQLite(t *testing.T) { //nolint:paralleltest
 						}
 					}`,
 			},
-			expectError: queries.ErrUnsupportedVarianceAggregate,
 		},
 		{
-			name: "variance aggregate rejected",
+			name: "variance aggregate",
 			query: query{
 				Query: `
 					query {
@@ -733,10 +727,9 @@ func TestAggregateBuildQuery_SQLite(t *testing.T) { //nolint:paralleltest
 						}
 					}`,
 			},
-			expectError: queries.ErrUnsupportedVarianceAggregate,
 		},
 		{
-			name: "var_pop aggregate rejected",
+			name: "var_pop aggregate",
 			query: query{
 				Query: `
 					query {
@@ -749,7 +742,6 @@ func TestAggregateBuildQuery_SQLite(t *testing.T) { //nolint:paralleltest
 						}
 					}`,
 			},
-			expectError: queries.ErrUnsupportedVarianceAggregate,
 		},
 		{
 			name: "aggregate with WHERE clause",
@@ -7,6 +7,7 @@ import (
 	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/multiplexed"
 )
 
@@ -44,7 +45,9 @@ func isSubscriptionTemplateSessionArgument(sessionVariables map[string]any) bool
 //
 // Stream subscriptions go through buildSubscriptionStreamSQL instead, which
 // has cursor-handling logic that doesn't fit this uniform post-processing.
-func multiplexify(name string, builder core.Operation) core.Operation {
+// Dialects without multiplexed subscriptions get the per-subscriber form from
+// subscriptionSQL instead.
+func multiplexify(name string, d dialect.Dialect, builder core.Operation) core.Operation {
 	return func(
 		field *ast.Field,
 		fragments ast.FragmentDefinitionList,
@@ -67,7 +70,7 @@ func multiplexify(name string, builder core.Operation) core.Operation {
 			)
 		}
 
-		op.SQL, op.Parameters, err = multiplexed.Multiplex(op)
+		op.SQL, op.Parameters, err = subscriptionSQL(d, op)
 		if err != nil {
 			return core.SQLOperation{}, fmt.Errorf(
 				"failed to multiplex subscription %s SQL: %w", name, err,
@@ -77,3 +80,21 @@ func multiplexify(name string, builder core.Operation) core.Operation {
 		return op, nil
 	}
 }
+
+// subscriptionSQL converts a built subscription operation into the SQL the
+// cohort poller executes. Dialects that support it get the multiplexed form
+// (see multiplexed.Multiplex). Others get the operation wrapped in the same
+// {"<name>": ...} envelope, with its markers left in Parameters so the
+// connector can bind them per subscriber (see multiplexed.BindParams).
+func subscriptionSQL(d dialect.Dialect, op core.SQLOperation) (string, []any, error) {
+	if d.SupportsMultiplexedSubscriptions() {
+		return multiplexed.Multiplex(op) //nolint:wrapcheck // callers wrap with the subscription kind
+	}
+
+	if err := multiplexed.Validate(op); err != nil {
+		return "", nil, err //nolint:wrapcheck // callers wrap with the subscription kind
+	}
+
+	return "SELECT " + d.JSONBuildObject() + "('" + op.Name + "', " + d.ToJSON("("+op.SQL+")") + ")",
+		op.Parameters, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
@@ -374,14 +375,17 @@ func registerTableRoots(
 
 	rootsByOperation[OperationSubscription][t.queryCollectionName] = multiplexify(
 		"collection",
+		t.dialect,
 		t.buildQueryCollectionSQL,
 	)
 	rootsByOperation[OperationSubscription][t.queryByPkName] = multiplexify(
 		"by_pk",
+		t.dialect,
 		t.buildQueryByPkSQL,
 	)
 	rootsByOperation[OperationSubscription][t.queryAggregateName] = multiplexify(
 		"aggregate",
+		t.dialect,
 		t.buildQueryAggregateSQL,
 	)
 	rootsByOperation[OperationSubscription][t.subscriptionStreamName] = t.buildSubscriptionStreamSQL
@@ -413,10 +417,12 @@ func registerTableFunctionRoots(
 				)
 				rootsByOperation[OperationSubscription][fn.querySelectName] = multiplexify(
 					"function collection",
+					t.dialect,
 					t.makeFunctionOp(fn, t.buildQueryFunctionCollectionSQL),
 				)
 				rootsByOperation[OperationSubscription][fn.queryAggregateName] = multiplexify(
 					"function aggregate",
+					t.dialect,
 					t.makeFunctionOp(fn, t.buildQueryFunctionAggregateSQL),
 				)
 			} else {
@@ -436,6 +442,7 @@ func registerTableFunctionRoots(
 			)
 			rootsByOperation[OperationSubscription][fn.querySelectName] = multiplexify(
 				"function one",
+				t.dialect,
 				t.makeFunctionOp(fn, t.buildQueryFunctionOneSQL),
 			)
 		} else {
@@ -483,3 +490,15 @@ func (t *table) tableFromClause() string {
 func (t *table) tableSourceRef() string {
 	return t.cachedTableRef
 }
+
+// writeReturningAll writes the RETURNING clause of a mutation statement whose
+// rows later statements read as a stand-in for the table.
+func (t *table) writeReturningAll(b *strings.Builder) {
+	columns := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		columns[i] = column.SQLName
+	}
+
+	b.WriteString(" RETURNING ")
+	t.dialect.WriteReturningAll(b, columns)
+}
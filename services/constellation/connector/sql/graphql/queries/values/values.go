@@ -53,6 +53,41 @@ func ResolveVariable(value *ast.Value, variables map[string]any) (*ast.Value, er
 	return resolved, nil
 }
 
+// ResolveVariables returns value with every variable reference, at any depth,
+// replaced by the AST of the variable's value. Unlike ResolveASTValue it keeps
+// the AST shape (enum literals stay enums), for parsers that walk an input
+// object and only need some of its leaves as Go values. Literal leaves are
+// shared with value; objects and lists are copied.
+func ResolveVariables(value *ast.Value, variables map[string]any) (*ast.Value, error) {
+	resolved, err := ResolveVariable(value, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolved.Kind != ast.ObjectValue && resolved.Kind != ast.ListValue {
+		return resolved, nil
+	}
+
+	out := *resolved
+	out.Children = make(ast.ChildValueList, len(resolved.Children))
+
+	for i, child := range resolved.Children {
+		childValue, err := ResolveVariables(child.Value, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Children[i] = &ast.ChildValue{
+			Name:     child.Name,
+			Value:    childValue,
+			Position: child.Position,
+			Comment:  child.Comment,
+		}
+	}
+
+	return &out, nil
+}
+
 // ResolveASTValue resolves an AST value to a Go value, recursively substituting
 // any nested variable references inside objects and lists. ExtractGoValue alone
 // errors on a Variable child because it has no variables map — callers like
@@ -206,10 +241,11 @@ func AnyToString(val any) string {
 
 // CoerceSQLValue converts values that need type-specific SQL constructors into
 // the parameter representation those constructors expect. PostGIS spatial
-// constructors accept GeoJSON text, so object/list literals and variables are
+// constructors accept GeoJSON text and pgvector accepts the JSON array text of
+// an embedding ("[1,2,3]"), so object/list literals and variables are
 // marshalled to deterministic JSON strings while raw string values pass through.
 func CoerceSQLValue(sqlType string, val any) (any, error) {
-	if !pgtypes.IsSpatial(sqlType) || val == nil {
+	if !needsJSONTextCoercion(sqlType) || val == nil {
 		return val, nil
 	}
 
@@ -221,7 +257,7 @@ func CoerceSQLValue(sqlType string, val any) (any, error) {
 	default:
 		b, err := stdjson.Marshal(v)
 		if err != nil {
-			return nil, fmt.Errorf("marshalling spatial GeoJSON value: %w", err)
+			return nil, fmt.Errorf("marshalling %s value: %w", sqlType, err)
 		}
 
 		return string(b), nil
@@ -229,9 +265,9 @@ func CoerceSQLValue(sqlType string, val any) (any, error) {
 }
 
 // CoerceSQLValues applies CoerceSQLValue to a slice, preserving byte-for-byte
-// identity for non-spatial SQL types.
+// identity for SQL types CoerceSQLValue leaves alone.
 func CoerceSQLValues(sqlType string, vals []any) ([]any, error) {
-	if !pgtypes.IsSpatial(sqlType) {
+	if !needsJSONTextCoercion(sqlType) {
 		return vals, nil
 	}
 
@@ -239,7 +275,7 @@ func CoerceSQLValues(sqlType string, vals []any) ([]any, error) {
 	for i, val := range vals {
 		coerced, err := CoerceSQLValue(sqlType, val)
 		if err != nil {
-			return nil, fmt.Errorf("coercing spatial value %d: %w", i, err)
+			return nil, fmt.Errorf("coercing %s value %d: %w", sqlType, i, err)
 		}
 
 		out[i] = coerced
@@ -248,6 +284,10 @@ func CoerceSQLValues(sqlType string, vals []any) ([]any, error) {
 	return out, nil
 }
 
+func needsJSONTextCoercion(sqlType string) bool {
+	return pgtypes.IsSpatial(sqlType) || pgtypes.IsVector(sqlType)
+}
+
 // ExtractStringArrayValues extracts an array of strings from an AST value.
 func ExtractStringArrayValues(value *ast.Value) ([]string, error) {
 	if value.Kind != ast.ListValue {
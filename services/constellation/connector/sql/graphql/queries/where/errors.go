@@ -6,6 +6,14 @@ var (
 	errRegexUnsupportedByDialect = errors.New(
 		"regex operators are not supported by the current dialect",
 	)
+	errLtreeUnsupportedByDialect = errors.New(
+		"ltree operators are not supported by the current dialect",
+	)
+	errLtreeOperatorOnNonLtreeColumn      = errors.New("ltree operator requires an ltree column")
+	errFullTextSearchUnsupportedByDialect = errors.New(
+		"full-text search operators are not supported by the current dialect",
+	)
+	errMatchOnNonTsvectorColumn    = errors.New("_match requires a tsvector column")
 	errSpatialUnsupportedByDialect = errors.New(
 		"spatial operators are not supported by the current dialect",
 	)
@@ -26,6 +34,14 @@ var (
 		"_st_d_within.use_spheroid must be a boolean",
 	)
 
+	errVectorUnsupportedByDialect = errors.New(
+		"vector distance operators are not supported by the current dialect",
+	)
+	errVectorOperatorOnNonVectorColumn = errors.New("vector distance operator requires a vector column")
+	errVectorDistanceMustBeObject      = errors.New("vector distance input must be an object")
+	errVectorDistanceFromRequired      = errors.New("vector distance input from is required")
+	errVectorDistanceDistanceRequired  = errors.New("vector distance input distance is required")
+
 	errExistsMustBeObject          = errors.New("_exists must be an object")
 	errExistsTableMustBeObject     = errors.New("_exists._table must be an object")
 	errExistsTableNameRequired     = errors.New("_exists._table.name is required")
@@ -490,6 +490,36 @@ func (f *notRegexFilter) WriteCondition(
 	return params, paramIndex + 1, nil
 }
 
+// similarFilter implements _similar / _nsimilar (SQL SIMILAR TO). Like the
+// regex filters it is only reachable on dialects that support regex.
+type similarFilter struct {
+	column  string
+	pattern string
+	negated bool
+	dialect dialect.Dialect
+}
+
+func (f *similarFilter) WriteCondition(
+	b *strings.Builder,
+	source string,
+	params []any,
+	paramIndex int,
+) ([]any, int, error) {
+	core.WriteQualifiedColumn(b, source, f.column)
+
+	if f.negated {
+		b.WriteString(" NOT SIMILAR TO ")
+	} else {
+		b.WriteString(" SIMILAR TO ")
+	}
+
+	b.WriteString(f.dialect.Placeholder(paramIndex))
+
+	params = append(params, f.pattern)
+
+	return params, paramIndex + 1, nil
+}
+
 type isNullFilter struct {
 	column string
 	target *comparisonTarget
@@ -0,0 +1,64 @@
+package where
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/values"
+	"github.com/nhost/nhost/services/constellation/connector/sql/pgtypes"
+)
+
+// tsvectorMatchFilter implements the _match operator for tsvector columns,
+// a Constellation extension: column @@ websearch_to_tsquery($N). The query
+// is parsed with the session's default_text_search_config.
+type tsvectorMatchFilter struct {
+	column  string
+	query   string
+	dialect dialect.Dialect
+}
+
+func (f *tsvectorMatchFilter) WriteCondition(
+	b *strings.Builder,
+	source string,
+	params []any,
+	paramIndex int,
+) ([]any, int, error) {
+	core.WriteQualifiedColumn(b, source, f.column)
+	b.WriteString(" @@ websearch_to_tsquery(")
+	b.WriteString(f.dialect.Placeholder(paramIndex))
+	b.WriteByte(')')
+
+	params = append(params, f.query)
+
+	return params, paramIndex + 1, nil
+}
+
+func parseTsvectorMatch( //nolint:ireturn,nolintlint
+	column *core.Column,
+	target *comparisonTarget,
+	value *ast.Value,
+	variables map[string]any,
+	d dialect.Dialect,
+) (Statement, error) {
+	if !d.SupportsFullTextSearch() {
+		return nil, errFullTextSearchUnsupportedByDialect
+	}
+
+	if !pgtypes.IsTsvector(comparisonTargetFor(column, target).sqlType) {
+		return nil, errMatchOnNonTsvectorColumn
+	}
+
+	val, err := resolveScalarValue(value, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tsvectorMatchFilter{
+		column:  sourceColumnForTarget(column, target),
+		query:   values.AnyToString(val),
+		dialect: d,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package where
+
+import (
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/values"
+	"github.com/nhost/nhost/services/constellation/connector/sql/pgtypes"
+)
+
+// ltreeFilter implements the ltree operators that take a single value
+// (_ancestor, _descendant, _matches, _matches_fulltext):
+// column <operator> $N::<valueType>.
+type ltreeFilter struct {
+	column    string
+	operator  string
+	valueType string
+	value     string
+	dialect   dialect.Dialect
+}
+
+func (f *ltreeFilter) WriteCondition(
+	b *strings.Builder,
+	source string,
+	params []any,
+	paramIndex int,
+) ([]any, int, error) {
+	core.WriteQualifiedColumn(b, source, f.column)
+	b.WriteString(" " + f.operator + " ")
+	b.WriteString(f.dialect.TypeCast(f.dialect.Placeholder(paramIndex), f.valueType))
+
+	params = append(params, f.value)
+
+	return params, paramIndex + 1, nil
+}
+
+// ltreeAnyFilter implements the array forms of the ltree operators
+// (_ancestor_any, _descendant_any, _matches_any). The values are bound as a
+// text[] and cast to the operator's array type, since the driver has no codec
+// for the extension's array types.
+type ltreeAnyFilter struct {
+	column    string
+	operator  string
+	valueType string
+	values    []string
+	dialect   dialect.Dialect
+}
+
+func (f *ltreeAnyFilter) WriteCondition(
+	b *strings.Builder,
+	source string,
+	params []any,
+	paramIndex int,
+) ([]any, int, error) {
+	core.WriteQualifiedColumn(b, source, f.column)
+	b.WriteString(" " + f.operator + " ")
+	b.WriteString(f.dialect.TypeCast(
+		f.dialect.TypeCast(f.dialect.Placeholder(paramIndex), "text[]"),
+		f.valueType+"[]",
+	))
+
+	params = append(params, f.values)
+
+	return params, paramIndex + 1, nil
+}
+
+// validateLtreeOperator gates the ltree operators on both the dialect and the
+// compared column, so a bypass of the schema-level gate in
+// connector/sql/graphql/schema/inputs.go fails at SQL generation.
+func validateLtreeOperator(column *core.Column, target *comparisonTarget, d dialect.Dialect) error {
+	if !d.SupportsLtree() {
+		return errLtreeUnsupportedByDialect
+	}
+
+	if !pgtypes.IsLtree(comparisonTargetFor(column, target).sqlType) {
+		return errLtreeOperatorOnNonLtreeColumn
+	}
+
+	return nil
+}
+
+// ltreeParser returns an operatorParser for a single-valued ltree operator.
+func ltreeParser(operator, valueType string) operatorParser {
+	return func(
+		c *core.Column,
+		target *comparisonTarget,
+		v *ast.Value,
+		vars map[string]any,
+		d dialect.Dialect,
+	) (Statement, error) {
+		if err := validateLtreeOperator(c, target, d); err != nil {
+			return nil, err
+		}
+
+		val, err := resolveScalarValue(v, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ltreeFilter{
+			column:    sourceColumnForTarget(c, target),
+			operator:  operator,
+			valueType: valueType,
+			value:     values.AnyToString(val),
+			dialect:   d,
+		}, nil
+	}
+}
+
+// ltreeAnyParser returns an operatorParser for an array-valued ltree operator.
+func ltreeAnyParser(operator, valueType string) operatorParser {
+	return func(
+		c *core.Column,
+		target *comparisonTarget,
+		v *ast.Value,
+		vars map[string]any,
+		d dialect.Dialect,
+	) (Statement, error) {
+		if err := validateLtreeOperator(c, target, d); err != nil {
+			return nil, err
+		}
+
+		vals, err := resolveStringArrayValue(v, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ltreeAnyFilter{
+			column:    sourceColumnForTarget(c, target),
+			operator:  operator,
+			valueType: valueType,
+			values:    vals,
+			dialect:   d,
+		}, nil
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/values"
+	"github.com/nhost/nhost/services/constellation/connector/sql/pgtypes"
 )
 
 // operatorParser parses one operator inside a field-comparison object
@@ -145,6 +146,34 @@ func buildRegex(negated, caseInsensitive bool) operatorParser {
 	}
 }
 
+// buildSimilar returns an operatorParser for _similar / _nsimilar. SIMILAR TO
+// shares the regex gate: both are advertised under SupportsRegex.
+func buildSimilar(negated bool) operatorParser {
+	return func(
+		c *core.Column,
+		target *comparisonTarget,
+		v *ast.Value,
+		vars map[string]any,
+		d dialect.Dialect,
+	) (Statement, error) {
+		if !d.SupportsRegex() {
+			return nil, errRegexUnsupportedByDialect
+		}
+
+		val, err := resolveScalarValue(v, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		return &similarFilter{
+			column:  sourceColumnForTarget(c, target),
+			pattern: values.AnyToString(val),
+			negated: negated,
+			dialect: d,
+		}, nil
+	}
+}
+
 // parseIsNull handles _is_null. The value must be resolved first: a client may
 // parameterize it as `_is_null: $v` (the schema types it as a nullable Boolean),
 // in which case the AST is an ast.Variable whose .Raw is the variable name, not
@@ -269,16 +298,18 @@ func operatorParserTable() map[string]operatorParser {
 				return &notInFilter{column: c, target: target, values: vs, dialect: d}
 			},
 		),
-		"_like":    scalarParser(buildLike(false, false)),
-		"_nlike":   scalarParser(buildLike(true, false)),
-		"_ilike":   scalarParser(buildLike(false, true)),
-		"_nilike":  scalarParser(buildLike(true, true)),
-		"_regex":   buildRegex(false, false),
-		"_nregex":  buildRegex(true, false),
-		"_iregex":  buildRegex(false, true),
-		"_niregex": buildRegex(true, true),
-		"_is_null": parseIsNull,
-		"_cast":    parseSpatialCast,
+		"_like":     scalarParser(buildLike(false, false)),
+		"_nlike":    scalarParser(buildLike(true, false)),
+		"_ilike":    scalarParser(buildLike(false, true)),
+		"_nilike":   scalarParser(buildLike(true, true)),
+		"_regex":    buildRegex(false, false),
+		"_nregex":   buildRegex(true, false),
+		"_iregex":   buildRegex(false, true),
+		"_niregex":  buildRegex(true, true),
+		"_similar":  buildSimilar(false),
+		"_nsimilar": buildSimilar(true),
+		"_is_null":  parseIsNull,
+		"_cast":     parseSpatialCast,
 		"_contains": containmentParser(
 			func(c *core.Column, vs []any, d dialect.Dialect) Statement {
 				return &arrayContainsFilter{
@@ -314,6 +345,19 @@ func operatorParserTable() map[string]operatorParser {
 				return &jsonbHasKeysAnyFilter{column: c.SQLName, keys: keys, dialect: d}
 			},
 		),
+		"_ancestor":         ltreeParser("@>", pgtypes.Ltree),
+		"_ancestor_any":     ltreeAnyParser("@>", pgtypes.Ltree),
+		"_descendant":       ltreeParser("<@", pgtypes.Ltree),
+		"_descendant_any":   ltreeAnyParser("<@", pgtypes.Ltree),
+		"_matches":          ltreeParser("~", pgtypes.Lquery),
+		"_matches_any":      ltreeAnyParser("?", pgtypes.Lquery),
+		"_matches_fulltext": ltreeParser("@", pgtypes.Ltxtquery),
+		"_match":            parseTsvectorMatch,
+
+		"_cosine_distance_lt":        vectorDistanceParser(dialect.VectorMetricCosine),
+		"_inner_product_distance_lt": vectorDistanceParser(dialect.VectorMetricInnerProduct),
+		"_l2_distance_lt":            vectorDistanceParser(dialect.VectorMetricL2),
+
 		"_st_3d_d_within": spatialDWithinParser(true),
 		"_st_3d_intersects": spatialPredicateParser(
 			dialect.SpatialPredicate3DIntersects,
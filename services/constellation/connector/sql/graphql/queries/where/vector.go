@@ -0,0 +1,116 @@
+package where
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/values"
+	"github.com/nhost/nhost/services/constellation/connector/sql/pgtypes"
+)
+
+// vectorDistanceFilter implements the _<metric>_distance_lt operators on
+// pgvector columns: (column <op> $N::vector) < $N+1.
+type vectorDistanceFilter struct {
+	column   string
+	metric   dialect.VectorMetric
+	from     any
+	distance any
+	dialect  dialect.Dialect
+}
+
+func (f *vectorDistanceFilter) WriteCondition(
+	b *strings.Builder,
+	source string,
+	params []any,
+	paramIndex int,
+) ([]any, int, error) {
+	from, err := values.CoerceSQLValue(pgtypes.Vector, f.from)
+	if err != nil {
+		return nil, 0, fmt.Errorf("coercing vector distance from: %w", err)
+	}
+
+	var column strings.Builder
+	core.WriteQualifiedColumn(&column, source, f.column)
+
+	f.dialect.WriteVectorDistance(b, f.metric, column.String(), f.dialect.Placeholder(paramIndex))
+	b.WriteString(" < ")
+	b.WriteString(f.dialect.Placeholder(paramIndex + 1))
+
+	params = append(params, from, f.distance)
+
+	return params, paramIndex + 2, nil
+}
+
+// vectorDistanceParser returns an operatorParser for the distance operator of
+// metric. The operator takes a vector_distance_input ({from, distance}).
+func vectorDistanceParser(metric dialect.VectorMetric) operatorParser {
+	return func(
+		column *core.Column,
+		target *comparisonTarget,
+		value *ast.Value,
+		variables map[string]any,
+		d dialect.Dialect,
+	) (Statement, error) {
+		if !d.SupportsVector() {
+			return nil, errVectorUnsupportedByDialect
+		}
+
+		if !pgtypes.IsVector(comparisonTargetFor(column, target).sqlType) {
+			return nil, errVectorOperatorOnNonVectorColumn
+		}
+
+		from, distance, err := parseVectorDistanceInput(value, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		return &vectorDistanceFilter{
+			column:   sourceColumnForTarget(column, target),
+			metric:   metric,
+			from:     from,
+			distance: distance,
+			dialect:  d,
+		}, nil
+	}
+}
+
+func parseVectorDistanceInput(value *ast.Value, variables map[string]any) (any, any, error) {
+	value, err := values.ResolveVariable(value, variables)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving vector distance input: %w", err)
+	}
+
+	if value.Kind != ast.ObjectValue {
+		return nil, nil, errVectorDistanceMustBeObject
+	}
+
+	var from, distance any
+
+	for _, child := range value.Children {
+		childValue, err := values.ResolveASTValue(child.Value, variables)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolving vector distance %s: %w", child.Name, err)
+		}
+
+		switch child.Name {
+		case "from":
+			from = childValue
+		case "distance":
+			distance = childValue
+		}
+	}
+
+	if from == nil {
+		return nil, nil, errVectorDistanceFromRequired
+	}
+
+	if distance == nil {
+		return nil, nil, errVectorDistanceDistanceRequired
+	}
+
+	return from, distance, nil
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/vektah/gqlparser/v2/ast"
 	"go.uber.org/mock/gomock"
 
@@ -2574,3 +2575,339 @@ func TestParseFieldComparison_Regex_Unsupported(t *testing.T) {
 		t.Errorf("error %q should explain the SupportsRegex gate", err)
 	}
 }
+
+// TestParseFieldComparison_TextSearchOperators covers the SIMILAR TO, ltree
+// and tsvector operators against the Postgres dialect.
+func TestParseFieldComparison_TextSearchOperators(t *testing.T) {
+	t.Parallel()
+
+	str := func(s string) *ast.Value { return &ast.Value{Kind: ast.StringValue, Raw: s} }
+	list := func(ss ...string) *ast.Value {
+		v := &ast.Value{Kind: ast.ListValue}
+		for _, s := range ss {
+			v.Children = append(v.Children, &ast.ChildValue{Value: str(s)})
+		}
+
+		return v
+	}
+
+	tests := []struct {
+		name       string
+		sqlType    string
+		operator   string
+		value      *ast.Value
+		wantSQL    string
+		wantParams []any
+	}{
+		{
+			name:       "similar",
+			sqlType:    "text",
+			operator:   "_similar",
+			value:      str("%(b|d)%"),
+			wantSQL:    `"t"."col" SIMILAR TO $1`,
+			wantParams: []any{"%(b|d)%"},
+		},
+		{
+			name:       "nsimilar",
+			sqlType:    "text",
+			operator:   "_nsimilar",
+			value:      str("%(b|d)%"),
+			wantSQL:    `"t"."col" NOT SIMILAR TO $1`,
+			wantParams: []any{"%(b|d)%"},
+		},
+		{
+			name:       "ancestor",
+			sqlType:    "ltree",
+			operator:   "_ancestor",
+			value:      str("Top.Science"),
+			wantSQL:    `"t"."col" @> $1::ltree`,
+			wantParams: []any{"Top.Science"},
+		},
+		{
+			name:       "descendant any",
+			sqlType:    "ltree",
+			operator:   "_descendant_any",
+			value:      list("Top.Science", "Top.Hobbies"),
+			wantSQL:    `"t"."col" <@ $1::text[]::ltree[]`,
+			wantParams: []any{[]string{"Top.Science", "Top.Hobbies"}},
+		},
+		{
+			name:       "matches",
+			sqlType:    "ltree",
+			operator:   "_matches",
+			value:      str("*.Astronomy.*"),
+			wantSQL:    `"t"."col" ~ $1::lquery`,
+			wantParams: []any{"*.Astronomy.*"},
+		},
+		{
+			name:       "matches any",
+			sqlType:    "ltree",
+			operator:   "_matches_any",
+			value:      list("*.Astronomy.*", "*.Cosmology.*"),
+			wantSQL:    `"t"."col" ? $1::text[]::lquery[]`,
+			wantParams: []any{[]string{"*.Astronomy.*", "*.Cosmology.*"}},
+		},
+		{
+			name:       "matches fulltext",
+			sqlType:    "ltree",
+			operator:   "_matches_fulltext",
+			value:      str("Astro* & !pictures@"),
+			wantSQL:    `"t"."col" @ $1::ltxtquery`,
+			wantParams: []any{"Astro* & !pictures@"},
+		},
+		{
+			name:       "tsvector match",
+			sqlType:    "tsvector",
+			operator:   "_match",
+			value:      str(`"sad cat" or fat -rat`),
+			wantSQL:    `"t"."col" @@ websearch_to_tsquery($1)`,
+			wantParams: []any{`"sad cat" or fat -rat`},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			col := &core.Column{SQLName: "col", GraphqlName: "col", SQLType: tc.sqlType}
+			value := &ast.Value{
+				Kind:     ast.ObjectValue,
+				Children: []*ast.ChildValue{{Name: tc.operator, Value: tc.value}},
+			}
+
+			sql, params, err := runParseFieldComparison(
+				t,
+				&stubTableForFieldComparison{d: dialect.NewPostgresDialect()},
+				col,
+				value,
+				nil,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if sql != tc.wantSQL {
+				t.Errorf("SQL = %s, want %s", sql, tc.wantSQL)
+			}
+
+			if diff := cmp.Diff(tc.wantParams, params); diff != "" {
+				t.Errorf("params mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestParseFieldComparison_TextSearchOperators_Rejected pins the dialect and
+// column-type gates on the ltree and tsvector operators.
+func TestParseFieldComparison_TextSearchOperators_Rejected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		dialect  dialect.Dialect
+		sqlType  string
+		operator string
+		wantErr  string
+	}{
+		{
+			name:     "ltree on sqlite",
+			dialect:  dialect.NewSQLiteDialect(),
+			sqlType:  "ltree",
+			operator: "_ancestor",
+			wantErr:  "ltree operators are not supported",
+		},
+		{
+			name:     "ltree operator on text column",
+			dialect:  dialect.NewPostgresDialect(),
+			sqlType:  "text",
+			operator: "_matches",
+			wantErr:  "requires an ltree column",
+		},
+		{
+			name:     "match on sqlite",
+			dialect:  dialect.NewSQLiteDialect(),
+			sqlType:  "tsvector",
+			operator: "_match",
+			wantErr:  "full-text search operators are not supported",
+		},
+		{
+			name:     "match on text column",
+			dialect:  dialect.NewPostgresDialect(),
+			sqlType:  "text",
+			operator: "_match",
+			wantErr:  "requires a tsvector column",
+		},
+		{
+			name:     "similar on sqlite",
+			dialect:  dialect.NewSQLiteDialect(),
+			sqlType:  "text",
+			operator: "_similar",
+			wantErr:  "regex operators are not supported",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			col := &core.Column{SQLName: "col", GraphqlName: "col", SQLType: tc.sqlType}
+			value := &ast.Value{
+				Kind: ast.ObjectValue,
+				Children: []*ast.ChildValue{
+					{Name: tc.operator, Value: &ast.Value{Kind: ast.StringValue, Raw: "x"}},
+				},
+			}
+
+			_, _, err := runParseFieldComparison(
+				t, &stubTableForFieldComparison{d: tc.dialect}, col, value, nil,
+			)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("err = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseFieldComparison_VectorDistance(t *testing.T) {
+	t.Parallel()
+
+	input := func(from *ast.Value, distance string) *ast.Value {
+		return &ast.Value{
+			Kind: ast.ObjectValue,
+			Children: []*ast.ChildValue{
+				{Name: "from", Value: from},
+				{Name: "distance", Value: &ast.Value{Kind: ast.FloatValue, Raw: distance}},
+			},
+		}
+	}
+	listFrom := &ast.Value{
+		Kind: ast.ListValue,
+		Children: []*ast.ChildValue{
+			{Value: &ast.Value{Kind: ast.IntValue, Raw: "1"}},
+			{Value: &ast.Value{Kind: ast.FloatValue, Raw: "0.5"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		operator   string
+		value      *ast.Value
+		variables  map[string]any
+		wantSQL    string
+		wantParams []any
+	}{
+		{
+			name:       "l2 with string vector",
+			operator:   "_l2_distance_lt",
+			value:      input(&ast.Value{Kind: ast.StringValue, Raw: "[1,0.5]"}, "0.3"),
+			wantSQL:    `("t"."col" <-> $1::vector) < $2`,
+			wantParams: []any{"[1,0.5]", 0.3},
+		},
+		{
+			name:       "cosine with list vector",
+			operator:   "_cosine_distance_lt",
+			value:      input(listFrom, "0.3"),
+			wantSQL:    `("t"."col" <=> $1::vector) < $2`,
+			wantParams: []any{"[1,0.5]", 0.3},
+		},
+		{
+			name:       "inner product with variable",
+			operator:   "_inner_product_distance_lt",
+			value:      input(&ast.Value{Kind: ast.Variable, Raw: "v"}, "-1"),
+			variables:  map[string]any{"v": []any{float64(1), 0.5}},
+			wantSQL:    `("t"."col" <#> $1::vector) < $2`,
+			wantParams: []any{"[1,0.5]", float64(-1)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			col := &core.Column{SQLName: "col", GraphqlName: "col", SQLType: "vector"}
+			value := &ast.Value{
+				Kind:     ast.ObjectValue,
+				Children: []*ast.ChildValue{{Name: tc.operator, Value: tc.value}},
+			}
+
+			sql, params, err := runParseFieldComparison(
+				t,
+				&stubTableForFieldComparison{d: dialect.NewPostgresDialect()},
+				col,
+				value,
+				tc.variables,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if sql != tc.wantSQL {
+				t.Errorf("SQL = %s, want %s", sql, tc.wantSQL)
+			}
+
+			if diff := cmp.Diff(tc.wantParams, params); diff != "" {
+				t.Errorf("params mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestParseFieldComparison_VectorDistance_Rejected pins the dialect and
+// column-type gates on the vector distance operators.
+func TestParseFieldComparison_VectorDistance_Rejected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		dialect dialect.Dialect
+		sqlType string
+		value   *ast.Value
+		wantErr string
+	}{
+		{
+			name:    "on sqlite",
+			dialect: dialect.NewSQLiteDialect(),
+			sqlType: "vector",
+			value:   &ast.Value{Kind: ast.ObjectValue},
+			wantErr: "vector distance operators are not supported",
+		},
+		{
+			name:    "on text column",
+			dialect: dialect.NewPostgresDialect(),
+			sqlType: "text",
+			value:   &ast.Value{Kind: ast.ObjectValue},
+			wantErr: "requires a vector column",
+		},
+		{
+			name:    "missing distance",
+			dialect: dialect.NewPostgresDialect(),
+			sqlType: "vector",
+			value: &ast.Value{
+				Kind: ast.ObjectValue,
+				Children: []*ast.ChildValue{
+					{Name: "from", Value: &ast.Value{Kind: ast.StringValue, Raw: "[1]"}},
+				},
+			},
+			wantErr: "distance is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			col := &core.Column{SQLName: "col", GraphqlName: "col", SQLType: tc.sqlType}
+			value := &ast.Value{
+				Kind:     ast.ObjectValue,
+				Children: []*ast.ChildValue{{Name: "_l2_distance_lt", Value: tc.value}},
+			}
+
+			_, _, err := runParseFieldComparison(
+				t, &stubTableForFieldComparison{d: tc.dialect}, col, value, nil,
+			)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("err = %v, want it to contain %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -154,9 +154,9 @@ func generateAggregateFieldsType(
 // appendNumericAggregateFields appends the numeric aggregate fields to
 // aggregate_fields in Hasura's canonical order. avg and sum are native on every
 // backend and always emitted; the stddev/variance family is gated by
-// caps.SupportsVarianceAggregates, since exposing it on SQLite — which lacks
-// those aggregate functions — would surface an opaque runtime "no such function"
-// error rather than a clean GraphQL validation error.
+// caps.SupportsVarianceAggregates, since exposing it on a backend lacking those
+// aggregate functions would surface an opaque runtime "no such function" error
+// rather than a clean GraphQL validation error.
 //
 // This deliberately mirrors appendNumericAggregateOrderByFields: the same
 // avg/[stddev]/sum/[var] gating shape over a different field type (graph.Field
@@ -340,8 +340,8 @@ func generateNumericAggregateFieldType(
 
 // generateNumericAggregateFieldsTypes generates all numeric aggregate field
 // types. avg and sum are emitted on every backend; the stddev/variance family
-// is gated by caps.SupportsVarianceAggregates so SQLite (which lacks those
-// aggregate functions) does not emit orphan *_stddev_fields/*_variance_fields
+// is gated by caps.SupportsVarianceAggregates so a backend lacking those
+// aggregate functions does not emit orphan *_stddev_fields/*_variance_fields
 // object types whose corresponding aggregate_fields entries were suppressed.
 func generateNumericAggregateFieldsTypes(
 	schema *graph.Schema,
@@ -526,9 +526,9 @@ func generateAggregateOrderByTypes( //nolint:funlen
 // are accepted on every backend and always emitted; the stddev/variance family
 // is gated by caps.SupportsStableVarianceOrderBy so the advertised order_by
 // surface matches what the aggregate-order_by builder accepts at runtime —
-// backends without stable variance ordering (SQLite) reject these functions, so
-// emitting the fields here would surface an opaque rejection instead of a clean
-// GraphQL validation error.
+// backends without stable variance ordering reject these functions, so emitting
+// the fields here would surface an opaque rejection instead of a clean GraphQL
+// validation error.
 //
 // This deliberately mirrors appendNumericAggregateFields: the same
 // avg/[stddev]/sum/[var] gating shape over a different field type
@@ -632,8 +632,8 @@ func generateNumericAggregateOrderByInputType(
 // generateNumericAggregateOrderByInputTypes generates all numeric aggregate
 // order_by input types in Hasura's canonical order. avg and sum are emitted on
 // every backend; the stddev/variance family is gated by
-// caps.SupportsStableVarianceOrderBy so SQLite (which rejects ordering by those
-// functions at runtime) does not emit orphan *_stddev_order_by/*_variance_order_by
+// caps.SupportsStableVarianceOrderBy so a backend that rejects ordering by those
+// functions at runtime does not emit orphan *_stddev_order_by/*_variance_order_by
 // input types whose corresponding aggregate_order_by entries were suppressed.
 // The order_by entries this gating mirrors live in appendNumericAggregateOrderByFields.
 func generateNumericAggregateOrderByInputTypes(
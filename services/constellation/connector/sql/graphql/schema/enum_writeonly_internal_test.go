@@ -181,6 +181,7 @@ func enumWriteOnlyObjects() *introspection.Objects {
 				Name:         "tasks",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				PrimaryKeys:  []string{"id"},
 				Columns: []introspection.Column{
 					{Name: "id", Type: "uuid"},
@@ -215,6 +216,7 @@ func enumPKObjects() *introspection.Objects {
 				Name:         "status_notes",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				PrimaryKeys:  []string{"status"},
 				Columns: []introspection.Column{
 					{Name: "status", Type: "text"},
@@ -241,6 +243,7 @@ func enumTable(name string) *introspection.Table {
 		Name:         name,
 		IsInsertable: true,
 		IsUpdatable:  true,
+		IsDeletable:  true,
 		PrimaryKeys:  []string{"value"},
 		Columns: []introspection.Column{
 			{Name: "value", Type: "text"},
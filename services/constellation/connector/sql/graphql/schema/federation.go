@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"github.com/nhost/nhost/services/constellation/connector/federation"
+	"github.com/nhost/nhost/services/constellation/connector/sql/introspection"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+// FederationEntitiesForRole returns the tables role can resolve as Apollo
+// Federation entities: tables with apollo_federation_config enabled, a
+// primary key, and a select permission covering every primary-key column —
+// the same condition under which the _by_pk root field is generated. The key
+// is the primary key, in GraphQL column names.
+func FederationEntitiesForRole(
+	objects *introspection.Objects,
+	role string,
+	md *metadata.DatabaseMetadata,
+) []federation.Entity {
+	var entities []federation.Entity
+
+	for i := range md.Tables {
+		tableMeta := &md.Tables[i]
+		if !tableMeta.FederationEnabled() {
+			continue
+		}
+
+		if role != roleAdmin && getSelectPermission(tableMeta, role) == nil {
+			continue
+		}
+
+		tableInfo, ok := objects.GetTable(tableMeta.Table.Schema, tableMeta.Table.Name)
+		if !ok || len(tableInfo.PrimaryKeys) == 0 {
+			continue
+		}
+
+		allowedColumns := getAllowedColumns(tableMeta, tableInfo, role)
+		if !allPKColumnsAllowed(tableInfo.PrimaryKeys, allowedColumns) {
+			continue
+		}
+
+		keyFields := make([]string, 0, len(tableInfo.PrimaryKeys))
+		for _, pk := range tableInfo.PrimaryKeys {
+			keyFields = append(keyFields, getCustomColumnName(tableMeta, pk))
+		}
+
+		typeName := getCustomOrDefaultTypeName(tableMeta)
+
+		entities = append(entities, federation.Entity{
+			TypeName:    typeName,
+			SelectField: selectFieldName(tableMeta, typeName),
+			KeyFields:   keyFields,
+		})
+	}
+
+	return entities
+}
@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/nhost/services/constellation/connector/federation"
+	"github.com/nhost/nhost/services/constellation/connector/sql/introspection"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+func federationObjects() *introspection.Objects {
+	objects := introspection.NewObjects()
+	objects.Schemas["public"] = &introspection.Schema{
+		Tables: map[string]*introspection.Table{
+			"users": {
+				Schema:      "public",
+				Name:        "users",
+				PrimaryKeys: []string{"id"},
+				Columns: []introspection.Column{
+					{Name: "id", Type: "uuid"},
+					{Name: "email", Type: "text"},
+				},
+			},
+			"memberships": {
+				Schema:      "public",
+				Name:        "memberships",
+				PrimaryKeys: []string{"org_id", "user_id"},
+				Columns: []introspection.Column{
+					{Name: "org_id", Type: "uuid"},
+					{Name: "user_id", Type: "uuid"},
+				},
+			},
+			"events": {
+				Schema: "public",
+				Name:   "events",
+				Columns: []introspection.Column{
+					{Name: "payload", Type: "jsonb"},
+				},
+			},
+			"posts": {
+				Schema:      "public",
+				Name:        "posts",
+				PrimaryKeys: []string{"id"},
+				Columns: []introspection.Column{
+					{Name: "id", Type: "uuid"},
+				},
+			},
+		},
+	}
+
+	return objects
+}
+
+func federationMetadata() *metadata.DatabaseMetadata {
+	enabled := &metadata.ApolloFederation{Enable: "v1"}
+
+	return &metadata.DatabaseMetadata{
+		Tables: []metadata.TableMetadata{
+			{
+				Table:            metadata.TableSource{Schema: "public", Name: "users"},
+				ApolloFederation: enabled,
+				Configuration:    metadata.TableConfiguration{CustomName: "User"},
+				SelectPermissions: []metadata.SelectPermission{
+					{
+						Role: "user",
+						Permission: metadata.SelectPermissionConfig{
+							Columns: []string{"id", "email"},
+						},
+					},
+					{
+						Role: "anonymous",
+						Permission: metadata.SelectPermissionConfig{
+							Columns: []string{"email"},
+						},
+					},
+				},
+			},
+			{
+				Table:            metadata.TableSource{Schema: "public", Name: "memberships"},
+				ApolloFederation: enabled,
+			},
+			{
+				Table:            metadata.TableSource{Schema: "public", Name: "events"},
+				ApolloFederation: enabled,
+			},
+			{
+				Table: metadata.TableSource{Schema: "public", Name: "posts"},
+			},
+		},
+	}
+}
+
+func TestFederationEntitiesForRole(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		role string
+		want []federation.Entity
+	}{
+		{
+			name: "admin sees every enabled table with a primary key",
+			role: roleAdmin,
+			want: []federation.Entity{
+				{TypeName: "User", SelectField: "User", KeyFields: []string{"id"}},
+				{
+					TypeName:    "memberships",
+					SelectField: "memberships",
+					KeyFields:   []string{"org_id", "user_id"},
+				},
+			},
+		},
+		{
+			name: "role with every key column",
+			role: "user",
+			want: []federation.Entity{
+				{TypeName: "User", SelectField: "User", KeyFields: []string{"id"}},
+			},
+		},
+		{
+			name: "role missing a key column",
+			role: "anonymous",
+			want: nil,
+		},
+		{
+			name: "role without select permission",
+			role: "guest",
+			want: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := FederationEntitiesForRole(federationObjects(), tc.role, federationMetadata())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FederationEntitiesForRole() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -623,8 +623,9 @@ func TestParseDBKind(t *testing.T) {
 		{name: "empty defaults to postgres", input: "", want: KindPostgres},
 		{name: "postgres explicit", input: "postgres", want: KindPostgres},
 		{name: "sqlite", input: "sqlite", want: KindSQLite},
+		{name: "mysql", input: "mysql", want: KindMySQL},
 		{name: "typo rejected", input: "postgress", wantErr: true},
-		{name: "unknown rejected", input: "mysql", wantErr: true},
+		{name: "unknown rejected", input: "mariadb", wantErr: true},
 		{name: "case-sensitive rejected", input: "Postgres", wantErr: true},
 	}
 
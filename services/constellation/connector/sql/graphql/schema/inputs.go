@@ -52,6 +52,16 @@ func comparisonOperatorType(op, scalarType string, caps Capabilities) *graph.Typ
 		return graph.NewNamedType("st_d_within_input")
 	case "_st_3d_d_within":
 		return graph.NewNamedType("st_d_within_input")
+	case "_ancestor_any", "_descendant_any", "_matches_any":
+		return graph.NewListType(graph.NewNonNullType("String"))
+	case "_matches":
+		return graph.NewNamedType(pgtypes.Lquery)
+	case "_matches_fulltext":
+		return graph.NewNamedType(pgtypes.Ltxtquery)
+	case "_cosine_distance_lt", "_inner_product_distance_lt", "_l2_distance_lt":
+		return graph.NewNamedType(vectorDistanceInput)
+	case "_match":
+		return graph.NewNamedType("String")
 	default:
 		return graph.NewNamedType(scalarType)
 	}
@@ -143,7 +153,7 @@ func generateSpatialOperatorInputs(
 }
 
 // getComparisonOperators returns the list of comparison operators for a scalar type.
-func getComparisonOperators(scalarType string, caps Capabilities) []string {
+func getComparisonOperators(scalarType string, caps Capabilities) []string { //nolint:cyclop,funlen
 	var ops []string
 
 	switch scalarType {
@@ -190,6 +200,32 @@ func getComparisonOperators(scalarType string, caps Capabilities) []string {
 			}
 		}
 
+		return []string{"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte", "_neq", "_nin"}
+	case pgtypes.Ltree:
+		if caps.SupportsLtree {
+			return []string{
+				"_ancestor", "_ancestor_any", "_descendant", "_descendant_any",
+				"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte",
+				"_matches", "_matches_any", "_matches_fulltext", "_neq", "_nin",
+			}
+		}
+
+		return []string{"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte", "_neq", "_nin"}
+	case pgtypes.Tsvector:
+		if caps.SupportsFullTextSearch {
+			return []string{"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte", "_match", "_neq", "_nin"}
+		}
+
+		return []string{"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte", "_neq", "_nin"}
+	case pgtypes.Vector:
+		if caps.SupportsVector {
+			return []string{
+				"_cosine_distance_lt", "_eq", "_gt", "_gte", "_in",
+				"_inner_product_distance_lt", "_is_null", "_l2_distance_lt",
+				"_lt", "_lte", "_neq", "_nin",
+			}
+		}
+
 		return []string{"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte", "_neq", "_nin"}
 	default:
 		return []string{"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte", "_neq", "_nin"}
@@ -321,6 +357,28 @@ func getOperatorDescription(op, scalarType string) string { //nolint:cyclop,funl
 		return "does the column have atleast one point in common with the given geometry value"
 	case "_st_within":
 		return "is the column contained in the given geometry value"
+	case "_ancestor":
+		return "is the left argument an ancestor of right (or equal)?"
+	case "_ancestor_any":
+		return "does array contain an ancestor of `ltree`?"
+	case "_descendant":
+		return "is the left argument a descendant of right (or equal)?"
+	case "_descendant_any":
+		return "does array contain a descendant of `ltree`?"
+	case "_matches":
+		return "does `ltree` match `lquery`?"
+	case "_matches_any":
+		return "does `ltree` match any `lquery` in array?"
+	case "_matches_fulltext":
+		return "does `ltree` match `ltxtquery`?"
+	case "_match":
+		return "does the column match the given web search query (websearch_to_tsquery)"
+	case "_cosine_distance_lt":
+		return "is the cosine distance between the column and the given vector less than the given distance"
+	case "_inner_product_distance_lt":
+		return "is the negative inner product of the column and the given vector less than the given distance"
+	case "_l2_distance_lt":
+		return "is the Euclidean distance between the column and the given vector less than the given distance"
 	default:
 		return ""
 	}
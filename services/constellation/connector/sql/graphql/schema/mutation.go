@@ -13,20 +13,17 @@ import (
 //
 // Views participate in the schema as read-or-write relations depending on
 // what the database itself allows. Postgres exposes that decision via
-// information_schema.views.is_insertable_into / is_updatable; SQLite views
+// pg_relation_is_updatable (see populateRelationKinds in
+// connector/sql/postgres/introspect.go); SQLite views
 // are read-only unless an INSTEAD OF trigger exists, in which case the
 // introspector detects the trigger and reports the view as writable (see
 // getViewMutability in connector/sql/sqlite/introspect.go). The IsInsertable
-// / IsUpdatable flags on tableInfo capture those decisions for both backends
-// and gate the corresponding mutations — even for admin, since admin's
+// / IsUpdatable / IsDeletable flags on tableInfo capture those decisions for
+// both backends and each gates its own mutations — even for admin, since admin's
 // "unconditional CRUD" is itself conditional on the database accepting the
-// write.
-//
-// Note: a writable SQLite view does get mutation fields here, but those
-// generated writes currently fail at execution time because the SQL pipeline
-// wraps DML in PostgreSQL-only data-modifying CTEs ("WITH ... AS (<DML> ...
-// RETURNING *)") that SQLite cannot parse. That is a runtime-execution gap,
-// separate from the field-generation gating this function performs.
+// write. On SQLite the writes run as staged statements (see
+// connector/sql/sqlite/staged.go), and SQLite fires the view's INSTEAD OF
+// trigger for each row the staged INSERT/UPDATE/DELETE ... RETURNING touches.
 func generateTableMutationFields(
 	mutationFields *[]*graph.Field,
 	tableMeta *metadata.TableMetadata,
@@ -36,13 +33,7 @@ func generateTableMutationFields(
 	role string,
 	md *metadata.DatabaseMetadata,
 ) {
-	// DELETE is intentionally gated on IsUpdatable. Postgres exposes
-	// is_trigger_deletable independently of is_updatable, so an INSTEAD OF
-	// trigger view can in principle be delete-only — but those are rare and
-	// would need a dedicated introspection signal to surface here. Until we
-	// add one, treat updatable-implies-deletable as the safe approximation:
-	// it correctly covers ordinary tables and simple (non-trigger) views.
-	if tableInfo.IsUpdatable &&
+	if tableInfo.IsDeletable &&
 		(role == roleAdmin || getDeletePermission(tableMeta, role) != nil) {
 		appendDeleteFields(mutationFields, tableMeta, tableInfo, customTableName, qualifiedName, md)
 	}
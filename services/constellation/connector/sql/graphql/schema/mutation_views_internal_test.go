@@ -12,8 +12,8 @@ import (
 
 // TestGenerateTableMutationFields_SkipsReadOnlyViews verifies that
 // generateTableMutationFields produces no insert/update/delete fields for
-// relations introspected as views with IsInsertable=false and
-// IsUpdatable=false (Postgres reports this for UNION ALL views, views over
+// relations introspected as views with IsInsertable=false,
+// IsUpdatable=false and IsDeletable=false (Postgres reports this for UNION ALL views, views over
 // aggregates, etc.). The admin role is used because the bug surfaced
 // specifically on admin: per-role permission gating already filtered the
 // mutations away for user/public roles.
@@ -32,6 +32,7 @@ func TestGenerateTableMutationFields_SkipsReadOnlyViews(t *testing.T) {
 		IsView:       true,
 		IsInsertable: false,
 		IsUpdatable:  false,
+		IsDeletable:  false,
 		Columns: []introspection.Column{
 			{Name: "id", Type: "uuid"},
 			{Name: "source", Type: "text"},
@@ -74,6 +75,7 @@ func TestGenerateTableMutationFields_UpdatableViewGetsMutations(t *testing.T) {
 		IsView:       true,
 		IsInsertable: true,
 		IsUpdatable:  true,
+		IsDeletable:  true,
 		Columns: []introspection.Column{
 			{Name: "id", Type: "uuid"},
 			{Name: "title", Type: "text"},
@@ -105,6 +107,48 @@ func TestGenerateTableMutationFields_UpdatableViewGetsMutations(t *testing.T) {
 	}
 }
 
+// TestGenerateTableMutationFields_DeleteOnlyViewGetsDeleteMutations verifies
+// that each flag gates its own mutations: a view that only accepts DELETE
+// (e.g. a SQLite view with just an INSTEAD OF DELETE trigger) gets the delete
+// mutations and neither the insert nor the update ones.
+func TestGenerateTableMutationFields_DeleteOnlyViewGetsDeleteMutations(t *testing.T) {
+	t.Parallel()
+
+	tableMeta := &metadata.TableMetadata{
+		Table: metadata.TableSource{Schema: "", Name: "archived_notes"},
+	}
+	tableInfo := &introspection.Table{
+		Name:         "archived_notes",
+		IsView:       true,
+		IsInsertable: false,
+		IsUpdatable:  false,
+		IsDeletable:  true,
+		PrimaryKeys:  []string{"id"},
+		Columns: []introspection.Column{
+			{Name: "id", Type: "integer"},
+			{Name: "title", Type: "text"},
+		},
+	}
+
+	var fields []*graph.Field
+	generateTableMutationFields(
+		&fields, tableMeta, tableInfo, "archived_notes", "archived_notes", roleAdmin,
+		&metadata.DatabaseMetadata{},
+	)
+
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+
+	slices.Sort(names)
+
+	want := []string{"delete_archived_notes", "delete_archived_notes_by_pk"}
+	if !slices.Equal(names, want) {
+		t.Errorf("mutation fields = %v, want %v", names, want)
+	}
+}
+
 // TestGenerateTableMutationFields_BaseTableGetsMutations is the regression
 // counterpart: base tables (IsView=false) must continue to receive
 // mutations for admin, regardless of the IsInsertable/IsUpdatable flags
@@ -124,6 +168,7 @@ func TestGenerateTableMutationFields_BaseTableGetsMutations(t *testing.T) {
 		IsView:       false,
 		IsInsertable: true,
 		IsUpdatable:  true,
+		IsDeletable:  true,
 		PrimaryKeys:  []string{"id"},
 		Columns: []introspection.Column{
 			{Name: "id", Type: "uuid"},
@@ -145,7 +190,8 @@ func TestGenerateTableMutationFields_BaseTableGetsMutations(t *testing.T) {
 // TestGenerateTableMutationFields_NonAdminRoleSkipsReadOnlyView locks in the
 // AND-semantics of the mutation gate for non-admin roles: even when the role
 // has explicit insert/update/delete permissions configured in metadata, a
-// view introspected as IsInsertable=false / IsUpdatable=false must still
+// view introspected as IsInsertable=false / IsUpdatable=false /
+// IsDeletable=false must still
 // emit no mutation fields. The role-permission branch of the gate must not
 // override the database's read-only verdict. The admin subtest above covers
 // the role==admin half of the OR; this covers the permission-bearing half.
@@ -173,6 +219,7 @@ func TestGenerateTableMutationFields_NonAdminRoleSkipsReadOnlyView(t *testing.T)
 		IsView:       true,
 		IsInsertable: false,
 		IsUpdatable:  false,
+		IsDeletable:  false,
 		Columns: []introspection.Column{
 			{Name: "id", Type: "uuid"},
 			{Name: "title", Type: "text"},
@@ -243,6 +290,7 @@ func TestGenerateForRole_ObjRelToReadOnlyViewHasNoDanglingRef(t *testing.T) {
 				IsView:       false,
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				PrimaryKeys:  []string{"id"},
 				Columns: []introspection.Column{
 					{Name: "id", Type: "uuid"},
@@ -255,6 +303,7 @@ func TestGenerateForRole_ObjRelToReadOnlyViewHasNoDanglingRef(t *testing.T) {
 				IsView:       true,
 				IsInsertable: false,
 				IsUpdatable:  false,
+				IsDeletable:  false,
 				Columns: []introspection.Column{
 					{Name: "id", Type: "uuid"},
 					{Name: "title", Type: "text"},
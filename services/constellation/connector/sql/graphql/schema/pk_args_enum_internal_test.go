@@ -50,6 +50,7 @@ func TestPkArgs_EnumFKResolvedToEnumType(t *testing.T) {
 				Name:         "muscle_groups",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				PrimaryKeys:  []string{"value"},
 				Columns: []introspection.Column{
 					{Name: "value", Type: "text"},
@@ -60,6 +61,7 @@ func TestPkArgs_EnumFKResolvedToEnumType(t *testing.T) {
 				Name:         "exercise_secondary_muscle_groups",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				PrimaryKeys:  []string{"exercise_id", "muscle_group"},
 				Columns: []introspection.Column{
 					{Name: "exercise_id", Type: "uuid"},
@@ -166,6 +168,7 @@ func TestPkArgs_EnumFKResolvedToEnumType_NullableIntrospection(t *testing.T) {
 				Name:         "muscle_groups",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				PrimaryKeys:  []string{"value"},
 				Columns: []introspection.Column{
 					{Name: "value", Type: "text"},
@@ -176,6 +179,7 @@ func TestPkArgs_EnumFKResolvedToEnumType_NullableIntrospection(t *testing.T) {
 				Name:         "exercise_secondary_muscle_groups",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				PrimaryKeys:  []string{"exercise_id", "muscle_group"},
 				Columns: []introspection.Column{
 					// Both PK columns introspected as nullable — mirrors the
@@ -290,6 +294,7 @@ func TestPkArgs_NullableIntrospectedColumnStillNonNull(t *testing.T) {
 				Name:         "items",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				PrimaryKeys:  []string{"id"},
 				Columns: []introspection.Column{
 					// SQLite reports IsNullable=true for a bare `id TEXT
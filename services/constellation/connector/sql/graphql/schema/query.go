@@ -50,10 +50,7 @@ func generateCollectionField(
 	qualifiedName string,
 	caps Capabilities,
 ) *graph.Field {
-	collectionName := customTableName
-	if tableMeta.Configuration.CustomRootFields.Select != "" {
-		collectionName = tableMeta.Configuration.CustomRootFields.Select
-	}
+	collectionName := selectFieldName(tableMeta, customTableName)
 
 	arguments := collectionArguments(customTableName, caps)
 
@@ -68,6 +65,16 @@ func generateCollectionField(
 	}
 }
 
+// selectFieldName returns the name of the table's collection root field:
+// the custom select root field when configured, the type name otherwise.
+func selectFieldName(tableMeta *metadata.TableMetadata, customTableName string) string {
+	if tableMeta.Configuration.CustomRootFields.Select != "" {
+		return tableMeta.Configuration.CustomRootFields.Select
+	}
+
+	return customTableName
+}
+
 // collectionArguments returns the standard arguments for collection fields,
 // conditionally including distinct_on based on database capabilities.
 func collectionArguments(customTableName string, caps Capabilities) []*graph.Argument {
@@ -385,6 +392,7 @@ func generateTableQueryInputTypes( //nolint:funlen
 	})
 
 	orderByFields := []*graph.InputField{}
+	orderByNames := orderByFieldNames(tableMeta, tableInfo, allowedColumns)
 
 	for _, col := range tableInfo.Columns {
 		if _, ok := allowedColumns[col.Name]; !ok {
@@ -396,6 +404,10 @@ func generateTableQueryInputTypes( //nolint:funlen
 			Description: getColumnDescription(&col),
 			Type:        graph.NewNamedType("order_by"),
 		})
+
+		if field := vectorDistanceOrderByField(tableMeta, &col, orderByNames, caps); field != nil {
+			orderByFields = append(orderByFields, field)
+		}
 	}
 
 	orderByFields = append(
@@ -46,6 +46,7 @@ func generateScalars(
 	}
 
 	generateSpatialOperatorInputs(schema, selectUsedScalars, caps)
+	generateVectorInputs(schema, selectUsedScalars, caps)
 
 	// Generate array comparison input types for each select-visible array element type.
 	if caps.SupportsArrays {
@@ -82,6 +83,13 @@ func ensureCastTargetScalars(
 		usedScalars["String"] = struct{}{}
 	}
 
+	// The ltree operators take lquery/ltxtquery arguments; declare those
+	// scalars without giving them comparison inputs of their own.
+	if _, hasLtree := selectUsedScalars[pgtypes.Ltree]; hasLtree && caps.SupportsLtree {
+		usedScalars[pgtypes.Lquery] = struct{}{}
+		usedScalars[pgtypes.Ltxtquery] = struct{}{}
+	}
+
 	if !caps.SupportsSpatialTypes {
 		return
 	}
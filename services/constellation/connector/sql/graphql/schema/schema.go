@@ -62,6 +62,8 @@ const (
 	KindPostgres DBKind = "postgres"
 	// KindSQLite is the DBKind value for SQLite backends.
 	KindSQLite DBKind = "sqlite"
+	// KindMySQL is the DBKind value for MySQL backends.
+	KindMySQL DBKind = "mysql"
 )
 
 // Capabilities describes the SQL dialect's feature support for schema
@@ -95,20 +97,31 @@ type Capabilities struct {
 	// surfaces so they are advertised only by backends with matching runtime SQL
 	// hooks.
 	SupportsSpatialTypes bool
+	// SupportsLtree gates the ltree operators (_ancestor, _descendant,
+	// _matches, _matches_fulltext and their _any forms) on ltree
+	// comparison_exp inputs, and the lquery/ltxtquery scalars they take.
+	SupportsLtree bool
+	// SupportsFullTextSearch gates the Constellation _match operator on
+	// tsvector comparison_exp inputs.
+	SupportsFullTextSearch bool
+	// SupportsVector gates the pgvector distance operators on vector
+	// comparison_exp inputs and the `<col>_distance` order_by fields.
+	SupportsVector bool
 	// SupportsVarianceAggregates gates the emission of the stddev/variance
 	// aggregate family (stddev, stddev_pop, stddev_samp, var_pop, var_samp,
 	// variance) on <table>_aggregate_fields and their <table>_<fn>_fields object
-	// types. SQLite lacks these aggregate functions, so exposing the fields would
-	// produce an opaque runtime "no such function" error; omitting them yields a
-	// clean GraphQL validation error instead. avg/sum/min/max/count are native
-	// everywhere and are emitted regardless.
+	// types. On a backend lacking these aggregate functions, exposing the fields
+	// would produce an opaque runtime "no such function" error; omitting them
+	// yields a clean GraphQL validation error instead. avg/sum/min/max/count are
+	// native everywhere and are emitted regardless.
 	SupportsVarianceAggregates bool
 	// SupportsStableVarianceOrderBy gates the emission of the stddev/variance
 	// aggregate order_by family (the <table>_<fn>_order_by input types and the
 	// stddev/stddev_pop/stddev_samp/var_pop/var_samp/variance fields on
 	// <table>_aggregate_order_by). It is distinct from
 	// SupportsVarianceAggregates: ordering needs a result numerically faithful to
-	// PostgreSQL's, which SQLite cannot provide, so the aggregate-order_by builder
+	// PostgreSQL's, which an emulation via the one-pass sum-of-squares identity
+	// cannot provide, so the aggregate-order_by builder
 	// rejects these functions when the backend lacks stable variance ordering
 	// (see queries/arguments.varianceOrderByFuncs). Advertising them in the
 	// schema while the runtime rejects them is schema/runtime drift; gating both
@@ -125,18 +138,21 @@ var ErrUnknownDBKind = errors.New("unknown database kind")
 
 // ParseDBKind converts an untyped string (typically
 // metadata.DatabaseMetadata.Kind) into a typed DBKind, returning
-// ErrUnknownDBKind for any value other than "", "postgres", or "sqlite". The
-// empty string is mapped to KindPostgres for backwards compatibility with
-// older metadata that predates the Kind field. This is the single validation
-// point for the otherwise-unchecked string→DBKind boundary; bypassing it
-// (i.e. constructing DBKind("postgress") via a direct conversion) silently
-// routes through the non-Postgres namespacing branch with no diagnostic.
+// ErrUnknownDBKind for any value other than "", "postgres", "sqlite", or
+// "mysql". The empty string is mapped to KindPostgres for backwards
+// compatibility with older metadata that predates the Kind field. This is the
+// single validation point for the otherwise-unchecked string→DBKind boundary;
+// bypassing it (i.e. constructing DBKind("postgress") via a direct conversion)
+// silently routes through the non-Postgres namespacing branch with no
+// diagnostic.
 func ParseDBKind(s string) (DBKind, error) {
 	switch s {
 	case "", string(KindPostgres):
 		return KindPostgres, nil
 	case string(KindSQLite):
 		return KindSQLite, nil
+	case string(KindMySQL):
+		return KindMySQL, nil
 	default:
 		return "", fmt.Errorf("%w: %q", ErrUnknownDBKind, s)
 	}
@@ -158,6 +174,9 @@ func NewCapabilities(kind DBKind, dial dialect.Dialect) Capabilities {
 		SupportsFunctions:             dial.SupportsFunctions(),
 		SupportsArrays:                dial.SupportsArrays(),
 		SupportsSpatialTypes:          dial.SupportsSpatialTypes(),
+		SupportsLtree:                 dial.SupportsLtree(),
+		SupportsFullTextSearch:        dial.SupportsFullTextSearch(),
+		SupportsVector:                dial.SupportsVector(),
 		SupportsVarianceAggregates:    dial.SupportsVarianceAggregates(),
 		SupportsStableVarianceOrderBy: dial.SupportsStableVarianceOrderBy(),
 	}
@@ -160,8 +160,8 @@ func TestGenerateForRole_SQLite(t *testing.T) { //nolint:paralleltest
 				SupportsFunctions:             false,
 				SupportsArrays:                false,
 				SupportsSpatialTypes:          false,
-				SupportsVarianceAggregates:    false,
-				SupportsStableVarianceOrderBy: false,
+				SupportsVarianceAggregates:    true,
+				SupportsStableVarianceOrderBy: true,
 			}, role)
 
 			testhelpers.GoldenGraphQLSchema(
@@ -182,10 +182,10 @@ var varianceAggregateFields = []string{ //nolint:gochecknoglobals
 
 // TestGenerateForRole_VarianceAggregateGating asserts that the stddev/variance
 // aggregate-selection family is gated by Capabilities.SupportsVarianceAggregates:
-// exposed (with its *_fields object types) on PostgreSQL, omitted on SQLite,
-// while the native avg/sum aggregates remain on both. It is the explicit,
-// self-documenting counterpart to the schema goldens, which assert the same
-// thing indirectly across the whole SDL.
+// exposed (with its *_fields object types) when the flag is set, omitted when it
+// is not, while the native avg/sum aggregates remain either way. Every current
+// backend sets it, so the omitted case runs SQLite introspection with the flag
+// cleared.
 //
 // It is a deliberate parallel of TestGenerateForRole_VarianceOrderByGating; the
 // two assert distinct surfaces (aggregate-selection *_fields vs aggregate
@@ -223,7 +223,7 @@ func TestGenerateForRole_VarianceAggregateGating(t *testing.T) {
 			wantVarianceFams: true,
 		},
 		{
-			name: "sqlite omits variance aggregates",
+			name: "backend without variance aggregates omits them",
 			objects: func(t *testing.T, dbMeta *metadata.DatabaseMetadata) *introspection.Objects {
 				t.Helper()
 
@@ -311,8 +311,8 @@ var varianceOrderByFields = []string{ //nolint:gochecknoglobals
 // TestGenerateForRole_VarianceOrderByGating asserts that the stddev/variance
 // aggregate order_by family is gated by Capabilities.SupportsStableVarianceOrderBy:
 // advertised (with its *_order_by input types and the matching fields on
-// <table>_aggregate_order_by) on PostgreSQL, omitted on SQLite, while avg/sum
-// order_by remain on both. It mirrors TestGenerateForRole_VarianceAggregateGating
+// <table>_aggregate_order_by) when the flag is set, omitted when it is not,
+// while avg/sum order_by remain either way. It mirrors TestGenerateForRole_VarianceAggregateGating
 // for the order_by surface and pins the schema/runtime contract checked by the
 // aggregate-order_by builder (queries/arguments.varianceOrderByFuncs).
 //
@@ -357,7 +357,7 @@ func TestGenerateForRole_VarianceOrderByGating(t *testing.T) {
 			wantVarianceOBs: true,
 		},
 		{
-			name: "sqlite omits variance order_by",
+			name: "backend without stable variance ordering omits them",
 			objects: func(t *testing.T, dbMeta *metadata.DatabaseMetadata) *introspection.Objects {
 				t.Helper()
 
@@ -431,6 +431,85 @@ func TestGenerateForRole_VarianceOrderByGating(t *testing.T) {
 	}
 }
 
+// TestGenerateForRole_SQLiteWritableSurface asserts that the admin schema of a
+// SQLite source reflects what the database accepts: generated columns (STORED
+// and VIRTUAL) are readable but absent from the insert and _set inputs, a view
+// gets exactly the mutations its INSTEAD OF triggers back (no delete without an
+// INSTEAD OF DELETE trigger), and a view without triggers gets none.
+func TestGenerateForRole_SQLiteWritableSurface(t *testing.T) {
+	t.Parallel()
+
+	const ddl = `CREATE TABLE items (
+	id integer PRIMARY KEY,
+	price integer NOT NULL,
+	total integer GENERATED ALWAYS AS (price * 2) STORED,
+	label text GENERATED ALWAYS AS ('item ' || id) VIRTUAL
+);
+CREATE VIEW item_prices AS SELECT id, price FROM items;
+CREATE TRIGGER item_prices_insert INSTEAD OF INSERT ON item_prices BEGIN
+	INSERT INTO items (id, price) VALUES (NEW.id, NEW.price);
+END;
+CREATE TRIGGER item_prices_update INSTEAD OF UPDATE ON item_prices BEGIN
+	UPDATE items SET price = NEW.price WHERE id = OLD.id;
+END;
+CREATE VIEW item_labels AS SELECT id, label FROM items;`
+
+	md := &metadata.DatabaseMetadata{
+		Name: "default",
+		Kind: "sqlite",
+		Tables: []metadata.TableMetadata{
+			{Table: metadata.TableSource{Schema: "main", Name: "items"}},
+			{Table: metadata.TableSource{Schema: "main", Name: "item_prices"}},
+			{Table: metadata.TableSource{Schema: "main", Name: "item_labels"}},
+		},
+	}
+
+	sqlite.FlattenMetadata(md)
+
+	sch, err := schema.GenerateForRole(
+		testdb.IntrospectSQLite(t, ddl, md), "admin", md, schema.Capabilities{
+			Kind:                          schema.KindSQLite,
+			SupportsRegex:                 false,
+			SupportsJSONB:                 false,
+			SupportsDistinctOn:            false,
+			SupportsFunctions:             false,
+			SupportsArrays:                false,
+			SupportsSpatialTypes:          false,
+			SupportsVarianceAggregates:    true,
+			SupportsStableVarianceOrderBy: true,
+		},
+	)
+	if err != nil {
+		t.Fatalf("GenerateForRole returned error: %v", err)
+	}
+
+	if fields := objectTypeFields(t, sch, "items"); !fields["total"] || !fields["label"] {
+		t.Errorf("items must expose its generated columns for reading, got %v", fields)
+	}
+
+	for _, input := range []string{"items_insert_input", "items_set_input"} {
+		fields := inputObjectFields(t, sch, input)
+		if !fields["price"] || fields["total"] || fields["label"] {
+			t.Errorf("%s = %v, want price without the generated total/label", input, fields)
+		}
+	}
+
+	mutations := objectTypeFields(t, sch, "mutation_root")
+
+	for field, want := range map[string]bool{
+		"insert_item_prices": true,
+		"update_item_prices": true,
+		"delete_item_prices": false,
+		"insert_item_labels": false,
+		"update_item_labels": false,
+		"delete_item_labels": false,
+	} {
+		if mutations[field] != want {
+			t.Errorf("mutation_root.%s present = %t, want %t", field, mutations[field], want)
+		}
+	}
+}
+
 // objectTypeFields returns the field-name set of the named object type in sch,
 // failing the test if the type is absent.
 func objectTypeFields(t *testing.T, sch *graph.Schema, typeName string) map[string]bool {
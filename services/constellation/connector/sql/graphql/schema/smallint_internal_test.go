@@ -27,6 +27,7 @@ func TestGenerateForRole_SmallintColumnsUseCustomScalar(t *testing.T) {
 				Name:         "metrics",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				PrimaryKeys:  []string{"id"},
 				Columns: []introspection.Column{
 					{Name: "id", Type: "int2", SupportsMinMax: true},
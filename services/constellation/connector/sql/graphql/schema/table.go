@@ -105,7 +105,7 @@ func generateForTable( //nolint:funlen
 		caps,
 	)
 
-	if (tableInfo.IsInsertable || tableInfo.IsUpdatable) &&
+	if (tableInfo.IsInsertable || tableInfo.IsUpdatable || tableInfo.IsDeletable) &&
 		(role == roleAdmin ||
 			getInsertPermission(tableMeta, role) != nil ||
 			getUpdatePermission(tableMeta, role) != nil ||
@@ -170,7 +170,7 @@ func collectMutationColumnTypeUses(
 
 	setInputGenerated := tableInfo.IsUpdatable && updatePermission && len(updateCols) > 0
 	pkColumnsInputGenerated := setInputGenerated && len(tableInfo.PrimaryKeys) > 0
-	deleteByPKGenerated := tableInfo.IsUpdatable && deletePermission &&
+	deleteByPKGenerated := tableInfo.IsDeletable && deletePermission &&
 		len(tableInfo.PrimaryKeys) > 0
 
 	for i := range tableInfo.Columns {
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/pgtypes"
+	"github.com/nhost/nhost/services/constellation/graph"
+)
+
+func TestGetComparisonOperatorsTextSearch(t *testing.T) {
+	t.Parallel()
+
+	standard := []string{"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte", "_neq", "_nin"}
+	tests := []struct {
+		name   string
+		scalar string
+		caps   Capabilities
+		want   []string
+	}{
+		{
+			name:   "ltree",
+			scalar: pgtypes.Ltree,
+			caps:   Capabilities{SupportsLtree: true},
+			want: []string{
+				"_ancestor", "_ancestor_any", "_descendant", "_descendant_any",
+				"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte",
+				"_matches", "_matches_any", "_matches_fulltext", "_neq", "_nin",
+			},
+		},
+		{
+			name:   "ltree without SupportsLtree",
+			scalar: pgtypes.Ltree,
+			caps:   Capabilities{},
+			want:   standard,
+		},
+		{
+			name:   "tsvector",
+			scalar: pgtypes.Tsvector,
+			caps:   Capabilities{SupportsFullTextSearch: true},
+			want:   []string{"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte", "_match", "_neq", "_nin"},
+		},
+		{
+			name:   "tsvector without SupportsFullTextSearch",
+			scalar: pgtypes.Tsvector,
+			caps:   Capabilities{},
+			want:   standard,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := getComparisonOperators(tt.scalar, tt.caps); !slices.Equal(got, tt.want) {
+				t.Fatalf("operators = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestGenerateScalarsLtreeDeclaresQueryScalars checks that the lquery and
+// ltxtquery argument types of ltree_comparison_exp are declared without
+// comparison inputs of their own.
+func TestGenerateScalarsLtreeDeclaresQueryScalars(t *testing.T) {
+	t.Parallel()
+
+	sch := &graph.Schema{}
+	generateScalars(
+		sch,
+		map[string]struct{}{pgtypes.Ltree: {}},
+		map[string]struct{}{pgtypes.Ltree: {}},
+		map[string]struct{}{},
+		Capabilities{SupportsLtree: true},
+	)
+
+	scalars := make([]string, 0, len(sch.Scalars))
+	for _, s := range sch.Scalars {
+		scalars = append(scalars, s.Name)
+	}
+
+	if want := []string{"lquery", "ltree", "ltxtquery"}; !slices.Equal(scalars, want) {
+		t.Errorf("scalars = %v, want %v", scalars, want)
+	}
+
+	if len(sch.Inputs) != 1 || sch.Inputs[0].Name != "ltree_comparison_exp" {
+		t.Fatalf("inputs = %v, want only ltree_comparison_exp", sch.Inputs)
+	}
+
+	types := map[string]*graph.Type{}
+	for _, f := range sch.Inputs[0].Fields {
+		types[f.Name] = f.Type
+	}
+
+	for field, want := range map[string]string{
+		"_ancestor":         pgtypes.Ltree,
+		"_matches":          pgtypes.Lquery,
+		"_matches_fulltext": pgtypes.Ltxtquery,
+	} {
+		if got := types[field]; got == nil || got.NamedType != want {
+			t.Errorf("%s type = %+v, want %s", field, got, want)
+		}
+	}
+
+	if got := types["_ancestor_any"]; got == nil || got.Elem == nil ||
+		got.Elem.NamedType != "String" || !got.Elem.NonNull {
+		t.Errorf("_ancestor_any type = %+v, want [String!]", got)
+	}
+}
@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"github.com/nhost/nhost/services/constellation/connector/sql/introspection"
+	"github.com/nhost/nhost/services/constellation/connector/sql/pgtypes"
+	"github.com/nhost/nhost/services/constellation/graph"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+const (
+	vectorDistanceInput   = "vector_distance_input"
+	vectorDistanceOrderBy = "vector_distance_order_by"
+	vectorDistanceMetric  = "vector_distance_metric"
+)
+
+// generateVectorInputs declares the pgvector distance operator input, the
+// distance order_by input and the metric enum they share. Like the spatial
+// inputs they are only emitted once a vector column is select-visible.
+func generateVectorInputs(
+	schema *graph.Schema,
+	selectUsedScalars map[string]struct{},
+	caps Capabilities,
+) {
+	if !caps.SupportsVector {
+		return
+	}
+
+	if _, hasVector := selectUsedScalars[pgtypes.Vector]; !hasVector {
+		return
+	}
+
+	defaultDirection := "asc"
+	defaultMetric := "l2"
+
+	schema.Inputs = append(schema.Inputs,
+		&graph.InputObjectType{ //nolint:exhaustruct
+			Name: vectorDistanceInput,
+			Fields: []*graph.InputField{
+				{Name: "distance", Type: graph.NewNonNullType("Float")},
+				{Name: "from", Type: graph.NewNonNullType(pgtypes.Vector)},
+			},
+		},
+		&graph.InputObjectType{ //nolint:exhaustruct
+			Name:        vectorDistanceOrderBy,
+			Description: "Ordering by the distance between a vector column and the given vector",
+			Fields: []*graph.InputField{
+				{
+					Name:         "direction",
+					Type:         graph.NewNamedType("order_by"),
+					DefaultValue: &defaultDirection,
+				},
+				{
+					Name:         "metric",
+					Type:         graph.NewNamedType(vectorDistanceMetric),
+					DefaultValue: &defaultMetric,
+				},
+				{Name: "to", Type: graph.NewNonNullType(pgtypes.Vector)},
+			},
+		},
+	)
+
+	schema.Enums = append(schema.Enums, &graph.EnumType{ //nolint:exhaustruct
+		Name:        vectorDistanceMetric,
+		Description: "pgvector distance metrics",
+		Values: []*graph.EnumValue{
+			{Name: "cosine", Description: "cosine distance (<=>)"},
+			{Name: "inner_product", Description: "negative inner product (<#>)"},
+			{Name: "l2", Description: "Euclidean distance (<->)"},
+		},
+	})
+}
+
+// orderByFieldNames returns the names of a table's column and relationship
+// order_by fields, which a `<col>_distance` field must not shadow.
+func orderByFieldNames(
+	tableMeta *metadata.TableMetadata,
+	tableInfo *introspection.Table,
+	allowedColumns map[string]struct{},
+) map[string]struct{} {
+	names := make(map[string]struct{}, len(allowedColumns))
+
+	for _, col := range tableInfo.Columns {
+		if _, ok := allowedColumns[col.Name]; ok {
+			names[getCustomColumnName(tableMeta, col.Name)] = struct{}{}
+		}
+	}
+
+	for _, rel := range tableMeta.ObjectRelationships {
+		names[rel.Name] = struct{}{}
+	}
+
+	for _, rel := range tableMeta.ArrayRelationships {
+		names[rel.Name+"_aggregate"] = struct{}{}
+	}
+
+	return names
+}
+
+// vectorDistanceOrderByField returns the `<col>_distance` order_by field for a
+// vector column, or nil when the column is not a vector, the dialect has no
+// pgvector support, or the name is already taken by another order_by field.
+func vectorDistanceOrderByField(
+	tableMeta *metadata.TableMetadata,
+	col *introspection.Column,
+	taken map[string]struct{},
+	caps Capabilities,
+) *graph.InputField {
+	if !caps.SupportsVector || col.IsArray || !pgtypes.IsVector(col.Type) {
+		return nil
+	}
+
+	columnName := getCustomColumnName(tableMeta, col.Name)
+
+	name := columnName + "_distance"
+	if _, ok := taken[name]; ok {
+		return nil
+	}
+
+	return &graph.InputField{ //nolint:exhaustruct
+		Name:        name,
+		Description: "distance of " + columnName + " from a given vector",
+		Type:        graph.NewNamedType(vectorDistanceOrderBy),
+	}
+}
@@ -0,0 +1,114 @@
+package schema
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/introspection"
+	"github.com/nhost/nhost/services/constellation/connector/sql/pgtypes"
+	"github.com/nhost/nhost/services/constellation/graph"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+func TestGetComparisonOperatorsVector(t *testing.T) {
+	t.Parallel()
+
+	want := []string{
+		"_cosine_distance_lt", "_eq", "_gt", "_gte", "_in",
+		"_inner_product_distance_lt", "_is_null", "_l2_distance_lt",
+		"_lt", "_lte", "_neq", "_nin",
+	}
+	if got := getComparisonOperators(pgtypes.Vector, Capabilities{SupportsVector: true}); !slices.Equal(got, want) {
+		t.Errorf("operators = %v, want %v", got, want)
+	}
+
+	standard := []string{"_eq", "_gt", "_gte", "_in", "_is_null", "_lt", "_lte", "_neq", "_nin"}
+	if got := getComparisonOperators(pgtypes.Vector, Capabilities{}); !slices.Equal(got, standard) {
+		t.Errorf("operators without SupportsVector = %v, want %v", got, standard)
+	}
+}
+
+func TestGenerateVectorInputs(t *testing.T) {
+	t.Parallel()
+
+	selectUsed := map[string]struct{}{pgtypes.Vector: {}}
+
+	sch := &graph.Schema{}
+	generateVectorInputs(sch, selectUsed, Capabilities{})
+
+	if len(sch.Inputs) != 0 || len(sch.Enums) != 0 {
+		t.Fatalf("emitted vector inputs without SupportsVector: %v %v", sch.Inputs, sch.Enums)
+	}
+
+	generateVectorInputs(sch, selectUsed, Capabilities{SupportsVector: true})
+
+	inputs := make([]string, 0, len(sch.Inputs))
+	for _, in := range sch.Inputs {
+		inputs = append(inputs, in.Name)
+	}
+
+	if want := []string{"vector_distance_input", "vector_distance_order_by"}; !slices.Equal(inputs, want) {
+		t.Errorf("inputs = %v, want %v", inputs, want)
+	}
+
+	if len(sch.Enums) != 1 || sch.Enums[0].Name != "vector_distance_metric" {
+		t.Errorf("enums = %v, want vector_distance_metric", sch.Enums)
+	}
+}
+
+func TestVectorDistanceOrderByField(t *testing.T) {
+	t.Parallel()
+
+	tableMeta := &metadata.TableMetadata{
+		ObjectRelationships: []metadata.ObjectRelationship{{Name: "summary_distance"}},
+	}
+	caps := Capabilities{SupportsVector: true}
+
+	tests := []struct {
+		name string
+		col  introspection.Column
+		caps Capabilities
+		want string
+	}{
+		{
+			name: "vector column",
+			col:  introspection.Column{Name: "embedding", Type: "vector"},
+			caps: caps,
+			want: "embedding_distance",
+		},
+		{
+			name: "without SupportsVector",
+			col:  introspection.Column{Name: "embedding", Type: "vector"},
+			caps: Capabilities{},
+		},
+		{
+			name: "non-vector column",
+			col:  introspection.Column{Name: "title", Type: "text"},
+			caps: caps,
+		},
+		{
+			name: "collides with a relationship",
+			col:  introspection.Column{Name: "summary", Type: "vector"},
+			caps: caps,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			taken := orderByFieldNames(tableMeta, &introspection.Table{}, nil)
+
+			field := vectorDistanceOrderByField(tableMeta, &tt.col, taken, tt.caps)
+
+			var got string
+			if field != nil {
+				got = field.Name
+			}
+
+			if got != tt.want {
+				t.Errorf("field = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
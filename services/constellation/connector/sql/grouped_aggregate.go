@@ -48,6 +48,8 @@ func (c *Connector) ExecuteGroupedAggregate(
 		return nil, err
 	}
 
+	ctx = WithSessionVariables(ctx, sessionVariables)
+
 	results, err := c.driver.ExecuteOperations(ctx, []core.SQLOperation{op}, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute grouped aggregate: %w", err)
@@ -5,6 +5,7 @@ package introspection
 import (
 	"errors"
 	"fmt"
+	"slices"
 )
 
 // ErrInvalidEnumTable is the sentinel returned for any validation failure
@@ -124,7 +125,14 @@ type Column struct {
 }
 
 // ForeignKey represents a foreign key relationship from a column to another table.
+// A composite foreign key is represented by one entry per column, all sharing
+// the same ConstraintName and listed contiguously in key order.
 type ForeignKey struct {
+	// ConstraintName identifies the foreign-key constraint the entry belongs
+	// to. PostgreSQL reports the constraint name; SQLite, which has no
+	// names for foreign keys, uses the PRAGMA foreign_key_list id. Empty
+	// when unknown, in which case entries are matched column by column.
+	ConstraintName string
 	// ColumnName is the column in the source table that holds the foreign
 	// key.
 	ColumnName string
@@ -181,11 +189,15 @@ type Table struct {
 	// relation. Always true for base tables; for views it mirrors
 	// PostgreSQL's information_schema.views.is_insertable_into.
 	IsInsertable bool
-	// IsUpdatable reports whether UPDATE and DELETE are allowed against
-	// the relation. Always true for base tables; for views it mirrors
-	// PostgreSQL's information_schema.views.is_updatable, which gates
-	// both UPDATE and DELETE per the SQL standard.
+	// IsUpdatable reports whether UPDATEs are allowed against the
+	// relation. Always true for base tables; for views it mirrors the
+	// UPDATE bit of PostgreSQL's pg_relation_is_updatable.
 	IsUpdatable bool
+	// IsDeletable reports whether DELETEs are allowed against the
+	// relation. Always true for base tables; for views it mirrors the
+	// DELETE bit of PostgreSQL's pg_relation_is_updatable, so a view with
+	// only an INSTEAD OF DELETE trigger is deletable but not updatable.
+	IsDeletable bool
 }
 
 // maxEnumTableColumns is the upper bound for an enum table: the primary-key value
@@ -237,41 +249,111 @@ func (t *Table) EnumColumns() (string, string, error) {
 	return valueCol, descCol, nil
 }
 
-// LookupForwardFKTarget walks fkColumns against t.ForeignKeys and returns the
-// shared (ForeignSchema, ForeignTable) all listed columns reference. It returns
-// empty strings when any column has no matching foreign key or when the listed
-// columns disagree on the target — both indicate a metadata/introspection
-// mismatch that callers treat as a misconfigured relationship.
+// LookupForwardFKTarget returns the (ForeignSchema, ForeignTable) the foreign
+// key on fkColumns references (see ForwardForeignKey). It returns empty
+// strings when no foreign key matches, which callers treat as a misconfigured
+// relationship.
 func (t *Table) LookupForwardFKTarget(fkColumns []string) (string, string) {
-	var (
-		schema string
-		name   string
-	)
+	fks := t.ForwardForeignKey(fkColumns)
+	if len(fks) == 0 {
+		return "", ""
+	}
+
+	return fks[0].ForeignSchema, fks[0].ForeignTable
+}
+
+// ForwardForeignKey returns the foreign-key entries backing a relationship
+// declared on fkColumns of t, ordered like fkColumns. A constraint whose
+// columns are exactly fkColumns (in any order) wins; this is what keeps a
+// column shared by several composite keys — a tenant_id in every
+// tenant-scoped key — paired with the right target. Without such a
+// constraint each column is matched on its own, and the columns must then
+// agree on the same target table. It returns nil when nothing matches.
+func (t *Table) ForwardForeignKey(fkColumns []string) []ForeignKey {
+	return t.matchForeignKey(fkColumns, func(ForeignKey) bool { return true })
+}
+
+// ReferencingForeignKey is ForwardForeignKey restricted to foreign keys that
+// reference the table schema.name. It resolves the reverse side of a
+// relationship (foreign_key_constraint_on with a table), where the foreign
+// key lives on t and points back at the relationship's parent table. An empty
+// ForeignSchema (SQLite) matches any schema.
+func (t *Table) ReferencingForeignKey(fkColumns []string, schema, name string) []ForeignKey {
+	return t.matchForeignKey(fkColumns, func(fk ForeignKey) bool {
+		return fk.ForeignTable == name && (fk.ForeignSchema == "" || fk.ForeignSchema == schema)
+	})
+}
+
+func (t *Table) matchForeignKey(
+	fkColumns []string,
+	accept func(ForeignKey) bool,
+) []ForeignKey {
+	if len(fkColumns) == 0 {
+		return nil
+	}
+
+	if fks := t.constraintForeignKey(fkColumns, accept); fks != nil {
+		return fks
+	}
+
+	fks := make([]ForeignKey, 0, len(fkColumns))
 
 	for _, col := range fkColumns {
-		matched := false
+		i := slices.IndexFunc(t.ForeignKeys, func(fk ForeignKey) bool {
+			return fk.ColumnName == col && accept(fk)
+		})
+		if i < 0 {
+			return nil
+		}
 
-		for _, fk := range t.ForeignKeys {
-			if fk.ColumnName != col {
-				continue
-			}
+		fk := t.ForeignKeys[i]
+		if len(fks) > 0 &&
+			(fk.ForeignSchema != fks[0].ForeignSchema || fk.ForeignTable != fks[0].ForeignTable) {
+			return nil
+		}
 
-			if schema == "" && name == "" {
-				schema = fk.ForeignSchema
-				name = fk.ForeignTable
-			} else if fk.ForeignSchema != schema || fk.ForeignTable != name {
-				return "", ""
-			}
+		fks = append(fks, fk)
+	}
+
+	return fks
+}
+
+// constraintForeignKey returns the entries of the named constraint whose
+// column set equals fkColumns, reordered to follow fkColumns, or nil.
+func (t *Table) constraintForeignKey(
+	fkColumns []string,
+	accept func(ForeignKey) bool,
+) []ForeignKey {
+	for start := 0; start < len(t.ForeignKeys); {
+		name := t.ForeignKeys[start].ConstraintName
+
+		end := start + 1
+		for end < len(t.ForeignKeys) && t.ForeignKeys[end].ConstraintName == name {
+			end++
+		}
+
+		group := t.ForeignKeys[start:end]
+		start = end
 
-			matched = true
+		if name == "" || len(group) != len(fkColumns) || !accept(group[0]) {
+			continue
+		}
+
+		ordered := make([]ForeignKey, 0, len(fkColumns))
+
+		for _, col := range fkColumns {
+			i := slices.IndexFunc(group, func(fk ForeignKey) bool { return fk.ColumnName == col })
+			if i < 0 {
+				break
+			}
 
-			break
+			ordered = append(ordered, group[i])
 		}
 
-		if !matched {
-			return "", ""
+		if len(ordered) == len(fkColumns) {
+			return ordered
 		}
 	}
 
-	return schema, name
+	return nil
 }
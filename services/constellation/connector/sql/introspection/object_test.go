@@ -491,3 +491,108 @@ func TestTableLookupForwardFKTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestTableForwardForeignKeyComposite(t *testing.T) {
+	t.Parallel()
+
+	// tenant_id takes part in both composite keys; the products key is listed
+	// first, so only constraint-aware matching pairs it with customers.
+	orders := &introspection.Table{
+		Schema: "public",
+		Name:   "orders",
+		ForeignKeys: []introspection.ForeignKey{
+			{
+				ConstraintName: "orders_product_fkey", ColumnName: "tenant_id",
+				ForeignSchema: "public", ForeignTable: "products", ForeignColumnName: "tenant_id",
+			},
+			{
+				ConstraintName: "orders_product_fkey", ColumnName: "product_id",
+				ForeignSchema: "public", ForeignTable: "products", ForeignColumnName: "id",
+			},
+			{
+				ConstraintName: "orders_customer_fkey", ColumnName: "tenant_id",
+				ForeignSchema: "public", ForeignTable: "customers", ForeignColumnName: "tenant_id",
+			},
+			{
+				ConstraintName: "orders_customer_fkey", ColumnName: "customer_id",
+				ForeignSchema: "public", ForeignTable: "customers", ForeignColumnName: "id",
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		fkColumns   []string
+		references  string
+		wantTable   string
+		wantColumns []string
+	}{
+		{
+			name:        "composite key in constraint order",
+			fkColumns:   []string{"tenant_id", "customer_id"},
+			wantTable:   "customers",
+			wantColumns: []string{"tenant_id", "id"},
+		},
+		{
+			name:        "composite key in metadata order",
+			fkColumns:   []string{"customer_id", "tenant_id"},
+			wantTable:   "customers",
+			wantColumns: []string{"id", "tenant_id"},
+		},
+		{
+			name:        "other composite key",
+			fkColumns:   []string{"tenant_id", "product_id"},
+			wantTable:   "products",
+			wantColumns: []string{"tenant_id", "id"},
+		},
+		{
+			name:        "columns spanning two keys",
+			fkColumns:   []string{"customer_id", "product_id"},
+			wantTable:   "",
+			wantColumns: nil,
+		},
+		{
+			name:        "referencing the expected table",
+			fkColumns:   []string{"tenant_id", "customer_id"},
+			references:  "customers",
+			wantTable:   "customers",
+			wantColumns: []string{"tenant_id", "id"},
+		},
+		{
+			name:        "referencing another table",
+			fkColumns:   []string{"tenant_id", "customer_id"},
+			references:  "products",
+			wantTable:   "",
+			wantColumns: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fks := orders.ForwardForeignKey(tt.fkColumns)
+			if tt.references != "" {
+				fks = orders.ReferencingForeignKey(tt.fkColumns, "public", tt.references)
+			}
+
+			var (
+				gotTable   string
+				gotColumns []string
+			)
+
+			for _, fk := range fks {
+				gotTable = fk.ForeignTable
+				gotColumns = append(gotColumns, fk.ForeignColumnName)
+			}
+
+			if gotTable != tt.wantTable {
+				t.Errorf("target table = %q, want %q", gotTable, tt.wantTable)
+			}
+
+			if strings.Join(gotColumns, ",") != strings.Join(tt.wantColumns, ",") {
+				t.Errorf("target columns = %v, want %v", gotColumns, tt.wantColumns)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ package mock
 
 import (
 	context "context"
+	jsontext "encoding/json/jsontext"
 	slog "log/slog"
 	reflect "reflect"
 
@@ -101,6 +102,21 @@ func (mr *MockDriverMockRecorder) ExecuteOperations(ctx, operations, logger any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteOperations", reflect.TypeOf((*MockDriver)(nil).ExecuteOperations), ctx, operations, logger)
 }
 
+// ExplainOperation mocks base method.
+func (m *MockDriver) ExplainOperation(ctx context.Context, op core.SQLOperation) (jsontext.Value, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainOperation", ctx, op)
+	ret0, _ := ret[0].(jsontext.Value)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainOperation indicates an expected call of ExplainOperation.
+func (mr *MockDriverMockRecorder) ExplainOperation(ctx, op any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainOperation", reflect.TypeOf((*MockDriver)(nil).ExplainOperation), ctx, op)
+}
+
 // Introspect mocks base method.
 func (m *MockDriver) Introspect(ctx context.Context, dbMeta *metadata.DatabaseMetadata) (*introspection.Objects, error) {
 	m.ctrl.T.Helper()
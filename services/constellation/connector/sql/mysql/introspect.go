@@ -0,0 +1,534 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/introspection"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+const (
+	mysqlTypeInt8    = "int8"
+	mysqlTypeInt4    = "int4"
+	mysqlTypeInt2    = "int2"
+	mysqlTypeFloat8  = "float8"
+	mysqlTypeNumeric = "numeric"
+	mysqlTypeText    = "text"
+)
+
+// tableKey identifies a relation by its MySQL database (the metadata schema)
+// and name.
+type tableKey struct {
+	schema string
+	name   string
+}
+
+// Introspect returns the database objects (tables, columns, primary keys,
+// foreign keys, unique constraints) for the relations listed in dbMeta, read
+// from information_schema. The metadata schema of each table is its MySQL
+// database. Every relation is reported as neither insertable nor updatable:
+// the connector is read-only, so no mutation fields are generated. Tracked
+// relations the server does not expose are elided so reconcile records them.
+func (c *Client) Introspect(
+	ctx context.Context, dbMeta *metadata.DatabaseMetadata,
+) (*introspection.Objects, error) {
+	objs := introspection.NewObjects()
+
+	keys := trackedTables(dbMeta)
+	if len(keys) == 0 {
+		return objs, nil
+	}
+
+	tables, err := getTables(ctx, c.db, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to introspect tables: %w", err)
+	}
+
+	if err := getColumns(ctx, c.db, keys, tables); err != nil {
+		return nil, fmt.Errorf("failed to introspect columns: %w", err)
+	}
+
+	if err := getConstraints(ctx, c.db, keys, tables); err != nil {
+		return nil, fmt.Errorf("failed to introspect constraints: %w", err)
+	}
+
+	for key, table := range tables {
+		schema, ok := objs.Schemas[key.schema]
+		if !ok {
+			schema = &introspection.Schema{Tables: map[string]*introspection.Table{}}
+			objs.Schemas[key.schema] = schema
+		}
+
+		schema.Tables[key.name] = table
+	}
+
+	objs.EnumValues = introspectEnumValues(ctx, c.db, dbMeta, tables)
+
+	return objs, nil
+}
+
+// trackedTables returns the distinct (database, table) pairs dbMeta tracks,
+// in metadata order.
+func trackedTables(dbMeta *metadata.DatabaseMetadata) []tableKey {
+	seen := make(map[tableKey]struct{}, len(dbMeta.Tables))
+	keys := make([]tableKey, 0, len(dbMeta.Tables))
+
+	for i := range dbMeta.Tables {
+		key := tableKey{schema: dbMeta.Tables[i].Table.Schema, name: dbMeta.Tables[i].Table.Name}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// tableFilter renders "(<schemaCol>, <nameCol>) IN ((?, ?), ...)" for keys
+// and returns the matching arguments.
+func tableFilter(schemaCol, nameCol string, keys []tableKey) (string, []any) {
+	args := make([]any, 0, 2*len(keys)) //nolint:mnd // two arguments per key
+
+	var b strings.Builder
+
+	b.WriteString("(" + schemaCol + ", " + nameCol + ") IN (")
+
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString("(?, ?)")
+
+		args = append(args, key.schema, key.name)
+	}
+
+	b.WriteString(")")
+
+	return b.String(), args
+}
+
+// getTables returns the tracked relations that exist, keyed by table. Views
+// carry the literal comment "VIEW" in information_schema, which is dropped.
+func getTables(
+	ctx context.Context, q Querier, keys []tableKey,
+) (map[tableKey]*introspection.Table, error) {
+	filter, args := tableFilter("TABLE_SCHEMA", "TABLE_NAME", keys)
+
+	rows, err := q.QueryContext(
+		ctx,
+		`SELECT TABLE_SCHEMA, TABLE_NAME, TABLE_TYPE, TABLE_COMMENT
+		 FROM information_schema.TABLES
+		 WHERE `+filter,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tables: %w", err)
+	}
+	defer rows.Close()
+
+	tables := make(map[tableKey]*introspection.Table, len(keys))
+
+	for rows.Next() {
+		var (
+			key       tableKey
+			tableType string
+			comment   sql.NullString
+			isView    bool
+		)
+
+		if err := rows.Scan(&key.schema, &key.name, &tableType, &comment); err != nil {
+			return nil, fmt.Errorf("failed to scan table: %w", err)
+		}
+
+		isView = tableType == "VIEW"
+
+		tables[key] = &introspection.Table{
+			Schema:                   key.schema,
+			Name:                     key.name,
+			Comment:                  tableComment(comment, isView),
+			Columns:                  nil,
+			PrimaryKeys:              nil,
+			PrimaryKeyConstraintName: "",
+			ForeignKeys:              nil,
+			UniqueConstraints:        nil,
+			IsView:                   isView,
+			IsInsertable:             false,
+			IsUpdatable:              false,
+			IsDeletable:              false,
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tables: %w", err)
+	}
+
+	return tables, nil
+}
+
+func tableComment(comment sql.NullString, isView bool) *string {
+	if isView || !comment.Valid || comment.String == "" {
+		return nil
+	}
+
+	return &comment.String
+}
+
+// getColumns appends each tracked table's columns in ordinal order. EXTRA
+// flags auto_increment columns as identities and VIRTUAL/STORED GENERATED
+// columns as generated.
+func getColumns(
+	ctx context.Context, q Querier, keys []tableKey, tables map[tableKey]*introspection.Table,
+) error {
+	filter, args := tableFilter("TABLE_SCHEMA", "TABLE_NAME", keys)
+
+	rows, err := q.QueryContext(
+		ctx,
+		`SELECT TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME, DATA_TYPE, COLUMN_TYPE,
+		        IS_NULLABLE, COLUMN_DEFAULT, EXTRA, COLUMN_COMMENT
+		 FROM information_schema.COLUMNS
+		 WHERE `+filter+`
+		 ORDER BY TABLE_SCHEMA, TABLE_NAME, ORDINAL_POSITION`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query columns: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			key                        tableKey
+			name, dataType, columnType string
+			isNullable, extra          string
+			dflt, comment              sql.NullString
+		)
+
+		if err := rows.Scan(
+			&key.schema, &key.name, &name, &dataType, &columnType,
+			&isNullable, &dflt, &extra, &comment,
+		); err != nil {
+			return fmt.Errorf("failed to scan column: %w", err)
+		}
+
+		table, ok := tables[key]
+		if !ok {
+			continue
+		}
+
+		table.Columns = append(table.Columns, newColumn(
+			name, mapMySQLType(dataType, columnType), isNullable == "YES", dflt, extra, comment,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating columns: %w", err)
+	}
+
+	return nil
+}
+
+func newColumn(
+	name, mappedType string,
+	isNullable bool,
+	dflt sql.NullString,
+	extra string,
+	comment sql.NullString,
+) introspection.Column {
+	extra = strings.ToLower(extra)
+
+	var defaultValue, commentValue *string
+	if dflt.Valid {
+		defaultValue = &dflt.String
+	}
+
+	if comment.Valid && comment.String != "" {
+		commentValue = &comment.String
+	}
+
+	return introspection.Column{
+		Name:           name,
+		Type:           mappedType,
+		IsNullable:     isNullable,
+		IsGenerated:    strings.Contains(extra, "generated"),
+		IsIdentity:     strings.Contains(extra, "auto_increment"),
+		IsArray:        false,
+		SupportsMinMax: typeSupportsMinMax(mappedType),
+		SupportsInc:    typeSupportsInc(mappedType),
+		SupportsAgg:    typeSupportsAgg(mappedType),
+		Default:        defaultValue,
+		Comment:        commentValue,
+	}
+}
+
+// getConstraints fills in primary keys, unique constraints, and foreign keys
+// from TABLE_CONSTRAINTS joined to KEY_COLUMN_USAGE. Rows are ordered by
+// constraint and column position, so the columns of a composite key arrive
+// contiguously and in declaration order, as introspection.ForeignKey expects.
+func getConstraints(
+	ctx context.Context, q Querier, keys []tableKey, tables map[tableKey]*introspection.Table,
+) error {
+	filter, args := tableFilter("tc.TABLE_SCHEMA", "tc.TABLE_NAME", keys)
+
+	rows, err := q.QueryContext(
+		ctx,
+		`SELECT tc.TABLE_SCHEMA, tc.TABLE_NAME, tc.CONSTRAINT_NAME, tc.CONSTRAINT_TYPE,
+		        kcu.COLUMN_NAME, kcu.REFERENCED_TABLE_SCHEMA, kcu.REFERENCED_TABLE_NAME,
+		        kcu.REFERENCED_COLUMN_NAME
+		 FROM information_schema.TABLE_CONSTRAINTS tc
+		 JOIN information_schema.KEY_COLUMN_USAGE kcu
+		   ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA
+		  AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+		  AND kcu.TABLE_SCHEMA = tc.TABLE_SCHEMA
+		  AND kcu.TABLE_NAME = tc.TABLE_NAME
+		 WHERE tc.CONSTRAINT_TYPE IN ('PRIMARY KEY', 'UNIQUE', 'FOREIGN KEY')
+		   AND `+filter+`
+		 ORDER BY tc.TABLE_SCHEMA, tc.TABLE_NAME, tc.CONSTRAINT_NAME, kcu.ORDINAL_POSITION`,
+		args...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query constraints: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			key                   tableKey
+			constraint, kind, col string
+			refSchema, refTable   sql.NullString
+			refColumn             sql.NullString
+		)
+
+		if err := rows.Scan(
+			&key.schema, &key.name, &constraint, &kind, &col, &refSchema, &refTable, &refColumn,
+		); err != nil {
+			return fmt.Errorf("failed to scan constraint: %w", err)
+		}
+
+		table, ok := tables[key]
+		if !ok {
+			continue
+		}
+
+		addConstraintColumn(table, constraint, kind, col, refSchema, refTable, refColumn)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating constraints: %w", err)
+	}
+
+	return nil
+}
+
+// addConstraintColumn records one constraint column on table. A foreign-key
+// row without a referenced table is skipped.
+func addConstraintColumn(
+	table *introspection.Table,
+	constraint, kind, col string,
+	refSchema, refTable, refColumn sql.NullString,
+) {
+	switch kind {
+	case "PRIMARY KEY":
+		table.PrimaryKeys = append(table.PrimaryKeys, col)
+		table.PrimaryKeyConstraintName = constraint
+	case "UNIQUE":
+		n := len(table.UniqueConstraints)
+		if n == 0 || table.UniqueConstraints[n-1].Name != constraint {
+			table.UniqueConstraints = append(table.UniqueConstraints, introspection.UniqueConstraint{
+				Name:             constraint,
+				Columns:          nil,
+				NullsNotDistinct: false,
+			})
+			n++
+		}
+
+		table.UniqueConstraints[n-1].Columns = append(table.UniqueConstraints[n-1].Columns, col)
+	case "FOREIGN KEY":
+		if !refTable.Valid || !refColumn.Valid {
+			return
+		}
+
+		table.ForeignKeys = append(table.ForeignKeys, introspection.ForeignKey{
+			ConstraintName:    constraint,
+			ColumnName:        col,
+			ForeignSchema:     refSchema.String,
+			ForeignTable:      refTable.String,
+			ForeignColumnName: refColumn.String,
+		})
+	}
+}
+
+// introspectEnumValues reads the value+description rows of every table that
+// metadata flags as an enum, keyed by "schema.table". Missing or malformed
+// enum tables, query errors, and empty value sets are elided so reconcile
+// records them, matching the other drivers.
+func introspectEnumValues(
+	ctx context.Context,
+	q Querier,
+	dbMeta *metadata.DatabaseMetadata,
+	tables map[tableKey]*introspection.Table,
+) map[string][]introspection.EnumValue {
+	result := make(map[string][]introspection.EnumValue)
+
+	for i := range dbMeta.Tables {
+		tableMeta := &dbMeta.Tables[i]
+		if !tableMeta.IsEnum {
+			continue
+		}
+
+		key := tableKey{schema: tableMeta.Table.Schema, name: tableMeta.Table.Name}
+
+		table, ok := tables[key]
+		if !ok {
+			continue
+		}
+
+		valueCol, descCol, err := table.EnumColumns()
+		if err != nil {
+			continue
+		}
+
+		enumValues, err := getEnumTable(ctx, q, key, valueCol, descCol)
+		if err != nil || len(enumValues) == 0 {
+			continue
+		}
+
+		result[key.schema+"."+key.name] = enumValues
+	}
+
+	return result
+}
+
+// getEnumTable queries an enum table for its values and optional
+// descriptions. Introspection runs outside the ANSI_QUOTES transactions, so
+// identifiers are quoted with backticks.
+func getEnumTable(
+	ctx context.Context, q Querier, key tableKey, valueCol, descCol string,
+) ([]introspection.EnumValue, error) {
+	selectCols := quoteIdentifier(valueCol)
+	if descCol != "" {
+		selectCols += ", " + quoteIdentifier(descCol)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s FROM %s.%s ORDER BY %s",
+		selectCols,
+		quoteIdentifier(key.schema),
+		quoteIdentifier(key.name),
+		quoteIdentifier(valueCol),
+	)
+
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query enum table: %w", err)
+	}
+	defer rows.Close()
+
+	var enumValues []introspection.EnumValue
+
+	for rows.Next() {
+		var (
+			value       string
+			description sql.NullString
+		)
+
+		scanDests := []any{&value}
+		if descCol != "" {
+			scanDests = append(scanDests, &description)
+		}
+
+		if err := rows.Scan(scanDests...); err != nil {
+			return nil, fmt.Errorf("failed to scan enum row: %w", err)
+		}
+
+		enumValues = append(enumValues, introspection.EnumValue{
+			Value:   value,
+			Comment: description.String,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating enum rows: %w", err)
+	}
+
+	return enumValues, nil
+}
+
+// quoteIdentifier quotes name with backticks, doubling embedded backticks.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// mapMySQLType maps an information_schema DATA_TYPE (with COLUMN_TYPE for
+// the tinyint(1) boolean convention) to the PostgreSQL-style type names the
+// rest of the introspection system uses. DATETIME has no time zone and maps
+// to timestamp; TIMESTAMP is stored as UTC and maps to timestamptz. Anything
+// unrecognised is exposed as text.
+func mapMySQLType(dataType, columnType string) string { //nolint:cyclop
+	switch strings.ToLower(dataType) {
+	case "bigint":
+		return mysqlTypeInt8
+	case "int", "integer", "mediumint", "year":
+		return mysqlTypeInt4
+	case "tinyint":
+		if strings.HasPrefix(strings.ToLower(columnType), "tinyint(1)") {
+			return "bool"
+		}
+
+		return mysqlTypeInt2
+	case "smallint":
+		return mysqlTypeInt2
+	case "bool", "boolean":
+		return "bool"
+	case "float", "double", "real":
+		return mysqlTypeFloat8
+	case "decimal", "numeric":
+		return mysqlTypeNumeric
+	case "date":
+		return "date"
+	case "datetime":
+		return "timestamp"
+	case "timestamp":
+		return "timestamptz"
+	case "time":
+		return "time"
+	case "json":
+		return "json"
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob", "bit":
+		return "bytea"
+	default:
+		return mysqlTypeText
+	}
+}
+
+// typeSupportsMinMax returns whether a mapped type supports min/max aggregation.
+func typeSupportsMinMax(mappedType string) bool {
+	switch mappedType {
+	case mysqlTypeInt8, mysqlTypeInt4, mysqlTypeInt2, mysqlTypeFloat8, mysqlTypeNumeric,
+		mysqlTypeText, "date", "timestamp", "timestamptz", "time":
+		return true
+	default:
+		return false
+	}
+}
+
+// typeSupportsInc returns whether a mapped type supports increment (+) operations.
+func typeSupportsInc(mappedType string) bool {
+	return typeSupportsAgg(mappedType)
+}
+
+// typeSupportsAgg returns whether a mapped type supports numeric aggregation (sum, avg, etc.).
+func typeSupportsAgg(mappedType string) bool {
+	switch mappedType {
+	case mysqlTypeInt8, mysqlTypeInt4, mysqlTypeInt2, mysqlTypeFloat8, mysqlTypeNumeric:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,82 @@
+package mysql
+
+import "testing"
+
+func TestMapMySQLType(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		dataType   string
+		columnType string
+		expected   string
+	}{
+		{"bigint", "bigint unsigned", "int8"},
+		{"int", "int", "int4"},
+		{"mediumint", "mediumint", "int4"},
+		{"year", "year", "int4"},
+		{"smallint", "smallint", "int2"},
+		{"tinyint", "tinyint(4)", "int2"},
+		{"tinyint", "tinyint(1)", "bool"},
+		{"TINYINT", "TINYINT(1) UNSIGNED", "bool"},
+		{"double", "double", "float8"},
+		{"float", "float", "float8"},
+		{"decimal", "decimal(10,2)", "numeric"},
+		{"varchar", "varchar(255)", "text"},
+		{"longtext", "longtext", "text"},
+		{"enum", "enum('a','b')", "text"},
+		{"date", "date", "date"},
+		{"datetime", "datetime(6)", "timestamp"},
+		{"timestamp", "timestamp", "timestamptz"},
+		{"time", "time", "time"},
+		{"json", "json", "json"},
+		{"varbinary", "varbinary(16)", "bytea"},
+		{"blob", "blob", "bytea"},
+		{"geometry", "geometry", "text"},
+	}
+
+	for _, tc := range cases {
+		if got := mapMySQLType(tc.dataType, tc.columnType); got != tc.expected {
+			t.Errorf("mapMySQLType(%q, %q) = %q, want %q", tc.dataType, tc.columnType, got, tc.expected)
+		}
+	}
+}
+
+func TestTypeSupports(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		mappedType string
+		minMax     bool
+		agg        bool
+	}{
+		{"int8", true, true},
+		{"numeric", true, true},
+		{"text", true, false},
+		{"timestamp", true, false},
+		{"bool", false, false},
+		{"json", false, false},
+		{"bytea", false, false},
+	}
+
+	for _, tc := range cases {
+		if got := typeSupportsMinMax(tc.mappedType); got != tc.minMax {
+			t.Errorf("typeSupportsMinMax(%q) = %v, want %v", tc.mappedType, got, tc.minMax)
+		}
+
+		if got := typeSupportsAgg(tc.mappedType); got != tc.agg {
+			t.Errorf("typeSupportsAgg(%q) = %v, want %v", tc.mappedType, got, tc.agg)
+		}
+
+		if got := typeSupportsInc(tc.mappedType); got != tc.agg {
+			t.Errorf("typeSupportsInc(%q) = %v, want %v", tc.mappedType, got, tc.agg)
+		}
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	t.Parallel()
+
+	if got := quoteIdentifier("we`ird"); got != "`we``ird`" {
+		t.Fatalf("quoteIdentifier = %s", got)
+	}
+}
@@ -0,0 +1,371 @@
+// Package mysql implements the csql.Driver interface for MySQL 8.0.14+,
+// exposing tracked tables and views read-only.
+//
+// # Driver registration
+//
+// The package talks to the server through database/sql under the "mysql"
+// driver name, which its blank import of github.com/go-sql-driver/mysql
+// registers, so every binary linking this package can open MySQL sources.
+//
+// # Read-only execution
+//
+// Every statement runs inside a READ ONLY transaction, and introspection
+// reports every relation as neither insertable nor updatable, so the schema
+// generator emits no mutation fields. Each transaction starts by adding
+// ANSI_QUOTES to the session sql_mode: the shared query builder quotes
+// identifiers with double quotes, which MySQL otherwise parses as strings.
+//
+// # Scope
+//
+// As with the SQLite driver, this package is a thin shim around database/sql:
+// introspection reads information_schema (see introspect.go), and SQL
+// generation lives in connector/sql/graphql/queries/dialect.MySQLDialect,
+// which [Client.Dialect] exposes. MariaDB is not supported: it lacks the
+// outer references in derived tables that relationship subqueries rely on.
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json/jsontext"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	_ "github.com/go-sql-driver/mysql" // registers the "mysql" database/sql driver.
+
+	csql "github.com/nhost/nhost/services/constellation/connector/sql"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+const (
+	mysqlDriverName = "mysql"
+
+	// setANSIQuotes appends ANSI_QUOTES to the session sql_mode. sql_mode is
+	// a SET, so repeating the flag on a pooled connection is harmless.
+	setANSIQuotes = "SET SESSION sql_mode = CONCAT(@@SESSION.sql_mode, ',ANSI_QUOTES')"
+)
+
+var (
+	errWriteOperation = errors.New("the mysql connector is read-only")
+	errMultiplexed    = errors.New("the mysql connector does not multiplex subscriptions")
+)
+
+// Querier abstracts MySQL query execution. Both DB and Tx satisfy this
+// interface, which lets unexported helpers run against either a connection
+// or a transaction without depending on database/sql directly.
+//
+// Querier, DB, Tx, Row, and Rows are exported only so mockgen can target
+// them from the mock/ subpackage; see the sqlite package for the same seam.
+//
+//go:generate mockgen -package mock -destination mock/mysql.go . Querier,DB,Tx,Row,Rows
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) Row
+	ExecContext(ctx context.Context, query string, args ...any) error
+}
+
+// DB extends Querier with transaction and lifecycle management. BeginTx
+// always opens a READ ONLY transaction.
+type DB interface {
+	Querier
+	BeginTx(ctx context.Context) (Tx, error)
+	Close() error
+}
+
+// Tx is the subset of sql.Tx the package uses.
+type Tx interface {
+	Querier
+	Commit() error
+	Rollback() error
+}
+
+// Row is the result of a single-row query — equivalent to sql.Row.
+type Row interface {
+	Scan(dest ...any) error
+}
+
+// Rows is the result of a multi-row query — the subset of sql.Rows the
+// package uses.
+type Rows interface {
+	Close() error
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}
+
+// dbAdapter wraps *sql.DB so it satisfies the local DB interface. Error
+// wrapping and rows.Err() handling happen at the Client methods.
+type dbAdapter struct {
+	db *sql.DB
+}
+
+func (a *dbAdapter) QueryContext( //nolint:ireturn,nolintlint
+	ctx context.Context, query string, args ...any,
+) (Rows, error) {
+	return a.db.QueryContext(ctx, query, args...) //nolint:wrapcheck,rowserrcheck
+}
+
+func (a *dbAdapter) QueryRowContext( //nolint:ireturn,nolintlint
+	ctx context.Context, query string, args ...any,
+) Row {
+	return a.db.QueryRowContext(ctx, query, args...)
+}
+
+func (a *dbAdapter) ExecContext(ctx context.Context, query string, args ...any) error {
+	_, err := a.db.ExecContext(ctx, query, args...)
+	return err //nolint:wrapcheck
+}
+
+func (a *dbAdapter) BeginTx(ctx context.Context) (Tx, error) { //nolint:ireturn,nolintlint
+	tx, err := a.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true}) //nolint:exhaustruct
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &txAdapter{tx: tx}, nil
+}
+
+func (a *dbAdapter) Close() error {
+	return a.db.Close() //nolint:wrapcheck
+}
+
+// txAdapter wraps *sql.Tx so it satisfies the local Tx interface.
+type txAdapter struct {
+	tx *sql.Tx
+}
+
+func (a *txAdapter) QueryContext( //nolint:ireturn,nolintlint
+	ctx context.Context, query string, args ...any,
+) (Rows, error) {
+	return a.tx.QueryContext(ctx, query, args...) //nolint:wrapcheck,rowserrcheck
+}
+
+func (a *txAdapter) QueryRowContext( //nolint:ireturn,nolintlint
+	ctx context.Context, query string, args ...any,
+) Row {
+	return a.tx.QueryRowContext(ctx, query, args...)
+}
+
+func (a *txAdapter) ExecContext(ctx context.Context, query string, args ...any) error {
+	_, err := a.tx.ExecContext(ctx, query, args...)
+	return err //nolint:wrapcheck
+}
+
+func (a *txAdapter) Commit() error {
+	return a.tx.Commit() //nolint:wrapcheck
+}
+
+func (a *txAdapter) Rollback() error {
+	return a.tx.Rollback() //nolint:wrapcheck
+}
+
+// Compile-time check that Client implements csql.Driver.
+var _ csql.Driver = (*Client)(nil)
+
+// Client implements the csql.Driver interface for MySQL.
+type Client struct {
+	db DB
+}
+
+// NewClient wraps the given DB in a Client. Production code goes through
+// [New]; NewClient is exported for tests that supply their own DB.
+func NewClient(db DB) *Client {
+	return &Client{db: db}
+}
+
+// Open opens a MySQL connection pool for dsn (in the registered driver's DSN
+// format) and pings it. On failure the pool is closed before returning; on
+// success the caller owns [DB.Close].
+func Open(ctx context.Context, dsn string) (DB, error) { //nolint:ireturn,nolintlint
+	rawDB, err := sql.Open(mysqlDriverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening mysql database: %w", err)
+	}
+
+	if err := rawDB.PingContext(ctx); err != nil {
+		return nil, errors.Join(
+			fmt.Errorf("opening mysql connection: %w", err),
+			rawDB.Close(),
+		)
+	}
+
+	return &dbAdapter{db: rawDB}, nil
+}
+
+// New creates a MySQL-backed sql.Connector — convenience for Open + NewClient
+// + csql.NewConnector. inconsistencies receives per-entity reconciliation
+// entries (pass nil to drop them on the floor).
+func New(
+	ctx context.Context,
+	dsn string,
+	dbMeta *metadata.DatabaseMetadata,
+	inconsistencies *metadata.Inconsistencies,
+	logger *slog.Logger,
+) (*csql.Connector, error) {
+	db, err := Open(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("creating mysql client: %w", err)
+	}
+
+	c, err := csql.NewConnector(ctx, NewClient(db), dbMeta, inconsistencies, logger)
+	if err != nil {
+		return nil, errors.Join(
+			fmt.Errorf("creating sql connector: %w", err),
+			db.Close(),
+		)
+	}
+
+	return c, nil
+}
+
+// Dialect returns the MySQL SQL dialect.
+func (c *Client) Dialect() dialect.Dialect { //nolint:ireturn,nolintlint
+	return dialect.NewMySQLDialect()
+}
+
+// Close releases the connection pool, logging any close error because the
+// csql.Driver contract has no error return.
+func (c *Client) Close() {
+	if err := c.db.Close(); err != nil {
+		slog.Error("failed to close mysql database", slog.String("error", err.Error()))
+	}
+}
+
+// ExecuteOperations executes a list of read-only SQL operations within a
+// single READ ONLY transaction, so every root field of a request reads the
+// same snapshot. Uses a named return so the rollback defer reads the actual
+// error returned by the function body.
+//
+//nolint:nonamedreturns
+func (c *Client) ExecuteOperations(
+	ctx context.Context, operations []core.SQLOperation, logger *slog.Logger,
+) (result map[string]any, err error) {
+	tx, err := c.db.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		logger.DebugContext(ctx, "rolling back transaction due to error")
+
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, context.Canceled) {
+			logger.ErrorContext(
+				ctx, "failed to rollback transaction", slog.String("error", rbErr.Error()),
+			)
+		}
+	}()
+
+	if err = tx.ExecContext(ctx, setANSIQuotes); err != nil {
+		return nil, fmt.Errorf("failed to enable ANSI_QUOTES: %w", err)
+	}
+
+	opsResults := make(map[string]any, len(operations))
+
+	for _, op := range operations {
+		opResult, opErr := executeOperation(ctx, tx, op)
+		if opErr != nil {
+			err = fmt.Errorf("failed to execute operation %s: %w", op.Name, opErr)
+			logger.ErrorContext(
+				ctx, "failed to execute operation",
+				slog.String("operation", op.Name), slog.String("error", opErr.Error()),
+			)
+
+			return nil, err
+		}
+
+		opsResults[op.Name] = opResult
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.ErrorContext(
+			ctx, "failed to commit transaction", slog.String("error", err.Error()),
+		)
+
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return opsResults, nil
+}
+
+// executeOperation runs a single read operation and returns its JSON result.
+// Sequential and staged operations only come from mutations, which the
+// schema never exposes for MySQL; they are rejected defensively.
+func executeOperation(ctx context.Context, q Querier, op core.SQLOperation) (any, error) {
+	if len(op.Sequential) > 0 || len(op.Stages) > 0 {
+		return nil, errWriteOperation
+	}
+
+	row := q.QueryRowContext(ctx, op.SQL, op.Parameters...)
+
+	var rawJSON []byte
+
+	if err := row.Scan(&rawJSON); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil //nolint:nilnil
+		}
+
+		return nil, fmt.Errorf("failed to scan result row: %w", err)
+	}
+
+	if rawJSON == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	return jsontext.Value(rawJSON), nil
+}
+
+// ExplainOperation returns the EXPLAIN FORMAT=JSON plan for op. It runs in
+// its own READ ONLY transaction because the statement needs the ANSI_QUOTES
+// sql_mode ExecuteOperations sets up; EXPLAIN only plans the statement, so
+// nothing is executed. Uses a named return so the deferred rollback can
+// report its error.
+//
+//nolint:nonamedreturns
+func (c *Client) ExplainOperation(
+	ctx context.Context, op core.SQLOperation,
+) (plan jsontext.Value, err error) {
+	if len(op.Sequential) > 0 || len(op.Stages) > 0 {
+		return nil, errWriteOperation
+	}
+
+	tx, err := c.db.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if rbErr := tx.Rollback(); rbErr != nil && err == nil {
+			err = fmt.Errorf("failed to rollback transaction: %w", rbErr)
+		}
+	}()
+
+	if err := tx.ExecContext(ctx, setANSIQuotes); err != nil {
+		return nil, fmt.Errorf("failed to enable ANSI_QUOTES: %w", err)
+	}
+
+	var rawPlan []byte
+	if err := tx.QueryRowContext(
+		ctx, "EXPLAIN FORMAT=JSON "+op.SQL, op.Parameters...,
+	).Scan(&rawPlan); err != nil {
+		return nil, fmt.Errorf("failed to explain operation: %w", err)
+	}
+
+	return jsontext.Value(rawPlan), nil
+}
+
+// ExecuteMultiplexedOperation is never called for MySQL: the dialect reports
+// no multiplexed subscriptions, so the connector polls each subscriber
+// through ExecuteOperations instead.
+func (c *Client) ExecuteMultiplexedOperation(
+	_ context.Context, _ string, _ []any, _ *slog.Logger,
+) ([]core.MultiplexedResult, error) {
+	return nil, errMultiplexed
+}
@@ -0,0 +1,342 @@
+package mysql_test
+
+import (
+	"database/sql"
+	"encoding/json/jsontext"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+	"github.com/nhost/nhost/services/constellation/connector/sql/introspection"
+	"github.com/nhost/nhost/services/constellation/connector/sql/mysql"
+	"github.com/nhost/nhost/services/constellation/connector/sql/mysql/mock"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+var (
+	errScanFailed   = errors.New("scan failed")
+	errCommitFailed = errors.New("commit failed")
+	errSetFailed    = errors.New("set failed")
+)
+
+const setANSIQuotes = "SET SESSION sql_mode = CONCAT(@@SESSION.sql_mode, ',ANSI_QUOTES')"
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+func scanBytesInto(t *testing.T, payload string) func(dest ...any) error {
+	t.Helper()
+
+	return func(dest ...any) error {
+		ptr, ok := dest[0].(*[]byte)
+		if !ok {
+			t.Fatal("expected *[]byte dest")
+		}
+
+		*ptr = []byte(payload)
+
+		return nil
+	}
+}
+
+// fakeRows is a canned result set. Scan copies each value into the matching
+// *string or *sql.NullString destination; nil becomes an invalid NullString.
+type fakeRows struct {
+	rows [][]any
+	pos  int
+}
+
+func (r *fakeRows) Close() error               { return nil }
+func (r *fakeRows) Columns() ([]string, error) { return nil, nil }
+func (r *fakeRows) Err() error                 { return nil }
+
+func (r *fakeRows) Next() bool {
+	r.pos++
+
+	return r.pos <= len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	for i, v := range r.rows[r.pos-1] {
+		switch d := dest[i].(type) {
+		case *string:
+			*d, _ = v.(string)
+		case *sql.NullString:
+			s, ok := v.(string)
+			*d = sql.NullString{String: s, Valid: ok}
+		}
+	}
+
+	return nil
+}
+
+func TestOpenGetsPastDriverLookup(t *testing.T) {
+	t.Parallel()
+
+	if !slices.Contains(sql.Drivers(), "mysql") {
+		t.Fatalf("no database/sql driver registered as mysql, got %v", sql.Drivers())
+	}
+
+	// Port 1 refuses the connection, so Open fails on the ping rather than on
+	// sql.Open's unknown-driver error.
+	_, err := mysql.Open(t.Context(), "user@tcp(127.0.0.1:1)/db?timeout=1s")
+	if err == nil {
+		t.Fatal("expected an error connecting to a closed port")
+	}
+
+	if got := err.Error(); !strings.HasPrefix(got, "opening mysql connection:") {
+		t.Fatalf("Open error = %v, want a connection error", err)
+	}
+}
+
+func TestDialect(t *testing.T) {
+	t.Parallel()
+
+	client := mysql.NewClient(nil)
+
+	if _, ok := client.Dialect().(*dialect.MySQLDialect); !ok {
+		t.Fatalf("Dialect() = %T, want *dialect.MySQLDialect", client.Dialect())
+	}
+}
+
+func TestExecuteOperationsMocked(t *testing.T) {
+	t.Parallel()
+
+	operations := []core.SQLOperation{
+		{Name: "users", SQL: `SELECT JSON_OBJECT('users', ?)`, Parameters: []any{"x"}},
+	}
+
+	tests := []struct {
+		name       string
+		operations []core.SQLOperation
+		setupMocks func(t *testing.T, db *mock.MockDB, tx *mock.MockTx, row *mock.MockRow)
+		wantErrIs  error
+		wantResult string
+	}{
+		{
+			name:       "success enables ANSI_QUOTES before querying",
+			operations: operations,
+			setupMocks: func(t *testing.T, db *mock.MockDB, tx *mock.MockTx, row *mock.MockRow) {
+				t.Helper()
+				gomock.InOrder(
+					db.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+					tx.EXPECT().ExecContext(gomock.Any(), setANSIQuotes).Return(nil),
+					tx.EXPECT().
+						QueryRowContext(gomock.Any(), `SELECT JSON_OBJECT('users', ?)`, "x").
+						Return(row),
+					row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanBytesInto(t, `{"users":[]}`)),
+					tx.EXPECT().Commit().Return(nil),
+				)
+			},
+			wantErrIs:  nil,
+			wantResult: `{"users":[]}`,
+		},
+		{
+			name:       "sql_mode error rolls back",
+			operations: operations,
+			setupMocks: func(_ *testing.T, db *mock.MockDB, tx *mock.MockTx, _ *mock.MockRow) {
+				db.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				tx.EXPECT().ExecContext(gomock.Any(), setANSIQuotes).Return(errSetFailed)
+				tx.EXPECT().Rollback().Return(nil)
+			},
+			wantErrIs: errSetFailed,
+		},
+		{
+			name:       "scan error rolls back",
+			operations: operations,
+			setupMocks: func(_ *testing.T, db *mock.MockDB, tx *mock.MockTx, row *mock.MockRow) {
+				db.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				tx.EXPECT().ExecContext(gomock.Any(), setANSIQuotes).Return(nil)
+				tx.EXPECT().QueryRowContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(row)
+				row.EXPECT().Scan(gomock.Any()).Return(errScanFailed)
+				tx.EXPECT().Rollback().Return(nil)
+			},
+			wantErrIs: errScanFailed,
+		},
+		{
+			name:       "commit error is returned",
+			operations: operations,
+			setupMocks: func(t *testing.T, db *mock.MockDB, tx *mock.MockTx, row *mock.MockRow) {
+				t.Helper()
+				db.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				tx.EXPECT().ExecContext(gomock.Any(), setANSIQuotes).Return(nil)
+				tx.EXPECT().QueryRowContext(gomock.Any(), gomock.Any(), gomock.Any()).Return(row)
+				row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanBytesInto(t, `{}`))
+				tx.EXPECT().Commit().Return(errCommitFailed)
+				tx.EXPECT().Rollback().Return(nil)
+			},
+			wantErrIs: errCommitFailed,
+		},
+		{
+			name: "staged operations are rejected",
+			operations: []core.SQLOperation{
+				{Name: "insert_users", SQL: "SELECT 1", Stages: []core.SQLStage{{Name: "s"}}},
+			},
+			setupMocks: func(_ *testing.T, db *mock.MockDB, tx *mock.MockTx, _ *mock.MockRow) {
+				db.EXPECT().BeginTx(gomock.Any()).Return(tx, nil)
+				tx.EXPECT().ExecContext(gomock.Any(), setANSIQuotes).Return(nil)
+				tx.EXPECT().Rollback().Return(nil)
+			},
+			wantErrIs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			db := mock.NewMockDB(ctrl)
+			tx := mock.NewMockTx(ctrl)
+			row := mock.NewMockRow(ctrl)
+
+			tt.setupMocks(t, db, tx, row)
+
+			result, err := mysql.NewClient(db).ExecuteOperations(
+				t.Context(), tt.operations, discardLogger(),
+			)
+
+			if tt.wantResult == "" {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErrIs)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExecuteOperations: %v", err)
+			}
+
+			got, ok := result["users"].(jsontext.Value)
+			if !ok || string(got) != tt.wantResult {
+				t.Fatalf("result = %v, want %s", result["users"], tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestExecuteMultiplexedOperationIsUnsupported(t *testing.T) {
+	t.Parallel()
+
+	if _, err := mysql.NewClient(nil).ExecuteMultiplexedOperation(
+		t.Context(), "SELECT 1", nil, discardLogger(),
+	); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestIntrospect(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	db := mock.NewMockDB(ctrl)
+
+	md := &metadata.DatabaseMetadata{
+		Name: "default",
+		Kind: "mysql",
+		Tables: []metadata.TableMetadata{
+			{Table: metadata.TableSource{Schema: "shop", Name: "orders"}},
+			{Table: metadata.TableSource{Schema: "shop", Name: "order_status"}, IsEnum: true},
+			{Table: metadata.TableSource{Schema: "shop", Name: "missing"}},
+		},
+	}
+
+	queried := func(fragment string) gomock.Matcher {
+		return gomock.Cond(func(q string) bool { return strings.Contains(q, fragment) })
+	}
+
+	db.EXPECT().QueryContext(gomock.Any(), queried("information_schema.TABLES"), gomock.Any()).
+		Return(&fakeRows{rows: [][]any{
+			{"shop", "orders", "BASE TABLE", "customer orders"},
+			{"shop", "order_status", "BASE TABLE", ""},
+		}}, nil)
+	db.EXPECT().QueryContext(gomock.Any(), queried("information_schema.COLUMNS"), gomock.Any()).
+		Return(&fakeRows{rows: [][]any{
+			{"shop", "order_status", "value", "varchar", "varchar(32)", "NO", nil, "", ""},
+			{"shop", "order_status", "comment", "text", "text", "YES", nil, "", ""},
+			{"shop", "orders", "id", "bigint", "bigint", "NO", nil, "auto_increment", ""},
+			{"shop", "orders", "status", "varchar", "varchar(32)", "NO", "new", "", "order state"},
+			{"shop", "orders", "paid", "tinyint", "tinyint(1)", "NO", "0", "", ""},
+			{"shop", "orders", "total", "decimal", "decimal(10,2)", "YES", nil, "STORED GENERATED", ""},
+		}}, nil)
+	db.EXPECT().QueryContext(gomock.Any(), queried("information_schema.TABLE_CONSTRAINTS"), gomock.Any()).
+		Return(&fakeRows{rows: [][]any{
+			{"shop", "order_status", "PRIMARY", "PRIMARY KEY", "value", nil, nil, nil},
+			{"shop", "orders", "PRIMARY", "PRIMARY KEY", "id", nil, nil, nil},
+			{"shop", "orders", "orders_status_fkey", "FOREIGN KEY", "status", "shop", "order_status", "value"},
+			{"shop", "orders", "orders_status_paid_key", "UNIQUE", "status", nil, nil, nil},
+			{"shop", "orders", "orders_status_paid_key", "UNIQUE", "paid", nil, nil, nil},
+		}}, nil)
+	db.EXPECT().QueryContext(
+		gomock.Any(), "SELECT `value`, `comment` FROM `shop`.`order_status` ORDER BY `value`",
+	).Return(&fakeRows{rows: [][]any{{"new", "just placed"}, {"paid", nil}}}, nil)
+
+	objs, err := mysql.NewClient(db).Introspect(t.Context(), md)
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+
+	orders, ok := objs.GetTable("shop", "orders")
+	if !ok {
+		t.Fatal("shop.orders not introspected")
+	}
+
+	if _, ok := objs.GetTable("shop", "missing"); ok {
+		t.Error("shop.missing should be elided")
+	}
+
+	assertOrders(t, orders)
+
+	wantEnum := []introspection.EnumValue{{Value: "new", Comment: "just placed"}, {Value: "paid", Comment: ""}}
+	if got := objs.EnumValues["shop.order_status"]; len(got) != 2 || got[0] != wantEnum[0] || got[1] != wantEnum[1] {
+		t.Errorf("enum values = %+v, want %+v", got, wantEnum)
+	}
+}
+
+func assertOrders(t *testing.T, orders *introspection.Table) {
+	t.Helper()
+
+	if orders.IsInsertable || orders.IsUpdatable || orders.IsDeletable {
+		t.Error("mysql tables must be read-only")
+	}
+
+	if orders.Comment == nil || *orders.Comment != "customer orders" {
+		t.Errorf("comment = %v", orders.Comment)
+	}
+
+	wantTypes := map[string]string{"id": "int8", "status": "text", "paid": "bool", "total": "numeric"}
+	for _, col := range orders.Columns {
+		if col.Type != wantTypes[col.Name] {
+			t.Errorf("%s type = %s, want %s", col.Name, col.Type, wantTypes[col.Name])
+		}
+	}
+
+	if !orders.Columns[0].IsIdentity || !orders.Columns[3].IsGenerated || !orders.Columns[3].IsNullable {
+		t.Errorf("unexpected column flags: %+v", orders.Columns)
+	}
+
+	if len(orders.PrimaryKeys) != 1 || orders.PrimaryKeys[0] != "id" {
+		t.Errorf("primary keys = %v", orders.PrimaryKeys)
+	}
+
+	if len(orders.ForeignKeys) != 1 || orders.ForeignKeys[0].ForeignTable != "order_status" {
+		t.Errorf("foreign keys = %+v", orders.ForeignKeys)
+	}
+
+	if len(orders.UniqueConstraints) != 1 ||
+		strings.Join(orders.UniqueConstraints[0].Columns, ",") != "status,paid" {
+		t.Errorf("unique constraints = %+v", orders.UniqueConstraints)
+	}
+}
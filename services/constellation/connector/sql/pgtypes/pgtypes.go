@@ -8,6 +8,16 @@ const (
 	Geometry = "geometry"
 	// Geography is the PostgreSQL/PostGIS geography type name.
 	Geography = "geography"
+	// Ltree is the PostgreSQL ltree extension's label-path type name.
+	Ltree = "ltree"
+	// Lquery is the ltree extension's path pattern type name.
+	Lquery = "lquery"
+	// Ltxtquery is the ltree extension's full-text path query type name.
+	Ltxtquery = "ltxtquery"
+	// Tsvector is the PostgreSQL full-text search document type name.
+	Tsvector = "tsvector"
+	// Vector is the pgvector extension's embedding type name.
+	Vector = "vector"
 )
 
 // IsSpatial reports whether sqlType is a PostGIS scalar spatial type.
@@ -37,6 +47,22 @@ func SpatialScalarName(sqlType string) string {
 	}
 }
 
+// IsLtree reports whether sqlType names the ltree extension's scalar type.
+func IsLtree(sqlType string) bool {
+	return normalizeTypeName(sqlType) == Ltree
+}
+
+// IsTsvector reports whether sqlType names the full-text search document type.
+func IsTsvector(sqlType string) bool {
+	return normalizeTypeName(sqlType) == Tsvector
+}
+
+// IsVector reports whether sqlType names the pgvector embedding type,
+// including its dimension typmod (vector(1536)).
+func IsVector(sqlType string) bool {
+	return normalizeTypeName(sqlType) == Vector
+}
+
 func normalizeTypeName(sqlType string) string {
 	s := strings.ToLower(strings.TrimSpace(sqlType))
 	if strings.HasSuffix(s, "[]") {
@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// coordinationChannel is the LISTEN/NOTIFY channel replicas coordinate their
+// live-query cohorts on.
+const coordinationChannel = "constellation_subscriptions"
+
+// coordinationRetryDelay is how long the coordinator waits before
+// reconnecting a lost connection.
+const coordinationRetryDelay = time.Second
+
+// coordinationBuffer is how many messages may wait for the subscription
+// handler before new ones are dropped.
+const coordinationBuffer = 256
+
+var errCoordinatorClosed = errors.New("subscription coordinator is closed")
+
+// Option customises [New].
+type Option func(*options)
+
+type options struct {
+	coordinateSubscriptions bool
+	sessionSettings         bool
+}
+
+// WithSubscriptionCoordination makes the connector's live-query subscriptions
+// share one poll per cohort with the other replicas serving the same source
+// from the same database. Ownership of a cohort is a session-level advisory
+// lock and results travel over LISTEN/NOTIFY, so the connection string must
+// reach Postgres directly or through a session-mode pooler.
+func WithSubscriptionCoordination() Option {
+	return func(o *options) {
+		o.coordinateSubscriptions = true
+	}
+}
+
+// coordinator implements subscription.Coordinator on two dedicated
+// connections: one holds the advisory locks and sends notifications, the
+// other listens. Losing the lock connection releases every lock, so another
+// replica takes over the cohorts this one owned; ownership is then reacquired
+// through a new connection on the next poll.
+type coordinator struct {
+	connStr   string
+	namespace string
+	replicaID string
+	logger    *slog.Logger
+
+	// mu guards conn and owned.
+	mu    sync.Mutex
+	conn  *pgx.Conn
+	owned map[string]struct{}
+
+	messages chan []byte
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// newCoordinator starts a coordinator for the source namespace. Cohort keys
+// and messages are scoped to namespace so sources sharing a database do not
+// see each other's cohorts.
+func newCoordinator(connStr, namespace string, logger *slog.Logger) *coordinator {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	c := &coordinator{
+		connStr:   connStr,
+		namespace: namespace,
+		replicaID: uuid.NewString(),
+		logger:    logger,
+		mu:        sync.Mutex{},
+		conn:      nil,
+		owned:     make(map[string]struct{}),
+		messages:  make(chan []byte, coordinationBuffer),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+
+	go c.listenLoop(ctx)
+
+	return c
+}
+
+// ReplicaID identifies this replica in published messages.
+func (c *coordinator) ReplicaID() string {
+	return c.replicaID
+}
+
+// Messages delivers the notifications published for this namespace.
+func (c *coordinator) Messages() <-chan []byte {
+	return c.messages
+}
+
+// Acquire takes the advisory lock for key unless this replica already holds
+// it. Any failure drops the lock connection, and with it every lock held.
+func (c *coordinator) Acquire(ctx context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.owned[key]; ok && c.conn != nil && !c.conn.IsClosed() {
+		return true, nil
+	}
+
+	conn, err := c.connectLocked(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(
+		ctx, "SELECT pg_try_advisory_lock($1)", c.lockID(key),
+	).Scan(&acquired); err != nil {
+		c.resetLocked(ctx)
+		return false, fmt.Errorf("acquiring subscription cohort lock: %w", err)
+	}
+
+	if acquired {
+		c.owned[key] = struct{}{}
+	}
+
+	return acquired, nil
+}
+
+// Release unlocks key if this replica holds it.
+func (c *coordinator) Release(ctx context.Context, key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.owned[key]; !ok {
+		return
+	}
+
+	delete(c.owned, key)
+
+	if c.conn == nil {
+		return
+	}
+
+	if _, err := c.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", c.lockID(key)); err != nil {
+		c.logger.WarnContext(
+			ctx, "failed to release subscription cohort lock",
+			slog.String("error", err.Error()),
+		)
+		c.resetLocked(ctx)
+	}
+}
+
+// Publish notifies every replica listening on the coordination channel.
+func (c *coordinator) Publish(ctx context.Context, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, err := c.connectLocked(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := conn.Exec(
+		ctx, "SELECT pg_notify($1, $2)", coordinationChannel, c.envelope(payload),
+	); err != nil {
+		c.resetLocked(ctx)
+		return fmt.Errorf("publishing subscription coordination message: %w", err)
+	}
+
+	return nil
+}
+
+// Close stops listening and closes both connections, releasing every lock.
+func (c *coordinator) Close() {
+	c.cancel()
+	<-c.done
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resetLocked(context.Background())
+}
+
+// lockID maps a cohort key to the bigint advisory lock key.
+func (c *coordinator) lockID(key string) int64 {
+	return int64(xxhash.Sum64String(c.namespace + "\x00" + key)) //nolint:gosec // wrap-around is fine for a hash
+}
+
+// envelope prefixes payload with the namespace, which listeners strip.
+func (c *coordinator) envelope(payload []byte) string {
+	return c.namespace + "\n" + string(payload)
+}
+
+// connectLocked returns the lock connection, dialling it if needed. The
+// caller must hold mu.
+func (c *coordinator) connectLocked(ctx context.Context) (*pgx.Conn, error) {
+	if c.conn != nil && !c.conn.IsClosed() {
+		return c.conn, nil
+	}
+
+	select {
+	case <-c.done:
+		return nil, errCoordinatorClosed
+	default:
+	}
+
+	c.owned = make(map[string]struct{})
+
+	conn, err := pgx.Connect(ctx, c.connStr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting subscription coordinator: %w", err)
+	}
+
+	c.conn = conn
+
+	return conn, nil
+}
+
+// resetLocked closes the lock connection, which releases every lock it held.
+// The caller must hold mu.
+func (c *coordinator) resetLocked(ctx context.Context) {
+	if c.conn != nil {
+		_ = c.conn.Close(ctx)
+	}
+
+	c.conn = nil
+	c.owned = make(map[string]struct{})
+}
+
+// listenLoop keeps a listening connection open until ctx is cancelled,
+// reconnecting after failures.
+func (c *coordinator) listenLoop(ctx context.Context) {
+	defer close(c.done)
+	defer close(c.messages)
+
+	for {
+		err := c.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		c.logger.WarnContext(
+			ctx, "subscription coordination listener failed, reconnecting",
+			slog.String("error", err.Error()),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(coordinationRetryDelay):
+		}
+	}
+}
+
+// listen relays this namespace's notifications to messages, dropping them
+// when the handler falls behind; followers recover through the hashes they
+// report back to the owner.
+func (c *coordinator) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, c.connStr)
+	if err != nil {
+		return fmt.Errorf("connecting subscription listener: %w", err)
+	}
+	defer conn.Close(context.Background()) //nolint:contextcheck // ctx may already be cancelled
+
+	if _, err := conn.Exec(ctx, "LISTEN "+coordinationChannel); err != nil {
+		return fmt.Errorf("listening for subscription coordination: %w", err)
+	}
+
+	prefix := []byte(c.namespace + "\n")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("waiting for subscription coordination: %w", err)
+		}
+
+		payload, ok := bytes.CutPrefix([]byte(notification.Payload), prefix)
+		if !ok {
+			continue
+		}
+
+		select {
+		case c.messages <- payload:
+		default:
+			c.logger.DebugContext(ctx, "dropping subscription coordination message")
+		}
+	}
+}
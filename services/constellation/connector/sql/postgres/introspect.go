@@ -196,15 +196,17 @@ func getTables(
 	return tables, nil
 }
 
-// populateRelationKinds fills in IsView / IsInsertable / IsUpdatable on every
-// relation previously discovered by populateTableColumns. Base tables (relkind
-// 'r') and partitioned tables ('p') are always insertable and updatable. Views
-// ('v') and foreign tables ('f') consult pg_relation_is_updatable(), which is
-// the same function information_schema.views uses internally: bit 8 (INSERT)
-// gates IsInsertable, and bits 4|16 (UPDATE|DELETE) jointly gate IsUpdatable —
-// the same (& 20) = 20 check information_schema.views.is_updatable uses. The
-// schema generator uses these to suppress mutation fields on relations the
-// database itself will reject writes to.
+// populateRelationKinds fills in IsView / IsInsertable / IsUpdatable /
+// IsDeletable on every relation previously discovered by
+// populateTableColumns. Base tables (relkind 'r') and partitioned tables ('p')
+// are always insertable, updatable and deletable. Views ('v') and foreign
+// tables ('f') consult pg_relation_is_updatable(), which is the same function
+// information_schema.views uses internally: bit 8 (INSERT) gates
+// IsInsertable, bit 4 (UPDATE) IsUpdatable and bit 16 (DELETE) IsDeletable.
+// information_schema.views.is_updatable requires both of the last two, which
+// would hide the delete mutations of a view whose rules or INSTEAD OF
+// triggers only allow DELETE. The schema generator uses these to suppress
+// mutation fields on relations the database itself will reject writes to.
 func populateRelationKinds(
 	ctx context.Context,
 	q Querier,
@@ -218,10 +220,8 @@ func populateRelationKinds(
 
 	// pg_relation_is_updatable() returns an int bitmask whose bits mirror
 	// the SQL standard's IS_UPDATABLE semantics (bit 4 = UPDATE, bit 8 =
-	// INSERT, bit 16 = DELETE). information_schema.views uses the same
-	// `& 8 = 8` (INSERT) and `& 20 = 20` (UPDATE|DELETE) checks; we
-	// replicate them here against pg_class so the query works without
-	// privileges on the relation's owner role.
+	// INSERT, bit 16 = DELETE). We test each bit against pg_class so the
+	// query works without privileges on the relation's owner role.
 	query := `
 		SELECT
 			cls.relname AS table_name,
@@ -235,9 +235,15 @@ func populateRelationKinds(
 			CASE
 				WHEN cls.relkind IN ('r', 'p') THEN true
 				WHEN cls.relkind IN ('v', 'f') THEN
-					(pg_catalog.pg_relation_is_updatable(cls.oid, false) & 20) = 20
+					(pg_catalog.pg_relation_is_updatable(cls.oid, false) & 4) = 4
 				ELSE false
-			END AS is_updatable
+			END AS is_updatable,
+			CASE
+				WHEN cls.relkind IN ('r', 'p') THEN true
+				WHEN cls.relkind IN ('v', 'f') THEN
+					(pg_catalog.pg_relation_is_updatable(cls.oid, false) & 16) = 16
+				ELSE false
+			END AS is_deletable
 		FROM pg_catalog.pg_class cls
 		JOIN pg_catalog.pg_namespace ns ON ns.oid = cls.relnamespace
 		WHERE ns.nspname = $1
@@ -257,8 +263,11 @@ func populateRelationKinds(
 			isView       bool
 			isInsertable bool
 			isUpdatable  bool
+			isDeletable  bool
 		)
-		if err := rows.Scan(&tableName, &isView, &isInsertable, &isUpdatable); err != nil {
+		if err := rows.Scan(
+			&tableName, &isView, &isInsertable, &isUpdatable, &isDeletable,
+		); err != nil {
 			return fmt.Errorf("failed to scan relation kind row: %w", err)
 		}
 
@@ -272,6 +281,7 @@ func populateRelationKinds(
 		table.IsView = isView
 		table.IsInsertable = isInsertable
 		table.IsUpdatable = isUpdatable
+		table.IsDeletable = isDeletable
 	}
 
 	if err := rows.Err(); err != nil {
@@ -449,6 +459,7 @@ func populateTableColumns( //nolint:funlen
 				IsView:                   false,
 				IsInsertable:             true,
 				IsUpdatable:              true,
+				IsDeletable:              true,
 			}
 			tableMap[tableName] = table
 		}
@@ -494,6 +505,7 @@ func populateForeignKeys( //nolint:funlen
 	query := `
 		SELECT
 			ct.relname AS table_name,
+			r.conname AS constraint_name,
 			ac.attname AS column_name,
 			cftn.nspname AS foreign_schema,
 			cft.relname AS foreign_table_name,
@@ -523,6 +535,7 @@ func populateForeignKeys( //nolint:funlen
 	for rows.Next() {
 		var (
 			tableName         string
+			constraintName    string
 			columnName        string
 			foreignSchema     string
 			foreignTableName  string
@@ -530,7 +543,7 @@ func populateForeignKeys( //nolint:funlen
 		)
 
 		if err := rows.Scan(
-			&tableName, &columnName,
+			&tableName, &constraintName, &columnName,
 			&foreignSchema, &foreignTableName, &foreignColumnName,
 		); err != nil {
 			return fmt.Errorf("failed to scan foreign key row: %w", err)
@@ -538,6 +551,7 @@ func populateForeignKeys( //nolint:funlen
 
 		if table, exists := tableMap[tableName]; exists {
 			table.ForeignKeys = append(table.ForeignKeys, introspection.ForeignKey{
+				ConstraintName:    constraintName,
 				ColumnName:        columnName,
 				ForeignSchema:     foreignSchema,
 				ForeignTable:      foreignTableName,
@@ -8,13 +8,13 @@ import (
 	"github.com/nhost/nhost/services/constellation/metadata"
 )
 
-// TestIntrospect_ViewFlags verifies the IsView / IsInsertable / IsUpdatable
-// flags populated on introspection.Table for the three relation kinds in
-// testdata/pg_schema.sql:
+// TestIntrospect_ViewFlags verifies the IsView / IsInsertable / IsUpdatable /
+// IsDeletable flags populated on introspection.Table for the three relation
+// kinds in testdata/pg_schema.sql:
 //
-//   - public.news            — base table     (IsView=false, both true)
-//   - public.published_news  — simple view    (IsView=true,  both true)
-//   - public.content_feed    — UNION ALL view (IsView=true,  both false)
+//   - public.news            — base table     (IsView=false, all true)
+//   - public.published_news  — simple view    (IsView=true,  all true)
+//   - public.content_feed    — UNION ALL view (IsView=true,  all false)
 //
 // The content_feed case is the regression we care about: Postgres reports
 // is_insertable_into = NO and is_updatable = NO for it, and the schema
@@ -76,10 +76,20 @@ CREATE VIEW public.content_feed AS
 		wantIsView       bool
 		wantIsInsertable bool
 		wantIsUpdatable  bool
+		wantIsDeletable  bool
 	}{
-		{name: "news", wantIsView: false, wantIsInsertable: true, wantIsUpdatable: true},
-		{name: "published_news", wantIsView: true, wantIsInsertable: true, wantIsUpdatable: true},
-		{name: "content_feed", wantIsView: true, wantIsInsertable: false, wantIsUpdatable: false},
+		{
+			name: "news", wantIsView: false,
+			wantIsInsertable: true, wantIsUpdatable: true, wantIsDeletable: true,
+		},
+		{
+			name: "published_news", wantIsView: true,
+			wantIsInsertable: true, wantIsUpdatable: true, wantIsDeletable: true,
+		},
+		{
+			name: "content_feed", wantIsView: true,
+			wantIsInsertable: false, wantIsUpdatable: false, wantIsDeletable: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -102,6 +112,10 @@ CREATE VIEW public.content_feed AS
 			if tbl.IsUpdatable != tc.wantIsUpdatable {
 				t.Errorf("IsUpdatable = %v, want %v", tbl.IsUpdatable, tc.wantIsUpdatable)
 			}
+
+			if tbl.IsDeletable != tc.wantIsDeletable {
+				t.Errorf("IsDeletable = %v, want %v", tbl.IsDeletable, tc.wantIsDeletable)
+			}
 		})
 	}
 }
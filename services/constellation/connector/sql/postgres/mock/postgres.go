@@ -265,6 +265,21 @@ func (mr *MockTxMockRecorder) Exec(ctx, sql any, args ...any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockTx)(nil).Exec), varargs...)
 }
 
+// ExecScript mocks base method.
+func (m *MockTx) ExecScript(ctx context.Context, sql string) (postgres.ScriptResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecScript", ctx, sql)
+	ret0, _ := ret[0].(postgres.ScriptResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecScript indicates an expected call of ExecScript.
+func (mr *MockTxMockRecorder) ExecScript(ctx, sql any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecScript", reflect.TypeOf((*MockTx)(nil).ExecScript), ctx, sql)
+}
+
 // Query mocks base method.
 func (m *MockTx) Query(ctx context.Context, sql string, args ...any) (postgres.Rows, error) {
 	m.ctrl.T.Helper()
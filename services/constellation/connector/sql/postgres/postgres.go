@@ -14,15 +14,24 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	csql "github.com/nhost/nhost/services/constellation/connector/sql"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+	sqlsub "github.com/nhost/nhost/services/constellation/connector/sql/subscription"
 	"github.com/nhost/nhost/services/constellation/metadata"
 )
 
-var errSequentialNonJSONResult = errors.New("sequential operation returned non-JSON result")
+// sqlStateQueryCanceled is the SQLSTATE Postgres reports for a statement
+// cancelled by statement_timeout.
+const sqlStateQueryCanceled = "57014"
+
+var (
+	errSequentialNonJSONResult = errors.New("sequential operation returned non-JSON result")
+	errExplainMultiStatement   = errors.New("only single-statement query operations can be explained")
+)
 
 // Querier abstracts database query execution. Both Pool and Tx satisfy this
 // interface, which lets unexported helpers run against either a pool or a
@@ -51,10 +60,21 @@ type Pool interface {
 // package's call boundaries.
 type Tx interface {
 	Querier
+	// ExecScript runs sql, which may hold several statements, with the
+	// simple query protocol and returns the outcome of the last statement.
+	ExecScript(ctx context.Context, sql string) (ScriptResult, error)
 	Commit(ctx context.Context) error
 	Rollback(ctx context.Context) error
 }
 
+// ScriptResult is the outcome of the last statement of a script run by
+// [Tx.ExecScript]: its column names and its rows in text format, a nil value
+// being NULL. Columns is nil when the statement returned no tuples.
+type ScriptResult struct {
+	Columns []string
+	Rows    [][][]byte
+}
+
 // Row is the local single-row result type. Keeping it local means pgx.Row
 // never appears on this package's call boundaries.
 type Row interface {
@@ -189,9 +209,62 @@ func (t *txAdapter) Exec(ctx context.Context, sql string, args ...any) error {
 	return err //nolint:wrapcheck
 }
 
+// ExecScript reads every result of the script so the connection is left
+// ready for the next statement, keeping only the last one.
+func (t *txAdapter) ExecScript(ctx context.Context, sql string) (ScriptResult, error) {
+	mrr := t.Conn().PgConn().Exec(ctx, sql)
+
+	var last ScriptResult
+
+	for mrr.NextResult() {
+		last = readScriptResult(mrr.ResultReader())
+		if _, err := mrr.ResultReader().Close(); err != nil {
+			_ = mrr.Close()
+			return ScriptResult{}, err //nolint:wrapcheck
+		}
+	}
+
+	if err := mrr.Close(); err != nil {
+		return ScriptResult{}, err //nolint:wrapcheck
+	}
+
+	return last, nil
+}
+
+func readScriptResult(rr *pgconn.ResultReader) ScriptResult {
+	fields := rr.FieldDescriptions()
+	if len(fields) == 0 {
+		return ScriptResult{Columns: nil, Rows: nil}
+	}
+
+	result := ScriptResult{Columns: make([]string, len(fields)), Rows: nil}
+	for i, field := range fields {
+		result.Columns[i] = field.Name
+	}
+
+	for rr.NextRow() {
+		values := rr.Values()
+
+		row := make([][]byte, len(values))
+		for i, value := range values {
+			if value != nil {
+				row[i] = bytes.Clone(value)
+			}
+		}
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result
+}
+
 // Client implements the sql.Driver interface for PostgreSQL.
 type Client struct {
 	pool Pool
+	// coordinator is set by [WithSubscriptionCoordination].
+	coordinator *coordinator
+	// sessionSettings is set by [WithSessionSettings].
+	sessionSettings bool
 }
 
 const sqlInit = `CREATE OR REPLACE FUNCTION constellation_throw_error(message text, errcode text)
@@ -234,9 +307,15 @@ func execSQLInit(ctx context.Context, q Querier) error {
 // exported so that [internal/lib/testdb] and white-box-style integration
 // tests in sibling packages (e.g. connector/sql/graphql/queries,
 // connector/sql/graphql/schema) can build a *Client around a Pool they
-// already own.
-func NewClient(pool Pool) *Client {
-	return &Client{pool: pool}
+// already own. Of opts, only [WithSessionSettings] applies here;
+// [WithSubscriptionCoordination] needs the connection string [New] has.
+func NewClient(pool Pool, opts ...Option) *Client {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Client{pool: pool, coordinator: nil, sessionSettings: o.sessionSettings}
 }
 
 // Open opens a pgx connection pool against connStr and runs the
@@ -270,15 +349,26 @@ func New(
 	dbMeta *metadata.DatabaseMetadata,
 	inconsistencies *metadata.Inconsistencies,
 	logger *slog.Logger,
+	opts ...Option,
 ) (*csql.Connector, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	pool, err := Open(ctx, connStr)
 	if err != nil {
 		return nil, err
 	}
 
-	c, err := csql.NewConnector(ctx, NewClient(pool), dbMeta, inconsistencies, logger)
+	client := NewClient(pool, opts...)
+	if o.coordinateSubscriptions {
+		client.coordinator = newCoordinator(connStr, dbMeta.Name, logger)
+	}
+
+	c, err := csql.NewConnector(ctx, client, dbMeta, inconsistencies, logger)
 	if err != nil {
-		pool.Close()
+		client.Close()
 		return nil, fmt.Errorf("failed to create sql connector: %w", err)
 	}
 
@@ -290,14 +380,33 @@ func (c *Client) Dialect() dialect.Dialect { //nolint:ireturn,nolintlint
 	return dialect.NewPostgresDialect()
 }
 
-// Close releases the underlying connection pool.
+// Close releases the underlying connection pool and the subscription
+// coordinator, if any.
 func (c *Client) Close() {
+	if c.coordinator != nil {
+		c.coordinator.Close()
+	}
+
 	c.pool.Close()
 }
 
+// SubscriptionCoordinator returns the coordinator live-query subscriptions
+// share their polls through, or nil unless the client was created with
+// [WithSubscriptionCoordination].
+func (c *Client) SubscriptionCoordinator() sqlsub.Coordinator { //nolint:ireturn,nolintlint
+	if c.coordinator == nil {
+		return nil
+	}
+
+	return c.coordinator
+}
+
 // ExecuteOperations executes a list of SQL operations within a single
-// transaction. Uses named returns so the rollback defer reads the actual
-// error returned by the function body.
+// transaction. A statement timeout on ctx (see [csql.WithStatementTimeout]) is
+// applied to the transaction with SET LOCAL, and with [WithSessionSettings]
+// the session variables on ctx are published as hasura.user. Uses named
+// returns so the rollback defer reads the actual error returned by the
+// function body.
 //
 //nolint:nonamedreturns
 func (c *Client) ExecuteOperations(
@@ -322,16 +431,23 @@ func (c *Client) ExecuteOperations(
 		}
 	}()
 
+	timeout := csql.StatementTimeoutFromContext(ctx)
+	if err = setStatementTimeout(ctx, tx, timeout); err != nil {
+		return nil, err
+	}
+
+	if c.sessionSettings {
+		if err = setSessionUser(ctx, tx, csql.SessionVariablesFromContext(ctx)); err != nil {
+			return nil, err
+		}
+	}
+
 	opsResults := make(map[string]any, len(operations))
 
 	for _, op := range operations {
 		opResult, opErr := c.executeOperation(ctx, tx, op)
 		if opErr != nil {
-			err = fmt.Errorf("failed to execute operation %s: %w", op.Name, opErr)
-			logger.ErrorContext(
-				ctx, "failed to execute operation",
-				slog.String("operation", op.Name), slog.String("error", opErr.Error()),
-			)
+			err = operationError(ctx, op, timeout, opErr, logger)
 
 			return nil, err
 		}
@@ -350,6 +466,54 @@ func (c *Client) ExecuteOperations(
 	return opsResults, nil
 }
 
+// setStatementTimeout bounds every statement of tx by timeout. A zero timeout
+// keeps the server's statement_timeout.
+func setStatementTimeout(ctx context.Context, tx Tx, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+
+	// SET does not take parameters; the value is an integer we format.
+	stmt := fmt.Sprintf("SET LOCAL statement_timeout = %d", max(timeout.Milliseconds(), 1))
+	if err := tx.Exec(ctx, stmt); err != nil {
+		return fmt.Errorf("failed to set statement timeout: %w", err)
+	}
+
+	return nil
+}
+
+// operationError logs a failed operation and wraps its error. A statement
+// Postgres cancelled for exceeding timeout is logged with its SQL and
+// returned as a [csql.StatementTimeoutError].
+func operationError(
+	ctx context.Context,
+	op core.SQLOperation,
+	timeout time.Duration,
+	opErr error,
+	logger *slog.Logger,
+) error {
+	err := fmt.Errorf("failed to execute operation %s: %w", op.Name, opErr)
+
+	pgErr, ok := errors.AsType[*pgconn.PgError](opErr)
+	if timeout > 0 && ok && pgErr.Code == sqlStateQueryCanceled && ctx.Err() == nil {
+		logger.WarnContext(
+			ctx, "statement timeout exceeded, query cancelled",
+			slog.String("operation", op.Name),
+			slog.Duration("timeout", timeout),
+			slog.String("sql", op.SQL),
+		)
+
+		return csql.NewStatementTimeoutError(timeout, err)
+	}
+
+	logger.ErrorContext(
+		ctx, "failed to execute operation",
+		slog.String("operation", op.Name), slog.String("error", opErr.Error()),
+	)
+
+	return err
+}
+
 func (c *Client) executeOperation(
 	ctx context.Context,
 	q Querier,
@@ -415,9 +579,29 @@ func (c *Client) executeSequentialOperation(
 	return jsontext.Value(b.Bytes()), nil
 }
 
+// ExplainOperation returns the EXPLAIN (FORMAT JSON) plan for op. EXPLAIN
+// without ANALYZE only plans the statement, so nothing is executed.
+func (c *Client) ExplainOperation(
+	ctx context.Context, op core.SQLOperation,
+) (jsontext.Value, error) {
+	if len(op.Sequential) > 0 {
+		return nil, errExplainMultiStatement
+	}
+
+	var plan []byte
+	if err := c.pool.QueryRow(
+		ctx, "EXPLAIN (FORMAT JSON) "+op.SQL, op.Parameters...,
+	).Scan(&plan); err != nil {
+		return nil, fmt.Errorf("failed to explain operation: %w", err)
+	}
+
+	return jsontext.Value(plan), nil
+}
+
 // ExecuteMultiplexedOperation executes a multiplexed SQL query and returns
 // each row as a {SubscriptionID, Data} pair — the two-column shape used by
-// the subscription poller.
+// the subscription poller. With [WithSessionSettings] the subscribers are
+// polled per session instead; see executeMultiplexedPerSession.
 func (c *Client) ExecuteMultiplexedOperation(
 	ctx context.Context,
 	sqlQuery string,
@@ -430,7 +614,35 @@ func (c *Client) ExecuteMultiplexedOperation(
 		slog.Int("args", len(args)),
 	)
 
-	rows, err := c.pool.Query(ctx, sqlQuery, args...)
+	var (
+		results []core.MultiplexedResult
+		err     error
+	)
+
+	if c.sessionSettings {
+		results, err = c.executeMultiplexedPerSession(ctx, sqlQuery, args)
+	} else {
+		results, err = queryMultiplexed(ctx, c.pool, sqlQuery, args)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	logger.DebugContext(
+		ctx, "multiplexed query returned results",
+		slog.Int("count", len(results)),
+	)
+
+	return results, nil
+}
+
+// queryMultiplexed runs a multiplexed query on q and scans its
+// {SubscriptionID, Data} rows.
+func queryMultiplexed(
+	ctx context.Context, q Querier, sqlQuery string, args []any,
+) ([]core.MultiplexedResult, error) {
+	rows, err := q.Query(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute multiplexed query: %w", err)
 	}
@@ -458,10 +670,5 @@ func (c *Client) ExecuteMultiplexedOperation(
 		return nil, fmt.Errorf("error iterating multiplexed results: %w", err)
 	}
 
-	logger.DebugContext(
-		ctx, "multiplexed query returned results",
-		slog.Int("count", len(results)),
-	)
-
 	return results, nil
 }
@@ -282,3 +282,24 @@ func TestBuildFunctionArguments(t *testing.T) {
 		})
 	}
 }
+
+func TestCoordinatorScopesLocksAndMessagesToNamespace(t *testing.T) {
+	t.Parallel()
+
+	app := &coordinator{namespace: "app"}
+	other := &coordinator{namespace: "other"}
+
+	const key = "user:OnUsers:abc:"
+
+	if app.lockID(key) != app.lockID(key) {
+		t.Error("lockID is not stable for the same key")
+	}
+
+	if app.lockID(key) == other.lockID(key) {
+		t.Error("the same cohort key locks the same id in different namespaces")
+	}
+
+	if got := app.envelope([]byte(`{"kind":"alive"}`)); got != "app\n{\"kind\":\"alive\"}" {
+		t.Errorf("envelope = %q", got)
+	}
+}
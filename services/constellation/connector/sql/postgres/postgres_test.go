@@ -5,10 +5,13 @@ import (
 	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"go.uber.org/mock/gomock"
 
+	csql "github.com/nhost/nhost/services/constellation/connector/sql"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
 	"github.com/nhost/nhost/services/constellation/connector/sql/postgres"
@@ -310,6 +313,109 @@ func TestExecuteOperations(t *testing.T) {
 	}
 }
 
+func TestExecuteOperationsStatementTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+	row := mock.NewMockRow(ctrl)
+
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		tx.EXPECT().Exec(gomock.Any(), "SET LOCAL statement_timeout = 2000").Return(nil),
+		tx.EXPECT().QueryRow(gomock.Any(), "SELECT pg_sleep(10)", gomock.Any()).Return(row),
+		row.EXPECT().Scan(gomock.Any()).Return(&pgconn.PgError{ //nolint:exhaustruct
+			Code:    "57014",
+			Message: "canceling statement due to statement timeout",
+		}),
+		tx.EXPECT().Rollback(gomock.Any()).Return(nil),
+	)
+
+	client := postgres.NewClient(pool)
+
+	_, err := client.ExecuteOperations(
+		csql.WithStatementTimeout(t.Context(), 2*time.Second),
+		[]core.SQLOperation{{Name: "slow", SQL: "SELECT pg_sleep(10)", Parameters: nil}},
+		discardLogger(),
+	)
+
+	timeoutErr, ok := errors.AsType[*csql.StatementTimeoutError](err)
+	if !ok {
+		t.Fatalf("expected a *StatementTimeoutError, got %T (%v)", err, err)
+	}
+
+	if got := timeoutErr.AsMap()["message"]; got != "database query error" {
+		t.Errorf("unexpected client message: %v", got)
+	}
+}
+
+func TestExecuteOperationsSessionSettings(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+	row := mock.NewMockRow(ctrl)
+
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		tx.EXPECT().Exec(
+			gomock.Any(),
+			"SELECT set_config('hasura.user', $1, true)",
+			`{"x-hasura-role":"user","x-hasura-user-id":"42"}`,
+		).Return(nil),
+		tx.EXPECT().QueryRow(gomock.Any(), "SELECT 1", gomock.Any()).Return(row),
+		row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanJSONInto(t, []byte(`1`))),
+		tx.EXPECT().Commit(gomock.Any()).Return(nil),
+	)
+
+	client := postgres.NewClient(pool, postgres.WithSessionSettings())
+
+	ctx := csql.WithSessionVariables(t.Context(), map[string]any{
+		"x-hasura-user-id": "42",
+		"x-hasura-role":    "user",
+	})
+
+	result, err := client.ExecuteOperations(
+		ctx,
+		[]core.SQLOperation{{Name: "one", SQL: "SELECT 1", Parameters: nil}},
+		discardLogger(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectJSONTextResult(t, result, "one", `1`)
+}
+
+func TestExecuteOperationsSessionSettingsWithoutSession(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+	row := mock.NewMockRow(ctrl)
+
+	// No session on ctx (e.g. an internal query): hasura.user is left unset.
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		tx.EXPECT().QueryRow(gomock.Any(), "SELECT 1", gomock.Any()).Return(row),
+		row.EXPECT().Scan(gomock.Any()).DoAndReturn(scanJSONInto(t, []byte(`1`))),
+		tx.EXPECT().Commit(gomock.Any()).Return(nil),
+	)
+
+	client := postgres.NewClient(pool, postgres.WithSessionSettings())
+
+	if _, err := client.ExecuteOperations(
+		t.Context(),
+		[]core.SQLOperation{{Name: "one", SQL: "SELECT 1", Parameters: nil}},
+		discardLogger(),
+	); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // multiplexedSuccessMocks wires the rows iterator for the successful path of
 // ExecuteMultiplexedOperation: two real subscription/data rows and a final
 // Next()=false sentinel.
@@ -452,6 +558,134 @@ func TestExecuteMultiplexedOperation(t *testing.T) {
 	}
 }
 
+// expectMultiplexedRows wires rows to return the {SubscriptionID, Data} row
+// of each of ids, in order, with the payload taken from data.
+func expectMultiplexedRows(t *testing.T, rows *mock.MockRows, data map[string]string, ids ...string) {
+	t.Helper()
+
+	for _, id := range ids {
+		rows.EXPECT().Next().Return(true)
+		rows.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+			strPtr, ok := dest[0].(*string)
+			if !ok {
+				t.Fatal("expected *string dest[0]")
+			}
+
+			dataPtr, ok := dest[1].(*[]byte)
+			if !ok {
+				t.Fatal("expected *[]byte dest[1]")
+			}
+
+			*strPtr = id
+			*dataPtr = []byte(data[id])
+
+			return nil
+		})
+	}
+
+	rows.EXPECT().Next().Return(false)
+	rows.EXPECT().Err().Return(nil)
+	rows.EXPECT().Close()
+}
+
+func TestExecuteMultiplexedOperationSessionSettings(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+	aliceRows := mock.NewMockRows(ctrl)
+	bobRows := mock.NewMockRows(ctrl)
+
+	const (
+		sqlQuery = "SELECT multiplexed"
+		setUser  = "SELECT set_config('hasura.user', $1, true)"
+		alice    = `{"session":{"x-hasura-role":"user","x-hasura-user-id":"alice"}}`
+		bob      = `{"session":{"x-hasura-user-id":"bob","x-hasura-role":"user","x-hasura-org":null}}`
+		alice2   = `{"session":{"x-hasura-user-id":"alice","x-hasura-role":"user"}}`
+	)
+
+	data := map[string]string{"sub-1": `{"a":1}`, "sub-2": `{"a":2}`, "sub-3": `{"a":3}`}
+
+	// Subscribers sharing a session share a run; a null session variable is
+	// left out of hasura.user.
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		tx.EXPECT().Exec(
+			gomock.Any(), setUser, `{"x-hasura-role":"user","x-hasura-user-id":"alice"}`,
+		).Return(nil),
+		tx.EXPECT().Query(
+			gomock.Any(), sqlQuery,
+			[]string{"sub-1", "sub-3"}, []string{alice, alice2}, "static",
+		).Return(aliceRows, nil),
+		tx.EXPECT().Exec(
+			gomock.Any(), setUser, `{"x-hasura-role":"user","x-hasura-user-id":"bob"}`,
+		).Return(nil),
+		tx.EXPECT().Query(
+			gomock.Any(), sqlQuery, []string{"sub-2"}, []string{bob}, "static",
+		).Return(bobRows, nil),
+		tx.EXPECT().Commit(gomock.Any()).Return(nil),
+	)
+
+	expectMultiplexedRows(t, aliceRows, data, "sub-1", "sub-3")
+	expectMultiplexedRows(t, bobRows, data, "sub-2")
+
+	client := postgres.NewClient(pool, postgres.WithSessionSettings())
+
+	results, err := client.ExecuteMultiplexedOperation(
+		t.Context(),
+		sqlQuery,
+		[]any{[]string{"sub-1", "sub-2", "sub-3"}, []string{alice, bob, alice2}, "static"},
+		discardLogger(),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]string, len(results))
+	for _, r := range results {
+		got[r.SubscriptionID] = string(r.Data)
+	}
+
+	if len(results) != len(data) {
+		t.Fatalf("expected %d results, got %d", len(data), len(results))
+	}
+
+	for id, want := range data {
+		if got[id] != want {
+			t.Errorf("result for %s = %s, want %s", id, got[id], want)
+		}
+	}
+}
+
+func TestExecuteMultiplexedOperationSessionSettingsRollsBack(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		tx.EXPECT().Exec(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+		tx.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errQueryFailed),
+		tx.EXPECT().Rollback(gomock.Any()).Return(nil),
+	)
+
+	client := postgres.NewClient(pool, postgres.WithSessionSettings())
+
+	_, err := client.ExecuteMultiplexedOperation(
+		t.Context(),
+		"SELECT multiplexed",
+		[]any{[]string{"sub-1"}, []string{`{"session":{"x-hasura-role":"user"}}`}},
+		discardLogger(),
+	)
+	if !errors.Is(err, errQueryFailed) {
+		t.Fatalf("expected %v in chain, got: %v", errQueryFailed, err)
+	}
+}
+
 func TestDialect(t *testing.T) {
 	t.Parallel()
 
@@ -495,3 +729,14 @@ func TestNewClient(t *testing.T) {
 		t.Error("expected non-nil Dialect from NewClient output")
 	}
 }
+
+func TestClient_SubscriptionCoordinatorDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+
+	if coordinator := postgres.NewClient(pool).SubscriptionCoordinator(); coordinator != nil {
+		t.Errorf("expected no coordinator, got %T", coordinator)
+	}
+}
@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/nhost/nhost/services/constellation/connector/runsql"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+// trackedTablesSQL returns each table of the $1 (schema) / $2 (name) arrays
+// with a text rendering of everything introspection reads from it: columns,
+// types, nullability, defaults, constraints and comments. The definition is
+// NULL for a table that does not exist.
+const trackedTablesSQL = `SELECT t.schema_name, t.table_name, (
+	SELECT concat_ws(
+		E'\n',
+		obj_description(c.oid, 'pg_class'),
+		(
+			SELECT string_agg(
+				concat_ws(
+					' ', quote_ident(a.attname), format_type(a.atttypid, a.atttypmod),
+					CASE WHEN a.attnotnull THEN 'NOT NULL' END,
+					pg_get_expr(d.adbin, d.adrelid), col_description(c.oid, a.attnum)
+				),
+				E'\n' ORDER BY a.attnum
+			)
+			FROM pg_attribute a
+			LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+			WHERE a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+		),
+		(
+			SELECT string_agg(pg_get_constraintdef(k.oid), E'\n' ORDER BY k.conname)
+			FROM pg_constraint k
+			WHERE k.conrelid = c.oid
+		)
+	)
+	FROM pg_class c
+	JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE n.nspname = t.schema_name AND c.relname = t.table_name
+)
+FROM unnest($1::text[], $2::text[]) AS t(schema_name, table_name)`
+
+// RunSQL runs scripts in order in a single transaction for POST /v2/query's
+// run_sql and bulk. The definitions of each script's Tracked tables are read
+// before and after it inside the transaction, so the comparison sees exactly
+// what that script did. A script that drops a tracked table without Cascade
+// rolls the whole run back with a [*runsql.DependencyError]; an error raised
+// by a script itself is a [*runsql.ExecutionError]. Uses named returns so the
+// rollback defer reads the actual error returned by the function body.
+//
+//nolint:nonamedreturns
+func (c *Client) RunSQL(
+	ctx context.Context, scripts []runsql.Script, opts runsql.Options,
+) (results []*runsql.Result, err error) {
+	tx, err := c.pool.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if opts.ReadOnly {
+		if err = tx.Exec(ctx, "SET TRANSACTION READ ONLY"); err != nil {
+			return nil, fmt.Errorf("failed to set transaction read only: %w", err)
+		}
+	}
+
+	results = make([]*runsql.Result, 0, len(scripts))
+
+	for i, script := range scripts {
+		var result *runsql.Result
+		if result, err = runScript(ctx, tx, i, script); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return results, nil
+}
+
+// runScript runs the i-th script of a run in tx, comparing its tracked tables
+// before and after it.
+func runScript(ctx context.Context, tx Tx, i int, script runsql.Script) (*runsql.Result, error) {
+	before, err := trackedDefinitions(ctx, tx, script.Tracked)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := tx.ExecScript(ctx, script.SQL)
+	if err != nil {
+		return nil, scriptError(i, err)
+	}
+
+	after, err := trackedDefinitions(ctx, tx, script.Tracked)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, dropped := compareDefinitions(script.Tracked, before, after)
+	if len(dropped) > 0 && !script.Cascade {
+		return nil, &runsql.DependencyError{Script: i, Tables: dropped}
+	}
+
+	return &runsql.Result{Rows: scriptRows(out), Changed: changed}, nil
+}
+
+// scriptError wraps an error the server raised for the i-th script in a
+// runsql.ExecutionError carrying its SQLSTATE.
+func scriptError(i int, err error) error {
+	if pgErr, ok := errors.AsType[*pgconn.PgError](err); ok {
+		return &runsql.ExecutionError{Script: i, Code: pgErr.Code, Err: err}
+	}
+
+	return fmt.Errorf("failed to execute script: %w", err)
+}
+
+// trackedDefinitions returns the definition of each existing table in tracked;
+// missing tables have no entry.
+func trackedDefinitions(
+	ctx context.Context, q Querier, tracked []metadata.TableSource,
+) (map[metadata.TableSource]string, error) {
+	if len(tracked) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	schemas := make([]string, len(tracked))
+	names := make([]string, len(tracked))
+
+	for i, t := range tracked {
+		schemas[i], names[i] = t.Schema, t.Name
+	}
+
+	rows, err := q.Query(ctx, trackedTablesSQL, schemas, names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tracked tables: %w", err)
+	}
+	defer rows.Close()
+
+	definitions := make(map[metadata.TableSource]string, len(tracked))
+
+	for rows.Next() {
+		var (
+			table      metadata.TableSource
+			definition *string
+		)
+
+		if err := rows.Scan(&table.Schema, &table.Name, &definition); err != nil {
+			return nil, fmt.Errorf("failed to scan tracked table: %w", err)
+		}
+
+		if definition != nil {
+			definitions[table] = *definition
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tracked tables: %w", err)
+	}
+
+	return definitions, nil
+}
+
+// compareDefinitions reports whether any tracked table was created, altered
+// or dropped between before and after, and lists the dropped ones.
+func compareDefinitions(
+	tracked []metadata.TableSource, before, after map[metadata.TableSource]string,
+) (bool, []metadata.TableSource) {
+	var (
+		changed bool
+		dropped []metadata.TableSource
+	)
+
+	for _, t := range tracked {
+		was, existed := before[t]
+		is, exists := after[t]
+
+		switch {
+		case existed && !exists:
+			dropped = append(dropped, t)
+			changed = true
+		case existed != exists || was != is:
+			changed = true
+		}
+	}
+
+	return changed, dropped
+}
+
+// scriptRows renders a script result in Hasura's TuplesOk shape: a header
+// row, then each row as text with NULL spelled "NULL". It returns nil for a
+// statement without tuples.
+func scriptRows(script ScriptResult) [][]string {
+	if script.Columns == nil {
+		return nil
+	}
+
+	rows := make([][]string, 0, len(script.Rows)+1)
+	rows = append(rows, script.Columns)
+
+	for _, raw := range script.Rows {
+		row := make([]string, len(raw))
+
+		for i, value := range raw {
+			if value == nil {
+				row[i] = "NULL"
+			} else {
+				row[i] = string(value)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
@@ -0,0 +1,233 @@
+package postgres_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/mock/gomock"
+
+	"github.com/nhost/nhost/services/constellation/connector/runsql"
+	"github.com/nhost/nhost/services/constellation/connector/sql/postgres"
+	"github.com/nhost/nhost/services/constellation/connector/sql/postgres/mock"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+// expectTrackedDefinitions wires one read of the tracked tables' definitions:
+// a row per entry of definitions, a nil definition meaning the table is
+// missing.
+func expectTrackedDefinitions(
+	t *testing.T, ctrl *gomock.Controller, tx *mock.MockTx, definitions map[string]*string,
+) *gomock.Call {
+	t.Helper()
+
+	rows := mock.NewMockRows(ctrl)
+	names := make([]string, 0, len(definitions))
+
+	for name := range definitions {
+		names = append(names, name)
+	}
+
+	call := 0
+
+	rows.EXPECT().Next().DoAndReturn(func() bool {
+		call++
+		return call <= len(names)
+	}).Times(len(names) + 1)
+
+	rows.EXPECT().Scan(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(dest ...any) error {
+		schema, _ := dest[0].(*string)
+		name, _ := dest[1].(*string)
+		definition, _ := dest[2].(**string)
+
+		*schema, *name, *definition = "public", names[call-1], definitions[names[call-1]]
+
+		return nil
+	}).Times(len(names))
+
+	rows.EXPECT().Err().Return(nil)
+	rows.EXPECT().Close()
+
+	return tx.EXPECT().Query(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(rows, nil)
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestRunSQL_ReturnsLastStatementTuples(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		tx.EXPECT().Exec(gomock.Any(), "SET TRANSACTION READ ONLY").Return(nil),
+		tx.EXPECT().ExecScript(gomock.Any(), "SELECT 1; SELECT id, name FROM users").Return(
+			postgres.ScriptResult{
+				Columns: []string{"id", "name"},
+				Rows:    [][][]byte{{[]byte("1"), []byte("ada")}, {[]byte("2"), nil}},
+			}, nil,
+		),
+		tx.EXPECT().Commit(gomock.Any()).Return(nil),
+	)
+
+	results, err := postgres.NewClient(pool).RunSQL(
+		t.Context(),
+		[]runsql.Script{{SQL: "SELECT 1; SELECT id, name FROM users"}},
+		runsql.Options{ReadOnly: true},
+	)
+	if err != nil {
+		t.Fatalf("RunSQL: %v", err)
+	}
+
+	want := []*runsql.Result{{
+		Rows:    [][]string{{"id", "name"}, {"1", "ada"}, {"2", "NULL"}},
+		Changed: false,
+	}}
+	if diff := cmp.Diff(want, results); diff != "" {
+		t.Errorf("RunSQL mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunSQL_ReportsChangedTrackedTables(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		expectTrackedDefinitions(t, ctrl, tx, map[string]*string{"users": ptr("id integer NOT NULL")}),
+		tx.EXPECT().ExecScript(gomock.Any(), gomock.Any()).Return(postgres.ScriptResult{}, nil),
+		expectTrackedDefinitions(t, ctrl, tx, map[string]*string{
+			"users": ptr("id integer NOT NULL\nname text"),
+		}),
+		tx.EXPECT().Commit(gomock.Any()).Return(nil),
+	)
+
+	results, err := postgres.NewClient(pool).RunSQL(
+		t.Context(),
+		[]runsql.Script{{
+			SQL:     "ALTER TABLE users ADD COLUMN name text",
+			Tracked: []metadata.TableSource{{Schema: "public", Name: "users"}},
+		}},
+		runsql.Options{},
+	)
+	if err != nil {
+		t.Fatalf("RunSQL: %v", err)
+	}
+
+	if len(results) != 1 || !results[0].Changed || results[0].Rows != nil {
+		t.Errorf("results = %+v, want one changed CommandOk result", results)
+	}
+}
+
+func TestRunSQL_RollsBackDroppedTrackedTableWithoutCascade(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		expectTrackedDefinitions(t, ctrl, tx, map[string]*string{"users": ptr("id integer")}),
+		tx.EXPECT().ExecScript(gomock.Any(), gomock.Any()).Return(postgres.ScriptResult{}, nil),
+		expectTrackedDefinitions(t, ctrl, tx, map[string]*string{"users": nil}),
+		tx.EXPECT().Rollback(gomock.Any()).Return(nil),
+	)
+
+	_, err := postgres.NewClient(pool).RunSQL(
+		t.Context(),
+		[]runsql.Script{{
+			SQL:     "DROP TABLE users",
+			Tracked: []metadata.TableSource{{Schema: "public", Name: "users"}},
+		}},
+		runsql.Options{},
+	)
+
+	depErr, ok := errors.AsType[*runsql.DependencyError](err)
+	if !ok {
+		t.Fatalf("expected a *runsql.DependencyError, got %T (%v)", err, err)
+	}
+
+	want := []metadata.TableSource{{Schema: "public", Name: "users"}}
+	if diff := cmp.Diff(want, depErr.Tables); diff != "" {
+		t.Errorf("dropped tables mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRunSQL_WrapsScriptErrors(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		tx.EXPECT().ExecScript(gomock.Any(), gomock.Any()).Return(
+			postgres.ScriptResult{},
+			&pgconn.PgError{ //nolint:exhaustruct
+				Code:    "42P01",
+				Message: `relation "missing" does not exist`,
+			},
+		),
+		tx.EXPECT().Rollback(gomock.Any()).Return(nil),
+	)
+
+	_, err := postgres.NewClient(pool).RunSQL(
+		t.Context(), []runsql.Script{{SQL: "SELECT * FROM missing"}}, runsql.Options{},
+	)
+
+	execErr, ok := errors.AsType[*runsql.ExecutionError](err)
+	if !ok {
+		t.Fatalf("expected a *runsql.ExecutionError, got %T (%v)", err, err)
+	}
+
+	if execErr.Code != "42P01" {
+		t.Errorf("code = %q, want 42P01", execErr.Code)
+	}
+}
+
+func TestRunSQL_RollsBackEveryScriptWhenOneFails(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	pool := mock.NewMockPool(ctrl)
+	tx := mock.NewMockTx(ctrl)
+
+	gomock.InOrder(
+		pool.EXPECT().BeginTx(gomock.Any()).Return(tx, nil),
+		tx.EXPECT().ExecScript(gomock.Any(), "CREATE TABLE a ()").Return(postgres.ScriptResult{}, nil),
+		tx.EXPECT().ExecScript(gomock.Any(), "CREATE TABLE b ()").Return(postgres.ScriptResult{}, nil),
+		tx.EXPECT().ExecScript(gomock.Any(), "BROKEN").Return(
+			postgres.ScriptResult{},
+			&pgconn.PgError{ //nolint:exhaustruct
+				Code:    "42601",
+				Message: `syntax error at or near "BROKEN"`,
+			},
+		),
+		tx.EXPECT().Rollback(gomock.Any()).Return(nil),
+	)
+
+	_, err := postgres.NewClient(pool).RunSQL(
+		t.Context(),
+		[]runsql.Script{{SQL: "CREATE TABLE a ()"}, {SQL: "CREATE TABLE b ()"}, {SQL: "BROKEN"}},
+		runsql.Options{},
+	)
+
+	execErr, ok := errors.AsType[*runsql.ExecutionError](err)
+	if !ok {
+		t.Fatalf("expected a *runsql.ExecutionError, got %T (%v)", err, err)
+	}
+
+	if execErr.Script != 2 {
+		t.Errorf("script = %d, want 2", execErr.Script)
+	}
+}
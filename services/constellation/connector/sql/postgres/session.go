@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	json "encoding/json/v2"
+	"errors"
+	"fmt"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+)
+
+// setHasuraUserSQL publishes a session as the transaction-local hasura.user
+// setting, which policies and triggers read with
+// current_setting('hasura.user').
+const setHasuraUserSQL = "SELECT set_config('hasura.user', $1, true)"
+
+// multiplexedFixedParams is the number of multiplexed query parameters ahead
+// of the static ones: $1 subscription IDs and $2 result variables.
+const multiplexedFixedParams = 2
+
+var errMultiplexedArgs = errors.New(
+	"multiplexed query arguments must start with subscription IDs and result variables",
+)
+
+// WithSessionSettings makes the connector publish the caller's session
+// variables, x-hasura-role included, as a JSON object in the transaction-local
+// hasura.user setting before it runs a query, mutation or subscription poll.
+// Row-level security policies and triggers can then read the caller's
+// identity with current_setting('hasura.user'), as they would under Hasura.
+func WithSessionSettings() Option {
+	return func(o *options) {
+		o.sessionSettings = true
+	}
+}
+
+// setSessionUser publishes sessionVariables as hasura.user for the rest of
+// tx. Without session variables (e.g. an internal query) nothing is set.
+func setSessionUser(ctx context.Context, tx Tx, sessionVariables map[string]any) error {
+	if sessionVariables == nil {
+		return nil
+	}
+
+	user, err := json.Marshal(sessionVariables, json.Deterministic(true))
+	if err != nil {
+		return fmt.Errorf("failed to encode session variables: %w", err)
+	}
+
+	if err := tx.Exec(ctx, setHasuraUserSQL, string(user)); err != nil {
+		return fmt.Errorf("failed to set hasura.user: %w", err)
+	}
+
+	return nil
+}
+
+// sessionGroup is the subscribers of a multiplexed poll that share one
+// session: the hasura.user JSON and their slice of the $1 subscription IDs
+// and $2 result variables.
+type sessionGroup struct {
+	user            string
+	subscriptionIDs []string
+	resultVars      []string
+}
+
+// executeMultiplexedPerSession runs a multiplexed query once per distinct
+// subscriber session, each run preceded by setting hasura.user to that
+// session, all in one transaction. A single statement cannot switch the
+// setting between subscribers reliably: Postgres treats current_setting as
+// stable and may evaluate a policy, or a subquery that does not depend on the
+// subscriber, only once. Subscribers with identical sessions still share a
+// run.
+//
+//nolint:nonamedreturns
+func (c *Client) executeMultiplexedPerSession(
+	ctx context.Context, sqlQuery string, args []any,
+) (results []core.MultiplexedResult, err error) {
+	groups, err := groupSubscribersBySession(args)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := c.pool.BeginTx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	for _, group := range groups {
+		if err = tx.Exec(ctx, setHasuraUserSQL, group.user); err != nil {
+			return nil, fmt.Errorf("failed to set hasura.user: %w", err)
+		}
+
+		groupArgs := append([]any{group.subscriptionIDs, group.resultVars}, args[multiplexedFixedParams:]...)
+
+		var groupResults []core.MultiplexedResult
+		if groupResults, err = queryMultiplexed(ctx, tx, sqlQuery, groupArgs); err != nil {
+			return nil, err
+		}
+
+		results = append(results, groupResults...)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return results, nil
+}
+
+// groupSubscribersBySession splits the $1 subscription IDs and $2 result
+// variables of a multiplexed query (see multiplexed.PrepareParams) by the
+// subscriber's session, in order of first appearance. Session variables a
+// subscriber did not send are null in its result variables and are left out
+// of its hasura.user, matching the query path.
+func groupSubscribersBySession(args []any) ([]*sessionGroup, error) {
+	if len(args) < multiplexedFixedParams {
+		return nil, errMultiplexedArgs
+	}
+
+	subscriptionIDs, idsOK := args[0].([]string)
+	resultVars, varsOK := args[1].([]string)
+
+	if !idsOK || !varsOK || len(subscriptionIDs) != len(resultVars) {
+		return nil, errMultiplexedArgs
+	}
+
+	var groups []*sessionGroup
+
+	byUser := make(map[string]*sessionGroup)
+
+	for i, vars := range resultVars {
+		user, err := sessionUser(vars)
+		if err != nil {
+			return nil, err
+		}
+
+		group, ok := byUser[user]
+		if !ok {
+			group = &sessionGroup{user: user, subscriptionIDs: nil, resultVars: nil}
+			byUser[user] = group
+			groups = append(groups, group)
+		}
+
+		group.subscriptionIDs = append(group.subscriptionIDs, subscriptionIDs[i])
+		group.resultVars = append(group.resultVars, vars)
+	}
+
+	return groups, nil
+}
+
+// sessionUser extracts the hasura.user JSON from a subscriber's result
+// variables.
+func sessionUser(resultVars string) (string, error) {
+	var vars struct {
+		Session map[string]any `json:"session"`
+	}
+
+	if err := json.Unmarshal([]byte(resultVars), &vars); err != nil {
+		return "", fmt.Errorf("failed to decode subscriber session: %w", err)
+	}
+
+	session := make(map[string]any, len(vars.Session))
+
+	for name, value := range vars.Session {
+		if value != nil {
+			session[name] = value
+		}
+	}
+
+	user, err := json.Marshal(session, json.Deterministic(true))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode session variables: %w", err)
+	}
+
+	return string(user), nil
+}
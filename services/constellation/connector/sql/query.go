@@ -2,6 +2,7 @@ package sql //nolint:revive,nolintlint // package name "sql" shadows database/sq
 
 import (
 	"context"
+	"encoding/json/jsontext"
 	"fmt"
 	"log/slog"
 
@@ -12,7 +13,8 @@ import (
 )
 
 // Execute translates a GraphQL operation into SQL and executes it,
-// returning the combined operation results keyed by root field alias.
+// returning the combined operation results keyed by root field alias. The
+// session variables also reach the driver on ctx (see [WithSessionVariables]).
 func (c *Connector) Execute(
 	ctx context.Context,
 	operation *ast.OperationDefinition,
@@ -27,6 +29,8 @@ func (c *Connector) Execute(
 		return nil, fmt.Errorf("failed to build query: %w", err)
 	}
 
+	ctx = WithSessionVariables(ctx, sessionVariables)
+
 	results, err := c.driver.ExecuteOperations(ctx, operations, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute operations: %w", err)
@@ -97,6 +101,12 @@ func (c *Connector) ExecuteMultiplexedQueryWithCursor(
 		return nil, nil
 	}
 
+	if !c.driver.Dialect().SupportsMultiplexedSubscriptions() {
+		return c.executePerSubscriber(
+			ctx, op, subscriptionIDs, sessionVarArrays, cursorValues, logger,
+		)
+	}
+
 	params := multiplexed.PrepareParams(
 		subscriptionIDs, sessionVarArrays, cursorValues,
 	)
@@ -116,3 +126,57 @@ func (c *Connector) ExecuteMultiplexedQueryWithCursor(
 
 	return results, nil
 }
+
+// executePerSubscriber runs a subscription built for a dialect without
+// multiplexed subscriptions (see dialect.Dialect.SupportsMultiplexedSubscriptions):
+// op is executed once per subscriber, with its session-variable, cursor, and
+// session_argument markers bound to that subscriber's values. All subscribers
+// run in one ExecuteOperations call so a poll still reads a single snapshot.
+func (c *Connector) executePerSubscriber(
+	ctx context.Context,
+	op core.SQLOperation,
+	subscriptionIDs []string,
+	sessionVarArrays map[string][]any,
+	cursorValues map[string]any,
+	logger *slog.Logger,
+) ([]core.MultiplexedResult, error) {
+	operations := make([]core.SQLOperation, len(subscriptionIDs))
+	for i, subID := range subscriptionIDs {
+		operations[i] = core.SQLOperation{
+			Name:          subID,
+			SQL:           op.SQL,
+			Parameters:    multiplexed.BindParams(op.Parameters, i, sessionVarArrays, cursorValues),
+			StreamCursors: nil,
+			Sequential:    nil,
+			Stages:        nil,
+		}
+	}
+
+	logger.DebugContext(
+		ctx, "executing subscription query per subscriber",
+		"sql", op.SQL,
+		"subscription_count", len(subscriptionIDs),
+		"has_cursor", len(cursorValues) > 0,
+	)
+
+	data, err := c.driver.ExecuteOperations(ctx, operations, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute subscription query: %w", err)
+	}
+
+	results := make([]core.MultiplexedResult, 0, len(subscriptionIDs))
+
+	for _, subID := range subscriptionIDs {
+		value, ok := data[subID].(jsontext.Value)
+		if !ok {
+			continue
+		}
+
+		results = append(results, core.MultiplexedResult{
+			SubscriptionID: subID,
+			Data:           value,
+		})
+	}
+
+	return results, nil
+}
@@ -518,9 +518,9 @@ func shouldDropRelationship(
 //     resolved target ends up empty and the queries package raises
 //     `errRelationshipTargetTableIntrospectionNotFound`.
 //   - **Reverse `ForeignKeyConstraint`** — the FK columns named in the
-//     constraint live on the target table. Each must have a matching
-//     introspected `ForeignKey` on that target; an unmatched column would
-//     otherwise render as `"alias".""` at execution time
+//     constraint live on the target table, which must have an introspected
+//     foreign key over those columns referencing the parent; unmatched
+//     columns would otherwise render as `"alias".""` at execution time
 //     (`errRelationshipReverseFKColumnUnmatched`).
 //
 // Both shapes are per-relationship inconsistencies, so we record them as
@@ -653,9 +653,9 @@ func dropIfForwardFKBroken(
 }
 
 // dropIfReverseFKBroken handles reverse `ForeignKeyConstraint` relationships:
-// the FK columns live on the target table and must each have a matching
-// introspected ForeignKey there. Missing target table or unmatched column
-// both abort the per-relationship build at queries time, so we drop and
+// the FK columns live on the target table and must form an introspected
+// foreign key there that references the parent table. Missing target table or
+// unmatched columns both abort the per-relationship build at queries time, so we drop and
 // record here.
 func dropIfReverseFKBroken(
 	ctx context.Context,
@@ -682,30 +682,20 @@ func dropIfReverseFKBroken(
 		return true
 	}
 
-	for _, col := range constraint.Columns {
-		matched := false
-
-		for _, fk := range targetTable.ForeignKeys {
-			if fk.ColumnName == col {
-				matched = true
-				break
-			}
-		}
-
-		if !matched {
-			inc.RecordRelationship(
-				ctx, logger,
-				dbName,
-				t.Table.Schema, t.Table.Name, relName,
-				fmt.Sprintf(
-					"reverse-FK column %q on %s has no matching foreign key in source",
-					col,
-					qualifyTable(constraint.Table.Schema, constraint.Table.Name),
-				),
-			)
+	if targetTable.ReferencingForeignKey(constraint.Columns, t.Table.Schema, t.Table.Name) == nil {
+		inc.RecordRelationship(
+			ctx, logger,
+			dbName,
+			t.Table.Schema, t.Table.Name, relName,
+			fmt.Sprintf(
+				"reverse-FK columns %v on %s have no matching foreign key to %s in source",
+				constraint.Columns,
+				qualifyTable(constraint.Table.Schema, constraint.Table.Name),
+				qualifyTable(t.Table.Schema, t.Table.Name),
+			),
+		)
 
-			return true
-		}
+		return true
 	}
 
 	return false
@@ -24,6 +24,7 @@ func makeObjects(opts ...func(*introspection.Objects)) *introspection.Objects {
 				IsView:       false,
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				Columns: []introspection.Column{
 					{Name: "id", Type: "uuid"},
 					{Name: "name", Type: "text"},
@@ -67,7 +68,7 @@ func withTable(schema, table string, columns ...string) func(*introspection.Obje
 		}
 
 		s.Tables[table] = &introspection.Table{ //nolint:exhaustruct
-			Schema: schema, Name: table, IsInsertable: true, IsUpdatable: true,
+			Schema: schema, Name: table, IsInsertable: true, IsUpdatable: true, IsDeletable: true,
 			Columns:     cols,
 			PrimaryKeys: nil,
 		}
@@ -762,7 +763,7 @@ func TestReconcileMetadata_DropsReverseFKWithUnmatchedColumn(t *testing.T) {
 		Tables: map[string]*introspection.Table{
 			"users": { //nolint:exhaustruct
 				Schema: "public", Name: "users",
-				IsInsertable: true, IsUpdatable: true,
+				IsInsertable: true, IsUpdatable: true, IsDeletable: true,
 				Columns: []introspection.Column{
 					{Name: "id", Type: "uuid"},
 				}, //nolint:exhaustruct
@@ -770,7 +771,7 @@ func TestReconcileMetadata_DropsReverseFKWithUnmatchedColumn(t *testing.T) {
 			},
 			"orders": { //nolint:exhaustruct
 				Schema: "public", Name: "orders",
-				IsInsertable: true, IsUpdatable: true,
+				IsInsertable: true, IsUpdatable: true, IsDeletable: true,
 				Columns: []introspection.Column{ //nolint:exhaustruct
 					{Name: "id", Type: "uuid"},
 					{Name: "other_col", Type: "uuid"},
@@ -832,6 +833,114 @@ func TestReconcileMetadata_DropsReverseFKWithUnmatchedColumn(t *testing.T) {
 		"public.users.orders", "user_id")
 }
 
+// TestReconcileMetadata_KeepsCompositeFKRelationships covers tenant-scoped
+// composite foreign keys: tenant_id takes part in two keys on orders, so
+// both relationship directions only resolve when the listed columns are
+// matched against a whole constraint.
+func TestReconcileMetadata_KeepsCompositeFKRelationships(t *testing.T) {
+	t.Parallel()
+
+	fk := func(constraint, column, target, targetColumn string) introspection.ForeignKey {
+		return introspection.ForeignKey{
+			ConstraintName:    constraint,
+			ColumnName:        column,
+			ForeignSchema:     "public",
+			ForeignTable:      target,
+			ForeignColumnName: targetColumn,
+		}
+	}
+
+	objs := introspection.NewObjects()
+	objs.Schemas["public"] = &introspection.Schema{
+		Tables: map[string]*introspection.Table{
+			"customers": { //nolint:exhaustruct
+				Schema: "public", Name: "customers",
+				IsInsertable: true, IsUpdatable: true, IsDeletable: true,
+				Columns: []introspection.Column{ //nolint:exhaustruct
+					{Name: "tenant_id", Type: "uuid"},
+					{Name: "id", Type: "uuid"},
+				},
+				PrimaryKeys: []string{"tenant_id", "id"},
+			},
+			"products": { //nolint:exhaustruct
+				Schema: "public", Name: "products",
+				IsInsertable: true, IsUpdatable: true, IsDeletable: true,
+				Columns: []introspection.Column{ //nolint:exhaustruct
+					{Name: "tenant_id", Type: "uuid"},
+					{Name: "id", Type: "uuid"},
+				},
+				PrimaryKeys: []string{"tenant_id", "id"},
+			},
+			"orders": { //nolint:exhaustruct
+				Schema: "public", Name: "orders",
+				IsInsertable: true, IsUpdatable: true, IsDeletable: true,
+				Columns: []introspection.Column{ //nolint:exhaustruct
+					{Name: "id", Type: "uuid"},
+					{Name: "tenant_id", Type: "uuid"},
+					{Name: "product_id", Type: "uuid"},
+					{Name: "customer_id", Type: "uuid"},
+				},
+				PrimaryKeys: []string{"id"},
+				ForeignKeys: []introspection.ForeignKey{
+					fk("orders_product_fkey", "tenant_id", "products", "tenant_id"),
+					fk("orders_product_fkey", "product_id", "products", "id"),
+					fk("orders_customer_fkey", "tenant_id", "customers", "tenant_id"),
+					fk("orders_customer_fkey", "customer_id", "customers", "id"),
+				},
+			},
+		},
+	}
+
+	dbMeta := &metadata.DatabaseMetadata{ //nolint:exhaustruct
+		Name: "default",
+		Tables: []metadata.TableMetadata{ //nolint:exhaustruct
+			{
+				Table: metadata.TableSource{Schema: "public", Name: "customers"},
+				ArrayRelationships: []metadata.ArrayRelationship{
+					{
+						Name: "orders",
+						Using: metadata.RelationshipUsing{ //nolint:exhaustruct
+							ForeignKeyConstraint: &metadata.ForeignKeyConstraint{
+								Columns: []string{"tenant_id", "customer_id"},
+								Table: metadata.TableSource{
+									Schema: "public", Name: "orders",
+								},
+							},
+						},
+					},
+				},
+			},
+			{Table: metadata.TableSource{Schema: "public", Name: "products"}},
+			{
+				Table: metadata.TableSource{Schema: "public", Name: "orders"},
+				ObjectRelationships: []metadata.ObjectRelationship{
+					{
+						Name: "customer",
+						Using: metadata.RelationshipUsing{ //nolint:exhaustruct
+							ForeignKeyColumns: []string{"tenant_id", "customer_id"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	inc := metadata.NewInconsistencies()
+	out := reconcileMetadata(t.Context(), nil, inc, dbMeta, objs)
+
+	if len(out.Tables[0].ArrayRelationships) != 1 {
+		t.Errorf("expected customers.orders to survive, got %+v", out.Tables[0].ArrayRelationships)
+	}
+
+	if len(out.Tables[2].ObjectRelationships) != 1 {
+		t.Errorf("expected orders.customer to survive, got %+v", out.Tables[2].ObjectRelationships)
+	}
+
+	if snap := inc.Snapshot(); len(snap) != 0 {
+		t.Errorf("expected no inconsistencies, got %+v", snap)
+	}
+}
+
 // TestReconcileMetadata_DropsForwardFKWithoutIntrospectedTarget covers the
 // forward `ForeignKeyColumns` shortcut whose target is resolved through
 // introspection. When the parent table has no FK for any of the listed
@@ -846,7 +955,7 @@ func TestReconcileMetadata_DropsForwardFKWithoutIntrospectedTarget(t *testing.T)
 		Tables: map[string]*introspection.Table{
 			"orders": { //nolint:exhaustruct
 				Schema: "public", Name: "orders",
-				IsInsertable: true, IsUpdatable: true,
+				IsInsertable: true, IsUpdatable: true, IsDeletable: true,
 				Columns: []introspection.Column{ //nolint:exhaustruct
 					{Name: "id", Type: "uuid"},
 					{Name: "user_id", Type: "uuid"},
@@ -907,7 +1016,7 @@ func TestReconcileMetadata_DropsForwardFKWithResolvedButUntrackedTarget(t *testi
 		Tables: map[string]*introspection.Table{
 			"orders": { //nolint:exhaustruct
 				Schema: "public", Name: "orders",
-				IsInsertable: true, IsUpdatable: true,
+				IsInsertable: true, IsUpdatable: true, IsDeletable: true,
 				Columns: []introspection.Column{ //nolint:exhaustruct
 					{Name: "id", Type: "uuid"},
 					{Name: "user_id", Type: "uuid"},
@@ -972,7 +1081,7 @@ func TestReconcileMetadata_DropsFunctionWithUntrackedBaseTable(t *testing.T) {
 		Tables: map[string]*introspection.Table{
 			"users": { //nolint:exhaustruct
 				Schema: "public", Name: "users",
-				IsInsertable: true, IsUpdatable: true,
+				IsInsertable: true, IsUpdatable: true, IsDeletable: true,
 				Columns: []introspection.Column{
 					{Name: "id", Type: "uuid"},
 				}, //nolint:exhaustruct
@@ -1024,7 +1133,7 @@ func TestReconcileMetadata_DropsFunctionWithNonTableReturnType(t *testing.T) {
 		Tables: map[string]*introspection.Table{
 			"users": { //nolint:exhaustruct
 				Schema: "public", Name: "users",
-				IsInsertable: true, IsUpdatable: true,
+				IsInsertable: true, IsUpdatable: true, IsDeletable: true,
 				Columns: []introspection.Column{
 					{Name: "id", Type: "uuid"},
 				}, //nolint:exhaustruct
@@ -0,0 +1,33 @@
+package sql //nolint:revive,nolintlint // package name "sql" shadows database/sql; see sql.go for the rationale.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nhost/nhost/services/constellation/connector/runsql"
+)
+
+// runSQLDriver is the optional interface a Driver satisfies when it can run
+// raw SQL scripts for POST /v2/query's run_sql.
+type runSQLDriver interface {
+	RunSQL(ctx context.Context, scripts []runsql.Script, opts runsql.Options) ([]*runsql.Result, error)
+}
+
+// RunSQL runs scripts against the source through the driver, satisfying
+// runsql.Runner. It returns runsql.ErrNotSupported when the driver cannot
+// run scripts.
+func (c *Connector) RunSQL(
+	ctx context.Context, scripts []runsql.Script, opts runsql.Options,
+) ([]*runsql.Result, error) {
+	d, ok := c.driver.(runSQLDriver)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", runsql.ErrNotSupported, c.dbMeta.Kind)
+	}
+
+	results, err := d.RunSQL(ctx, scripts, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run sql: %w", err)
+	}
+
+	return results, nil
+}
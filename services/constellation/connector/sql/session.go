@@ -0,0 +1,26 @@
+package sql //nolint:revive,nolintlint // package name "sql" shadows database/sql; see sql.go for the rationale.
+
+import "context"
+
+// sessionVariablesCtxKey keys the session map stored by
+// [WithSessionVariables].
+type sessionVariablesCtxKey struct{}
+
+// WithSessionVariables returns a context carrying the caller's session
+// variables, x-hasura-role included, for drivers that expose them to the
+// database (see postgres.WithSessionSettings). A nil map leaves ctx unchanged.
+func WithSessionVariables(ctx context.Context, sessionVariables map[string]any) context.Context {
+	if sessionVariables == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, sessionVariablesCtxKey{}, sessionVariables)
+}
+
+// SessionVariablesFromContext returns the session variables set by
+// [WithSessionVariables], or nil when there are none.
+func SessionVariablesFromContext(ctx context.Context) map[string]any {
+	sessionVariables, _ := ctx.Value(sessionVariablesCtxKey{}).(map[string]any)
+
+	return sessionVariables
+}
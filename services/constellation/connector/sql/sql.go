@@ -11,12 +11,14 @@ package sql //nolint:revive,nolintlint // package name "sql" shadows database/sq
 
 import (
 	"context"
+	"encoding/json/jsontext"
 	"fmt"
 	"log/slog"
 	"slices"
 	"strings"
 	"time"
 
+	"github.com/nhost/nhost/services/constellation/connector/federation"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
@@ -47,6 +49,9 @@ type Driver interface {
 	ExecuteMultiplexedOperation(
 		ctx context.Context, sql string, args []any, logger *slog.Logger,
 	) ([]core.MultiplexedResult, error)
+	// ExplainOperation returns the database's plan for a single query
+	// operation as JSON, without executing it.
+	ExplainOperation(ctx context.Context, op core.SQLOperation) (jsontext.Value, error)
 	// Dialect returns the SQL dialect for this driver.
 	Dialect() dialect.Dialect
 	// Close releases any resources held by the driver.
@@ -66,6 +71,9 @@ type Connector struct {
 	roots        queries.Roots
 	groupedAggOp *groupedaggdispatch.Ops
 	dbMeta       *metadata.DatabaseMetadata
+	// entities holds the Apollo Federation entities per role; see
+	// [Connector.FederationEntities].
+	entities map[string][]federation.Entity
 }
 
 // NewConnector creates a Connector by introspecting the database, reconciling
@@ -107,6 +115,7 @@ func NewConnector(
 		roots:        roots,
 		groupedAggOp: groupedAggOp,
 		dbMeta:       effectiveMeta,
+		entities:     federationEntities(objects, effectiveMeta),
 	}, nil
 }
 
@@ -145,6 +154,19 @@ func (c *Connector) GetTypeName(identifier string) string {
 	return ""
 }
 
+// FederationEntities returns the tables role can resolve as Apollo Federation
+// entities, satisfying federation.Provider.
+func (c *Connector) FederationEntities(role string) []federation.Entity {
+	return c.entities[role]
+}
+
+// subscriptionCoordinatorDriver is the optional interface a Driver satisfies
+// when its live-query cohorts can share polls with other replicas.
+type subscriptionCoordinatorDriver interface {
+	// SubscriptionCoordinator returns nil when coordination is disabled.
+	SubscriptionCoordinator() sqlsub.Coordinator
+}
+
 // NewSubscriptionHandler creates a subscription handler for this backend.
 // The returned subscription.Handler is non-nil; callers may dereference the
 // result without a nil check. The controller relies on this contract when
@@ -153,7 +175,14 @@ func (c *Connector) NewSubscriptionHandler( //nolint:ireturn,nolintlint
 	pollingInterval time.Duration,
 	logger *slog.Logger,
 ) subscription.Handler {
-	return sqlsub.NewHandler(c, c.roots, pollingInterval, logger)
+	var opts []sqlsub.HandlerOption
+	if d, ok := c.driver.(subscriptionCoordinatorDriver); ok {
+		if coordinator := d.SubscriptionCoordinator(); coordinator != nil {
+			opts = append(opts, sqlsub.WithCoordinator(coordinator))
+		}
+	}
+
+	return sqlsub.NewHandler(c, c.roots, pollingInterval, logger, opts...)
 }
 
 func reloadSchema(
@@ -189,6 +218,23 @@ func reloadSchema(
 	return schemas, nil
 }
 
+// federationEntities computes the federation entities of every role that has
+// at least one.
+func federationEntities(
+	objects *introspection.Objects,
+	dbMeta *metadata.DatabaseMetadata,
+) map[string][]federation.Entity {
+	entities := make(map[string][]federation.Entity)
+
+	for _, role := range collectRolesFromDatabaseMetadata(dbMeta) {
+		if roleEntities := schema.FederationEntitiesForRole(objects, role, dbMeta); len(roleEntities) > 0 {
+			entities[role] = roleEntities
+		}
+	}
+
+	return entities
+}
+
 // collectRolesFromDatabaseMetadata collects all unique roles from the database metadata.
 func collectRolesFromDatabaseMetadata(md *metadata.DatabaseMetadata) []string {
 	roles := make([]string, 0, 4) //nolint:mnd
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"strings"
 	"testing"
 	"time"
@@ -321,6 +322,7 @@ func TestNewConnector_KitchenSinkInconsistencies(t *testing.T) {
 				Name:         "users",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				Columns: []introspection.Column{
 					{Name: "id", Type: "uuid"},
 					{Name: "name", Type: "text"},
@@ -332,6 +334,7 @@ func TestNewConnector_KitchenSinkInconsistencies(t *testing.T) {
 				Name:         "user_status",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				Columns:      []introspection.Column{{Name: "value", Type: "text"}},
 				PrimaryKeys:  []string{"value"},
 			},
@@ -490,6 +493,7 @@ func TestNewConnector_RecordsInvalidHasuraRemoteRelationshipType(t *testing.T) {
 				Name:         "orders",
 				IsInsertable: true,
 				IsUpdatable:  true,
+				IsDeletable:  true,
 				Columns: []introspection.Column{
 					{Name: "id", Type: "uuid"},
 					{Name: "customer_id", Type: "uuid"},
@@ -664,6 +668,41 @@ func TestConnector_Execute(t *testing.T) {
 	}
 }
 
+func TestConnector_ExecutePassesSessionVariablesOnContext(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	driver := mock.NewMockDriver(ctrl)
+
+	c := newTestConnector(t, driver)
+
+	session := map[string]any{"x-hasura-role": "user", "x-hasura-user-id": "42"}
+
+	driver.EXPECT().
+		ExecuteOperations(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context, _ []core.SQLOperation, _ *slog.Logger,
+		) (map[string]any, error) {
+			if got := csql.SessionVariablesFromContext(ctx); !maps.Equal(got, session) {
+				t.Errorf("expected session %v on ctx, got %v", session, got)
+			}
+
+			return map[string]any{}, nil
+		})
+
+	if _, err := c.Execute(
+		context.Background(),
+		&ast.OperationDefinition{Operation: ast.Query, SelectionSet: ast.SelectionSet{}},
+		nil,
+		nil,
+		"user",
+		session,
+		slog.Default(),
+	); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestConnector_ValidateOperation(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,132 @@
+//go:build cgo
+
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+)
+
+var errNonNumericAggregateInput = errors.New("non-numeric input")
+
+// errThrown carries the message and SQLSTATE-style code a generated statement
+// raises through constellation_throw_error.
+type errThrown struct {
+	message string
+	code    string
+}
+
+func (e *errThrown) Error() string {
+	return e.message + " (SQLSTATE " + e.code + ")"
+}
+
+// varianceAggregate accumulates the stddev/variance family with Welford's
+// online algorithm, which stays accurate for large, close values where the
+// one-pass sum-of-squares identity cancels out. NULL inputs, which go-sqlite3
+// passes as a nil []byte, are skipped as in PostgreSQL.
+type varianceAggregate struct {
+	name   string
+	sample bool
+	sqrt   bool
+	count  int64
+	mean   float64
+	m2     float64
+}
+
+func (a *varianceAggregate) Step(value any) error {
+	var x float64
+
+	switch v := value.(type) {
+	case []byte:
+		if v == nil {
+			return nil
+		}
+
+		return fmt.Errorf("%s: %w: blob", a.name, errNonNumericAggregateInput)
+	case int64:
+		x = float64(v)
+	case float64:
+		x = v
+	default:
+		return fmt.Errorf("%s: %w: %T", a.name, errNonNumericAggregateInput, value)
+	}
+
+	a.count++
+	delta := x - a.mean
+	a.mean += delta / float64(a.count)
+	a.m2 += delta * (x - a.mean)
+
+	return nil
+}
+
+// Done returns NULL when there are too few rows, as PostgreSQL does: none for
+// the population functions, fewer than two for the sample ones.
+func (a *varianceAggregate) Done() any {
+	n := a.count
+	if a.sample {
+		n--
+	}
+
+	if n < 1 {
+		return nil
+	}
+
+	variance := a.m2 / float64(n)
+	if a.sqrt {
+		return math.Sqrt(variance)
+	}
+
+	return variance
+}
+
+// varianceAggregates maps each stddev/variance aggregate to whether it is the
+// sample (rather than population) statistic and whether it is a standard
+// deviation. stddev and variance are PostgreSQL's aliases of the sample forms.
+//
+//nolint:gochecknoglobals // immutable lookup table.
+var varianceAggregates = map[string]struct{ sample, sqrt bool }{
+	"stddev":      {sample: true, sqrt: true},
+	"stddev_pop":  {sample: false, sqrt: true},
+	"stddev_samp": {sample: true, sqrt: true},
+	"var_pop":     {sample: false, sqrt: false},
+	"var_samp":    {sample: true, sqrt: false},
+	"variance":    {sample: true, sqrt: false},
+}
+
+// registerConnectionFunctions installs the Go functions generated SQL calls.
+// constellation_throw_error mirrors the PL/pgSQL function the postgres driver
+// creates: dialect.SQLiteDialect.ThrowError emits a call to it wherever a
+// statement must abort, such as a failed insert/update permission check. It is
+// registered as impure so SQLite never evaluates it ahead of the CASE branch
+// that guards it. The stddev/variance aggregates fill in the PostgreSQL
+// built-ins SQLite lacks, so both backends expose the same aggregate fields.
+func registerConnectionFunctions(conn *sqlite3.SQLiteConn) error {
+	if err := conn.RegisterFunc(
+		"constellation_throw_error",
+		func(message, code string) (int64, error) {
+			return 0, &errThrown{message: message, code: code}
+		},
+		false,
+	); err != nil {
+		return fmt.Errorf("registering constellation_throw_error: %w", err)
+	}
+
+	for name, kind := range varianceAggregates {
+		if err := conn.RegisterAggregator(
+			name,
+			func() *varianceAggregate {
+				return &varianceAggregate{ //nolint:exhaustruct // accumulators start at zero.
+					name: name, sample: kind.sample, sqrt: kind.sqrt,
+				}
+			},
+			true,
+		); err != nil {
+			return fmt.Errorf("registering %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !cgo
+
+package sqlite
+
+import sqlite3 "github.com/mattn/go-sqlite3"
+
+func registerConnectionFunctions(_ *sqlite3.SQLiteConn) error {
+	// See execConnectionPragma in pragma_nocgo.go: SQLite cannot open without
+	// CGO, so there is no connection to register functions on.
+	return nil
+}
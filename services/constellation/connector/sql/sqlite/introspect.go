@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
@@ -109,7 +110,7 @@ func introspectTables(
 
 // relationEntry pairs a relation name with whether it is a view; the kind is
 // needed at construction time so we can populate IsView / IsInsertable /
-// IsUpdatable directly on the introspected Table.
+// IsUpdatable / IsDeletable directly on the introspected Table.
 type relationEntry struct {
 	name   string
 	isView bool
@@ -174,10 +175,8 @@ func getTableNames(
 //
 // SQLite views are read-only by default. A view becomes writable when it is
 // backed by INSTEAD OF triggers — INSTEAD OF INSERT makes the view
-// insertable, INSTEAD OF UPDATE or DELETE makes it "updatable" in the sense
-// the introspection model uses (IsUpdatable gates both UPDATE and DELETE,
-// matching Postgres' information_schema.views.is_updatable). For base tables
-// we always report (true, true).
+// insertable, INSTEAD OF UPDATE updatable and INSTEAD OF DELETE deletable,
+// each independently of the others. Base tables are always all three.
 func introspectTable(
 	ctx context.Context,
 	q Querier,
@@ -210,17 +209,13 @@ func introspectTable(
 		return nil, fmt.Errorf("reading unique constraints: %w", err)
 	}
 
-	isInsertable := !isView
-	isUpdatable := !isView
+	mutability := viewMutability{insertable: true, updatable: true, deletable: true}
 
 	if isView {
-		insertable, updatable, terr := getViewMutability(ctx, q, tableName)
-		if terr != nil {
-			return nil, fmt.Errorf("reading view mutability: %w", terr)
+		mutability, err = getViewMutability(ctx, q, tableName)
+		if err != nil {
+			return nil, fmt.Errorf("reading view mutability: %w", err)
 		}
-
-		isInsertable = insertable
-		isUpdatable = updatable
 	}
 
 	return &introspection.Table{
@@ -233,16 +228,23 @@ func introspectTable(
 		ForeignKeys:              fks,
 		UniqueConstraints:        ucs,
 		IsView:                   isView,
-		IsInsertable:             isInsertable,
-		IsUpdatable:              isUpdatable,
+		IsInsertable:             mutability.insertable,
+		IsUpdatable:              mutability.updatable,
+		IsDeletable:              mutability.deletable,
 	}, nil
 }
 
+// viewMutability records which writes a relation accepts.
+type viewMutability struct {
+	insertable bool
+	updatable  bool
+	deletable  bool
+}
+
 // getViewMutability inspects sqlite_master for INSTEAD OF triggers attached
-// to viewName and returns (insertable, updatable). A view is "insertable" if
-// it has an INSTEAD OF INSERT trigger, and "updatable" if it has an
-// INSTEAD OF UPDATE or INSTEAD OF DELETE trigger — matching how Postgres'
-// information_schema.views.is_updatable conflates UPDATE and DELETE.
+// to viewName. A view is insertable if it has an INSTEAD OF INSERT trigger,
+// updatable if it has an INSTEAD OF UPDATE trigger and deletable if it has an
+// INSTEAD OF DELETE trigger.
 //
 // The match is done by scanning each trigger's stored CREATE TRIGGER text
 // for the INSTEAD OF clause and the operation keyword. SQLite stores the
@@ -255,7 +257,9 @@ func introspectTable(
 // between CREATE TRIGGER <name> and ON <table>, both of which precede BEGIN.
 func getViewMutability(
 	ctx context.Context, q Querier, viewName string,
-) (bool, bool, error) {
+) (viewMutability, error) {
+	var mutability viewMutability
+
 	rows, err := q.QueryContext(
 		ctx,
 		`SELECT sql FROM sqlite_master
@@ -263,16 +267,14 @@ func getViewMutability(
 		viewName,
 	)
 	if err != nil {
-		return false, false, fmt.Errorf("failed to query triggers: %w", err)
+		return mutability, fmt.Errorf("failed to query triggers: %w", err)
 	}
 	defer rows.Close()
 
-	var insertable, updatable bool
-
 	for rows.Next() {
 		var sql string
 		if err := rows.Scan(&sql); err != nil {
-			return false, false, fmt.Errorf("failed to scan trigger sql: %w", err)
+			return mutability, fmt.Errorf("failed to scan trigger sql: %w", err)
 		}
 
 		// Restrict the search to the trigger header (the text before the
@@ -293,7 +295,7 @@ func getViewMutability(
 			continue
 		}
 
-		// Evaluate the INSERT and UPDATE/DELETE markers independently rather
+		// Evaluate the INSERT, UPDATE and DELETE markers independently rather
 		// than via a switch: SQLite restricts each trigger to a single event,
 		// but the header text can still mention a different INSTEAD OF
 		// operation inside a SQL comment. A switch would let the first
@@ -301,20 +303,23 @@ func getViewMutability(
 		// header-content false-positive concern handled elsewhere in this
 		// file.
 		if strings.Contains(upper, "INSTEAD OF INSERT") {
-			insertable = true
+			mutability.insertable = true
+		}
+
+		if strings.Contains(upper, "INSTEAD OF UPDATE") {
+			mutability.updatable = true
 		}
 
-		if strings.Contains(upper, "INSTEAD OF UPDATE") ||
-			strings.Contains(upper, "INSTEAD OF DELETE") {
-			updatable = true
+		if strings.Contains(upper, "INSTEAD OF DELETE") {
+			mutability.deletable = true
 		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return false, false, fmt.Errorf("error iterating triggers: %w", err)
+		return mutability, fmt.Errorf("error iterating triggers: %w", err)
 	}
 
-	return insertable, updatable, nil
+	return mutability, nil
 }
 
 // indexBeginKeyword returns the byte offset of the first standalone BEGIN
@@ -565,8 +570,10 @@ func tableHasExplicitPKIndex(
 // getForeignKeys returns the outbound foreign keys declared on tableName via
 // PRAGMA foreign_key_list. PRAGMA foreign_key_list returns rows of (id, seq,
 // table, from, to, on_update, on_delete, match) — the Scan call below tracks
-// that exact column order. Only (from, table, to) are used; the action codes
-// are unused because the introspection model has no field for them today.
+// that exact column order. Only (id, from, table, to) are used — id groups the
+// columns of a composite key and stands in for the constraint name SQLite does
+// not report; the action codes are unused because the introspection model has
+// no field for them today.
 func getForeignKeys(
 	ctx context.Context, q Querier, tableName string,
 ) ([]introspection.ForeignKey, error) {
@@ -606,6 +613,7 @@ func getForeignKeys(
 		}
 
 		fks = append(fks, introspection.ForeignKey{
+			ConstraintName:    strconv.Itoa(id),
 			ColumnName:        from,
 			ForeignSchema:     "",
 			ForeignTable:      table,
@@ -332,8 +332,9 @@ func assertPlainUniqueAndSkippedIndex(
 // introspectTable. The default golden test above intentionally has no views,
 // so this test sets up the matrix of view shapes (no triggers, INSTEAD OF
 // INSERT only, INSTEAD OF UPDATE only, INSTEAD OF DELETE only, all three)
-// and asserts that IsInsertable / IsUpdatable reflect the writable surface
-// the database actually exposes.
+// and asserts that IsInsertable / IsUpdatable / IsDeletable reflect the
+// writable surface the database actually exposes, each on its own: a view
+// with only an INSTEAD OF DELETE trigger is deletable but not updatable.
 func TestIntrospectViewMutability(t *testing.T) {
 	t.Parallel()
 
@@ -370,10 +371,10 @@ CREATE TRIGGER v_full_del INSTEAD OF DELETE ON v_full
 BEGIN DELETE FROM base WHERE id = OLD.id; END;
 
 -- v_body_false_positive only has an INSTEAD OF INSERT trigger (so the
--- view should be insertable but NOT updatable), but the trigger body
+-- view should be insertable but NOT updatable or deletable), but the trigger body
 -- contains the literal phrases "INSTEAD OF UPDATE" and "INSTEAD OF DELETE"
 -- inside a string literal and a comment. The header-only header parse must
--- ignore those occurrences and not flip IsUpdatable to true.
+-- ignore those occurrences and not flip IsUpdatable or IsDeletable to true.
 CREATE VIEW v_body_false_positive AS SELECT id, name FROM base;
 CREATE TRIGGER v_body_false_positive_ins INSTEAD OF INSERT ON v_body_false_positive
 BEGIN
@@ -447,16 +448,17 @@ BEGIN UPDATE base SET name = NEW.name WHERE id = OLD.id; END;
 		wantIsView     bool
 		wantInsertable bool
 		wantUpdatable  bool
+		wantDeletable  bool
 	}{
-		{"base", false, true, true},
-		{"v_readonly", true, false, false},
-		{"v_insert_only", true, true, false},
-		{"v_update_only", true, false, true},
-		{"v_delete_only", true, false, true},
-		{"v_full", true, true, true},
-		{"v_body_false_positive", true, true, false},
-		{"v_begin", true, true, false},
-		{"v_header_comment_mismatch", true, true, true},
+		{"base", false, true, true, true},
+		{"v_readonly", true, false, false, false},
+		{"v_insert_only", true, true, false, false},
+		{"v_update_only", true, false, true, false},
+		{"v_delete_only", true, false, false, true},
+		{"v_full", true, true, true, true},
+		{"v_body_false_positive", true, true, false, false},
+		{"v_begin", true, true, false, false},
+		{"v_header_comment_mismatch", true, true, true, false},
 	}
 
 	for _, tc := range cases {
@@ -479,6 +481,10 @@ BEGIN UPDATE base SET name = NEW.name WHERE id = OLD.id; END;
 			if tbl.IsUpdatable != tc.wantUpdatable {
 				t.Errorf("IsUpdatable = %v, want %v", tbl.IsUpdatable, tc.wantUpdatable)
 			}
+
+			if tbl.IsDeletable != tc.wantDeletable {
+				t.Errorf("IsDeletable = %v, want %v", tbl.IsDeletable, tc.wantDeletable)
+			}
 		})
 	}
 }
@@ -14,7 +14,8 @@
 // the receiver must not be reused after closing. [Open] uses this package's
 // private go-sqlite3 driver registration, whose per-connection hook enables WAL
 // journal mode, foreign-key enforcement, and case-sensitive LIKE for every new
-// physical database/sql connection. The PRAGMAs are idempotent, so reopening a
+// physical database/sql connection and registers the constellation_throw_error
+// function generated mutations call. The PRAGMAs are idempotent, so reopening a
 // database returned by an earlier [Client.Close] is safe.
 //
 // # Scope
@@ -30,10 +31,12 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json/jsontext"
+	json "encoding/json/v2"
 	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	sqlite3 "github.com/mattn/go-sqlite3"
 
@@ -47,6 +50,7 @@ const sqliteDriverName = "sqlite3_constellation"
 
 var (
 	errSequentialNonJSONResult = errors.New("sequential operation returned non-JSON result")
+	errExplainMultiStatement   = errors.New("only single-statement query operations can be explained")
 	// registerSQLiteDriverOnce guards database/sql's process-wide driver registry.
 	registerSQLiteDriverOnce sync.Once //nolint:gochecknoglobals
 )
@@ -71,7 +75,7 @@ func registerSQLiteDriver() {
 						}
 					}
 
-					return nil
+					return registerConnectionFunctions(conn)
 				},
 			},
 		)
@@ -125,6 +129,7 @@ type Row interface {
 // package uses.
 type Rows interface {
 	Close() error
+	Columns() ([]string, error)
 	Next() bool
 	Scan(dest ...any) error
 	Err() error
@@ -300,15 +305,28 @@ func (c *Client) Close() {
 }
 
 // ExecuteOperations executes a list of SQL operations within a single
-// transaction. Uses a named return so the rollback defer reads the actual
-// error returned by the function body — adding an early `return nil, X`
-// without setting err would otherwise silently skip rollback.
+// transaction. A statement timeout on ctx (see [csql.WithStatementTimeout])
+// bounds the whole transaction: SQLite has no statement_timeout, so the
+// operations run under a context that is cancelled when it expires, which
+// interrupts the running statement. Uses a named return so the rollback defer
+// reads the actual error returned by the function body — adding an early
+// `return nil, X` without setting err would otherwise silently skip rollback.
 //
 //nolint:nonamedreturns
 func (c *Client) ExecuteOperations(
 	ctx context.Context, operations []core.SQLOperation, logger *slog.Logger,
 ) (result map[string]any, err error) {
-	tx, err := c.db.BeginTx(ctx)
+	timeout := csql.StatementTimeoutFromContext(ctx)
+
+	execCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		execCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	tx, err := c.db.BeginTx(execCtx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -320,7 +338,9 @@ func (c *Client) ExecuteOperations(
 
 		logger.DebugContext(ctx, "rolling back transaction due to error")
 
-		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, context.Canceled) {
+		// A cancelled context has already rolled the transaction back.
+		if rbErr := tx.Rollback(); rbErr != nil &&
+			!errors.Is(rbErr, context.Canceled) && !errors.Is(rbErr, sql.ErrTxDone) {
 			logger.ErrorContext(
 				ctx, "failed to rollback transaction", slog.String("error", rbErr.Error()),
 			)
@@ -330,13 +350,9 @@ func (c *Client) ExecuteOperations(
 	opsResults := make(map[string]any, len(operations))
 
 	for _, op := range operations {
-		opResult, opErr := executeOperation(ctx, tx, op)
+		opResult, opErr := executeOperation(execCtx, tx, op)
 		if opErr != nil {
-			err = fmt.Errorf("failed to execute operation %s: %w", op.Name, opErr)
-			logger.ErrorContext(
-				ctx, "failed to execute operation",
-				slog.String("operation", op.Name), slog.String("error", opErr.Error()),
-			)
+			err = operationError(ctx, execCtx, op, timeout, opErr, logger)
 
 			return nil, err
 		}
@@ -355,13 +371,54 @@ func (c *Client) ExecuteOperations(
 	return opsResults, nil
 }
 
+// operationError logs a failed operation and wraps its error. An operation
+// interrupted because execCtx, and not the caller's ctx, expired is logged
+// with its SQL and returned as a [csql.StatementTimeoutError].
+func operationError(
+	ctx, execCtx context.Context,
+	op core.SQLOperation,
+	timeout time.Duration,
+	opErr error,
+	logger *slog.Logger,
+) error {
+	err := fmt.Errorf("failed to execute operation %s: %w", op.Name, opErr)
+
+	if timeout > 0 && ctx.Err() == nil && errors.Is(execCtx.Err(), context.DeadlineExceeded) {
+		logger.WarnContext(
+			ctx, "statement timeout exceeded, query cancelled",
+			slog.String("operation", op.Name),
+			slog.Duration("timeout", timeout),
+			slog.String("sql", op.SQL),
+		)
+
+		return csql.NewStatementTimeoutError(timeout, err)
+	}
+
+	logger.ErrorContext(
+		ctx, "failed to execute operation",
+		slog.String("operation", op.Name), slog.String("error", opErr.Error()),
+	)
+
+	return err
+}
+
 // executeOperation executes a single SQL operation and returns the JSON result.
 func executeOperation(ctx context.Context, q Querier, op core.SQLOperation) (any, error) {
 	if len(op.Sequential) > 0 {
 		return executeSequentialOperation(ctx, q, op.Sequential)
 	}
 
-	row := q.QueryRowContext(ctx, op.SQL, op.Parameters...)
+	if len(op.Stages) > 0 {
+		return executeStagedOperation(ctx, q, op)
+	}
+
+	return queryJSON(ctx, q, op.SQL, op.Parameters)
+}
+
+// queryJSON runs a statement returning a single JSON column and returns it as
+// a jsontext.Value, or nil when the statement returns no row.
+func queryJSON(ctx context.Context, q Querier, query string, params []any) (any, error) {
+	row := q.QueryRowContext(ctx, query, params...)
 
 	var rawJSON string
 
@@ -376,6 +433,57 @@ func executeOperation(ctx context.Context, q Querier, op core.SQLOperation) (any
 	return jsontext.Value(rawJSON), nil
 }
 
+// queryPlanStep is one row of SQLite's EXPLAIN QUERY PLAN output. Parent
+// refers to the id of the enclosing step (0 for top-level steps).
+type queryPlanStep struct {
+	ID     int64  `json:"id"`
+	Parent int64  `json:"parent"`
+	Detail string `json:"detail"`
+}
+
+// ExplainOperation returns the EXPLAIN QUERY PLAN rows for op as a JSON
+// array of {id, parent, detail} objects. SQLite has no JSON plan format, and
+// EXPLAIN QUERY PLAN only prepares the statement, so nothing is executed.
+func (c *Client) ExplainOperation(
+	ctx context.Context, op core.SQLOperation,
+) (jsontext.Value, error) {
+	if len(op.Sequential) > 0 || len(op.Stages) > 0 {
+		return nil, errExplainMultiStatement
+	}
+
+	rows, err := c.db.QueryContext(ctx, "EXPLAIN QUERY PLAN "+op.SQL, op.Parameters...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain operation: %w", err)
+	}
+	defer rows.Close()
+
+	steps := make([]queryPlanStep, 0)
+
+	for rows.Next() {
+		var (
+			step    queryPlanStep
+			notUsed int64
+		)
+
+		if err := rows.Scan(&step.ID, &step.Parent, &notUsed, &step.Detail); err != nil {
+			return nil, fmt.Errorf("failed to scan query plan row: %w", err)
+		}
+
+		steps = append(steps, step)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating query plan rows: %w", err)
+	}
+
+	plan, err := json.Marshal(steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode query plan: %w", err)
+	}
+
+	return jsontext.Value(plan), nil
+}
+
 func executeSequentialOperation(
 	ctx context.Context,
 	q Querier,
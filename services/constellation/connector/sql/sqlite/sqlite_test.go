@@ -5,13 +5,16 @@ import (
 	"encoding/json/jsontext"
 	"errors"
 	"log/slog"
+	"math"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"go.uber.org/mock/gomock"
 
+	csql "github.com/nhost/nhost/services/constellation/connector/sql"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
 	"github.com/nhost/nhost/services/constellation/connector/sql/sqlite"
@@ -325,6 +328,72 @@ func TestOpenEnforcesForeignKeysOnEveryConnection(t *testing.T) {
 	assertForeignKeyViolation(t, tx1, 1)
 }
 
+func TestOpenRegistersVarianceAggregates(t *testing.T) {
+	t.Parallel()
+
+	db, err := sqlite.Open(t.Context(), filepath.Join(t.TempDir(), "variance.db"))
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("failed to close database: %v", err)
+		}
+	})
+
+	// Large, close values: the one-pass sum-of-squares identity cancels out
+	// here, so matching the exact results pins the accumulator's stability.
+	if err := db.ExecContext(
+		t.Context(),
+		`CREATE TABLE v (x REAL);
+		INSERT INTO v VALUES (1e9 + 4), (1e9 + 7), (NULL), (1e9 + 13), (1e9 + 16);
+		CREATE TABLE one (x INTEGER);
+		INSERT INTO one VALUES (5);`,
+	); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	tests := []struct {
+		function string
+		table    string
+		want     *float64
+	}{
+		{function: "var_pop", table: "v", want: new(22.5)},
+		{function: "var_samp", table: "v", want: new(30.0)},
+		{function: "variance", table: "v", want: new(30.0)},
+		{function: "stddev_pop", table: "v", want: new(math.Sqrt(22.5))},
+		{function: "stddev_samp", table: "v", want: new(math.Sqrt(30))},
+		{function: "stddev", table: "v", want: new(math.Sqrt(30))},
+		{function: "var_pop", table: "one", want: new(0.0)},
+		{function: "var_samp", table: "one", want: nil},
+		{function: "stddev", table: "one", want: nil},
+		{function: "stddev_pop", table: "one WHERE x > 5", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function+" over "+tt.table, func(t *testing.T) {
+			t.Parallel()
+
+			var got *float64
+			if err := db.QueryRowContext(
+				t.Context(), "SELECT "+tt.function+"(x) FROM "+tt.table,
+			).Scan(&got); err != nil {
+				t.Fatalf("failed to query %s: %v", tt.function, err)
+			}
+
+			switch {
+			case tt.want == nil && got != nil:
+				t.Fatalf("%s = %v, want NULL", tt.function, *got)
+			case tt.want != nil && got == nil:
+				t.Fatalf("%s = NULL, want %v", tt.function, *tt.want)
+			case tt.want != nil && math.Abs(*got-*tt.want) > 1e-9:
+				t.Fatalf("%s = %v, want %v", tt.function, *got, *tt.want)
+			}
+		})
+	}
+}
+
 func TestDialect(t *testing.T) {
 	t.Parallel()
 
@@ -575,6 +644,47 @@ func TestExecuteOperationsSequential(t *testing.T) {
 	}
 }
 
+func TestExecuteOperationsStatementTimeout(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t)
+
+	// An unbounded recursive CTE only ends when the statement is interrupted.
+	operations := []core.SQLOperation{{
+		Name: "runaway",
+		SQL: `WITH RECURSIVE n(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM n)
+			SELECT json_object('count', count(*)) FROM n`,
+		Parameters: nil,
+	}}
+
+	start := time.Now()
+
+	_, err := client.ExecuteOperations(
+		csql.WithStatementTimeout(t.Context(), 50*time.Millisecond),
+		operations,
+		discardLogger(),
+	)
+
+	if _, ok := errors.AsType[*csql.StatementTimeoutError](err); !ok {
+		t.Fatalf("expected a *StatementTimeoutError, got %T (%v)", err, err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("statement was not interrupted promptly: %s", elapsed)
+	}
+
+	result, err := client.ExecuteOperations(
+		t.Context(),
+		[]core.SQLOperation{{Name: "after", SQL: `SELECT json_object('ok', 1)`, Parameters: nil}},
+		discardLogger(),
+	)
+	if err != nil {
+		t.Fatalf("client unusable after a timeout: %v", err)
+	}
+
+	expectJSONTextResult(t, result, "after", `{"ok":1}`)
+}
+
 func assertSequentialSuccess(
 	t *testing.T,
 	_ *sqlite.Client,
@@ -1195,3 +1305,49 @@ func TestExecuteMultiplexedOperationMocked(t *testing.T) {
 		})
 	}
 }
+
+func TestExplainOperation(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClientWithSchema(t, `
+		CREATE TABLE kv (
+			key TEXT NOT NULL PRIMARY KEY,
+			value TEXT NOT NULL
+		);
+		INSERT INTO kv (key, value) VALUES ('explained', '1');
+	`)
+
+	plan, err := client.ExplainOperation(t.Context(), core.SQLOperation{
+		Name:       "kv",
+		SQL:        `DELETE FROM kv WHERE key = ? RETURNING json_object('key', key)`,
+		Parameters: []any{"explained"},
+	})
+	if err != nil {
+		t.Fatalf("ExplainOperation: %v", err)
+	}
+
+	if !strings.Contains(string(plan), `"detail":"SEARCH kv USING INDEX`) {
+		t.Errorf("plan = %s, want a SEARCH on the primary key index", plan)
+	}
+
+	// EXPLAIN QUERY PLAN only prepares the statement: the row the DELETE
+	// matches must still be there.
+	result, err := client.ExecuteOperations(t.Context(), []core.SQLOperation{{
+		Name:       "count",
+		SQL:        `SELECT json_object('n', count(*)) FROM kv`,
+		Parameters: nil,
+	}}, discardLogger())
+	if err != nil {
+		t.Fatalf("ExecuteOperations: %v", err)
+	}
+
+	expectJSONTextResult(t, result, "count", `{"n":1}`)
+
+	_, err = client.ExplainOperation(t.Context(), core.SQLOperation{
+		Name:       "sequential",
+		Sequential: []core.SQLOperation{{Name: "child", SQL: `SELECT 1`}},
+	})
+	if err == nil {
+		t.Error("ExplainOperation accepted a multi-statement operation")
+	}
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+)
+
+// executeStagedOperation runs a mutation built as stages (see
+// core.SQLOperation.Stages). Each stage fills a temporary table named after
+// the CTE it replaces; the final statement
+// shapes the GraphQL response from those tables, which are dropped afterwards
+// so the next operation in the transaction can reuse the names. On error the
+// caller rolls the transaction back, which discards the tables with it.
+func executeStagedOperation(ctx context.Context, q Querier, op core.SQLOperation) (any, error) {
+	for _, stage := range op.Stages {
+		if err := executeStage(ctx, q, stage); err != nil {
+			return nil, fmt.Errorf("failed to execute stage %s: %w", stage.Name, err)
+		}
+	}
+
+	result, err := queryJSON(ctx, q, op.SQL, op.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stage := range op.Stages {
+		if err := q.ExecContext(ctx, "DROP TABLE temp."+core.QuoteIdentifier(stage.Name)); err != nil {
+			return nil, fmt.Errorf("failed to drop stage %s: %w", stage.Name, err)
+		}
+	}
+
+	return result, nil
+}
+
+func executeStage(ctx context.Context, q Querier, stage core.SQLStage) error {
+	if !stage.Modifying {
+		if err := q.ExecContext(
+			ctx,
+			"CREATE TEMP TABLE "+core.QuoteIdentifier(stage.Name)+" AS "+stage.SQL,
+			stage.Parameters...,
+		); err != nil {
+			return fmt.Errorf("failed to create staging table: %w", err)
+		}
+
+		return nil
+	}
+
+	columns, rows, err := queryReturningRows(ctx, q, stage)
+	if err != nil {
+		return err
+	}
+
+	table := core.QuoteIdentifier(stage.Name)
+
+	var b strings.Builder
+
+	b.WriteString("CREATE TEMP TABLE ")
+	b.WriteString(table)
+	b.WriteString(" (")
+
+	for i, column := range columns {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		core.WriteQuotedIdentifier(&b, column)
+	}
+
+	b.WriteByte(')')
+
+	if err := q.ExecContext(ctx, b.String()); err != nil {
+		return fmt.Errorf("failed to create staging table: %w", err)
+	}
+
+	insert := "INSERT INTO temp." + table + " VALUES (" +
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+
+	for _, row := range rows {
+		if err := q.ExecContext(ctx, insert, row...); err != nil {
+			return fmt.Errorf("failed to stage returned row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// queryReturningRows executes a stage's DML and buffers its RETURNING rows:
+// the transaction's connection cannot run the staging inserts while the
+// result set is still open.
+func queryReturningRows(
+	ctx context.Context, q Querier, stage core.SQLStage,
+) ([]string, [][]any, error) {
+	rows, err := q.QueryContext(ctx, stage.SQL, stage.Parameters...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute statement: %w", err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read returned columns: %w", err)
+	}
+
+	var values [][]any
+
+	for rows.Next() {
+		row := make([]any, len(columns))
+		dest := make([]any, len(columns))
+
+		for i := range row {
+			dest[i] = &row[i]
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan returned row: %w", err)
+		}
+
+		values = append(values, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error iterating returned rows: %w", err)
+	}
+
+	return columns, values, nil
+}
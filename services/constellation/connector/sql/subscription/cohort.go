@@ -84,6 +84,10 @@ type cohortSubscription struct {
 	sessionVariables map[string]any
 	// graphQLVariables are the $limit, $offset, etc. from the subscription.
 	graphQLVariables map[string]any
+	// sessionHash fingerprints sessionVariables; subscribers with the same
+	// fingerprint get the same result, which is how coordinated replicas
+	// address them.
+	sessionHash string
 	// lastHash is the xxhash of the last result for change detection.
 	lastHash string
 	// updateCh is the channel to send updates to.
@@ -106,6 +110,7 @@ func newCohortSubscription(
 		id:               id,
 		sessionVariables: sessionVars,
 		graphQLVariables: graphqlVars,
+		sessionHash:      hashVariables(sessionVars),
 		lastHash:         "",
 		updateCh:         make(chan sub.Update, 1),
 		stopCh:           make(chan struct{}),
@@ -140,6 +145,37 @@ func (s *cohortSubscription) sendUpdate(update sub.Update) bool {
 	s.sendMu.Lock()
 	defer s.sendMu.Unlock()
 
+	return s.sendUpdateLocked(update)
+}
+
+// sendData sends data unless it is what the subscription last received.
+// The hash check and the send happen under sendMu because coordinated
+// cohorts deliver from both the poll goroutine and the message dispatcher.
+func (s *cohortSubscription) sendData(data []byte) {
+	hash := computeDataHash(data)
+
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	if hash == s.lastHash {
+		return
+	}
+
+	if s.sendUpdateLocked(sub.NewUpdateData(s.id, data)) {
+		s.lastHash = hash
+	}
+}
+
+// deliveredHash returns the hash of the data the subscription last received.
+func (s *cohortSubscription) deliveredHash() string {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+
+	return s.lastHash
+}
+
+// sendUpdateLocked does the work of sendUpdate; sendMu must be held.
+func (s *cohortSubscription) sendUpdateLocked(update sub.Update) bool {
 	if s.stopped {
 		return false
 	}
@@ -185,6 +221,9 @@ type cohort struct {
 	subscriptions map[string]*cohortSubscription
 	// mu protects access to subscriptions.
 	mu sync.RWMutex
+	// coordination is the cohort's share of the cross-replica state, nil
+	// unless the manager has a Coordinator.
+	coordination *cohortCoordination
 	// stopCh signals the cohort to stop polling.
 	stopCh chan struct{}
 	// stopped indicates if the cohort has been stopped.
@@ -205,6 +244,7 @@ func newCohort(
 		cachedOp:      nil,
 		subscriptions: make(map[string]*cohortSubscription),
 		mu:            sync.RWMutex{},
+		coordination:  nil,
 		stopCh:        make(chan struct{}),
 		stopped:       false,
 	}
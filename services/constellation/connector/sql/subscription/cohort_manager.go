@@ -29,6 +29,11 @@ type cohortManager struct {
 	mu                sync.RWMutex
 	pollingInterval   time.Duration
 	logger            *slog.Logger
+	// coordinator shares cohort polls with other replicas; nil polls every
+	// cohort locally. See [WithCoordinator].
+	coordinator Coordinator
+	// done is closed by shutdown to stop the coordination dispatcher.
+	done chan struct{}
 }
 
 // newCohortManager creates a new cohort manager for non-stream subscriptions.
@@ -54,6 +59,16 @@ func newCohortManager(
 		mu:                sync.RWMutex{},
 		pollingInterval:   pollingInterval,
 		logger:            logger,
+		coordinator:       nil,
+		done:              make(chan struct{}),
+	}
+}
+
+// startCoordination begins routing coordination messages to the cohorts.
+// It is a no-op without a coordinator.
+func (m *cohortManager) startCoordination() {
+	if m.coordinator != nil {
+		go m.dispatchCoordination()
 	}
 }
 
@@ -118,6 +133,10 @@ func (m *cohortManager) findOrCreateCohort(
 		c, exists := m.cohorts[keyStr]
 		if !exists {
 			c = newCohort(key, req.Operation, req.Fragments, req.OperationName)
+			if m.coordinator != nil {
+				c.coordination = newCohortCoordination()
+			}
+
 			m.cohorts[keyStr] = c
 
 			// Use background context so polling continues even when the first subscriber disconnects.
@@ -200,6 +219,10 @@ func (m *cohortManager) pollCohort(ctx context.Context, c *cohort) {
 	ticker := time.NewTicker(m.pollingInterval)
 	defer ticker.Stop()
 
+	if m.coordinator != nil {
+		defer m.coordinator.Release(ctx, c.key.String())
+	}
+
 	logger.DebugContext(
 		ctx, "started polling cohort",
 		slog.String("cohort_key", c.key.String()),
@@ -235,7 +258,11 @@ func (m *cohortManager) pollCohort(ctx context.Context, c *cohort) {
 
 			m.mu.Unlock()
 
-			m.executeAndNotifyCohort(ctx, c, logger)
+			if m.coordinator != nil {
+				m.coordinatedTick(ctx, c, logger)
+			} else {
+				m.executeAndNotifyCohort(ctx, c, logger)
+			}
 		}
 	}
 }
@@ -252,6 +279,23 @@ func (m *cohortManager) executeAndNotifyCohort(
 		return
 	}
 
+	results, err := m.executeCohort(ctx, c, subscriptions, logger)
+	if err != nil {
+		broadcastError(subscriptions, err)
+		return
+	}
+
+	distributeResults(results, subscriptions)
+}
+
+// executeCohort runs the cohort's multiplexed query for subscriptions,
+// logging any failure before returning it.
+func (m *cohortManager) executeCohort(
+	ctx context.Context,
+	c *cohort,
+	subscriptions map[string]*cohortSubscription,
+	logger *slog.Logger,
+) ([]MultiplexedResult, error) {
 	subIDs, sessionVarArrays := buildSubscriberInputs(subscriptions)
 
 	op, err := m.getOrBuildSQL(c, sessionVarArrays, subscriptions, logger)
@@ -261,9 +305,8 @@ func (m *cohortManager) executeAndNotifyCohort(
 			slog.String("cohort_key", c.key.String()),
 			slog.String("error", err.Error()),
 		)
-		broadcastError(subscriptions, err)
 
-		return
+		return nil, err
 	}
 
 	results, err := m.executor.ExecuteMultiplexedQuery(ctx, op, subIDs, sessionVarArrays, logger)
@@ -275,12 +318,11 @@ func (m *cohortManager) executeAndNotifyCohort(
 			slog.String("cohort_key", c.key.String()),
 			slog.String("error", wrapped.Error()),
 		)
-		broadcastError(subscriptions, wrapped)
 
-		return
+		return nil, wrapped
 	}
 
-	distributeResults(results, subscriptions)
+	return results, nil
 }
 
 // buildSubscriberInputs assembles the per-subscriber subscription-ID list and
@@ -328,14 +370,7 @@ func distributeResults(
 			continue
 		}
 
-		hash := computeDataHash(result.Data)
-		if hash == s.lastHash {
-			continue
-		}
-
-		if s.sendUpdate(sub.NewUpdateData(s.id, result.Data)) {
-			s.lastHash = hash
-		}
+		s.sendData(result.Data)
 	}
 }
 
@@ -417,6 +452,12 @@ func (m *cohortManager) shutdown(ctx context.Context) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	select {
+	case <-m.done:
+	default:
+		close(m.done)
+	}
+
 	logger.InfoContext(
 		ctx, "shutting down cohort manager",
 		slog.Int("cohorts", len(m.cohorts)),
@@ -0,0 +1,500 @@
+package subscription
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxCoordinationPayload is the largest payload a Coordinator must deliver.
+// It stays under the 8000-byte limit of a Postgres NOTIFY payload with room
+// for the envelope a coordinator may add. Results larger than this are not
+// fanned out: the owner tells the other replicas to poll locally instead.
+const MaxCoordinationPayload = 7800
+
+// ownerSilenceIntervals is how many polling intervals a follower waits without
+// hearing from the owner of its cohort before it polls locally again.
+const ownerSilenceIntervals = 3
+
+// remoteSubscriberPrefix marks the multiplexed subscription IDs the owner
+// polls on behalf of other replicas; the rest of the ID is the fingerprint.
+const remoteSubscriberPrefix = "remote:"
+
+// Coordinator lets the live-query cohorts of several replicas share one poll.
+// Every replica that has subscribers for a cohort asks to own it; the owner
+// polls for its own subscribers and for the session variables the others
+// announce, and publishes their results. Followers only poll locally when the
+// owner tells them to or goes silent. Stream subscriptions are not
+// coordinated: their cursors are per cohort and advance on every poll.
+type Coordinator interface {
+	// ReplicaID identifies this replica in the messages it publishes.
+	ReplicaID() string
+	// Acquire reports whether this replica owns the cohort key, taking it
+	// over when no replica does. It is called on every poll, so it must be
+	// cheap when ownership has not changed.
+	Acquire(ctx context.Context, key string) (bool, error)
+	// Release gives up ownership of key. It is a no-op if not owned.
+	Release(ctx context.Context, key string)
+	// Publish broadcasts payload, at most MaxCoordinationPayload bytes, to
+	// every replica.
+	Publish(ctx context.Context, payload []byte) error
+	// Messages delivers the payloads published by any replica, this one
+	// included. The channel is closed when the coordinator shuts down.
+	Messages() <-chan []byte
+}
+
+// HandlerOption customises [NewHandler].
+type HandlerOption func(*Handler)
+
+// WithCoordinator makes live-query cohorts share their polls with the other
+// replicas reachable through coordinator. A nil coordinator leaves every
+// cohort polling locally.
+func WithCoordinator(coordinator Coordinator) HandlerOption {
+	return func(h *Handler) {
+		h.cohortMgr.coordinator = coordinator
+	}
+}
+
+// Kinds of coordinationMessage.
+const (
+	// messageJoin announces a follower's subscribers for a cohort.
+	messageJoin = "join"
+	// messageResult carries the owner's result for one fingerprint.
+	messageResult = "result"
+	// messageFallback asks followers to poll the cohort locally this time,
+	// because the owner's poll failed or a result was too large to publish.
+	messageFallback = "fallback"
+	// messageAlive tells followers the owner is still polling when no result
+	// changed.
+	messageAlive = "alive"
+)
+
+// coordinationMessage is the payload replicas exchange through a Coordinator.
+type coordinationMessage struct {
+	Kind        string             `json:"kind"`
+	Cohort      string             `json:"cohort"`
+	Replica     string             `json:"replica"`
+	Fingerprint string             `json:"fingerprint,omitempty"`
+	Data        jsontext.Value     `json:"data,omitempty"`
+	Subscribers []remoteSubscriber `json:"subscribers,omitempty"`
+}
+
+// remoteSubscriber is a distinct set of session variables a follower polls
+// for. Hash is the result hash the follower last delivered for it, which
+// lets the owner resend a result a follower missed.
+type remoteSubscriber struct {
+	Fingerprint      string         `json:"fingerprint"`
+	SessionVariables map[string]any `json:"session_variables"`
+	Hash             string         `json:"hash,omitempty"`
+}
+
+// remoteEntry is the owner's view of one fingerprint announced by followers.
+type remoteEntry struct {
+	sessionVariables map[string]any
+	// seen is when a follower last announced the fingerprint; entries not
+	// refreshed for ownerSilenceIntervals are dropped.
+	seen time.Time
+	// published is the hash of the result last published for it.
+	published   string
+	publishedAt time.Time
+	// stale is set when a follower reports a hash other than published long
+	// enough after publishing that it cannot be in flight.
+	stale bool
+}
+
+// cohortCoordination is a cohort's share of the coordination state. It is
+// written by the poll goroutine and by the manager's message dispatcher.
+type cohortCoordination struct {
+	mu sync.Mutex
+	// remote holds the fingerprints other replicas poll for, used while this
+	// replica owns the cohort.
+	remote map[string]*remoteEntry
+	// ownerSeen is when the owner was last heard from.
+	ownerSeen time.Time
+	// fallback asks the next follower poll to run locally.
+	fallback bool
+}
+
+func newCohortCoordination() *cohortCoordination {
+	return &cohortCoordination{
+		mu:        sync.Mutex{},
+		remote:    make(map[string]*remoteEntry),
+		ownerSeen: time.Now(),
+		fallback:  false,
+	}
+}
+
+// join records the subscribers a follower announced.
+func (cc *cohortCoordination) join(subscribers []remoteSubscriber, now time.Time, interval time.Duration) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	for _, s := range subscribers {
+		entry, ok := cc.remote[s.Fingerprint]
+		if !ok {
+			entry = &remoteEntry{ //nolint:exhaustruct
+				sessionVariables: s.SessionVariables,
+			}
+			cc.remote[s.Fingerprint] = entry
+		}
+
+		entry.seen = now
+
+		if s.Hash != entry.published && now.Sub(entry.publishedAt) > interval {
+			entry.stale = true
+		}
+	}
+}
+
+// activeRemote drops the entries no follower refreshed within expiry and
+// returns the session variables of the rest by fingerprint.
+func (cc *cohortCoordination) activeRemote(now time.Time, expiry time.Duration) map[string]map[string]any {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	active := make(map[string]map[string]any, len(cc.remote))
+
+	for fingerprint, entry := range cc.remote {
+		if now.Sub(entry.seen) > expiry {
+			delete(cc.remote, fingerprint)
+			continue
+		}
+
+		active[fingerprint] = entry.sessionVariables
+	}
+
+	return active
+}
+
+// needsPublish reports whether the result with the given hash must be
+// published for fingerprint, and records it as published if so.
+func (cc *cohortCoordination) needsPublish(fingerprint, hash string, now time.Time) bool {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	entry, ok := cc.remote[fingerprint]
+	if !ok || (entry.published == hash && !entry.stale) {
+		return false
+	}
+
+	entry.published = hash
+	entry.publishedAt = now
+	entry.stale = false
+
+	return true
+}
+
+// ownerHeard records a message from the cohort's owner; fallback asks the
+// next poll to run locally.
+func (cc *cohortCoordination) ownerHeard(now time.Time, fallback bool) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	cc.ownerSeen = now
+	cc.fallback = cc.fallback || fallback
+}
+
+// pollLocally reports whether a follower must poll itself this time: the
+// owner asked it to, or has been silent for longer than silence.
+func (cc *cohortCoordination) pollLocally(now time.Time, silence time.Duration) bool {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	fallback := cc.fallback
+	cc.fallback = false
+
+	return fallback || now.Sub(cc.ownerSeen) > silence
+}
+
+// coordinatedTick runs one poll of a cohort under coordination: the owner
+// polls for every replica, a follower announces its subscribers and waits for
+// results unless it has to poll locally.
+func (m *cohortManager) coordinatedTick(ctx context.Context, c *cohort, logger *slog.Logger) {
+	owner, err := m.coordinator.Acquire(ctx, c.key.String())
+	if err != nil {
+		logger.WarnContext(
+			ctx, "subscription coordination unavailable, polling locally",
+			slog.String("cohort_key", c.key.String()),
+			slog.String("error", err.Error()),
+		)
+		m.executeAndNotifyCohort(ctx, c, logger)
+
+		return
+	}
+
+	if owner {
+		m.executeAsOwner(ctx, c, logger)
+		return
+	}
+
+	now := time.Now()
+
+	if !m.announceSubscribers(ctx, c, logger) ||
+		c.coordination.pollLocally(now, ownerSilenceIntervals*m.pollingInterval) {
+		m.executeAndNotifyCohort(ctx, c, logger)
+	}
+}
+
+// executeAsOwner polls a cohort for the local subscribers and the
+// fingerprints followers announced, then publishes the followers' results.
+func (m *cohortManager) executeAsOwner(ctx context.Context, c *cohort, logger *slog.Logger) {
+	subscriptions := c.getSubscriptionsCopy()
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	now := time.Now()
+	remote := c.coordination.activeRemote(now, ownerSilenceIntervals*m.pollingInterval)
+
+	if len(remote) == 0 {
+		m.executeAndNotifyCohort(ctx, c, logger)
+		return
+	}
+
+	polled := withRemoteSubscribers(subscriptions, remote)
+
+	results, err := m.executeCohort(ctx, c, polled, logger)
+	if err != nil {
+		broadcastError(subscriptions, err)
+		m.publish(ctx, c, coordinationMessage{Kind: messageFallback}, logger) //nolint:exhaustruct
+
+		return
+	}
+
+	distributeResults(results, subscriptions)
+
+	published := false
+
+	for _, result := range results {
+		fingerprint, ok := strings.CutPrefix(result.SubscriptionID, remoteSubscriberPrefix)
+		if !ok || !c.coordination.needsPublish(fingerprint, computeDataHash(result.Data), now) {
+			continue
+		}
+
+		msg := coordinationMessage{ //nolint:exhaustruct
+			Kind:        messageResult,
+			Fingerprint: fingerprint,
+			Data:        jsontext.Value(result.Data),
+		}
+
+		if !m.publish(ctx, c, msg, logger) {
+			m.publish(ctx, c, coordinationMessage{Kind: messageFallback}, logger) //nolint:exhaustruct
+		}
+
+		published = true
+	}
+
+	if !published {
+		m.publish(ctx, c, coordinationMessage{Kind: messageAlive}, logger) //nolint:exhaustruct
+	}
+}
+
+// withRemoteSubscribers returns subscriptions plus one stand-in per remote
+// fingerprint, so the owner's multiplexed poll covers every replica.
+func withRemoteSubscribers(
+	subscriptions map[string]*cohortSubscription,
+	remote map[string]map[string]any,
+) map[string]*cohortSubscription {
+	polled := make(map[string]*cohortSubscription, len(subscriptions)+len(remote))
+
+	var graphQLVars map[string]any
+
+	for id, s := range subscriptions {
+		polled[id] = s
+		graphQLVars = s.graphQLVariables
+	}
+
+	for fingerprint, sessionVars := range remote {
+		id := remoteSubscriberPrefix + fingerprint
+		polled[id] = &cohortSubscription{ //nolint:exhaustruct
+			id:               id,
+			sessionVariables: sessionVars,
+			graphQLVariables: graphQLVars,
+		}
+	}
+
+	return polled
+}
+
+// announceSubscribers publishes the distinct session variables of a
+// follower's subscribers, split across messages to respect
+// MaxCoordinationPayload. It reports false when any of them could not be
+// announced, in which case the cohort must poll locally.
+func (m *cohortManager) announceSubscribers(ctx context.Context, c *cohort, logger *slog.Logger) bool {
+	announced := true
+
+	var batch []remoteSubscriber
+
+	flush := func() {
+		if len(batch) > 0 {
+			announced = m.publish(ctx, c, joinMessage(batch), logger) && announced
+		}
+
+		batch = nil
+	}
+
+	for _, s := range localFingerprints(c.getSubscriptionsCopy()) {
+		if !m.fits(c, joinMessage(append(batch, s))) {
+			flush()
+
+			if !m.fits(c, joinMessage([]remoteSubscriber{s})) {
+				announced = false
+				continue
+			}
+		}
+
+		batch = append(batch, s)
+	}
+
+	flush()
+
+	return announced
+}
+
+func joinMessage(subscribers []remoteSubscriber) coordinationMessage {
+	return coordinationMessage{Kind: messageJoin, Subscribers: subscribers} //nolint:exhaustruct
+}
+
+// localFingerprints groups subscriptions by session variables. A
+// fingerprint's hash is the one its subscribers last received, or empty
+// when they disagree.
+func localFingerprints(subscriptions map[string]*cohortSubscription) []remoteSubscriber {
+	byFingerprint := make(map[string]*remoteSubscriber, len(subscriptions))
+	order := make([]string, 0, len(subscriptions))
+
+	for _, s := range subscriptions {
+		fingerprint := s.sessionHash
+		hash := s.deliveredHash()
+
+		existing, ok := byFingerprint[fingerprint]
+		if !ok {
+			byFingerprint[fingerprint] = &remoteSubscriber{
+				Fingerprint:      fingerprint,
+				SessionVariables: s.sessionVariables,
+				Hash:             hash,
+			}
+			order = append(order, fingerprint)
+
+			continue
+		}
+
+		if existing.Hash != hash {
+			existing.Hash = ""
+		}
+	}
+
+	subscribers := make([]remoteSubscriber, 0, len(order))
+	for _, fingerprint := range order {
+		subscribers = append(subscribers, *byFingerprint[fingerprint])
+	}
+
+	return subscribers
+}
+
+// fits reports whether msg, once stamped for c, is small enough to publish.
+func (m *cohortManager) fits(c *cohort, msg coordinationMessage) bool {
+	payload, err := m.encode(c, msg)
+
+	return err == nil && len(payload) <= MaxCoordinationPayload
+}
+
+func (m *cohortManager) encode(c *cohort, msg coordinationMessage) ([]byte, error) {
+	msg.Cohort = c.key.String()
+	msg.Replica = m.coordinator.ReplicaID()
+
+	return json.Marshal(msg) //nolint:wrapcheck
+}
+
+// publish sends msg for cohort c, reporting false when it is too large or the
+// coordinator failed.
+func (m *cohortManager) publish(
+	ctx context.Context, c *cohort, msg coordinationMessage, logger *slog.Logger,
+) bool {
+	payload, err := m.encode(c, msg)
+	if err == nil && len(payload) > MaxCoordinationPayload {
+		return false
+	}
+
+	if err == nil {
+		err = m.coordinator.Publish(ctx, payload)
+	}
+
+	if err != nil {
+		logger.WarnContext(
+			ctx, "failed to publish subscription coordination message",
+			slog.String("cohort_key", c.key.String()),
+			slog.String("kind", msg.Kind),
+			slog.String("error", err.Error()),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// dispatchCoordination routes the coordinator's messages to the local cohorts
+// until the coordinator closes its channel or the manager shuts down.
+func (m *cohortManager) dispatchCoordination() {
+	messages := m.coordinator.Messages()
+
+	for {
+		select {
+		case <-m.done:
+			return
+		case payload, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			m.handleCoordinationMessage(payload)
+		}
+	}
+}
+
+func (m *cohortManager) handleCoordinationMessage(payload []byte) {
+	var msg coordinationMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		m.logger.Warn(
+			"ignoring malformed subscription coordination message",
+			slog.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	if msg.Replica == m.coordinator.ReplicaID() {
+		return
+	}
+
+	m.mu.RLock()
+	c, ok := m.cohorts[msg.Cohort]
+	m.mu.RUnlock()
+
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+
+	switch msg.Kind {
+	case messageJoin:
+		c.coordination.join(msg.Subscribers, now, m.pollingInterval)
+	case messageResult:
+		c.coordination.ownerHeard(now, false)
+
+		for _, s := range c.getSubscriptionsCopy() {
+			if s.sessionHash == msg.Fingerprint {
+				s.sendData(msg.Data)
+			}
+		}
+	case messageFallback:
+		c.coordination.ownerHeard(now, true)
+	case messageAlive:
+		c.coordination.ownerHeard(now, false)
+	}
+}
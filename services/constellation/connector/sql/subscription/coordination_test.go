@@ -0,0 +1,278 @@
+package subscription_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	subscription "github.com/nhost/nhost/services/constellation/connector/sql/subscription"
+	submock "github.com/nhost/nhost/services/constellation/connector/sql/subscription/mock"
+	sub "github.com/nhost/nhost/services/constellation/subscription"
+)
+
+const coordinationInterval = 50 * time.Millisecond
+
+// fakeBus stands in for the database the replicas coordinate through: the
+// first replica to ask for a key owns it, and every message reaches every
+// replica.
+type fakeBus struct {
+	mu       sync.Mutex
+	owners   map[string]string
+	replicas []*fakeCoordinator
+}
+
+type fakeCoordinator struct {
+	bus      *fakeBus
+	id       string
+	messages chan []byte
+}
+
+func (b *fakeBus) join(id string) *fakeCoordinator {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := &fakeCoordinator{bus: b, id: id, messages: make(chan []byte, 64)}
+	b.replicas = append(b.replicas, c)
+
+	return c
+}
+
+func (c *fakeCoordinator) ReplicaID() string { return c.id }
+
+func (c *fakeCoordinator) Acquire(_ context.Context, key string) (bool, error) {
+	c.bus.mu.Lock()
+	defer c.bus.mu.Unlock()
+
+	owner, ok := c.bus.owners[key]
+	if !ok {
+		c.bus.owners[key] = c.id
+		return true, nil
+	}
+
+	return owner == c.id, nil
+}
+
+func (c *fakeCoordinator) Release(_ context.Context, key string) {
+	c.bus.mu.Lock()
+	defer c.bus.mu.Unlock()
+
+	if c.bus.owners[key] == c.id {
+		delete(c.bus.owners, key)
+	}
+}
+
+func (c *fakeCoordinator) Publish(_ context.Context, payload []byte) error {
+	c.bus.mu.Lock()
+	defer c.bus.mu.Unlock()
+
+	for _, r := range c.bus.replicas {
+		select {
+		case r.messages <- payload:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (c *fakeCoordinator) Messages() <-chan []byte { return c.messages }
+
+// coordinatedHandler builds a handler on bus whose executor answers every
+// subscriber with data(userID) and counts its polls.
+func coordinatedHandler(
+	t *testing.T,
+	bus *fakeBus,
+	id string,
+	data func(userID any) string,
+) (*subscription.Handler, *atomic.Int32) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	executor := submock.NewMockQueryExecutor(ctrl)
+	builder := submock.NewMockQueryBuilder(ctrl)
+
+	expectIsStreamSubscription(builder, false)
+	builder.EXPECT().BuildQuery(
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).Return([]core.SQLOperation{nonStreamOp()}, nil).AnyTimes()
+
+	var polls atomic.Int32
+
+	executor.EXPECT().ExecuteMultiplexedQuery(
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+	).DoAndReturn(func(
+		_ context.Context,
+		_ core.SQLOperation,
+		subIDs []string,
+		sessionVarArrays map[string][]any,
+		_ *slog.Logger,
+	) ([]subscription.MultiplexedResult, error) {
+		polls.Add(1)
+
+		results := make([]subscription.MultiplexedResult, len(subIDs))
+		for i, subID := range subIDs {
+			results[i] = subscription.MultiplexedResult{
+				SubscriptionID: subID,
+				Data:           []byte(data(sessionVarArrays["x-hasura-user-id"][i])),
+			}
+		}
+
+		return results, nil
+	}).AnyTimes()
+
+	h := subscription.NewHandler(
+		executor, builder, coordinationInterval, integrationLogger(),
+		subscription.WithCoordinator(bus.join(id)),
+	)
+
+	return h, &polls
+}
+
+func coordinatedRequest(id, userID string) sub.Request {
+	req := testRequest(id)
+	req.SessionVariables = map[string]any{"x-hasura-user-id": userID}
+
+	return req
+}
+
+// receiveData waits for an update carrying want, skipping others.
+func receiveData(t *testing.T, ch <-chan sub.Update, want string) {
+	t.Helper()
+
+	deadline := time.After(2 * time.Second)
+
+	for {
+		select {
+		case u := <-ch:
+			if string(u.Data) == want {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+}
+
+func TestHandler_Coordination_FollowerUsesOwnerResults(t *testing.T) {
+	t.Parallel()
+
+	bus := &fakeBus{owners: map[string]string{}}
+
+	owner, _ := coordinatedHandler(t, bus, "a", func(userID any) string {
+		return fmt.Sprintf(`{"me":%q}`, userID)
+	})
+	defer owner.Shutdown(context.Background())
+
+	follower, followerPolls := coordinatedHandler(t, bus, "b", func(any) string {
+		return `{"local":true}`
+	})
+	defer follower.Shutdown(context.Background())
+
+	ownerCh, err := owner.Start(context.Background(), coordinatedRequest("sub-a", "1"), integrationLogger())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	receiveData(t, ownerCh, `{"me":"1"}`)
+
+	// Let the owner's first tick acquire the cohort.
+	time.Sleep(2 * coordinationInterval)
+
+	followerCh, err := follower.Start(context.Background(), coordinatedRequest("sub-b", "2"), integrationLogger())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	// The follower answers its first poll itself, then takes the owner's
+	// result for its session variables.
+	receiveData(t, followerCh, `{"local":true}`)
+	receiveData(t, followerCh, `{"me":"2"}`)
+
+	polls := followerPolls.Load()
+
+	time.Sleep(6 * coordinationInterval)
+
+	if got := followerPolls.Load(); got != polls {
+		t.Errorf("follower polled %d more times while the owner was polling", got-polls)
+	}
+}
+
+func TestHandler_Coordination_FollowerTakesOverWhenOwnerStops(t *testing.T) {
+	t.Parallel()
+
+	bus := &fakeBus{owners: map[string]string{}}
+
+	owner, _ := coordinatedHandler(t, bus, "a", func(userID any) string {
+		return fmt.Sprintf(`{"me":%q}`, userID)
+	})
+
+	follower, _ := coordinatedHandler(t, bus, "b", func(any) string {
+		return `{"local":true}`
+	})
+	defer follower.Shutdown(context.Background())
+
+	ownerCh, err := owner.Start(context.Background(), coordinatedRequest("sub-a", "1"), integrationLogger())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	receiveData(t, ownerCh, `{"me":"1"}`)
+	time.Sleep(2 * coordinationInterval)
+
+	followerCh, err := follower.Start(context.Background(), coordinatedRequest("sub-b", "2"), integrationLogger())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	receiveData(t, followerCh, `{"me":"2"}`)
+
+	owner.Shutdown(context.Background())
+
+	receiveData(t, followerCh, `{"local":true}`)
+}
+
+func TestHandler_Coordination_OversizedResultFallsBack(t *testing.T) {
+	t.Parallel()
+
+	bus := &fakeBus{owners: map[string]string{}}
+	large := `{"blob":"` + strings.Repeat("x", subscription.MaxCoordinationPayload) + `"}`
+
+	owner, _ := coordinatedHandler(t, bus, "a", func(any) string { return large })
+	defer owner.Shutdown(context.Background())
+
+	follower, followerPolls := coordinatedHandler(t, bus, "b", func(any) string {
+		return `{"local":true}`
+	})
+	defer follower.Shutdown(context.Background())
+
+	ownerCh, err := owner.Start(context.Background(), coordinatedRequest("sub-a", "1"), integrationLogger())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	receiveData(t, ownerCh, large)
+	time.Sleep(2 * coordinationInterval)
+
+	if _, err := follower.Start(
+		context.Background(), coordinatedRequest("sub-b", "2"), integrationLogger(),
+	); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for followerPolls.Load() < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("follower polled %d times, want it to poll locally", followerPolls.Load())
+		}
+
+		time.Sleep(coordinationInterval)
+	}
+}
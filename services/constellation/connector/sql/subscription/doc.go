@@ -60,6 +60,14 @@
 // last result. Before sending an update, the manager compares the new hash to
 // the stored hash. Only if they differ is the update sent.
 //
+// # Cross-replica coordination
+//
+// With a Coordinator (see WithCoordinator), the replicas running the same
+// live-query cohort elect an owner per cohort. The owner polls for its own
+// subscribers and for the session variables the other replicas announce, and
+// publishes their results; the others only poll locally when told to, when
+// the owner goes silent, or once it is gone and they take ownership over.
+//
 // # Dependency on the queries package
 //
 // The QueryBuilder and QueryExecutor interfaces are the seam this package
@@ -91,12 +91,20 @@ func NewHandler(
 	roots QueryBuilder,
 	pollingInterval time.Duration,
 	logger *slog.Logger,
+	opts ...HandlerOption,
 ) *Handler {
-	return &Handler{
+	h := &Handler{
 		cohortMgr:       newCohortManager(executor, roots, pollingInterval, logger),
 		streamCohortMgr: newStreamCohortManager(executor, roots, pollingInterval, logger),
 		roots:           roots,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.cohortMgr.startCoordination()
+
+	return h
 }
 
 // Start registers a subscription and begins sending updates.
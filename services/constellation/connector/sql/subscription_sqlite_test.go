@@ -0,0 +1,115 @@
+package sql_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+
+	csql "github.com/nhost/nhost/services/constellation/connector/sql"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
+	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/dialect"
+	"github.com/nhost/nhost/services/constellation/connector/sql/sqlite"
+	"github.com/nhost/nhost/services/constellation/internal/lib/testdb"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+func notesMetadata() *metadata.DatabaseMetadata {
+	return &metadata.DatabaseMetadata{
+		Name: "default",
+		Kind: "sqlite",
+		Tables: []metadata.TableMetadata{
+			{
+				Table: metadata.TableSource{Schema: "main", Name: "notes"},
+				SelectPermissions: []metadata.SelectPermission{
+					{
+						Role: "user",
+						Permission: metadata.SelectPermissionConfig{
+							Columns: []string{"id", "body"},
+							Filter: map[string]any{
+								"owner": map[string]any{"_eq": "X-Hasura-User-Id"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// TestConnector_ExecuteMultiplexedQuery_SQLitePerSubscriber runs a
+// permission-filtered subscription against SQLite, whose dialect cannot
+// express the multiplexed statement: each subscriber must be polled with its
+// own session value and receive only its own rows, wrapped in the root-field
+// envelope the poller expects.
+func TestConnector_ExecuteMultiplexedQuery_SQLitePerSubscriber(t *testing.T) {
+	t.Parallel()
+
+	md := notesMetadata()
+	sqlite.FlattenMetadata(md)
+
+	client := testdb.NewSQLite(
+		t,
+		`CREATE TABLE notes (id integer PRIMARY KEY, owner text NOT NULL, body text NOT NULL);`,
+		`INSERT INTO notes (id, owner, body) VALUES (1, 'alice', 'a1'), (2, 'bob', 'b1'), (3, 'alice', 'a2');`,
+	)
+
+	c, err := csql.NewConnector(t.Context(), client, md, nil, nil)
+	if err != nil {
+		t.Fatalf("NewConnector: %v", err)
+	}
+
+	objects, err := client.Introspect(t.Context(), md)
+	if err != nil {
+		t.Fatalf("Introspect: %v", err)
+	}
+
+	roots, _, err := queries.BuildRoots(objects, md, &dialect.SQLiteDialect{})
+	if err != nil {
+		t.Fatalf("BuildRoots: %v", err)
+	}
+
+	doc, gqlErr := parser.ParseQuery(&ast.Source{
+		Input: `subscription { notes(order_by: {id: asc}) { id body } }`,
+	})
+	if gqlErr != nil {
+		t.Fatalf("ParseQuery: %v", gqlErr)
+	}
+
+	ops, err := roots.BuildQuery(
+		doc.Operations[0], doc.Fragments, nil, "user",
+		map[string]any{"x-hasura-user-id": core.SessionVarValue{Name: "x-hasura-user-id"}},
+	)
+	if err != nil {
+		t.Fatalf("BuildQuery: %v", err)
+	}
+
+	results, err := c.ExecuteMultiplexedQuery(
+		t.Context(),
+		ops[0],
+		[]string{"sub-alice", "sub-bob", "sub-nobody"},
+		map[string][]any{"x-hasura-user-id": {"alice", "bob", nil}},
+		slog.New(slog.DiscardHandler),
+	)
+	if err != nil {
+		t.Fatalf("ExecuteMultiplexedQuery: %v", err)
+	}
+
+	want := map[string]string{
+		"sub-alice":  `{"notes":[{"id":1,"body":"a1"},{"id":3,"body":"a2"}]}`,
+		"sub-bob":    `{"notes":[{"id":2,"body":"b1"}]}`,
+		"sub-nobody": `{"notes":[]}`,
+	}
+
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d: %+v", len(results), len(want), results)
+	}
+
+	for _, r := range results {
+		if got := string(r.Data); got != want[r.SubscriptionID] {
+			t.Errorf("%s: got %s, want %s", r.SubscriptionID, got, want[r.SubscriptionID])
+		}
+	}
+}
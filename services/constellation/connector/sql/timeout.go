@@ -0,0 +1,67 @@
+package sql //nolint:revive,nolintlint // package name "sql" shadows database/sql; see sql.go for the rationale.
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// statementTimeoutCtxKey keys the time.Duration stored by
+// [WithStatementTimeout].
+type statementTimeoutCtxKey struct{}
+
+// WithStatementTimeout returns a context asking drivers to cancel any
+// statement of ExecuteOperations that runs longer than timeout. Postgres
+// applies it with SET LOCAL statement_timeout, SQLite by cancelling the
+// context. A zero timeout leaves ctx unchanged.
+func WithStatementTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, statementTimeoutCtxKey{}, timeout)
+}
+
+// StatementTimeoutFromContext returns the timeout set by
+// [WithStatementTimeout], or 0 when there is none.
+func StatementTimeoutFromContext(ctx context.Context) time.Duration {
+	timeout, _ := ctx.Value(statementTimeoutCtxKey{}).(time.Duration)
+
+	return timeout
+}
+
+// StatementTimeoutError reports an operation whose statement the database
+// cancelled because it exceeded the statement timeout. Drivers return it
+// instead of the raw cancellation error so the client gets a stable envelope.
+type StatementTimeoutError struct {
+	timeout time.Duration
+	err     error
+}
+
+// NewStatementTimeoutError wraps the driver's cancellation error err.
+func NewStatementTimeoutError(timeout time.Duration, err error) *StatementTimeoutError {
+	return &StatementTimeoutError{timeout: timeout, err: err}
+}
+
+// Error describes the timeout and the underlying driver error for logs.
+func (e *StatementTimeoutError) Error() string {
+	return fmt.Sprintf("statement timeout of %s exceeded: %v", e.timeout, e.err)
+}
+
+// Unwrap exposes the driver's cancellation error.
+func (e *StatementTimeoutError) Unwrap() error {
+	return e.err
+}
+
+// AsMap renders the error the way Hasura reports a statement cancelled by
+// statement_timeout: the generic "database query error" message with code
+// "unexpected" at path "$". The statement and driver error stay in the logs.
+func (e *StatementTimeoutError) AsMap() map[string]any {
+	return map[string]any{
+		"message": "database query error",
+		"extensions": map[string]any{
+			"code": "unexpected",
+			"path": "$",
+		},
+	}
+}
@@ -20,11 +20,15 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/nhost/nhost/services/constellation/connector"
 	"github.com/nhost/nhost/services/constellation/connector/composer"
+	"github.com/nhost/nhost/services/constellation/connector/federation"
+	"github.com/nhost/nhost/services/constellation/controller/introspection"
 	"github.com/nhost/nhost/services/constellation/controller/middleware"
 	"github.com/nhost/nhost/services/constellation/controller/planner"
 	"github.com/nhost/nhost/services/constellation/controller/relationships"
@@ -60,10 +64,23 @@ type controllerState struct {
 	queryPlanner               *planner.QueryPlanner
 	subHandlers                map[string]subscription.Handler
 	queryCache                 *queryCache
+	// entities maps role → type name → the Apollo Federation entity the
+	// role resolves through _entities; see connector/federation.
+	entities map[string]map[string]federation.Target
+	// introspection decides which roles may run __schema / __type queries,
+	// combining the metadata's disabled_for_roles with the server-wide flag.
+	introspection introspection.Policy
 	// inconsistencies is the snapshot of per-source / per-role build failures
 	// recorded by the metadata reload that produced this state. Captured once
 	// at build time; the next reload produces a fresh snapshot.
 	inconsistencies []metadata.Inconsistency
+	// refreshes receives the background re-introspection outcomes of this
+	// state's remote schemas and holds the failures recorded since the build.
+	refreshes *schemaRefreshes
+	// reused names the connectors carried over from the state this one was
+	// rebuilt from (see reuseExcept). They stay open when that state is
+	// replaced, and when this state is discarded before it is swapped in.
+	reused []string
 	// done is closed when this state is shut down (metadata reload or server stop).
 	// WebSocket connections select on this to close when the state becomes stale.
 	done chan struct{}
@@ -80,7 +97,11 @@ func newControllerState(
 	meta *metadata.Metadata,
 	queryPlanner *planner.QueryPlanner,
 	subHandlers map[string]subscription.Handler,
+	entities map[string]map[string]federation.Target,
+	introspectionPolicy introspection.Policy,
 	inconsistencies []metadata.Inconsistency,
+	refreshes *schemaRefreshes,
+	reused []string,
 ) *controllerState {
 	return &controllerState{
 		validatedSchemas:           validatedSchemas,
@@ -91,7 +112,11 @@ func newControllerState(
 		queryPlanner:               queryPlanner,
 		subHandlers:                subHandlers,
 		queryCache:                 newQueryCache(),
+		entities:                   entities,
+		introspection:              introspectionPolicy,
 		inconsistencies:            inconsistencies,
+		refreshes:                  refreshes,
+		reused:                     reused,
 		done:                       make(chan struct{}),
 	}
 }
@@ -108,10 +133,49 @@ func (s *controllerState) shutdown(ctx context.Context) {
 	}
 }
 
-// closeConnectors closes all connectors, releasing their resources.
-func (s *controllerState) closeConnectors() {
-	for _, conn := range s.connectors {
-		conn.Close()
+// closeConnectors closes the connectors not named in keep, releasing their
+// resources.
+func (s *controllerState) closeConnectors(keep []string) {
+	for name, conn := range s.connectors {
+		if !slices.Contains(keep, name) {
+			conn.Close()
+		}
+	}
+}
+
+// reusedSources is the part of a state that a rebuild re-introspecting only
+// some sources carries over: the connectors of the other sources, the
+// inconsistencies recorded for them, and the refreshes their remote schemas
+// report to.
+type reusedSources struct {
+	connectors      map[string]connector.Connector
+	inconsistencies []metadata.Inconsistency
+	refreshes       *schemaRefreshes
+}
+
+// reuseExcept returns what a rebuild re-introspecting only sources carries
+// over from s.
+func (s *controllerState) reuseExcept(sources []string) *reusedSources {
+	connectors := make(map[string]connector.Connector, len(s.connectors))
+
+	for name, conn := range s.connectors {
+		if !slices.Contains(sources, name) {
+			connectors[name] = conn
+		}
+	}
+
+	var inconsistencies []metadata.Inconsistency
+
+	for _, inc := range s.inconsistencies {
+		if _, ok := connectors[inc.Source]; ok {
+			inconsistencies = append(inconsistencies, inc)
+		}
+	}
+
+	return &reusedSources{
+		connectors:      connectors,
+		inconsistencies: inconsistencies,
+		refreshes:       s.refreshes,
 	}
 }
 
@@ -119,18 +183,41 @@ func (s *controllerState) closeConnectors() {
 // directly on the struct; everything that gets rebuilt on metadata changes
 // is behind an atomic pointer.
 type Controller struct {
-	state           atomic.Pointer[controllerState]
+	state atomic.Pointer[controllerState]
+	// swapMu serializes swapState, from reading the current state as the
+	// diff baseline to storing the new one.
+	swapMu          sync.Mutex
 	adminSecret     string
 	jwtAuth         middleware.JWTAuthenticator
 	pollingInterval time.Duration
-	logger          *slog.Logger
+	// coordination shares live-query subscription polls across replicas;
+	// see connector.WithSubscriptionCoordination.
+	coordination bool
+	logger       *slog.Logger
 	// devMode, when true, returns raw connector/database error detail to
 	// clients instead of the sanitized generic message (Hasura
 	// HASURA_GRAPHQL_DEV_MODE parity). Never enable in production.
 	devMode bool
+	// disableIntrospection rejects __schema / __type queries from every
+	// non-admin role, on top of the metadata's per-role list.
+	disableIntrospection bool
+	// breakingChangeRoles lists the roles for which a rebuilt state with
+	// breaking schema changes is refused instead of swapped in.
+	breakingChangeRoles []string
+	// sessionSettings lists the Postgres sources that publish the caller's
+	// session as hasura.user; see connector.WithSessionSettings.
+	sessionSettings []string
+	// schemaDiff is the report of the most recent rebuild; see
+	// HandlerSchemaDiff.
+	schemaDiff atomic.Pointer[SchemaDiff]
 
 	source metadata.Source
 
+	// sqlChanges queues the sources run_sql changed for Run to re-introspect;
+	// see rebuildForSQLChange. stopped is closed when Run returns.
+	sqlChanges chan sqlChange
+	stopped    chan struct{}
+
 	// version is the build-time version string surfaced by the GetVersion
 	// OpenAPI handler.
 	version string
@@ -147,8 +234,12 @@ type Controller struct {
 func New(
 	ctx context.Context,
 	subscriptionPollInterval time.Duration,
+	subscriptionCoordination bool,
+	sessionSettingsSources []string,
 	adminSecret string,
 	devMode bool,
+	disableIntrospection bool,
+	breakingChangeRoles []string,
 	jwtAuth middleware.JWTAuthenticator,
 	source metadata.Source,
 	logger *slog.Logger,
@@ -160,7 +251,10 @@ func New(
 		return nil, fmt.Errorf("initial metadata load: %w", err)
 	}
 
-	state, err := buildState(ctx, meta, subscriptionPollInterval, logger)
+	state, err := buildState(
+		ctx, meta, nil, subscriptionPollInterval, subscriptionCoordination, sessionSettingsSources,
+		disableIntrospection, logger,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("building initial state: %w", err)
 	}
@@ -168,15 +262,23 @@ func New(
 	logInconsistencySummary(ctx, logger, state.inconsistencies)
 
 	ctrl := &Controller{
-		state:           atomic.Pointer[controllerState]{},
-		adminSecret:     adminSecret,
-		jwtAuth:         jwtAuth,
-		pollingInterval: subscriptionPollInterval,
-		logger:          logger,
-		devMode:         devMode,
-		source:          source,
-		version:         version,
-		hasuraProxy:     hasuraProxy,
+		state:                atomic.Pointer[controllerState]{},
+		swapMu:               sync.Mutex{},
+		adminSecret:          adminSecret,
+		jwtAuth:              jwtAuth,
+		pollingInterval:      subscriptionPollInterval,
+		coordination:         subscriptionCoordination,
+		logger:               logger,
+		devMode:              devMode,
+		disableIntrospection: disableIntrospection,
+		breakingChangeRoles:  breakingChangeRoles,
+		sessionSettings:      sessionSettingsSources,
+		schemaDiff:           atomic.Pointer[SchemaDiff]{},
+		source:               source,
+		sqlChanges:           make(chan sqlChange, sqlChangeBuffer),
+		stopped:              make(chan struct{}),
+		version:              version,
+		hasuraProxy:          hasuraProxy,
 	}
 	ctrl.state.Store(state)
 
@@ -184,15 +286,21 @@ func New(
 }
 
 // Inconsistencies returns the partial-failure entries recorded during the
-// most recent successful metadata build. The returned slice is a snapshot;
-// it does not reflect later reloads.
+// most recent successful metadata build, followed by the remote schemas whose
+// background re-introspection is currently failing. The returned slice is a
+// snapshot; it does not reflect later reloads or refreshes.
 func (c *Controller) Inconsistencies() []metadata.Inconsistency {
 	state := c.state.Load()
 	if state == nil {
 		return nil
 	}
 
-	return state.inconsistencies
+	failures := state.refreshes.snapshot()
+	if len(failures) == 0 {
+		return state.inconsistencies
+	}
+
+	return append(slices.Clone(state.inconsistencies), failures...)
 }
 
 // logInconsistencySummary emits a single summary log line after a build so
@@ -215,34 +323,48 @@ func logInconsistencySummary(
 // both at startup and on every metadata reload. Per-source and per-role
 // build failures are recorded as inconsistencies on the returned state rather
 // than aborting; the function only returns an error if the build cannot
-// produce any usable state at all.
+// produce any usable state at all. A non-nil reused carries over the
+// connectors of the sources that are not to be re-introspected.
 func buildState(
 	ctx context.Context,
 	meta *metadata.Metadata,
+	reused *reusedSources,
 	subscriptionPollInterval time.Duration,
+	subscriptionCoordination bool,
+	sessionSettingsSources []string,
+	disableIntrospection bool,
 	logger *slog.Logger,
 ) (*controllerState, error) {
-	built, err := connector.BuildConnectorsFromMetadata(ctx, meta, logger)
+	refreshes := newSchemaRefreshes()
+	if reused != nil {
+		refreshes = reused.refreshes
+	}
+
+	opts := []connector.Option{
+		connector.WithSchemaRefreshes(refreshes.notify),
+		connector.WithSubscriptionCoordination(subscriptionCoordination),
+		connector.WithSessionSettings(sessionSettingsSources),
+	}
+	if reused != nil {
+		opts = append(opts, connector.WithConnectors(reused.connectors))
+	}
+
+	built, err := connector.BuildConnectorsFromMetadata(ctx, meta, logger, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build connectors from metadata: %w", err)
 	}
 
-	// Create subscription handlers for all subscription-capable connectors.
-	// A nil handler means the connector reports the capability but cannot
-	// actually serve it (e.g. a customization wrapper around a remote schema),
-	// so it is skipped rather than registered.
-	subHandlers := make(map[string]subscription.Handler)
-	for dbName, conn := range built.Connectors {
-		subCapable, ok := conn.(subscriptionCapableConnector)
-		if !ok {
-			continue
-		}
+	inconsistencies := built.Inconsistencies
 
-		if handler := subCapable.NewSubscriptionHandler(
-			subscriptionPollInterval,
-			logger,
-		); handler != nil {
-			subHandlers[dbName] = handler
+	var reusedNames []string
+
+	if reused != nil {
+		inconsistencies = append(slices.Clone(reused.inconsistencies), inconsistencies...)
+
+		for name := range reused.connectors {
+			if _, ok := built.Connectors[name]; ok {
+				reusedNames = append(reusedNames, name)
+			}
 		}
 	}
 
@@ -263,47 +385,134 @@ func buildState(
 		built.FieldToConnector,
 		meta,
 		queryPlanner,
-		subHandlers,
-		built.Inconsistencies,
+		newSubscriptionHandlers(built.Connectors, subscriptionPollInterval, logger),
+		built.Entities,
+		introspection.NewPolicy(
+			meta.GraphQLSchemaIntrospection.DisabledForRoles, disableIntrospection,
+		),
+		inconsistencies,
+		refreshes,
+		reusedNames,
 	), nil
 }
 
-// Run consumes metadata updates from the source and reloads state. It returns
-// when the source channel closes or ctx is cancelled. In serve.go the
-// deferred cancel() ensures the rest of the process shuts down.
+// newSubscriptionHandlers creates subscription handlers for all
+// subscription-capable connectors. A nil handler means the connector reports
+// the capability but cannot actually serve it (e.g. a customization wrapper
+// around a connector without subscription support), so it is skipped rather
+// than registered.
+func newSubscriptionHandlers(
+	connectors map[string]connector.Connector,
+	subscriptionPollInterval time.Duration,
+	logger *slog.Logger,
+) map[string]subscription.Handler {
+	subHandlers := make(map[string]subscription.Handler)
+
+	for dbName, conn := range connectors {
+		subCapable, ok := conn.(subscriptionCapableConnector)
+		if !ok {
+			continue
+		}
+
+		if handler := subCapable.NewSubscriptionHandler(
+			subscriptionPollInterval,
+			logger,
+		); handler != nil {
+			subHandlers[dbName] = handler
+		}
+	}
+
+	return subHandlers
+}
+
+// Run consumes metadata updates from the source and reloads state. It also
+// applies the re-introspection outcomes reported by the current state's
+// remote schemas (see handleSchemaRefresh) and re-introspects the sources
+// run_sql changed (see applySQLChanges). It returns when the source
+// channel closes or ctx is cancelled. In serve.go the deferred cancel()
+// ensures the rest of the process shuts down.
 func (c *Controller) Run(
 	ctx context.Context,
 	logger *slog.Logger,
 ) {
+	defer close(c.stopped)
 	defer c.shutdownState(ctx, logger)
 
-	for update := range c.source.Watch(ctx) {
-		if update.Err != nil {
-			logger.ErrorContext(
-				ctx, "metadata reload failed, keeping current state", "error", update.Err,
-			)
-
-			continue
+	updates := c.source.Watch(ctx)
+
+	for {
+		// Every swap happens on this goroutine, so the refreshes of the
+		// state current at each iteration are the ones to wait on; a replaced
+		// state's are never selected again.
+		refreshes := c.state.Load().refreshes.updates()
+
+		select {
+		case <-ctx.Done():
+			return
+		case refresh := <-refreshes:
+			c.handleSchemaRefresh(ctx, refresh, logger)
+		case change := <-c.sqlChanges:
+			c.applySQLChanges(ctx, change, logger)
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+
+			c.applyUpdate(ctx, update, logger)
 		}
+	}
+}
 
-		newState, err := buildState(ctx, update.Metadata, c.pollingInterval, logger)
-		if err != nil {
-			logger.ErrorContext(ctx, "failed to rebuild controller state", "error", err)
+// applyUpdate rebuilds the state from a metadata update, keeping the current
+// state when the update carries an error or the build fails.
+func (c *Controller) applyUpdate(
+	ctx context.Context, update metadata.Update, logger *slog.Logger,
+) {
+	if update.Err != nil {
+		logger.ErrorContext(
+			ctx, "metadata reload failed, keeping current state", "error", update.Err,
+		)
 
-			continue
-		}
+		return
+	}
 
-		logInconsistencySummary(ctx, logger, newState.inconsistencies)
-		c.swapState(ctx, newState, logger)
+	newState, err := buildState(
+		ctx, update.Metadata, nil, c.pollingInterval, c.coordination,
+		c.sessionSettings, c.disableIntrospection, logger,
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to rebuild controller state", "error", err)
+
+		return
 	}
+
+	logInconsistencySummary(ctx, logger, newState.inconsistencies)
+	c.swapState(ctx, newState, true, logger)
 }
 
 // swapState atomically replaces the current state and shuts down the
-// old one in the background.
+// old one in the background. The per-role schema changes are diffed first
+// (see admitSchemaChanges); when refuseBreaking is set and they break a
+// protected role newState is discarded and the current state keeps serving.
+// Connectors newState reused from the old state are left open. Outside tests
+// it is only called from Run's goroutine, which selects on the current
+// state's refreshes; swapMu still makes the diff and the swap one step.
 func (c *Controller) swapState(
-	ctx context.Context, newState *controllerState, logger *slog.Logger,
+	ctx context.Context, newState *controllerState, refuseBreaking bool, logger *slog.Logger,
 ) {
-	oldState := c.state.Swap(newState)
+	c.swapMu.Lock()
+	defer c.swapMu.Unlock()
+
+	oldState := c.state.Load()
+
+	if !c.admitSchemaChanges(ctx, oldState, newState, refuseBreaking, logger) {
+		newState.shutdown(ctx)
+		newState.closeConnectors(newState.reused)
+
+		return
+	}
+
+	c.state.Store(newState)
 
 	logger.Info("metadata reloaded successfully")
 
@@ -312,7 +521,7 @@ func (c *Controller) swapState(
 		defer cancel()
 
 		oldState.shutdown(shutdownCtx)
-		oldState.closeConnectors()
+		oldState.closeConnectors(newState.reused)
 	}()
 }
 
@@ -322,7 +531,7 @@ func (c *Controller) shutdownState(ctx context.Context, logger *slog.Logger) {
 
 	state := c.state.Load()
 	state.shutdown(ctx)
-	state.closeConnectors()
+	state.closeConnectors(nil)
 }
 
 // NewFromConnectors builds a Controller around an already-constructed set of
@@ -344,8 +553,10 @@ func NewFromConnectors(
 	}
 
 	composed := composer.New(providers, &metadata.Metadata{
-		Databases:     nil,
-		RemoteSchemas: nil,
+		Databases:                  nil,
+		RemoteSchemas:              nil,
+		GraphQLSchemaIntrospection: metadata.GraphQLSchemaIntrospection{DisabledForRoles: nil},
+		APILimits:                  metadata.APILimits{Disabled: false, TimeLimit: nil},
 	}, nil).Compose(context.Background(), logger)
 
 	queryPlanner := planner.New(
@@ -359,22 +570,39 @@ func NewFromConnectors(
 		composed.ValidatedSchemas,
 		connectors,
 		composed.FieldToConnector,
-		&metadata.Metadata{Databases: nil, RemoteSchemas: nil},
+		&metadata.Metadata{
+			Databases:                  nil,
+			RemoteSchemas:              nil,
+			GraphQLSchemaIntrospection: metadata.GraphQLSchemaIntrospection{DisabledForRoles: nil},
+			APILimits:                  metadata.APILimits{Disabled: false, TimeLimit: nil},
+		},
 		queryPlanner,
 		nil,
+		composed.Entities,
+		introspection.NewPolicy(nil, false),
+		nil,
+		nil,
 		nil,
 	)
 
 	ctrl := &Controller{
-		adminSecret:     adminSecret,
-		jwtAuth:         middleware.NewNoOpJWTAuthenticator(),
-		pollingInterval: 0,
-		logger:          logger,
-		devMode:         false,
-		source:          nil,
-		hasuraProxy:     nil,
-		version:         "",
-		state:           atomic.Pointer[controllerState]{},
+		adminSecret:          adminSecret,
+		jwtAuth:              middleware.NewNoOpJWTAuthenticator(),
+		pollingInterval:      0,
+		coordination:         false,
+		logger:               logger,
+		devMode:              false,
+		disableIntrospection: false,
+		breakingChangeRoles:  nil,
+		sessionSettings:      nil,
+		schemaDiff:           atomic.Pointer[SchemaDiff]{},
+		source:               nil,
+		sqlChanges:           nil,
+		stopped:              nil,
+		hasuraProxy:          nil,
+		version:              "",
+		state:                atomic.Pointer[controllerState]{},
+		swapMu:               sync.Mutex{},
 	}
 	ctrl.state.Store(state)
 
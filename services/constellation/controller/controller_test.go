@@ -8,8 +8,10 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -132,7 +134,6 @@ func TestHandlerPost_RejectsNonJSONContentType(t *testing.T) {
 	for _, contentType := range []string{
 		"text/plain",
 		"application/graphql",
-		"multipart/form-data; boundary=x",
 		"not a media type",
 	} {
 		t.Run(contentType, func(t *testing.T) {
@@ -269,6 +270,98 @@ func TestHandlerPost_RejectsBodyLargerThanLimit(t *testing.T) {
 	}
 }
 
+// newMultipartBody encodes a GraphQL multipart request whose file parts, if
+// any, are named by their index.
+func newMultipartBody(
+	t *testing.T, operations, fileMap string, files ...string,
+) (*bytes.Buffer, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := multipart.NewWriter(&buf)
+
+	if err := w.WriteField("operations", operations); err != nil {
+		t.Fatalf("writing operations: %v", err)
+	}
+
+	if err := w.WriteField("map", fileMap); err != nil {
+		t.Fatalf("writing map: %v", err)
+	}
+
+	for i, content := range files {
+		part, err := w.CreateFormFile(strconv.Itoa(i), "file.txt")
+		if err != nil {
+			t.Fatalf("creating file part: %v", err)
+		}
+
+		if _, err := io.WriteString(part, content); err != nil {
+			t.Fatalf("writing file part: %v", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("closing multipart writer: %v", err)
+	}
+
+	return &buf, w.FormDataContentType()
+}
+
+// TestHandlerPost_AcceptsMultipartRequest checks that a multipart request is
+// resolved like its operations field, and that the body limit applies to the
+// contents of its parts, not to the multipart framing around them.
+func TestHandlerPost_AcceptsMultipartRequest(t *testing.T) {
+	t.Parallel()
+
+	const (
+		operations = `{"query":"{ users { id name } }"}`
+		fileMap    = `{}`
+	)
+
+	router := newLimitedTestRouter(t, newTestController(t), int64(len(operations)+len(fileMap)))
+
+	body, contentType := newMultipartBody(t, operations, fileMap)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", body)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Hasura-Admin-Secret", testAdminSecret)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	const wantPrefix = `{"data":{"users":[`
+	if got := w.Body.String(); !strings.HasPrefix(got, wantPrefix) {
+		t.Errorf("expected body to start with %q, got %q", wantPrefix, got)
+	}
+}
+
+func TestHandlerPost_RejectsMultipartPartLargerThanLimit(t *testing.T) {
+	t.Parallel()
+
+	const operations = `{"query":"{ users { id } }","variables":{"file":null}}`
+
+	router := newLimitedTestRouter(t, newTestController(t), int64(len(operations)))
+
+	body, contentType := newMultipartBody(
+		t, operations, `{"0":["variables.file"]}`, strings.Repeat("x", len(operations)+1),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", body)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Hasura-Admin-Secret", testAdminSecret)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
 func TestHandlerPost_HappyPath_UsesRawResponseFastPath(t *testing.T) {
 	t.Parallel()
 
@@ -451,8 +544,12 @@ func TestHandlerGet_ConnectionClosesOnMetadataReload(t *testing.T) {
 	ctrl, err := controller.New(
 		context.Background(),
 		0,
+		false,
+		nil,
 		testAdminSecret,
 		false,
+		false,
+		nil,
 		middleware.NewNoOpJWTAuthenticator(),
 		src,
 		logger,
@@ -664,8 +761,12 @@ func TestNew_InitialLoadErrorPropagated(t *testing.T) {
 	_, err := controller.New(
 		context.Background(),
 		0,
+		false,
+		nil,
 		testAdminSecret,
 		false,
+		false,
+		nil,
 		middleware.NewNoOpJWTAuthenticator(),
 		src,
 		logger,
@@ -705,8 +806,12 @@ func TestNew_BuildStateRecordsInconsistency(t *testing.T) {
 	ctrl, err := controller.New(
 		context.Background(),
 		0,
+		false,
+		nil,
 		testAdminSecret,
 		false,
+		false,
+		nil,
 		middleware.NewNoOpJWTAuthenticator(),
 		src,
 		logger,
@@ -749,8 +854,12 @@ func TestNew_HappyPath(t *testing.T) {
 	ctrl, err := controller.New(
 		context.Background(),
 		0,
+		false,
+		nil,
 		testAdminSecret,
 		false,
+		false,
+		nil,
 		middleware.NewNoOpJWTAuthenticator(),
 		src,
 		logger,
@@ -779,8 +888,12 @@ func TestRun_ExitsOnContextCancel(t *testing.T) {
 	ctrl, err := controller.New(
 		context.Background(),
 		0,
+		false,
+		nil,
 		testAdminSecret,
 		false,
+		false,
+		nil,
 		middleware.NewNoOpJWTAuthenticator(),
 		src,
 		logger,
@@ -822,8 +935,12 @@ func TestRun_ReloadErrorKeepsCurrentState(t *testing.T) {
 	ctrl, err := controller.New(
 		context.Background(),
 		0,
+		false,
+		nil,
 		testAdminSecret,
 		false,
+		false,
+		nil,
 		middleware.NewNoOpJWTAuthenticator(),
 		src,
 		logger,
@@ -890,8 +1007,12 @@ func TestRun_ReloadReplacesInconsistencies(t *testing.T) {
 	ctrl, err := controller.New(
 		context.Background(),
 		0,
+		false,
+		nil,
 		testAdminSecret,
 		false,
+		false,
+		nil,
 		middleware.NewNoOpJWTAuthenticator(),
 		src,
 		logger,
@@ -1014,6 +1135,13 @@ func (d validationTestDriver) ExecuteMultiplexedOperation(
 	)
 }
 
+func (d validationTestDriver) ExplainOperation(
+	context.Context,
+	core.SQLOperation,
+) (jsontext.Value, error) {
+	return jsontext.Value(`[{"Plan":{"Node Type":"Seq Scan"}}]`), nil
+}
+
 func (d validationTestDriver) Dialect() dialect.Dialect {
 	return dialect.NewPostgresDialect()
 }
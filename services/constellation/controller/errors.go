@@ -11,16 +11,17 @@ import (
 
 	oapimw "github.com/nhost/nhost/internal/lib/oapi/middleware"
 	"github.com/nhost/nhost/services/constellation/connector/remoteschema"
+	csql "github.com/nhost/nhost/services/constellation/connector/sql"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/arguments"
 )
 
 var (
-	errContentTypeNotJSON  = errors.New("Content-Type must be application/json")
-	errInvalidRequestBody  = errors.New("invalid request body")
-	errRequestBodyTooLarge = errors.New("request body too large")
-	errInternalServerError = errors.New("internal server error")
-	errNoSchemaForRole     = errors.New("no schema available for role")
-	errOperationNotFound   = errors.New("operation not found")
+	errUnsupportedContentType = errors.New("Content-Type must be application/json or multipart/form-data")
+	errInvalidRequestBody     = errors.New("invalid request body")
+	errRequestBodyTooLarge    = errors.New("request body too large")
+	errInternalServerError    = errors.New("internal server error")
+	errNoSchemaForRole        = errors.New("no schema available for role")
+	errOperationNotFound      = errors.New("operation not found")
 )
 
 // operationSelectionMessage returns the Hasura-matching message for an operation
@@ -145,6 +146,10 @@ func sanitizeConnectorError(
 // constructed by the arguments package with a fixed message, so it also passes
 // through verbatim via AsMap.
 //
+// A *csql.StatementTimeoutError is a statement the database cancelled for
+// exceeding the role's time limit. Its envelope is Hasura's fixed "database
+// query error" shape; the driver has already logged the statement.
+//
 // Any other error is treated as a raw connector/driver failure and routed
 // through sanitizeConnectorError so SQLSTATE codes, table/column names, and
 // offending values never reach an unauthenticated caller.
@@ -187,6 +192,10 @@ func classifyStructuredConnectorError(err error) ([]map[string]any, bool) {
 		return []map[string]any{dataErr.AsMap()}, true
 	}
 
+	if timeoutErr, ok := errors.AsType[*csql.StatementTimeoutError](err); ok {
+		return []map[string]any{timeoutErr.AsMap()}, true
+	}
+
 	return nil, false
 }
 
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	oapimw "github.com/nhost/nhost/internal/lib/oapi/middleware"
+	"github.com/nhost/nhost/services/constellation/connector/explain"
+	"github.com/nhost/nhost/services/constellation/controller/middleware"
+	"github.com/nhost/nhost/services/constellation/controller/planner/transform"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ExplainRequest is the JSON payload accepted by HandlerExplain, matching
+// Hasura's POST /v1/graphql/explain: the GraphQL request to explain and,
+// optionally, the session to build it for. User holds session variables keyed
+// by name (case-insensitive); its x-hasura-role selects the role, which
+// defaults to admin.
+type ExplainRequest struct {
+	Query GraphQLRequest    `json:"query"`
+	User  map[string]string `json:"user"`
+}
+
+// ExplainedField is one entry of the explain response: a root field, the SQL
+// generated for it, and the database's plan for that SQL.
+type ExplainedField struct {
+	Field string         `json:"field"`
+	SQL   string         `json:"sql"`
+	Plan  jsontext.Value `json:"plan"`
+}
+
+// explainError is a request-level explain failure, written in Hasura's API
+// error shape ({path, error, code}) with HTTP 400.
+type explainError struct {
+	Path    string `json:"path"`
+	Message string `json:"error"`
+	Code    string `json:"code"`
+}
+
+func (e *explainError) Error() string {
+	return e.Message
+}
+
+func newExplainError(code, msg string) *explainError {
+	return &explainError{Path: "$", Message: msg, Code: code}
+}
+
+// HandlerExplain is the Gin handler for POST /v1/graphql/explain. Only
+// requests authenticated with the admin secret may explain; others are
+// rejected with 401 before the body is read.
+func (c *Controller) HandlerExplain(g *gin.Context) {
+	session := middleware.SessionFromContext(g.Request.Context())
+	if session == nil || !session.IsAdminSecret {
+		g.JSON(
+			http.StatusUnauthorized,
+			newExplainError("access-denied", "restricted access : admin only"),
+		)
+
+		return
+	}
+
+	g.Request.Body = http.MaxBytesReader(g.Writer, g.Request.Body, DefaultMaxGraphQLRequestBodyBytes)
+
+	var req ExplainRequest
+	if err := json.UnmarshalRead(g.Request.Body, &req); err != nil {
+		err = fmt.Errorf("%w: %w", errInvalidRequestBody, err)
+		_ = g.Error(err)
+		g.JSON(http.StatusBadRequest, newExplainError("parse-failed", err.Error()))
+
+		return
+	}
+
+	fields, err := c.Explain(g.Request.Context(), req)
+	if err != nil {
+		_ = g.Error(fmt.Errorf("explaining request: %w", err))
+
+		if explainErr, ok := errors.AsType[*explainError](err); ok {
+			g.JSON(http.StatusBadRequest, explainErr)
+
+			return
+		}
+
+		g.JSON(http.StatusInternalServerError, newExplainError("unexpected", err.Error()))
+
+		return
+	}
+
+	g.JSON(http.StatusOK, fields)
+}
+
+// Explain returns, for each root field of the query in req, the SQL the
+// owning connector would run and the database's plan for it, without
+// executing anything. The query is parsed, validated and routed exactly as
+// Resolve would for the requested role, including remote-relationship
+// stripping, so the SQL is the SQL Resolve would send. Only queries can be
+// explained, and every data field must be owned by a connector implementing
+// explain.Explainer; introspection fields are skipped.
+func (c *Controller) Explain(ctx context.Context, req ExplainRequest) ([]ExplainedField, error) {
+	state := c.state.Load()
+	role, sessionVariables := explainSession(req.User)
+
+	operation, fragments, variables, err := prepareExplain(state, req.Query, role)
+	if err != nil {
+		return nil, err
+	}
+
+	dataByConnector, _, resp := groupFieldsByConnector(state, operation)
+	if resp != nil {
+		return nil, newExplainError("validation-failed", "no connector found")
+	}
+
+	plan, err := state.queryPlanner.Plan(operation, fragments, role)
+	if err != nil {
+		return nil, fmt.Errorf("planning query: %w", err)
+	}
+
+	var explained []ExplainedField
+
+	for _, connName := range slices.Sorted(maps.Keys(dataByConnector)) {
+		explainer, ok := state.connectors[connName].(explain.Explainer)
+		if !ok {
+			return nil, explainNotSupported(connName)
+		}
+
+		execOp, execFragments := buildConnectorOperation(
+			plan, connName, operation, dataByConnector[connName], fragments,
+		)
+
+		fields, err := explainer.Explain(
+			ctx, execOp, execFragments, variables, role, sessionVariables,
+		)
+		if err != nil {
+			return nil, explainConnectorError(connName, err)
+		}
+
+		for _, f := range fields {
+			explained = append(explained, ExplainedField(f))
+		}
+	}
+
+	sortExplainedFields(explained, operation)
+
+	oapimw.LoggerFromContext(ctx).DebugContext(
+		ctx, "explained query", "role", role, "fields", len(explained),
+	)
+
+	return explained, nil
+}
+
+// explainSession builds the role and session variables for an explain
+// request from its user object. Keys are lowercased like session headers.
+func explainSession(user map[string]string) (string, map[string]any) {
+	variables := make(map[string]any, len(user)+1)
+	for key, value := range user {
+		variables[strings.ToLower(key)] = value
+	}
+
+	role, _ := variables["x-hasura-role"].(string)
+	if role == "" {
+		role = "admin"
+	}
+
+	variables["x-hasura-role"] = role
+
+	return role, variables
+}
+
+// prepareExplain parses and validates the GraphQL request for role and
+// returns the normalized query operation with its pruned fragments and
+// coerced variables, mirroring Resolve and execute.
+func prepareExplain(
+	state *controllerState, req GraphQLRequest, role string,
+) (*ast.OperationDefinition, ast.FragmentDefinitionList, map[string]any, error) {
+	validatedSchema, ok := state.validatedSchemas[role]
+	if !ok {
+		return nil, nil, nil, newExplainError(
+			"validation-failed", fmt.Sprintf("%s: %s", errNoSchemaForRole, role),
+		)
+	}
+
+	query, gqlErrs := loadQuery(state.queryCache, validatedSchema, req.Query, role)
+	if gqlErrs != nil {
+		return nil, nil, nil, newExplainError("validation-failed", gqlErrs[0].Message)
+	}
+
+	operation := selectOperation(query, req.OperationName)
+	if operation == nil {
+		msg := operationSelectionMessage(req.OperationName, len(query.Operations))
+		if msg == "" {
+			msg = errOperationNotFound.Error()
+		}
+
+		return nil, nil, nil, newExplainError("validation-failed", msg)
+	}
+
+	if operation.Operation != ast.Query {
+		return nil, nil, nil, newExplainError("not-supported", "only queries can be explained")
+	}
+
+	variables, err := coerceVariables(validatedSchema, operation, req.Variables)
+	if err != nil {
+		return nil, nil, nil, newExplainError("validation-failed", err.Error())
+	}
+
+	operation = transform.BuildSubOperation(
+		operation, normalizeRootSelections(operation.SelectionSet, query.Fragments, variables),
+	)
+
+	return operation, pruneFragments(query.Fragments, variables), variables, nil
+}
+
+func explainNotSupported(connName string) *explainError {
+	return newExplainError(
+		"not-supported",
+		fmt.Sprintf("source %q does not support explain; only database sources can be explained", connName),
+	)
+}
+
+// explainConnectorError maps a connector's explain failure to the response:
+// a connector that cannot explain and a structured argument failure are
+// request errors; anything else (e.g. the database rejecting the EXPLAIN) is
+// returned as is.
+func explainConnectorError(connName string, err error) error {
+	if errors.Is(err, explain.ErrNotSupported) {
+		return explainNotSupported(connName)
+	}
+
+	if structured, ok := classifyStructuredConnectorError(err); ok && len(structured) > 0 {
+		if msg, ok := structured[0]["message"].(string); ok {
+			return newExplainError("validation-failed", msg)
+		}
+	}
+
+	return fmt.Errorf("explaining %s: %w", connName, err)
+}
+
+// sortExplainedFields orders the fields as their root fields appear in
+// operation. Connectors report namespaced fields as "namespace.field", so
+// only the part before the first dot is matched against the root selections.
+func sortExplainedFields(fields []ExplainedField, operation *ast.OperationDefinition) {
+	position := make(map[string]int, len(operation.SelectionSet))
+
+	for i, selection := range operation.SelectionSet {
+		if field, ok := selection.(*ast.Field); ok {
+			position[cmp.Or(field.Alias, field.Name)] = i
+		}
+	}
+
+	slices.SortStableFunc(fields, func(a, b ExplainedField) int {
+		rootA, _, _ := strings.Cut(a.Field, ".")
+		rootB, _, _ := strings.Cut(b.Field, ".")
+
+		return cmp.Compare(position[rootA], position[rootB])
+	})
+}
@@ -0,0 +1,144 @@
+package controller_test
+
+import (
+	"bytes"
+	json "encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/nhost/services/constellation/controller"
+	"github.com/nhost/nhost/services/constellation/controller/middleware"
+)
+
+func newExplainTestRouter(t *testing.T, ctrl *controller.Controller) *gin.Engine {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.Session(testAdminSecret, middleware.NewNoOpJWTAuthenticator()))
+	router.POST("/v1/graphql/explain", ctrl.HandlerExplain)
+
+	return router
+}
+
+func postExplain(t *testing.T, router *gin.Engine, body string, admin bool) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/graphql/explain", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+
+	if admin {
+		req.Header.Set("X-Hasura-Admin-Secret", testAdminSecret)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestHandlerExplain_ReturnsSQLAndPlanPerRootField(t *testing.T) {
+	t.Parallel()
+
+	router := newExplainTestRouter(t, newValidationSQLController(t))
+
+	w := postExplain(t, router, `{
+		"query": {"query": "{ __typename second: users { id } first: users(limit: 1) { id } }"},
+		"user": {"X-Hasura-Role": "admin"}
+	}`, true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var fields []controller.ExplainedField
+	if err := json.Unmarshal(w.Body.Bytes(), &fields); err != nil {
+		t.Fatalf("decoding response %s: %v", w.Body.String(), err)
+	}
+
+	if len(fields) != 2 {
+		t.Fatalf("expected one entry per data root field, got %+v", fields)
+	}
+
+	for i, want := range []string{"second", "first"} {
+		if fields[i].Field != want {
+			t.Errorf("fields[%d].Field = %q, want %q", i, fields[i].Field, want)
+		}
+
+		if !strings.Contains(fields[i].SQL, `"public"."users"`) {
+			t.Errorf("fields[%d].SQL = %q, want it to select from public.users", i, fields[i].SQL)
+		}
+
+		if !strings.Contains(string(fields[i].Plan), "Seq Scan") {
+			t.Errorf("fields[%d].Plan = %s, want the driver's plan", i, fields[i].Plan)
+		}
+	}
+}
+
+func TestHandlerExplain_Errors(t *testing.T) {
+	t.Parallel()
+
+	router := newExplainTestRouter(t, newValidationSQLController(t))
+
+	tests := []struct {
+		name     string
+		body     string
+		admin    bool
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "non-admin",
+			body:     `{"query":{"query":"{ users { id } }"}}`,
+			admin:    false,
+			wantCode: http.StatusUnauthorized,
+			wantBody: `"access-denied"`,
+		},
+		{
+			name:     "subscription",
+			body:     `{"query":{"query":"subscription { users { id } }"}}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"not-supported"`,
+		},
+		{
+			name:     "invalid query",
+			body:     `{"query":{"query":"{ nope }"}}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"validation-failed"`,
+		},
+		{
+			name:     "unknown role",
+			body:     `{"query":{"query":"{ users { id } }"},"user":{"x-hasura-role":"ghost"}}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"validation-failed"`,
+		},
+		{
+			name:     "malformed body",
+			body:     `{"query":`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"parse-failed"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := postExplain(t, router, tc.body, tc.admin)
+			if w.Code != tc.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tc.wantCode, w.Code, w.Body.String())
+			}
+
+			if !strings.Contains(w.Body.String(), tc.wantBody) {
+				t.Errorf("expected body to contain %s, got %s", tc.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/nhost/nhost/services/constellation/connector/federation"
+	"github.com/nhost/nhost/services/constellation/connector/schemamerge"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var (
+	errInvalidRepresentation = errors.New("invalid entity representation")
+	errUnknownEntityType     = errors.New("unknown entity type")
+)
+
+// isFederationField reports whether a root field is one of the Apollo
+// Federation root fields the composer added. Those have no owning connector,
+// so a connector that happens to expose a field of the same name (a remote
+// schema that is itself a subgraph) keeps it.
+func isFederationField(state *controllerState, op ast.Operation, name string) bool {
+	return op == ast.Query && federation.IsRootField(name) &&
+		state.fieldToConnector[schemamerge.FieldKey(op, name)] == ""
+}
+
+// resolveFederationFields resolves the _service and _entities root fields of
+// operation into results, keyed by response name, and returns the errors of
+// the fields that failed (those are set to null). Other root fields are
+// ignored.
+func (c *Controller) resolveFederationFields(
+	ctx context.Context,
+	state *controllerState,
+	schema *ast.Schema,
+	operation *ast.OperationDefinition,
+	fragments ast.FragmentDefinitionList,
+	variables map[string]any,
+	role string,
+	sessionVariables map[string]any,
+	logger *slog.Logger,
+	results map[string]any,
+) []map[string]any {
+	var errs []map[string]any
+
+	for _, selection := range operation.SelectionSet {
+		field, ok := selection.(*ast.Field)
+		if !ok || !isFederationField(state, operation.Operation, field.Name) {
+			continue
+		}
+
+		key := field.Alias
+		if key == "" {
+			key = field.Name
+		}
+
+		if field.Name == federation.ServiceField {
+			if !state.introspection.Allowed(role) {
+				// The SDL is the role's schema, so it is hidden whenever
+				// introspection is.
+				results[key] = nil
+				errs = append(errs, serviceDisabledError(schema, key))
+
+				continue
+			}
+
+			results[key] = serviceResult(schema, field.SelectionSet, fragments)
+
+			continue
+		}
+
+		entities, err := c.resolveEntities(
+			ctx, state, field, fragments, variables, role, sessionVariables, logger,
+		)
+		if err != nil {
+			results[key] = nil
+
+			if errors.Is(err, errInvalidRepresentation) || errors.Is(err, errUnknownEntityType) {
+				errs = append(errs, map[string]any{"message": err.Error(), "path": []any{key}})
+			} else {
+				errs = append(errs, c.classifyConnectorError(ctx, logger, err)...)
+			}
+
+			continue
+		}
+
+		results[key] = entities
+	}
+
+	return errs
+}
+
+// serviceDisabledError reports _service as missing from the query root, the
+// way introspection.Policy reports __schema for a role without introspection.
+func serviceDisabledError(schema *ast.Schema, key string) map[string]any {
+	return map[string]any{
+		"message": fmt.Sprintf(
+			"field '%s' not found in type: '%s'", federation.ServiceField, schema.Query.Name,
+		),
+		"extensions": map[string]any{
+			"code": "validation-failed",
+			"path": "$.selectionSet." + federation.ServiceField,
+		},
+		"path": []any{key},
+	}
+}
+
+// serviceResult builds the _Service object for the selection: sdl is the
+// role's subgraph SDL.
+func serviceResult(
+	schema *ast.Schema, selections ast.SelectionSet, fragments ast.FragmentDefinitionList,
+) map[string]any {
+	result := make(map[string]any)
+
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			key := s.Alias
+			if key == "" {
+				key = s.Name
+			}
+
+			switch s.Name {
+			case "sdl":
+				result[key] = federation.SDL(schema)
+			case "__typename":
+				result[key] = "_Service"
+			}
+		case *ast.InlineFragment:
+			maps.Copy(result, serviceResult(schema, s.SelectionSet, fragments))
+		case *ast.FragmentSpread:
+			if frag := fragments.ForName(s.Name); frag != nil {
+				maps.Copy(result, serviceResult(schema, frag.SelectionSet, fragments))
+			}
+		}
+	}
+
+	return result
+}
+
+// resolveEntities resolves the representations argument of an _entities
+// field. Representations are grouped by __typename and each group is fetched
+// in one batch from the connector owning the type, as the requesting role.
+func (c *Controller) resolveEntities(
+	ctx context.Context,
+	state *controllerState,
+	field *ast.Field,
+	fragments ast.FragmentDefinitionList,
+	variables map[string]any,
+	role string,
+	sessionVariables map[string]any,
+	logger *slog.Logger,
+) ([]any, error) {
+	representations, byType, err := groupRepresentations(
+		field.ArgumentMap(variables)[federation.RepresentationsArgument],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]any, len(representations))
+
+	for _, typeName := range slices.Sorted(maps.Keys(byType)) {
+		target, ok := state.entities[role][typeName]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", errUnknownEntityType, typeName)
+		}
+
+		indexes := byType[typeName]
+
+		group := make([]map[string]any, len(indexes))
+		for i, idx := range indexes {
+			group[i] = representations[idx]
+		}
+
+		resolved, err := state.remoteRelationshipResolver.ResolveEntities(
+			ctx, target, group,
+			entitySelection(field.SelectionSet, fragments, typeName),
+			fragments, variables, role, sessionVariables, logger,
+		)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		for i, idx := range indexes {
+			entities[idx] = resolved[i]
+		}
+	}
+
+	return entities, nil
+}
+
+// groupRepresentations validates the representations argument and returns
+// the representations together with their indexes grouped by __typename.
+func groupRepresentations(arg any) ([]map[string]any, map[string][]int, error) {
+	list, ok := arg.([]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: expected a list", errInvalidRepresentation)
+	}
+
+	representations := make([]map[string]any, len(list))
+	byType := make(map[string][]int)
+
+	for i, item := range list {
+		representation, ok := item.(map[string]any)
+		if !ok {
+			return nil, nil, fmt.Errorf(
+				"%w: representation %d is not an object", errInvalidRepresentation, i,
+			)
+		}
+
+		typeName, ok := representation[federation.TypenameKey].(string)
+		if !ok || typeName == "" {
+			return nil, nil, fmt.Errorf(
+				"%w: representation %d has no %s", errInvalidRepresentation, i, federation.TypenameKey,
+			)
+		}
+
+		representations[i] = representation
+		byType[typeName] = append(byType[typeName], i)
+	}
+
+	return representations, byType, nil
+}
+
+// entitySelection returns the part of an _entities selection set that applies
+// to typeName: fields selected on the union itself (__typename), inline
+// fragments and fragment spreads on typeName, and the contents of fragments on
+// the _Entity union, expanded recursively.
+func entitySelection(
+	selections ast.SelectionSet, fragments ast.FragmentDefinitionList, typeName string,
+) ast.SelectionSet {
+	out := make(ast.SelectionSet, 0, len(selections))
+
+	for _, selection := range selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			out = append(out, s)
+		case *ast.InlineFragment:
+			switch s.TypeCondition {
+			case typeName:
+				out = append(out, s)
+			case "", federation.EntityUnion:
+				out = append(out, entitySelection(s.SelectionSet, fragments, typeName)...)
+			}
+		case *ast.FragmentSpread:
+			frag := fragments.ForName(s.Name)
+			if frag == nil {
+				continue
+			}
+
+			switch frag.TypeCondition {
+			case typeName:
+				out = append(out, s)
+			case federation.EntityUnion:
+				out = append(out, entitySelection(frag.SelectionSet, fragments, typeName)...)
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,147 @@
+package controller_test
+
+import (
+	"encoding/json/jsontext"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/nhost/services/constellation/connector"
+	"github.com/nhost/nhost/services/constellation/connector/federation"
+	"github.com/nhost/nhost/services/constellation/connector/memconnector"
+	"github.com/nhost/nhost/services/constellation/controller"
+	"github.com/nhost/nhost/services/constellation/graph"
+)
+
+// federatedConnector exposes the wrapped connector's User type as a
+// federation entity keyed by id.
+type federatedConnector struct {
+	connector.Connector
+}
+
+func (federatedConnector) FederationEntities(string) []federation.Entity {
+	return []federation.Entity{
+		{TypeName: "User", SelectField: "users", KeyFields: []string{"id"}},
+	}
+}
+
+func newFederationController(t *testing.T) *controller.Controller {
+	t.Helper()
+
+	conn, err := memconnector.New(
+		[]*graph.ObjectType{
+			memconnector.Object("User", memconnector.ID("id"), memconnector.String("name")),
+		},
+		[]memconnector.QueryDef{
+			memconnector.Query(
+				"users",
+				graph.NewNonNullListType(graph.NewNonNullType("User")),
+				jsontext.Value(`[{"__typename":"User","id":"1","name":"Alice"},{"__typename":"User","id":"2","name":"Bob"}]`),
+			),
+		},
+	)
+	if err != nil {
+		t.Fatalf("memconnector.New: %v", err)
+	}
+
+	ctrl, err := controller.NewFromConnectors(
+		testAdminSecret,
+		map[string]connector.Connector{"db": federatedConnector{Connector: conn}},
+		nil,
+		slog.New(slog.DiscardHandler),
+	)
+	if err != nil {
+		t.Fatalf("NewFromConnectors: %v", err)
+	}
+
+	return ctrl
+}
+
+func TestResolve_FederationEntities(t *testing.T) {
+	t.Parallel()
+
+	ctrl := newFederationController(t)
+
+	resp, err := ctrl.Resolve(adminSessionContext(t), controller.GraphQLRequest{
+		OperationName: "",
+		Query: `query ($representations: [_Any!]!) {
+			_entities(representations: $representations) {
+				__typename
+				... on User { name }
+			}
+		}`,
+		Variables: map[string]any{
+			"representations": []any{
+				map[string]any{"__typename": "User", "id": "2"},
+				map[string]any{"__typename": "User", "id": "3"},
+				map[string]any{"__typename": "User", "id": "1"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Errors != nil {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+
+	want := map[string]any{
+		"_entities": []any{
+			map[string]any{"__typename": "User", "name": "Bob"},
+			nil,
+			map[string]any{"__typename": "User", "name": "Alice"},
+		},
+	}
+	if diff := cmp.Diff(want, resp.Data); diff != "" {
+		t.Errorf("data mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolve_FederationUnknownEntityType(t *testing.T) {
+	t.Parallel()
+
+	ctrl := newFederationController(t)
+
+	resp, err := ctrl.Resolve(adminSessionContext(t), controller.GraphQLRequest{
+		OperationName: "",
+		Query:         `{ _entities(representations: [{__typename: "Post", id: "1"}]) { __typename } }`,
+		Variables:     nil,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	errs, ok := resp.Errors.([]map[string]any)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", resp.Errors)
+	}
+
+	if msg, _ := errs[0]["message"].(string); !strings.Contains(msg, "unknown entity type") {
+		t.Errorf("error message = %q, want unknown entity type", msg)
+	}
+}
+
+func TestResolve_FederationService(t *testing.T) {
+	t.Parallel()
+
+	ctrl := newFederationController(t)
+
+	resp, err := ctrl.Resolve(adminSessionContext(t), controller.GraphQLRequest{
+		OperationName: "",
+		Query:         `{ _service { sdl } }`,
+		Variables:     nil,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, _ := resp.Data.(map[string]any)
+	service, _ := data["_service"].(map[string]any)
+	sdl, _ := service["sdl"].(string)
+
+	if !strings.Contains(sdl, `type User @key(fields: "id")`) {
+		t.Errorf("sdl has no keyed User type:\n%s", sdl)
+	}
+}
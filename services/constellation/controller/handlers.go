@@ -11,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	oapimw "github.com/nhost/nhost/internal/lib/oapi/middleware"
 	"github.com/nhost/nhost/services/constellation/controller/websocket"
+	"github.com/nhost/nhost/services/constellation/internal/upload"
 )
 
 const bytesPerMiB int64 = 1024 * 1024
@@ -29,16 +30,17 @@ const defaultSendBufferSize = 50
 var errMetadataReloaded = errors.New("metadata reloaded")
 
 // HandlerPost is the Gin handler for POST /graphql. It expects a JSON-encoded
-// GraphQLRequest, dispatches it through Resolve, and writes the response —
-// taking the raw-bytes fast path when the connector returned pre-built JSON.
+// GraphQLRequest or a GraphQL multipart request carrying file uploads,
+// dispatches it through Resolve, and writes the response — taking the
+// raw-bytes fast path when the connector returned pre-built JSON.
 func (c *Controller) HandlerPost(g *gin.Context) {
 	c.handlePost(g, DefaultMaxGraphQLRequestBodyBytes)
 }
 
 // HandlerPostWithMaxBodyBytes returns a Gin handler for POST /graphql that
-// rejects JSON request bodies larger than maxBodyBytes. Non-positive values use
-// DefaultMaxGraphQLRequestBodyBytes so direct callers cannot accidentally create
-// an unbounded handler.
+// rejects JSON request bodies larger than maxBodyBytes, and multipart requests
+// whose parts add up to more. Non-positive values use DefaultMaxGraphQLRequestBodyBytes so
+// direct callers cannot accidentally create an unbounded handler.
 func (c *Controller) HandlerPostWithMaxBodyBytes(maxBodyBytes int64) gin.HandlerFunc {
 	return func(g *gin.Context) {
 		c.handlePost(g, maxBodyBytes)
@@ -47,44 +49,21 @@ func (c *Controller) HandlerPostWithMaxBodyBytes(maxBodyBytes int64) gin.Handler
 
 func (c *Controller) handlePost(g *gin.Context, maxBodyBytes int64) {
 	maxBodyBytes = normalizeMaxGraphQLRequestBodyBytes(maxBodyBytes)
-	if g.Request.ContentLength > maxBodyBytes {
-		err := fmt.Errorf("%w: limit is %d bytes", errRequestBodyTooLarge, maxBodyBytes)
-		_ = g.Error(err)
-		g.JSON(http.StatusRequestEntityTooLarge, errorResponse(errRequestBodyTooLarge.Error()))
-
-		return
-	}
-
-	g.Request.Body = http.MaxBytesReader(g.Writer, g.Request.Body, maxBodyBytes)
-	// A missing Content-Type is treated as application/json. Otherwise the
-	// media type is parsed so that parameters such as "; charset=utf-8" and
-	// differing case are tolerated, matching what most GraphQL clients send.
-	if ct := g.Request.Header.Get("Content-Type"); ct != "" {
-		mediaType, _, err := mime.ParseMediaType(ct)
-		if err != nil || mediaType != "application/json" {
-			_ = g.Error(errContentTypeNotJSON)
-			g.JSON(http.StatusBadRequest, errorResponse(errContentTypeNotJSON.Error()))
 
-			return
-		}
-	}
+	reqBody, uploads, err := readGraphQLRequest(g, maxBodyBytes)
+	if err != nil {
+		_ = g.Error(err)
 
-	var reqBody GraphQLRequest
-	if err := json.UnmarshalRead(g.Request.Body, &reqBody); err != nil {
-		if requestBodyExceedsLimit(err) {
-			err = fmt.Errorf("%w: limit is %d bytes", errRequestBodyTooLarge, maxBodyBytes)
-			_ = g.Error(err)
+		if errors.Is(err, errRequestBodyTooLarge) {
 			g.JSON(http.StatusRequestEntityTooLarge, errorResponse(errRequestBodyTooLarge.Error()))
-
 			return
 		}
 
-		err = fmt.Errorf("%w: %w", errInvalidRequestBody, err)
-		_ = g.Error(err)
 		g.JSON(http.StatusBadRequest, errorResponse(err.Error()))
 
 		return
 	}
+	defer removeUploads(uploads)
 
 	resp, err := c.Resolve(
 		g.Request.Context(),
@@ -107,6 +86,61 @@ func (c *Controller) handlePost(g *gin.Context, maxBodyBytes int64) {
 	g.JSON(http.StatusOK, resp)
 }
 
+// readGraphQLRequest decodes the request body by its Content-Type: a JSON
+// GraphQLRequest capped at maxBodyBytes, or a multipart file upload request
+// whose parts together are capped at maxBodyBytes. A missing Content-Type is
+// treated as application/json. Otherwise the media type is parsed so that
+// parameters such as "; charset=utf-8" and differing case are tolerated,
+// matching what most GraphQL clients send.
+func readGraphQLRequest(
+	g *gin.Context, maxBodyBytes int64,
+) (GraphQLRequest, []upload.File, error) {
+	mediaType := "application/json"
+
+	if ct := g.Request.Header.Get("Content-Type"); ct != "" {
+		parsed, _, err := mime.ParseMediaType(ct)
+		if err != nil {
+			return GraphQLRequest{}, nil, errUnsupportedContentType
+		}
+
+		mediaType = parsed
+	}
+
+	switch mediaType {
+	case "application/json":
+		reqBody, err := readJSONRequest(g, maxBodyBytes)
+		return reqBody, nil, err
+	case "multipart/form-data":
+		mr, err := g.Request.MultipartReader()
+		if err != nil {
+			return GraphQLRequest{}, nil, fmt.Errorf("%w: %w", errInvalidRequestBody, err)
+		}
+
+		return readMultipartRequest(mr, maxBodyBytes)
+	default:
+		return GraphQLRequest{}, nil, errUnsupportedContentType
+	}
+}
+
+func readJSONRequest(g *gin.Context, maxBodyBytes int64) (GraphQLRequest, error) {
+	if g.Request.ContentLength > maxBodyBytes {
+		return GraphQLRequest{}, fmt.Errorf("%w: limit is %d bytes", errRequestBodyTooLarge, maxBodyBytes)
+	}
+
+	g.Request.Body = http.MaxBytesReader(g.Writer, g.Request.Body, maxBodyBytes)
+
+	var reqBody GraphQLRequest
+	if err := json.UnmarshalRead(g.Request.Body, &reqBody); err != nil {
+		if requestBodyExceedsLimit(err) {
+			return GraphQLRequest{}, fmt.Errorf("%w: limit is %d bytes", errRequestBodyTooLarge, maxBodyBytes)
+		}
+
+		return GraphQLRequest{}, fmt.Errorf("%w: %w", errInvalidRequestBody, err)
+	}
+
+	return reqBody, nil
+}
+
 func normalizeMaxGraphQLRequestBodyBytes(maxBodyBytes int64) int64 {
 	if maxBodyBytes <= 0 {
 		return DefaultMaxGraphQLRequestBodyBytes
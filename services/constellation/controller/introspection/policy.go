@@ -0,0 +1,96 @@
+package introspection
+
+import (
+	"fmt"
+
+	"github.com/nhost/nhost/services/constellation/metadata"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Policy decides which roles may run __schema / __type queries. The zero
+// value allows introspection for every role.
+type Policy struct {
+	disabledForRoles   map[string]struct{}
+	disableForNonAdmin bool
+}
+
+// NewPolicy builds a Policy from the roles listed in the metadata's
+// graphql_schema_introspection.disabled_for_roles and the server-wide switch
+// that disables introspection for every role other than admin.
+func NewPolicy(disabledForRoles []string, disableForNonAdmin bool) Policy {
+	var roles map[string]struct{}
+	if len(disabledForRoles) > 0 {
+		roles = make(map[string]struct{}, len(disabledForRoles))
+		for _, role := range disabledForRoles {
+			roles[role] = struct{}{}
+		}
+	}
+
+	return Policy{
+		disabledForRoles:   roles,
+		disableForNonAdmin: disableForNonAdmin,
+	}
+}
+
+// Allowed reports whether role may run introspection queries. Roles listed in
+// disabled_for_roles are always rejected, admin included; the server-wide
+// switch only applies to non-admin roles.
+func (p Policy) Allowed(role string) bool {
+	if _, disabled := p.disabledForRoles[role]; disabled {
+		return false
+	}
+
+	return !p.disableForNonAdmin || role == metadata.RoleAdmin
+}
+
+// Check returns one validation error per __schema / __type root field of
+// operation when introspection is disabled for role, and nil otherwise.
+// __typename stays available. The errors mirror what Hasura reports for a
+// role whose schema hides the introspection fields: the field is "not found"
+// on the operation root type.
+//
+// schema is the role's validated schema, used to name the root type;
+// fragments is consulted to resolve named fragment spreads at the root.
+func (p Policy) Check(
+	schema *ast.Schema,
+	operation *ast.OperationDefinition,
+	fragments ast.FragmentDefinitionList,
+	role string,
+) gqlerror.List {
+	if p.Allowed(role) {
+		return nil
+	}
+
+	query := &ast.QueryDocument{
+		Operations: nil,
+		Fragments:  fragments,
+		Comment:    nil,
+		Position:   nil,
+	}
+
+	var errs gqlerror.List
+
+	forEachSelectedField(operation.SelectionSet, query, func(field *ast.Field) {
+		if field.Name != "__schema" && field.Name != "__type" {
+			return
+		}
+
+		errs = append(errs, &gqlerror.Error{
+			Err: nil,
+			Message: fmt.Sprintf(
+				"field '%s' not found in type: '%s'",
+				field.Name, rootTypeName(schema, operation.Operation),
+			),
+			Path:      nil,
+			Locations: nil,
+			Extensions: map[string]any{
+				"code": "validation-failed",
+				"path": "$.selectionSet." + field.Name,
+			},
+			Rule: "",
+		})
+	})
+
+	return errs
+}
@@ -0,0 +1,65 @@
+package introspection_test
+
+import (
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/controller/introspection"
+)
+
+func TestPolicyAllowed(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		policy introspection.Policy
+		role   string
+		want   bool
+	}{
+		{
+			name:   "zero value allows everyone",
+			policy: introspection.Policy{},
+			role:   "user",
+			want:   true,
+		},
+		{
+			name:   "listed role is rejected",
+			policy: introspection.NewPolicy([]string{"anonymous"}, false),
+			role:   "anonymous",
+			want:   false,
+		},
+		{
+			name:   "unlisted role is allowed",
+			policy: introspection.NewPolicy([]string{"anonymous"}, false),
+			role:   "user",
+			want:   true,
+		},
+		{
+			name:   "listed admin is rejected",
+			policy: introspection.NewPolicy([]string{"admin"}, false),
+			role:   "admin",
+			want:   false,
+		},
+		{
+			name:   "server switch rejects non-admin",
+			policy: introspection.NewPolicy(nil, true),
+			role:   "user",
+			want:   false,
+		},
+		{
+			name:   "server switch keeps admin",
+			policy: introspection.NewPolicy(nil, true),
+			role:   "admin",
+			want:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.policy.Allowed(tc.role); got != tc.want {
+				t.Errorf("Allowed(%q) = %v, want %v", tc.role, got, tc.want)
+			}
+		})
+	}
+}
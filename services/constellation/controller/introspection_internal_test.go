@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/nhost/services/constellation/controller/introspection"
+	"github.com/nhost/nhost/services/constellation/controller/middleware"
+	"github.com/nhost/nhost/services/constellation/controller/websocket"
+	"github.com/nhost/nhost/services/constellation/internal/lib/syncmap"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+const introspectionTestAdminSecret = "introspection-test-secret" //nolint:gosec
+
+// introspectionTestController returns a Controller whose state serves the
+// websocket test schema to the admin role under policy.
+func introspectionTestController(
+	t *testing.T, policy introspection.Policy,
+) *Controller {
+	t.Helper()
+
+	c := &Controller{}
+	c.state.Store(newControllerState(
+		wsTestSchemas(t), nil, nil, &metadata.Metadata{}, nil, nil, nil, policy, nil, nil, nil,
+	))
+
+	return c
+}
+
+// adminContext runs the session middleware over an admin-secret request and
+// returns the request context it produced.
+func adminContext(t *testing.T) context.Context {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	var captured context.Context
+
+	router := gin.New()
+	router.Use(middleware.Session(
+		introspectionTestAdminSecret, middleware.NewNoOpJWTAuthenticator(),
+	))
+	router.GET("/probe", func(ctx *gin.Context) {
+		captured = ctx.Request.Context()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/probe", nil)
+	req.Header.Set("X-Hasura-Admin-Secret", introspectionTestAdminSecret)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if captured == nil {
+		t.Fatal("session middleware did not reach the probe handler")
+	}
+
+	return captured
+}
+
+func TestResolveIntrospectionPolicy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		policy      introspection.Policy
+		query       string
+		wantMessage string
+		wantPath    string
+	}{
+		{
+			name:        "allowed role",
+			policy:      introspection.NewPolicy(nil, true),
+			query:       `{ __schema { queryType { name } } }`,
+			wantMessage: "",
+			wantPath:    "",
+		},
+		{
+			name:        "__schema disabled",
+			policy:      introspection.NewPolicy([]string{"admin"}, false),
+			query:       `{ __schema { queryType { name } } }`,
+			wantMessage: "field '__schema' not found in type: 'query_root'",
+			wantPath:    "$.selectionSet.__schema",
+		},
+		{
+			name:        "__type behind a fragment disabled",
+			policy:      introspection.NewPolicy([]string{"admin"}, false),
+			query:       `query { ...F } fragment F on query_root { __type(name: "User") { name } }`,
+			wantMessage: "field '__type' not found in type: 'query_root'",
+			wantPath:    "$.selectionSet.__type",
+		},
+		{
+			name:        "__typename stays available",
+			policy:      introspection.NewPolicy([]string{"admin"}, false),
+			query:       `{ __typename }`,
+			wantMessage: "",
+			wantPath:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := introspectionTestController(t, tc.policy)
+
+			resp, err := c.Resolve(adminContext(t), GraphQLRequest{
+				OperationName: "",
+				Query:         tc.query,
+				Variables:     nil,
+			})
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+
+			if tc.wantMessage == "" {
+				if resp.Errors != nil {
+					t.Fatalf("unexpected errors: %+v", resp.Errors)
+				}
+
+				return
+			}
+
+			if resp.Data != nil {
+				t.Fatalf("rejected introspection must return no data, got %+v", resp.Data)
+			}
+
+			errs, ok := resp.Errors.([]map[string]any)
+			if !ok || len(errs) != 1 {
+				t.Fatalf("expected exactly one error, got %+v", resp.Errors)
+			}
+
+			if errs[0]["message"] != tc.wantMessage {
+				t.Errorf("message: got %v, want %q", errs[0]["message"], tc.wantMessage)
+			}
+
+			ext, _ := errs[0]["extensions"].(map[string]any)
+			if ext["code"] != "validation-failed" || ext["path"] != tc.wantPath {
+				t.Errorf("extensions: got %v, want validation-failed at %s", ext, tc.wantPath)
+			}
+		})
+	}
+}
+
+func TestWebSocketHandlerOnSubscribeRejectsDisabledIntrospection(t *testing.T) {
+	t.Parallel()
+
+	sendCh := make(chan *websocket.Message, 1)
+
+	state := newControllerState(
+		wsTestSchemas(t), nil, nil, &metadata.Metadata{}, nil, nil, nil,
+		introspection.NewPolicy([]string{"admin"}, false), nil, nil, nil,
+	)
+
+	h := &webSocketHandler{
+		state:           state,
+		adminSecret:     "",
+		jwtAuth:         nil,
+		pollingInterval: defaultPollingInterval,
+		devMode:         false,
+		logger:          slog.New(slog.DiscardHandler),
+		session:         &middleware.SessionVariables{Role: "admin", Variables: nil},
+		sendCh:          sendCh,
+		subs:            syncmap.New[string, *subscriptionState](),
+	}
+
+	h.OnSubscribe(context.Background(), "sub-1", websocket.SubscribePayload{
+		OperationName: "",
+		Query:         `query { __schema { queryType { name } } }`,
+		Variables:     nil,
+		Extensions:    nil,
+	})
+
+	errs := firstErrorPayload(t, sendCh)
+
+	want := "field '__schema' not found in type: 'query_root'"
+	if got, _ := errs[0]["message"].(string); got != want {
+		t.Fatalf("message: got %q, want %q", got, want)
+	}
+
+	if _, exists := h.subs.Load("sub-1"); exists {
+		t.Fatal("subscription must not be registered when introspection is disabled")
+	}
+}
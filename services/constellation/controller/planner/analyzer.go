@@ -228,7 +228,7 @@ func (a *analyzer) buildRemoteQueryPlan(
 		outputField = subField.Alias
 	}
 
-	// Schema resolver is required for db→rs relationships (RemoteFieldPath set);
+	// Schema resolver is required for db→rs and rs→rs relationships (RemoteFieldPath set);
 	// everything else (db→db, rs→db) uses the database resolver.
 	resolverType := ResolverKindDatabase
 	if len(rel.RemoteFieldPath) > 0 {
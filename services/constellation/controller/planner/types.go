@@ -47,7 +47,7 @@ const (
 	ResolverKindDatabase ResolverKind = "database"
 
 	// ResolverKindSchema resolves the remote side of a relationship by issuing
-	// a GraphQL query against a remote schema connector (db→rs).
+	// a GraphQL query against a remote schema connector (db→rs, rs→rs).
 	ResolverKindSchema ResolverKind = "schema"
 )
 
@@ -146,7 +146,7 @@ type RemoteQueryPlan struct {
 
 	// ResolverType indicates which resolver strategy to use.
 	// For database relationships (db→db, rs→db): use ResolverKindDatabase.
-	// For schema relationships (db→rs): use ResolverKindSchema.
+	// For schema relationships (db→rs, rs→rs): use ResolverKindSchema.
 	ResolverType ResolverKind
 
 	// LHSFields are the source fields used for joining (for schema relationships).
@@ -159,7 +159,7 @@ type RemoteQueryPlan struct {
 }
 
 // RemoteFieldPathEntry describes a step in a remote schema field path.
-// Used for db→rs relationships where we need to navigate through the remote schema.
+// Used for db→rs and rs→rs relationships where we need to navigate through the remote schema.
 type RemoteFieldPathEntry struct {
 	// FieldName is the remote field name at this step.
 	FieldName string
@@ -201,11 +201,11 @@ type RelationshipMetadata struct {
 	// IsRemote indicates this crosses connector boundaries.
 	IsRemote bool
 
-	// LHSFields are the source fields used for joining (for db→rs relationships).
+	// LHSFields are the source fields used for joining (for db→rs and rs→rs).
 	// These are the field names on the source type that provide join values.
 	LHSFields []string
 
-	// RemoteFieldPath is the path through the remote schema (for db→rs relationships).
+	// RemoteFieldPath is the path through the remote schema (for db→rs and rs→rs).
 	// Each entry specifies a field name and its arguments.
 	RemoteFieldPath []RemoteFieldPathEntry
 }
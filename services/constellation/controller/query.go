@@ -0,0 +1,448 @@
+package controller
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/nhost/services/constellation/connector/runsql"
+	"github.com/nhost/nhost/services/constellation/controller/middleware"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+const (
+	queryTypeRunSQL = "run_sql"
+	queryTypeBulk   = "bulk"
+
+	defaultQuerySource = "default"
+
+	resultTypeTuplesOk  = "TuplesOk"
+	resultTypeCommandOk = "CommandOk"
+)
+
+// QueryRequest is the JSON payload accepted by POST /v2/query, matching
+// Hasura's POST /v2/query envelope. Only run_sql, and bulk requests made of
+// run_sql queries, are served natively.
+type QueryRequest struct {
+	Type string         `json:"type"`
+	Args jsontext.Value `json:"args"`
+}
+
+// RunSQLArgs are the arguments of a run_sql query. Source defaults to
+// "default"; CheckMetadataConsistency defaults to true unless ReadOnly is set.
+type RunSQLArgs struct {
+	Source                   string `json:"source"`
+	SQL                      string `json:"sql"`
+	Cascade                  bool   `json:"cascade"`
+	ReadOnly                 bool   `json:"read_only"`
+	CheckMetadataConsistency *bool  `json:"check_metadata_consistency"`
+}
+
+// RunSQLResult is the response to a run_sql query. Result holds the header
+// row followed by the tuples of the last statement for TuplesOk, and is null
+// for CommandOk.
+type RunSQLResult struct {
+	ResultType string     `json:"result_type"`
+	Result     [][]string `json:"result"`
+}
+
+// queryError is a request-level /v2/query failure, written in Hasura's API
+// error shape ({path, error, code, internal}) with HTTP 400.
+type queryError struct {
+	Path     string `json:"path"`
+	Message  string `json:"error"`
+	Code     string `json:"code"`
+	Internal any    `json:"internal,omitempty"`
+}
+
+func (e *queryError) Error() string {
+	return e.Message
+}
+
+func newQueryError(path, code, msg string) *queryError {
+	return &queryError{Path: path, Message: msg, Code: code, Internal: nil}
+}
+
+// HandlerQueryWithMaxBodyBytes returns the Gin handler for POST /v2/query,
+// which rejects bodies larger than maxBodyBytes (0 disables the cap). Only
+// requests authenticated with the admin secret may run queries; others are
+// rejected with 401 before the body is read.
+func (c *Controller) HandlerQueryWithMaxBodyBytes(maxBodyBytes int64) gin.HandlerFunc {
+	return func(g *gin.Context) {
+		c.handleQuery(g, maxBodyBytes)
+	}
+}
+
+func (c *Controller) handleQuery(g *gin.Context, maxBodyBytes int64) {
+	session := middleware.SessionFromContext(g.Request.Context())
+	if session == nil || !session.IsAdminSecret {
+		g.JSON(
+			http.StatusUnauthorized,
+			newQueryError("$", "access-denied", "restricted access : admin only"),
+		)
+
+		return
+	}
+
+	if maxBodyBytes > 0 {
+		g.Request.Body = http.MaxBytesReader(g.Writer, g.Request.Body, maxBodyBytes)
+	}
+
+	var req QueryRequest
+	if err := json.UnmarshalRead(g.Request.Body, &req); err != nil {
+		err = fmt.Errorf("%w: %w", errInvalidRequestBody, err)
+		_ = g.Error(err)
+		g.JSON(http.StatusBadRequest, newQueryError("$", "parse-failed", err.Error()))
+
+		return
+	}
+
+	result, err := c.RunQuery(g.Request.Context(), req)
+	if err != nil {
+		_ = g.Error(fmt.Errorf("running query: %w", err))
+
+		if qErr, ok := errors.AsType[*queryError](err); ok {
+			g.JSON(http.StatusBadRequest, qErr)
+
+			return
+		}
+
+		g.JSON(http.StatusInternalServerError, newQueryError("$", "unexpected", err.Error()))
+
+		return
+	}
+
+	g.JSON(http.StatusOK, result)
+}
+
+// RunQuery serves a /v2/query request: a run_sql query returns a
+// RunSQLResult, a bulk of run_sql queries a []RunSQLResult. Every query of a
+// bulk is decoded and validated before any of them runs, and they then run in
+// order in one transaction, so a bulk commits as a whole or not at all; its
+// queries must therefore share their source and read_only setting. When the
+// SQL changes the source's tracked tables the state is rebuilt from the
+// current metadata, re-introspecting the source, before RunQuery returns.
+func (c *Controller) RunQuery(ctx context.Context, req QueryRequest) (any, error) {
+	switch req.Type {
+	case queryTypeRunSQL:
+		results, err := c.runSQLQueries(ctx, []QueryRequest{req}, "$")
+		if err != nil {
+			return nil, err
+		}
+
+		return results[0], nil
+	case queryTypeBulk:
+		var queries []QueryRequest
+		if err := json.Unmarshal(req.Args, &queries); err != nil {
+			return nil, newQueryError("$.args", "parse-failed", err.Error())
+		}
+
+		for i, q := range queries {
+			if q.Type != queryTypeRunSQL {
+				return nil, unsupportedQueryType(fmt.Sprintf("$.args[%d]", i), q.Type)
+			}
+		}
+
+		return c.runSQLQueries(ctx, queries, "$.args")
+	default:
+		return nil, unsupportedQueryType("$", req.Type)
+	}
+}
+
+func unsupportedQueryType(path, queryType string) *queryError {
+	return newQueryError(
+		path, "not-supported",
+		fmt.Sprintf("query type %q is not supported; only run_sql and bulk are served natively", queryType),
+	)
+}
+
+// sqlRun is a validated list of run_sql queries, ready to run as one
+// transaction on their source.
+type sqlRun struct {
+	source  string
+	runner  runsql.Runner
+	scripts []runsql.Script
+	opts    runsql.Options
+	// paths holds the args path of each script, for its errors.
+	paths []string
+}
+
+// runSQLQueries runs the run_sql queries against the state current when it
+// starts, as one run on their source. If they changed the source's tracked
+// tables it is re-introspected; other sources keep their connectors.
+func (c *Controller) runSQLQueries(
+	ctx context.Context, queries []QueryRequest, path string,
+) ([]RunSQLResult, error) {
+	if len(queries) == 0 {
+		return []RunSQLResult{}, nil
+	}
+
+	run, err := prepareSQLRun(c.state.Load(), queries, path)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := run.runner.RunSQL(ctx, run.scripts, run.opts)
+	if err != nil {
+		return nil, runSQLError(run, err)
+	}
+
+	out := make([]RunSQLResult, 0, len(results))
+	changed := false
+
+	for _, result := range results {
+		changed = changed || result.Changed
+		out = append(out, runSQLResult(result))
+	}
+
+	if changed {
+		c.rebuildForSQLChange(ctx, []string{run.source})
+	}
+
+	return out, nil
+}
+
+// prepareSQLRun decodes and validates every query before anything runs: the
+// source must exist and support run_sql, and all queries must share it and
+// their read_only setting, as they run in one transaction. The tracked tables
+// are compared around each query unless it is read-only or opts out of the
+// consistency check.
+func prepareSQLRun(state *controllerState, queries []QueryRequest, path string) (*sqlRun, error) {
+	run := &sqlRun{
+		source:  "",
+		runner:  nil,
+		scripts: make([]runsql.Script, 0, len(queries)),
+		opts:    runsql.Options{ReadOnly: false},
+		paths:   make([]string, 0, len(queries)),
+	}
+
+	for i, q := range queries {
+		argsPath := path + ".args"
+		if path != "$" {
+			argsPath = fmt.Sprintf("%s[%d].args", path, i)
+		}
+
+		var args RunSQLArgs
+		if err := json.Unmarshal(q.Args, &args); err != nil {
+			return nil, newQueryError(argsPath, "parse-failed", err.Error())
+		}
+
+		if args.Source == "" {
+			args.Source = defaultQuerySource
+		}
+
+		if err := run.admit(state, args, i, argsPath); err != nil {
+			return nil, err
+		}
+
+		script := runsql.Script{SQL: args.SQL, Cascade: args.Cascade, Tracked: nil}
+		if !args.ReadOnly && (args.CheckMetadataConsistency == nil || *args.CheckMetadataConsistency) {
+			script.Tracked = trackedTables(state.metadata, args.Source)
+		}
+
+		run.scripts = append(run.scripts, script)
+		run.paths = append(run.paths, argsPath)
+	}
+
+	return run, nil
+}
+
+// admit checks the i-th query's source and read_only setting against the
+// run: the first query picks the source's runner, the others must match it.
+func (r *sqlRun) admit(state *controllerState, args RunSQLArgs, i int, path string) error {
+	if i > 0 {
+		switch {
+		case args.Source != r.source:
+			return newQueryError(
+				path, "not-supported",
+				fmt.Sprintf(
+					"a bulk runs in one transaction, so every query must use source %q", r.source,
+				),
+			)
+		case args.ReadOnly != r.opts.ReadOnly:
+			return newQueryError(
+				path, "not-supported",
+				"a bulk runs in one transaction, so every query must set the same read_only",
+			)
+		default:
+			return nil
+		}
+	}
+
+	conn, ok := state.connectors[args.Source]
+	if !ok {
+		return newQueryError(
+			path, "not-exists", fmt.Sprintf("source with name %q does not exist", args.Source),
+		)
+	}
+
+	runner, ok := conn.(runsql.Runner)
+	if !ok {
+		return runSQLNotSupported(path, args.Source)
+	}
+
+	r.source, r.runner, r.opts.ReadOnly = args.Source, runner, args.ReadOnly
+
+	return nil
+}
+
+// trackedTables returns the tables source's metadata tracks, including those
+// missing from the database, so SQL that creates them is noticed too.
+func trackedTables(meta *metadata.Metadata, source string) []metadata.TableSource {
+	for _, db := range meta.Databases {
+		if db.Name != source {
+			continue
+		}
+
+		tables := make([]metadata.TableSource, 0, len(db.Tables))
+		for _, t := range db.Tables {
+			tables = append(tables, t.Table)
+		}
+
+		return tables
+	}
+
+	return nil
+}
+
+func runSQLNotSupported(path, source string) *queryError {
+	return newQueryError(
+		path, "not-supported",
+		fmt.Sprintf("source %q does not support run_sql; only postgres sources can run SQL", source),
+	)
+}
+
+// runSQLError maps a connector's run_sql failure to the response: a script
+// being rejected by the database, a dropped tracked table and a connector that
+// cannot run SQL are request errors, reported at the failing query; anything
+// else is returned as is. The whole run was rolled back either way.
+func runSQLError(run *sqlRun, err error) error {
+	if errors.Is(err, runsql.ErrNotSupported) {
+		return runSQLNotSupported(run.paths[0], run.source)
+	}
+
+	if depErr, ok := errors.AsType[*runsql.DependencyError](err); ok {
+		return newQueryError(run.paths[depErr.Script], "dependency-error", depErr.Error())
+	}
+
+	if execErr, ok := errors.AsType[*runsql.ExecutionError](err); ok {
+		qErr := newQueryError(run.paths[execErr.Script], "postgres-error", "query execution failed")
+		qErr.Internal = map[string]any{
+			"statement": run.scripts[execErr.Script].SQL,
+			"error": map[string]any{
+				"message":     execErr.Error(),
+				"status_code": execErr.Code,
+			},
+		}
+
+		return qErr
+	}
+
+	return fmt.Errorf("running sql on %s: %w", run.source, err)
+}
+
+func runSQLResult(result *runsql.Result) RunSQLResult {
+	if result.Rows == nil {
+		return RunSQLResult{ResultType: resultTypeCommandOk, Result: nil}
+	}
+
+	return RunSQLResult{ResultType: resultTypeTuplesOk, Result: result.Rows}
+}
+
+// sqlChangeBuffer bounds the run_sql changes queued for Run; a request that
+// does not fit waits for Run to take one.
+const sqlChangeBuffer = 16
+
+// sqlChange is a run_sql request's report of the sources whose tracked tables
+// it changed. Run closes done once the state no longer predates the change.
+type sqlChange struct {
+	sources []string
+	done    chan struct{}
+}
+
+// rebuildForSQLChange hands the sources whose tracked tables run_sql changed
+// to Run, which re-introspects them, and waits until it has, so the response
+// reflects the new schema. Controllers built by NewFromConnectors have no Run
+// loop and keep their connectors.
+func (c *Controller) rebuildForSQLChange(ctx context.Context, sources []string) {
+	if c.source == nil {
+		return
+	}
+
+	change := sqlChange{sources: sources, done: make(chan struct{})}
+
+	// The SQL is committed, so the change is queued even when the client has
+	// gone away; only the wait below gives up with the request.
+	select {
+	case c.sqlChanges <- change:
+	case <-c.stopped:
+		return
+	}
+
+	select {
+	case <-change.done:
+	case <-ctx.Done():
+	case <-c.stopped:
+	}
+}
+
+// applySQLChanges re-introspects the sources of change, and of any other
+// change already queued, in a single rebuild from the current state. A failed
+// build keeps the current state; the next metadata reload retries. It runs on
+// Run's goroutine, so it cannot race a metadata reload or another rebuild.
+func (c *Controller) applySQLChanges(
+	ctx context.Context, change sqlChange, logger *slog.Logger,
+) {
+	changes := []sqlChange{change}
+
+	for queued := true; queued; {
+		select {
+		case next := <-c.sqlChanges:
+			changes = append(changes, next)
+		default:
+			queued = false
+		}
+	}
+
+	defer func() {
+		for _, change := range changes {
+			close(change.done)
+		}
+	}()
+
+	var sources []string
+
+	for _, change := range changes {
+		for _, source := range change.sources {
+			if !slices.Contains(sources, source) {
+				sources = append(sources, source)
+			}
+		}
+	}
+
+	logger.InfoContext(
+		ctx, "tracked tables changed by run_sql, rebuilding controller state",
+		slog.Any("sources", sources),
+	)
+
+	state := c.state.Load()
+
+	newState, err := buildState(
+		ctx, state.metadata, state.reuseExcept(sources), c.pollingInterval, c.coordination,
+		c.sessionSettings, c.disableIntrospection, logger,
+	)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to rebuild controller state", "error", err)
+
+		return
+	}
+
+	logInconsistencySummary(ctx, logger, newState.inconsistencies)
+	c.swapState(ctx, newState, false, logger)
+}
@@ -0,0 +1,315 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/nhost/services/constellation/connector"
+	"github.com/nhost/nhost/services/constellation/connector/runsql"
+	sqlconnector "github.com/nhost/nhost/services/constellation/connector/sql"
+	"github.com/nhost/nhost/services/constellation/controller"
+	"github.com/nhost/nhost/services/constellation/controller/middleware"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+// runSQLTestDriver is a validationTestDriver that can also run SQL. The
+// outcome of each script is chosen by its SQL text; runs, when set, counts
+// the runs it was asked for.
+type runSQLTestDriver struct {
+	validationTestDriver
+
+	runs *atomic.Int32
+}
+
+func (d runSQLTestDriver) RunSQL(
+	_ context.Context, scripts []runsql.Script, _ runsql.Options,
+) ([]*runsql.Result, error) {
+	if d.runs != nil {
+		d.runs.Add(1)
+	}
+
+	results := make([]*runsql.Result, 0, len(scripts))
+
+	for i, script := range scripts {
+		switch script.SQL {
+		case "SELECT":
+			results = append(results, &runsql.Result{
+				Rows: [][]string{{"id", "name"}, {"1", "NULL"}}, Changed: false,
+			})
+		case "DROP TABLE users":
+			return nil, &runsql.DependencyError{
+				Script: i,
+				Tables: []metadata.TableSource{{Schema: "public", Name: "users"}},
+			}
+		case "BROKEN":
+			return nil, &runsql.ExecutionError{
+				Script: i,
+				Code:   "42601",
+				Err:    errors.New(`syntax error at or near "BROKEN"`), //nolint:err113
+			}
+		default:
+			results = append(results, &runsql.Result{Rows: nil, Changed: false})
+		}
+	}
+
+	return results, nil
+}
+
+func newQueryTestRouter(t *testing.T, driver runSQLTestDriver) *gin.Engine {
+	t.Helper()
+
+	newSQLConnector := func(driver sqlconnector.Driver) connector.Connector {
+		conn, err := sqlconnector.NewConnector(
+			t.Context(),
+			driver,
+			&metadata.DatabaseMetadata{
+				Kind: "postgres",
+				Tables: []metadata.TableMetadata{
+					{Table: metadata.TableSource{Schema: "public", Name: "users"}},
+				},
+			},
+			nil,
+			slog.New(slog.DiscardHandler),
+		)
+		if err != nil {
+			t.Fatalf("NewConnector: %v", err)
+		}
+
+		return conn
+	}
+
+	ctrl, err := controller.NewFromConnectors(
+		testAdminSecret,
+		map[string]connector.Connector{
+			"default": newSQLConnector(driver),
+			"other":   newSQLConnector(validationTestDriver{}),
+		},
+		nil,
+		slog.New(slog.DiscardHandler),
+	)
+	if err != nil {
+		t.Fatalf("NewFromConnectors: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.Session(testAdminSecret, middleware.NewNoOpJWTAuthenticator()))
+	router.POST("/v2/query", ctrl.HandlerQueryWithMaxBodyBytes(0))
+
+	return router
+}
+
+func postV2Query(t *testing.T, router *gin.Engine, body string, admin bool) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/v2/query", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+
+	if admin {
+		req.Header.Set("X-Hasura-Admin-Secret", testAdminSecret)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestHandlerQuery_RunSQL(t *testing.T) {
+	t.Parallel()
+
+	router := newQueryTestRouter(t, runSQLTestDriver{})
+
+	tests := []struct {
+		name     string
+		body     string
+		wantBody string
+	}{
+		{
+			name:     "tuples",
+			body:     `{"type":"run_sql","args":{"sql":"SELECT"}}`,
+			wantBody: `{"result_type":"TuplesOk","result":[["id","name"],["1","NULL"]]}`,
+		},
+		{
+			name:     "command",
+			body:     `{"type":"run_sql","args":{"source":"default","sql":"CREATE TABLE t ()"}}`,
+			wantBody: `{"result_type":"CommandOk","result":null}`,
+		},
+		{
+			name: "bulk",
+			body: `{"type":"bulk","args":[
+				{"type":"run_sql","args":{"sql":"CREATE TABLE t ()"}},
+				{"type":"run_sql","args":{"sql":"SELECT"}}
+			]}`,
+			wantBody: `[{"result_type":"CommandOk","result":null},` +
+				`{"result_type":"TuplesOk","result":[["id","name"],["1","NULL"]]}]`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := postV2Query(t, router, tc.body, true)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			if got := strings.TrimSpace(w.Body.String()); got != tc.wantBody {
+				t.Errorf("body = %s, want %s", got, tc.wantBody)
+			}
+		})
+	}
+}
+
+func TestHandlerQuery_Errors(t *testing.T) {
+	t.Parallel()
+
+	router := newQueryTestRouter(t, runSQLTestDriver{})
+
+	tests := []struct {
+		name     string
+		body     string
+		admin    bool
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "non-admin",
+			body:     `{"type":"run_sql","args":{"sql":"SELECT"}}`,
+			admin:    false,
+			wantCode: http.StatusUnauthorized,
+			wantBody: `"access-denied"`,
+		},
+		{
+			name:     "malformed body",
+			body:     `{"type":`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"parse-failed"`,
+		},
+		{
+			name:     "unsupported type",
+			body:     `{"type":"count","args":{}}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"not-supported"`,
+		},
+		{
+			name:     "unsupported type in bulk",
+			body:     `{"type":"bulk","args":[{"type":"select","args":{}}]}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"path":"$.args[0]"`,
+		},
+		{
+			name:     "unknown source",
+			body:     `{"type":"run_sql","args":{"source":"ghost","sql":"SELECT"}}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"not-exists"`,
+		},
+		{
+			name:     "source without run_sql",
+			body:     `{"type":"run_sql","args":{"source":"other","sql":"SELECT"}}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"not-supported"`,
+		},
+		{
+			name:     "dropping a tracked table",
+			body:     `{"type":"run_sql","args":{"sql":"DROP TABLE users"}}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"dependency-error"`,
+		},
+		{
+			name:     "sql error",
+			body:     `{"type":"run_sql","args":{"sql":"BROKEN"}}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"status_code":"42601"`,
+		},
+		{
+			name: "sql error in bulk",
+			body: `{"type":"bulk","args":[
+				{"type":"run_sql","args":{"sql":"CREATE TABLE t ()"}},
+				{"type":"run_sql","args":{"sql":"BROKEN"}}
+			]}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"path":"$.args[1].args"`,
+		},
+		{
+			name: "bulk across sources",
+			body: `{"type":"bulk","args":[
+				{"type":"run_sql","args":{"sql":"CREATE TABLE t ()"}},
+				{"type":"run_sql","args":{"source":"other","sql":"SELECT"}}
+			]}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"path":"$.args[1].args"`,
+		},
+		{
+			name: "bulk mixing read_only",
+			body: `{"type":"bulk","args":[
+				{"type":"run_sql","args":{"sql":"CREATE TABLE t ()"}},
+				{"type":"run_sql","args":{"sql":"SELECT","read_only":true}}
+			]}`,
+			admin:    true,
+			wantCode: http.StatusBadRequest,
+			wantBody: `"path":"$.args[1].args"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := postV2Query(t, router, tc.body, tc.admin)
+			if w.Code != tc.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tc.wantCode, w.Code, w.Body.String())
+			}
+
+			if !strings.Contains(w.Body.String(), tc.wantBody) {
+				t.Errorf("expected body to contain %s, got %s", tc.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+// TestHandlerQuery_BulkValidatesEveryQueryFirst checks that a bulk whose
+// later query cannot be decoded is rejected before any of its queries run.
+func TestHandlerQuery_BulkValidatesEveryQueryFirst(t *testing.T) {
+	t.Parallel()
+
+	var runs atomic.Int32
+
+	router := newQueryTestRouter(t, runSQLTestDriver{runs: &runs})
+
+	w := postV2Query(t, router, `{"type":"bulk","args":[
+		{"type":"run_sql","args":{"sql":"CREATE TABLE a ()"}},
+		{"type":"run_sql","args":{"sql":"CREATE TABLE b ()"}},
+		{"type":"run_sql","args":{"sql":42}}
+	]}`, true)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if !strings.Contains(w.Body.String(), `"path":"$.args[2].args"`) {
+		t.Errorf("expected the error at the third query, got %s", w.Body.String())
+	}
+
+	if n := runs.Load(); n != 0 {
+		t.Errorf("expected no run, got %d", n)
+	}
+}
@@ -11,6 +11,8 @@
 package relationships
 
 import (
+	"slices"
+
 	"github.com/nhost/nhost/services/constellation/connector"
 	"github.com/nhost/nhost/services/constellation/controller/planner"
 	"github.com/nhost/nhost/services/constellation/metadata"
@@ -89,13 +91,21 @@ func forDatabase(
 	return out
 }
 
-// forRemoteSchema builds rs→db relationship metadata for a single remote
-// schema. rs→rs is not supported.
+// forRemoteSchema builds rs→db and rs→rs relationship metadata for a single
+// remote schema.
 func forRemoteSchema(rs metadata.RemoteSchemaMetadata) []*planner.RelationshipMetadata {
 	var out []*planner.RelationshipMetadata
 
 	for _, typeRel := range rs.RemoteRelationships {
 		for _, rel := range typeRel.Relationships {
+			if toRS := rel.Definition.ToRemoteSchema; toRS != nil {
+				if rm := forRemoteSchemaToRemoteSchema(typeRel.TypeName, rel.Name, toRS); rm != nil {
+					out = append(out, rm)
+				}
+
+				continue
+			}
+
 			if rel.Definition.ToSource == nil {
 				continue
 			}
@@ -126,6 +136,54 @@ func forRemoteSchema(rs metadata.RemoteSchemaMetadata) []*planner.RelationshipMe
 	return out
 }
 
+// forRemoteSchemaToRemoteSchema builds the planner metadata for an rs→rs
+// relationship. Each lhs field joins under its own name, the way db→rs
+// relationships map lhs columns, so the schema resolver reads the $field
+// arguments of the remote field path straight from the parent objects.
+// Returns nil when the remote field path is empty.
+func forRemoteSchemaToRemoteSchema(
+	sourceType, relName string,
+	toRS *metadata.ToRemoteSchemaRelationship,
+) *planner.RelationshipMetadata {
+	path := metadata.ExtractRemoteFieldPath(toRS.RemoteField)
+	if len(path) == 0 {
+		return nil
+	}
+
+	joinMapping := make(map[string]string, len(toRS.LHSFields))
+	for _, lhs := range toRS.LHSFields {
+		joinMapping[lhs] = lhs
+	}
+
+	return &planner.RelationshipMetadata{
+		Name:              relName,
+		SourceType:        sourceType,
+		TargetConnector:   toRS.RemoteSchema,
+		TargetTable:       "",
+		TargetTableSchema: "",
+		JoinMapping:       joinMapping,
+		IsArray:           false,
+		IsArrayAggregate:  false,
+		IsRemote:          true,
+		LHSFields:         slices.Clone(toRS.LHSFields),
+		RemoteFieldPath:   plannerFieldPath(path),
+	}
+}
+
+// plannerFieldPath converts a metadata remote field path into the planner's
+// representation.
+func plannerFieldPath(path []metadata.RemoteFieldPathEntry) []planner.RemoteFieldPathEntry {
+	out := make([]planner.RemoteFieldPathEntry, len(path))
+	for i, entry := range path {
+		out[i] = planner.RemoteFieldPathEntry{
+			FieldName: entry.FieldName,
+			Arguments: entry.Arguments,
+		}
+	}
+
+	return out
+}
+
 // aggregateRelationship produces the "<rel>_aggregate" sibling relationship
 // metadata for a cross-database array relationship. Returns nil for non-array
 // or non-remote relationships, or when the target is a remote schema (remote
@@ -136,7 +194,7 @@ func aggregateRelationship(base *planner.RelationshipMetadata) *planner.Relation
 	}
 
 	if len(base.RemoteFieldPath) > 0 {
-		// db→rs: remote schemas don't have aggregate types.
+		// db→rs / rs→rs: remote schemas don't have aggregate types.
 		return nil
 	}
 
@@ -184,14 +242,6 @@ func forDatabaseRelationship(
 			lhsFields = append(lhsFields, localCol)
 		}
 
-		remoteFieldPath := make([]planner.RemoteFieldPathEntry, len(mc.RemoteFieldPath))
-		for i, entry := range mc.RemoteFieldPath {
-			remoteFieldPath[i] = planner.RemoteFieldPathEntry{
-				FieldName: entry.FieldName,
-				Arguments: entry.Arguments,
-			}
-		}
-
 		return &planner.RelationshipMetadata{
 			Name:              relName,
 			SourceType:        sourceType,
@@ -203,7 +253,7 @@ func forDatabaseRelationship(
 			IsArrayAggregate:  false,
 			IsRemote:          true,
 			LHSFields:         lhsFields,
-			RemoteFieldPath:   remoteFieldPath,
+			RemoteFieldPath:   plannerFieldPath(mc.RemoteFieldPath),
 		}
 	}
 
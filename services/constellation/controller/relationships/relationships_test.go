@@ -359,6 +359,58 @@ func TestFromMetadata_RemoteSchemaToSourceRelationship(t *testing.T) {
 	}
 }
 
+func TestFromMetadata_RemoteSchemaToRemoteSchemaRelationship(t *testing.T) {
+	t.Parallel()
+
+	meta := &metadata.Metadata{
+		Databases: nil,
+		RemoteSchemas: []metadata.RemoteSchemaMetadata{{
+			Name: "billing",
+			RemoteRelationships: []metadata.RemoteSchemaTypeRemoteRelationship{{
+				TypeName: "Invoice",
+				Relationships: []metadata.RemoteSchemaRelationshipDef{{
+					Name: "customer",
+					Definition: metadata.RemoteSchemaRelationshipDefinition{
+						ToSource: nil,
+						ToRemoteSchema: &metadata.ToRemoteSchemaRelationship{
+							RemoteSchema: "crm",
+							LHSFields:    []string{"customerId"},
+							RemoteField: map[string]metadata.RemoteFieldCall{
+								"customer": {
+									Arguments: map[string]string{"id": "$customerId"},
+									Field:     nil,
+								},
+							},
+						},
+					},
+				}},
+			}},
+		}},
+	}
+
+	got := relationships.FromMetadata(meta, map[string]connector.Connector{})
+
+	want := []*planner.RelationshipMetadata{{
+		Name:              "customer",
+		SourceType:        "Invoice",
+		TargetConnector:   "crm",
+		TargetTable:       "",
+		TargetTableSchema: "",
+		JoinMapping:       map[string]string{"customerId": "customerId"},
+		IsArray:           false,
+		IsArrayAggregate:  false,
+		IsRemote:          true,
+		LHSFields:         []string{"customerId"},
+		RemoteFieldPath: []planner.RemoteFieldPathEntry{{
+			FieldName: "customer",
+			Arguments: map[string]string{"id": "$customerId"},
+		}},
+	}}
+	if diff := cmp.Diff(want, got["billing"]); diff != "" {
+		t.Errorf("FromMetadata mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestFromMetadata_RemoteSchemaWithoutToSourceSkipped(t *testing.T) {
 	t.Parallel()
 
@@ -12,6 +12,7 @@ import (
 
 	oapimw "github.com/nhost/nhost/internal/lib/oapi/middleware"
 	"github.com/nhost/nhost/services/constellation/connector/schemamerge"
+	csql "github.com/nhost/nhost/services/constellation/connector/sql"
 	"github.com/nhost/nhost/services/constellation/controller/introspection"
 	"github.com/nhost/nhost/services/constellation/controller/middleware"
 	"github.com/nhost/nhost/services/constellation/controller/planner"
@@ -99,11 +100,25 @@ func (c *Controller) Resolve(
 		return operationSelectionResponse(req.OperationName, len(query.Operations)), nil
 	}
 
+	if errs := state.introspection.Check(
+		validatedSchema, operation, query.Fragments, role,
+	); errs != nil {
+		return &GraphQLResponse{
+			Data:        nil,
+			Errors:      formatGQLErrors(errs),
+			rawResponse: nil,
+		}, nil
+	}
+
 	validatedVariables, varResp := validateVariables(validatedSchema, operation, req.Variables)
 	if varResp != nil {
 		return varResp, nil
 	}
 
+	// The role's API time limit bounds every database statement the request
+	// runs, remote-relationship follow-ups included.
+	ctx = csql.WithStatementTimeout(ctx, state.metadata.APILimits.TimeLimitForRole(role))
+
 	result := c.execute(
 		ctx, state, validatedSchema, query, operation, query.Fragments,
 		validatedVariables, role, session.Variables, logger,
@@ -265,7 +280,15 @@ func (c *Controller) executeWithMeta(
 	metaQuery.Fragments = fragments
 	results := introspection.Execute(schema, metaOp, &metaQuery)
 
+	federationErrs := c.resolveFederationFields(
+		ctx, state, schema, metaOp, fragments, variables, role, sessionVariables, logger, results,
+	)
+
 	if len(dataByConnector) == 0 {
+		if len(federationErrs) > 0 {
+			return &GraphQLResponse{Data: results, Errors: federationErrs, rawResponse: nil}
+		}
+
 		return &GraphQLResponse{Data: results, Errors: nil, rawResponse: nil}
 	}
 
@@ -279,6 +302,7 @@ func (c *Controller) executeWithMeta(
 
 	maps.Copy(results, dataResults)
 
+	errs = append(errs, federationErrs...)
 	if len(errs) > 0 {
 		return &GraphQLResponse{Data: results, Errors: errs, rawResponse: nil}
 	}
@@ -367,8 +391,13 @@ func (c *Controller) resolveData(
 		return nil, nil, errorResponse(fmt.Errorf("planning query: %w", err).Error())
 	}
 
+	// Over WebSocket, subscriptions resolve their remote relationships on
+	// every result (see planSubscriptionJoins); a one-shot HTTP subscription
+	// has no such join phase.
 	if plan.HasRemoteQueries() && operation.Operation == ast.Subscription {
-		return nil, nil, errorResponse("remote relationships are not supported in subscriptions")
+		return nil, nil, errorResponse(
+			"remote relationships in subscriptions are only supported over WebSocket",
+		)
 	}
 
 	// Requests that fan out to multiple root connectors, or that will resolve
@@ -446,7 +475,7 @@ func groupFieldsByConnector(
 			continue
 		}
 
-		if isMetaField(field.Name) {
+		if isMetaField(field.Name) || isFederationField(state, operation.Operation, field.Name) {
 			metaSelections = append(metaSelections, selection)
 
 			continue
@@ -603,8 +632,7 @@ func buildConnectorOperation(
 }
 
 // resolveRemoteRelationships handles cross-connector relationship resolution.
-// It unmarshals raw JSON results, builds remote queries from the plan, and
-// executes them. Returns an error response if any step fails, nil on success.
+// Returns an error response if any step fails, nil on success.
 func (c *Controller) resolveRemoteRelationships(
 	ctx context.Context,
 	state *controllerState,
@@ -616,13 +644,41 @@ func (c *Controller) resolveRemoteRelationships(
 	sessionVariables map[string]any,
 	logger *slog.Logger,
 ) *GraphQLResponse {
+	if err := resolvePlannedRelationships(
+		ctx, state, results, plan, fragments, variables, role, sessionVariables, logger,
+	); err != nil {
+		return &GraphQLResponse{
+			Data:        nil,
+			Errors:      c.classifyConnectorError(ctx, logger, err),
+			rawResponse: nil,
+		}
+	}
+
+	return nil
+}
+
+// resolvePlannedRelationships unmarshals raw JSON results, builds the remote
+// queries of plan from them, and executes them, merging their results into
+// results in place. It is shared by query execution and by subscriptions,
+// which run it on every changed result.
+func resolvePlannedRelationships(
+	ctx context.Context,
+	state *controllerState,
+	results map[string]any,
+	plan *planner.QueryPlan,
+	fragments ast.FragmentDefinitionList,
+	variables map[string]any,
+	role string,
+	sessionVariables map[string]any,
+	logger *slog.Logger,
+) error {
 	if plan == nil || !plan.HasRemoteQueries() {
 		return nil
 	}
 
 	// Unmarshal raw JSON values back to map[string]any so jsonpath can traverse them.
 	if err := unmarshalRawResults(results); err != nil {
-		return errorResponse(sanitizeConnectorError(ctx, logger, c.devMode, err))
+		return err
 	}
 
 	pendingQueries := resolver.BuildRemoteQueriesFromPlan(
@@ -642,18 +698,10 @@ func (c *Controller) resolveRemoteRelationships(
 		return nil
 	}
 
-	if err := state.remoteRelationshipResolver.Resolve(
+	return state.remoteRelationshipResolver.Resolve( //nolint:wrapcheck
 		ctx, results, pendingQueries,
 		fragments, variables, role, sessionVariables, logger,
-	); err != nil {
-		return &GraphQLResponse{
-			Data:        nil,
-			Errors:      c.classifyConnectorError(ctx, logger, err),
-			rawResponse: nil,
-		}
-	}
-
-	return nil
+	)
 }
 
 // errorResponse builds a GraphQLResponse with a single error message.
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nhost/nhost/services/constellation/connector/schemamerge"
@@ -16,6 +17,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	oapimw "github.com/nhost/nhost/internal/lib/oapi/middleware"
+	csql "github.com/nhost/nhost/services/constellation/connector/sql"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/arguments"
 	argmock "github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/arguments/mock"
 	"github.com/nhost/nhost/services/constellation/connector/sql/graphql/queries/core"
@@ -636,3 +638,33 @@ func TestClassifyConnectorError_DataExceptionError(t *testing.T) {
 		t.Errorf("classifyConnectorError (-want +got):\n%s", diff)
 	}
 }
+
+func TestClassifyConnectorError_StatementTimeoutError(t *testing.T) {
+	t.Parallel()
+
+	c := &Controller{devMode: false}
+
+	timeoutErr := csql.NewStatementTimeoutError(
+		2*time.Second,
+		errors.New("ERROR: canceling statement due to statement timeout (SQLSTATE 57014)"), //nolint:err113
+	)
+
+	got := c.classifyConnectorError(
+		context.Background(), slog.Default(),
+		fmt.Errorf("failed to execute operations: %w", timeoutErr),
+	)
+
+	want := []map[string]any{
+		{
+			"message": "database query error",
+			"extensions": map[string]any{
+				"code": "unexpected",
+				"path": "$",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("classifyConnectorError (-want +got):\n%s", diff)
+	}
+}
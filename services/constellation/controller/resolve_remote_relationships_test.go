@@ -1,15 +1,18 @@
 package controller_test
 
 import (
+	"context"
 	"encoding/json/jsontext"
 	"log/slog"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/nhost/nhost/services/constellation/connector"
 	"github.com/nhost/nhost/services/constellation/connector/memconnector"
 	"github.com/nhost/nhost/services/constellation/controller"
 	plannerpkg "github.com/nhost/nhost/services/constellation/controller/planner"
 	"github.com/nhost/nhost/services/constellation/graph"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 // TestResolve_PhantomFieldStrippedOnPartialErrorPath pins that phantom join
@@ -148,3 +151,145 @@ func TestResolve_PhantomFieldStrippedOnPartialErrorPath(t *testing.T) {
 		}
 	}
 }
+
+// recordingConnector records the operations sent to the wrapped connector.
+type recordingConnector struct {
+	connector.Connector
+
+	operations []*ast.OperationDefinition
+}
+
+func (r *recordingConnector) Execute(
+	ctx context.Context,
+	op *ast.OperationDefinition,
+	frags ast.FragmentDefinitionList,
+	vars map[string]any,
+	role string,
+	sessionVars map[string]any,
+	logger *slog.Logger,
+) (map[string]any, error) {
+	r.operations = append(r.operations, op)
+
+	return r.Connector.Execute(ctx, op, frags, vars, role, sessionVars, logger) //nolint:wrapcheck
+}
+
+// TestResolve_RemoteSchemaToRemoteSchemaRelationship pins rs→rs resolution:
+// the billing schema's Invoice.customer relationship binds the customerId lhs
+// field into the crm schema's customer(id:) field. The two invoices must be
+// fetched in one aliased batch, and the injected customerId phantom must not
+// leak into the response.
+func TestResolve_RemoteSchemaToRemoteSchemaRelationship(t *testing.T) {
+	t.Parallel()
+
+	billing, err := memconnector.New(
+		[]*graph.ObjectType{
+			memconnector.Object(
+				"Invoice",
+				memconnector.ID("id"),
+				memconnector.String("customerId"),
+				memconnector.Field("customer", memconnector.Named("Customer")),
+			),
+		},
+		[]memconnector.QueryDef{
+			memconnector.Query(
+				"invoices",
+				graph.NewNonNullListType(graph.NewNonNullType("Invoice")),
+				jsontext.Value(`[{"id":"i1","customerId":"c1"},{"id":"i2","customerId":"c2"}]`),
+			),
+		},
+	)
+	if err != nil {
+		t.Fatalf("memconnector.New(billing): %v", err)
+	}
+
+	crmBase, err := memconnector.New(
+		[]*graph.ObjectType{
+			memconnector.Object("Customer", memconnector.ID("id"), memconnector.String("name")),
+		},
+		[]memconnector.QueryDef{
+			memconnector.Query(
+				"customer",
+				graph.NewNamedType("Customer"),
+				jsontext.Value(`{"name":"Acme"}`),
+			),
+		},
+	)
+	if err != nil {
+		t.Fatalf("memconnector.New(crm): %v", err)
+	}
+
+	crm := &recordingConnector{Connector: crmBase, operations: nil}
+
+	relationships := map[string][]*plannerpkg.RelationshipMetadata{
+		"billing": {
+			{
+				Name:              "customer",
+				SourceType:        "Invoice",
+				TargetConnector:   "crm",
+				TargetTable:       "",
+				TargetTableSchema: "",
+				JoinMapping:       map[string]string{"customerId": "customerId"},
+				IsArray:           false,
+				IsArrayAggregate:  false,
+				IsRemote:          true,
+				LHSFields:         []string{"customerId"},
+				RemoteFieldPath: []plannerpkg.RemoteFieldPathEntry{{
+					FieldName: "customer",
+					Arguments: map[string]string{"id": "$customerId"},
+				}},
+			},
+		},
+	}
+
+	ctrl, err := controller.NewFromConnectors(
+		testAdminSecret,
+		map[string]connector.Connector{"billing": billing, "crm": crm},
+		relationships,
+		slog.New(slog.DiscardHandler),
+	)
+	if err != nil {
+		t.Fatalf("NewFromConnectors: %v", err)
+	}
+
+	resp, err := ctrl.Resolve(adminSessionContext(t), controller.GraphQLRequest{
+		OperationName: "",
+		Query:         `{ invoices { id customer { name } } }`,
+		Variables:     nil,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.Errors != nil {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+
+	want := map[string]any{
+		"invoices": []any{
+			map[string]any{"id": "i1", "customer": map[string]any{"name": "Acme"}},
+			map[string]any{"id": "i2", "customer": map[string]any{"name": "Acme"}},
+		},
+	}
+	if diff := cmp.Diff(want, resp.Data); diff != "" {
+		t.Errorf("data mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(crm.operations) != 1 {
+		t.Fatalf("expected one batched crm operation, got %d", len(crm.operations))
+	}
+
+	got := make(map[string]string)
+
+	for _, sel := range crm.operations[0].SelectionSet {
+		field, ok := sel.(*ast.Field)
+		if !ok {
+			t.Fatalf("unexpected selection %T", sel)
+		}
+
+		got[field.Alias] = field.Arguments.ForName("id").Value.Raw
+	}
+
+	if diff := cmp.Diff(map[string]string{"_0": "c1", "_1": "c2"}, got); diff != "" {
+		t.Errorf("batched arguments mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,87 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/nhost/nhost/services/constellation/connector/federation"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// ResolveEntities resolves Apollo Federation representations of a single
+// entity type. The representations are batched into one query against the
+// target's select root field through the same databaseResolver WHERE col IN
+// (...) path db→db relationships use, executed as role so select permissions
+// apply. selectionSet is the selection for the entity type.
+//
+// The returned slice has one entry per representation, in order: the matching
+// row, or nil when the row does not exist, is hidden by the role's permission
+// filter, or the representation lacks a key field.
+func (r *RemoteRelationshipResolver) ResolveEntities(
+	ctx context.Context,
+	target federation.Target,
+	representations []map[string]any,
+	selectionSet ast.SelectionSet,
+	fragments ast.FragmentDefinitionList,
+	variables map[string]any,
+	role string,
+	sessionVariables map[string]any,
+	logger *slog.Logger,
+) ([]any, error) {
+	entities := make([]any, len(representations))
+
+	joinColumns := make(map[string]string, len(target.KeyFields))
+	for _, keyField := range target.KeyFields {
+		joinColumns[keyField] = keyField
+	}
+
+	rq := &remoteQuery{ //nolint:exhaustruct
+		targetConnector: target.Connector,
+		isArray:         true,
+		joinArguments:   buildJoinArguments(representations, target.KeyFields, nil),
+		sourceField: &ast.Field{ //nolint:exhaustruct
+			Name:         target.SelectField,
+			SelectionSet: selectionSet,
+		},
+		fragments: fragments,
+		resolver:  newDatabaseResolver(joinColumns, target.SelectField),
+	}
+
+	if len(rq.joinArguments) == 0 {
+		return entities, nil
+	}
+
+	targetConnector := r.connectors[target.Connector]
+	if targetConnector == nil {
+		return nil, fmt.Errorf("%w: %s", errTargetConnectorNotFound, target.Connector)
+	}
+
+	remoteOp := rq.buildOperation()
+	resolveVariableReferences(remoteOp.SelectionSet, variables)
+
+	execResult, err := targetConnector.Execute(
+		ctx, remoteOp, collectReferencedFragments(remoteOp, fragments),
+		variables, role, sessionVariables, logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s entities: %w", target.TypeName, err)
+	}
+
+	if err := unmarshalRawResults(execResult); err != nil {
+		return nil, fmt.Errorf("failed to parse %s entities: %w", target.TypeName, err)
+	}
+
+	rows := rq.extractResults(execResult)
+	lookup := rq.buildResultLookup(rows)
+
+	for i, representation := range representations {
+		if matches := lookup[rq.getJoinKeyFromParent(representation)]; len(matches) > 0 {
+			entities[i] = matches[0]
+		}
+	}
+
+	rq.removePhantomFieldsFromRemoteResults(rows)
+
+	return entities, nil
+}
@@ -0,0 +1,185 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/nhost/internal/lib/schemadiff"
+	"github.com/nhost/nhost/services/constellation/controller/middleware"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Change kinds for a role that appears or disappears between two states,
+// reported alongside the schemadiff kinds.
+const (
+	roleAdded   schemadiff.ChangeKind = "ROLE_ADDED"
+	roleRemoved schemadiff.ChangeKind = "ROLE_REMOVED"
+)
+
+// SchemaDiff is the per-role schema report computed when a rebuilt state is
+// about to replace the current one (metadata reload, remote-schema refresh,
+// run_sql DDL).
+type SchemaDiff struct {
+	At time.Time `json:"at"`
+	// Applied is false when the swap was refused because it broke a role
+	// configured to reject breaking changes.
+	Applied bool `json:"applied"`
+	// Roles maps each role whose schema changed to its changes, ordered by
+	// path. Unchanged roles are omitted.
+	Roles map[string][]schemadiff.Change `json:"roles"`
+}
+
+// diffSchemas compares the per-role schemas of two states. Both sides are
+// copied and run through the normalisers of `nhost schema diff` first, so the
+// differences it filters out are neither reported nor held against a swap. A
+// role present on one side only gets a single ROLE_ADDED or ROLE_REMOVED
+// change; removing a role is breaking for its clients.
+func diffSchemas(from, to map[string]*ast.Schema) map[string][]schemadiff.Change {
+	roles := make(map[string][]schemadiff.Change)
+
+	for role, oldSchema := range from {
+		newSchema, ok := to[role]
+		if !ok {
+			roles[role] = []schemadiff.Change{{
+				Kind:        roleRemoved,
+				Path:        "",
+				Description: fmt.Sprintf("role %s was removed", role),
+				Breaking:    true,
+			}}
+
+			continue
+		}
+
+		from, to := schemadiff.Clone(oldSchema), schemadiff.Clone(newSchema)
+		schemadiff.Normalize(from, to)
+
+		if changes := schemadiff.Compare(from, to); len(changes) > 0 {
+			roles[role] = changes
+		}
+	}
+
+	for role := range to {
+		if _, ok := from[role]; !ok {
+			roles[role] = []schemadiff.Change{{
+				Kind:        roleAdded,
+				Path:        "",
+				Description: fmt.Sprintf("role %s was added", role),
+				Breaking:    false,
+			}}
+		}
+	}
+
+	return roles
+}
+
+// breakingDescriptions returns the descriptions of the breaking changes.
+func breakingDescriptions(changes []schemadiff.Change) []string {
+	var out []string
+
+	for _, change := range changes {
+		if change.Breaking {
+			out = append(out, change.Description)
+		}
+	}
+
+	return out
+}
+
+// admitSchemaChanges diffs newState's schemas against oldState's, logs and
+// records the result, and reports whether the swap may proceed. With
+// refuseBreaking set (metadata reloads) it may not when a role listed in
+// breakingChangeRoles has a breaking change; other rebuilds only reflect the
+// database or remote schemas as they already are, so their breaking changes
+// are logged and applied.
+func (c *Controller) admitSchemaChanges(
+	ctx context.Context,
+	oldState, newState *controllerState,
+	refuseBreaking bool,
+	logger *slog.Logger,
+) bool {
+	diff := &SchemaDiff{
+		At:      time.Now(),
+		Applied: true,
+		Roles:   diffSchemas(oldState.validatedSchemas, newState.validatedSchemas),
+	}
+
+	var protected []string
+
+	for _, role := range slices.Sorted(maps.Keys(diff.Roles)) {
+		changes := diff.Roles[role]
+		breaking := breakingDescriptions(changes)
+
+		if len(breaking) == 0 {
+			logger.InfoContext(
+				ctx, "schema changed",
+				slog.String("role", role), slog.Int("changes", len(changes)),
+			)
+
+			continue
+		}
+
+		logger.WarnContext(
+			ctx, "schema changed with breaking changes",
+			slog.String("role", role),
+			slog.Int("changes", len(changes)),
+			slog.Any("breaking", breaking),
+		)
+
+		if slices.Contains(c.breakingChangeRoles, role) {
+			protected = append(protected, role)
+		}
+	}
+
+	switch {
+	case len(protected) == 0:
+	case refuseBreaking:
+		diff.Applied = false
+
+		logger.ErrorContext(
+			ctx, "refusing to swap in rebuilt state: breaking schema changes for protected roles",
+			slog.Any("roles", protected),
+		)
+	default:
+		logger.WarnContext(
+			ctx, "applying breaking schema changes for protected roles: the rebuild was not a metadata reload",
+			slog.Any("roles", protected),
+		)
+	}
+
+	c.schemaDiff.Store(diff)
+
+	return diff.Applied
+}
+
+// HandlerSchemaDiff is the Gin handler for GET /v1/graphql/schema-diff. It
+// returns the SchemaDiff of the most recent rebuild, or 404 before the first
+// one. Only requests authenticated with the admin secret are served.
+func (c *Controller) HandlerSchemaDiff(g *gin.Context) {
+	session := middleware.SessionFromContext(g.Request.Context())
+	if session == nil || !session.IsAdminSecret {
+		g.JSON(
+			http.StatusUnauthorized,
+			newQueryError("$", "access-denied", "restricted access : admin only"),
+		)
+
+		return
+	}
+
+	diff := c.schemaDiff.Load()
+	if diff == nil {
+		g.JSON(
+			http.StatusNotFound,
+			newQueryError("$", "not-found", "no state has been rebuilt since startup"),
+		)
+
+		return
+	}
+
+	g.JSON(http.StatusOK, diff)
+}
@@ -0,0 +1,218 @@
+package controller
+
+import (
+	json "encoding/json/v2"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nhost/nhost/internal/lib/schemadiff"
+	"github.com/nhost/nhost/services/constellation/controller/middleware"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const schemaDiffTestAdminSecret = "schema-diff-secret" //nolint:gosec
+
+func mustLoadSchema(t *testing.T, sdl string) *ast.Schema {
+	t.Helper()
+
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: "schema_diff_test", Input: sdl})
+	if err != nil {
+		t.Fatalf("loading schema: %v", err)
+	}
+
+	return schema
+}
+
+func schemaDiffTestStates(t *testing.T) (*controllerState, *controllerState) {
+	t.Helper()
+
+	oldState := &controllerState{
+		validatedSchemas: map[string]*ast.Schema{
+			"user":   mustLoadSchema(t, `type Query { users: [user!]! } type user { id: ID! email: String! }`),
+			"public": mustLoadSchema(t, `type Query { users: [user!]! } type user { id: ID! }`),
+		},
+		done: make(chan struct{}),
+	}
+
+	newState := &controllerState{
+		validatedSchemas: map[string]*ast.Schema{
+			"user":  mustLoadSchema(t, `type Query { users: [user!]! } type user { id: ID! }`),
+			"admin": mustLoadSchema(t, `type Query { users: [user!]! } type user { id: ID! }`),
+		},
+		done: make(chan struct{}),
+	}
+
+	return oldState, newState
+}
+
+func TestDiffSchemas(t *testing.T) {
+	t.Parallel()
+
+	oldState, newState := schemaDiffTestStates(t)
+
+	roles := diffSchemas(oldState.validatedSchemas, newState.validatedSchemas)
+
+	want := map[string][]schemadiff.ChangeKind{
+		"user":   {schemadiff.FieldRemoved},
+		"public": {roleRemoved},
+		"admin":  {roleAdded},
+	}
+
+	if len(roles) != len(want) {
+		t.Fatalf("roles = %+v, want changes for %v", roles, want)
+	}
+
+	for role, kinds := range want {
+		if len(roles[role]) != len(kinds) || roles[role][0].Kind != kinds[0] {
+			t.Errorf("roles[%s] = %+v, want kinds %v", role, roles[role], kinds)
+		}
+	}
+}
+
+// TestDiffSchemas_IgnoresNormalizedNoise checks that the differences the
+// schemadiff normalisers filter out are not reported, and that the served
+// schemas are left as they were.
+func TestDiffSchemas_IgnoresNormalizedNoise(t *testing.T) {
+	t.Parallel()
+
+	from := map[string]*ast.Schema{"user": mustLoadSchema(t, `
+type Query { users_aggregate: users_aggregate_fields }
+type users_aggregate_fields { max: users_max_fields }
+type users_max_fields { id: Int, name: String }
+input search_users_args { query: String }
+`)}
+	to := map[string]*ast.Schema{"user": mustLoadSchema(t, `
+type Query { users_aggregate: users_aggregate_fields }
+type users_aggregate_fields { max: users_max_fields }
+type users_max_fields { id: Int }
+input search_users_args { query: String! }
+`)}
+
+	if roles := diffSchemas(from, to); len(roles) != 0 {
+		t.Errorf("expected no changes, got %+v", roles)
+	}
+
+	if len(from["user"].Types["users_max_fields"].Fields) != 2 {
+		t.Error("diffSchemas modified the served schema")
+	}
+}
+
+func TestSwapState_RefusesBreakingChangeForProtectedRole(t *testing.T) {
+	t.Parallel()
+
+	oldState, newState := schemaDiffTestStates(t)
+
+	c := &Controller{logger: slog.Default(), breakingChangeRoles: []string{"user"}}
+	c.state.Store(oldState)
+
+	c.swapState(t.Context(), newState, true, slog.New(slog.DiscardHandler))
+
+	if c.state.Load() != oldState {
+		t.Fatal("state must not be swapped when a protected role breaks")
+	}
+
+	select {
+	case <-newState.done:
+	default:
+		t.Error("the refused state must be shut down")
+	}
+
+	if diff := c.schemaDiff.Load(); diff == nil || diff.Applied {
+		t.Errorf("schema diff = %+v, want a recorded, unapplied diff", diff)
+	}
+}
+
+func TestSwapState_AppliesBreakingChangeOutsideMetadataReloads(t *testing.T) {
+	t.Parallel()
+
+	oldState, newState := schemaDiffTestStates(t)
+
+	c := &Controller{logger: slog.Default(), breakingChangeRoles: []string{"user"}}
+	c.state.Store(oldState)
+
+	c.swapState(t.Context(), newState, false, slog.New(slog.DiscardHandler))
+
+	if c.state.Load() != newState {
+		t.Fatal("state should have been swapped when breaking changes are not refused")
+	}
+
+	diff := c.schemaDiff.Load()
+	if diff == nil || !diff.Applied || len(diff.Roles["user"]) != 1 {
+		t.Errorf("schema diff = %+v, want an applied diff with the user role's change", diff)
+	}
+}
+
+func TestSwapState_RecordsDiffForUnprotectedRoles(t *testing.T) {
+	t.Parallel()
+
+	oldState, newState := schemaDiffTestStates(t)
+
+	c := &Controller{logger: slog.Default(), breakingChangeRoles: []string{"admin"}}
+	c.state.Store(oldState)
+
+	c.swapState(t.Context(), newState, true, slog.New(slog.DiscardHandler))
+
+	if c.state.Load() != newState {
+		t.Fatal("state should have been swapped")
+	}
+
+	diff := c.schemaDiff.Load()
+	if diff == nil || !diff.Applied || len(diff.Roles["user"]) != 1 {
+		t.Errorf("schema diff = %+v, want an applied diff with the user role's change", diff)
+	}
+}
+
+func TestHandlerSchemaDiff(t *testing.T) {
+	t.Parallel()
+
+	oldState, newState := schemaDiffTestStates(t)
+
+	c := &Controller{logger: slog.Default()}
+	c.state.Store(oldState)
+
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.Session(schemaDiffTestAdminSecret, middleware.NewNoOpJWTAuthenticator()))
+	router.GET("/v1/graphql/schema-diff", c.HandlerSchemaDiff)
+
+	get := func(admin bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/graphql/schema-diff", nil)
+		if admin {
+			req.Header.Set("X-Hasura-Admin-Secret", schemaDiffTestAdminSecret)
+		}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	if w := get(false); w.Code != http.StatusUnauthorized {
+		t.Errorf("non-admin: expected 401, got %d", w.Code)
+	}
+
+	if w := get(true); w.Code != http.StatusNotFound {
+		t.Errorf("before any rebuild: expected 404, got %d: %s", w.Code, w.Body.String())
+	}
+
+	c.swapState(t.Context(), newState, true, slog.New(slog.DiscardHandler))
+
+	w := get(true)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var got SchemaDiff
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding %s: %v", w.Body.String(), err)
+	}
+
+	if !got.Applied || len(got.Roles) != 3 || got.Roles["user"][0].Path != "user.email" {
+		t.Errorf("unexpected diff %s", w.Body.String())
+	}
+}
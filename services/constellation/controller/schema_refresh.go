@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nhost/nhost/services/constellation/connector/remoteschema"
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+// schemaRefreshBuffer bounds the refreshes queued for Run. A refresh that does
+// not fit is dropped; the watcher reports again on its next TTL tick.
+const schemaRefreshBuffer = 16
+
+// schemaRefreshes collects the background re-introspection outcomes of the
+// remote schemas built into one controllerState (see
+// remoteschema.WithSchemaRefreshes). Connectors report on ch; Run drains it
+// and records failing re-introspections in failures, keyed by remote schema,
+// until the same source recovers or the state is replaced. A nil
+// *schemaRefreshes (NewFromConnectors, tests) never reports and has no
+// failures.
+type schemaRefreshes struct {
+	ch chan remoteschema.SchemaRefresh
+
+	mu       sync.Mutex
+	failures map[string]metadata.Inconsistency
+}
+
+func newSchemaRefreshes() *schemaRefreshes {
+	return &schemaRefreshes{
+		ch:       make(chan remoteschema.SchemaRefresh, schemaRefreshBuffer),
+		mu:       sync.Mutex{},
+		failures: make(map[string]metadata.Inconsistency),
+	}
+}
+
+// notify is the sink handed to the remote-schema connectors. It never blocks
+// so a connector's watcher is not held up by a busy Run loop.
+func (r *schemaRefreshes) notify(refresh remoteschema.SchemaRefresh) {
+	select {
+	case r.ch <- refresh:
+	default:
+	}
+}
+
+// updates returns the channel Run selects on; nil (blocks forever) when the
+// state has no remote schemas being watched.
+func (r *schemaRefreshes) updates() <-chan remoteschema.SchemaRefresh {
+	if r == nil {
+		return nil
+	}
+
+	return r.ch
+}
+
+func (r *schemaRefreshes) fail(
+	ctx context.Context, logger *slog.Logger, source, reason string,
+) {
+	if r == nil {
+		return
+	}
+
+	inc := metadata.Inconsistency{
+		Kind:   metadata.InconsistencyKindRemoteSchema,
+		Source: "",
+		Name:   source,
+		Reason: reason,
+		At:     time.Now(),
+	}
+
+	r.mu.Lock()
+	r.failures[source] = inc
+	r.mu.Unlock()
+
+	logger.WarnContext(
+		ctx, "metadata inconsistency recorded",
+		slog.String("kind", inc.Kind),
+		slog.String("name", inc.Name),
+		slog.String("reason", inc.Reason),
+	)
+}
+
+// resolve clears source's failure and reports whether there was one.
+func (r *schemaRefreshes) resolve(source string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.failures[source]
+	delete(r.failures, source)
+
+	return ok
+}
+
+// snapshot returns the current failures ordered by remote schema name.
+func (r *schemaRefreshes) snapshot() []metadata.Inconsistency {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := slices.Sorted(maps.Keys(r.failures))
+	out := make([]metadata.Inconsistency, 0, len(names))
+
+	for _, name := range names {
+		out = append(out, r.failures[name])
+	}
+
+	return out
+}
+
+// handleSchemaRefresh applies one re-introspection outcome reported by a
+// connector of the current state. Failures are recorded as remote_schema
+// inconsistencies while the connector keeps serving its last good schema; a
+// changed schema rebuilds the state from the current metadata.
+func (c *Controller) handleSchemaRefresh(
+	ctx context.Context, refresh remoteschema.SchemaRefresh, logger *slog.Logger,
+) {
+	state := c.state.Load()
+
+	switch {
+	case refresh.Err != nil:
+		state.refreshes.fail(
+			ctx, logger, refresh.Source,
+			"re-introspection failed, serving last good schema: "+refresh.Err.Error(),
+		)
+	case !refresh.Changed:
+		if state.refreshes.resolve(refresh.Source) {
+			logger.InfoContext(
+				ctx, "remote schema re-introspection recovered",
+				slog.String("remote_schema", refresh.Source),
+			)
+		}
+	default:
+		c.rebuildForSchemaChange(ctx, state, refresh.Source, logger)
+	}
+}
+
+// rebuildForSchemaChange re-introspects source after its SDL changed and swaps
+// the result in. The other sources' connectors, their inconsistencies and the
+// refresh failures of the other remote schemas are carried over (see
+// reuseExcept), so only source's old connector is closed. A rebuild that loses
+// source (the endpoint failed between the refresh and the rebuild's own
+// introspection) is discarded so the last good schema keeps serving; the old
+// connector reports the change again on its next tick, retrying the rebuild.
+func (c *Controller) rebuildForSchemaChange(
+	ctx context.Context, state *controllerState, source string, logger *slog.Logger,
+) {
+	logger.InfoContext(
+		ctx, "remote schema changed, rebuilding controller state",
+		slog.String("remote_schema", source),
+	)
+
+	newState, err := buildState(
+		ctx, state.metadata, state.reuseExcept([]string{source}), c.pollingInterval, c.coordination,
+		c.sessionSettings, c.disableIntrospection, logger,
+	)
+	if err != nil {
+		state.refreshes.fail(ctx, logger, source, "rebuilding after schema change: "+err.Error())
+
+		return
+	}
+
+	if reason, failed := remoteSchemaFailure(newState.inconsistencies, source); failed {
+		newState.shutdown(ctx)
+		newState.closeConnectors(newState.reused)
+
+		state.refreshes.fail(
+			ctx, logger, source,
+			"rebuilding after schema change, serving last good schema: "+reason,
+		)
+
+		return
+	}
+
+	logInconsistencySummary(ctx, logger, newState.inconsistencies)
+	c.swapState(ctx, newState, false, logger)
+
+	// The rebuilt state shares state's refreshes; source introspected fine.
+	newState.refreshes.resolve(source)
+}
+
+// remoteSchemaFailure returns the reason recorded for source if the build
+// dropped that remote schema.
+func remoteSchemaFailure(inc []metadata.Inconsistency, source string) (string, bool) {
+	reasons := make([]string, 0)
+
+	for _, i := range inc {
+		if i.Kind == metadata.InconsistencyKindRemoteSchema && i.Name == source {
+			reasons = append(reasons, i.Reason)
+		}
+	}
+
+	return strings.Join(reasons, "; "), len(reasons) > 0
+}
@@ -2,12 +2,19 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/nhost/nhost/services/constellation/connector"
 	connectormock "github.com/nhost/nhost/services/constellation/connector/mock"
+	"github.com/nhost/nhost/services/constellation/connector/remoteschema"
+	"github.com/nhost/nhost/services/constellation/graph"
 	"github.com/nhost/nhost/services/constellation/metadata"
 	metadatamock "github.com/nhost/nhost/services/constellation/metadata/mock"
 	"github.com/nhost/nhost/services/constellation/subscription"
@@ -77,7 +84,7 @@ func TestControllerState_CloseConnectors(t *testing.T) {
 		},
 	}
 
-	state.closeConnectors()
+	state.closeConnectors(nil)
 }
 
 // --- swapState tests ------------------------------------------------------
@@ -111,7 +118,7 @@ func TestSwapState(t *testing.T) {
 	}
 	c.state.Store(oldState)
 
-	c.swapState(t.Context(), newState, slog.Default())
+	c.swapState(t.Context(), newState, true, slog.Default())
 
 	if c.state.Load() != newState {
 		t.Error("state should have been swapped")
@@ -151,8 +158,9 @@ func TestRun_FileSource_ShutdownOnCancel(t *testing.T) {
 	source.EXPECT().Watch(gomock.Any()).Return(ch)
 
 	c := &Controller{
-		source: source,
-		logger: slog.Default(),
+		source:  source,
+		logger:  slog.Default(),
+		stopped: make(chan struct{}),
 	}
 
 	// Pre-store state so shutdownState tears it down.
@@ -194,8 +202,9 @@ func TestRun_WithSource_ShutdownOnClose(t *testing.T) {
 	source.EXPECT().Watch(gomock.Any()).Return(ch)
 
 	c := &Controller{
-		source: source,
-		logger: slog.Default(),
+		source:  source,
+		logger:  slog.Default(),
+		stopped: make(chan struct{}),
 	}
 	c.state.Store(state)
 
@@ -273,6 +282,7 @@ func TestRun_SuccessfulReloadSwapsStateAndClosesOldConnectors(t *testing.T) {
 		pollingInterval: 0,
 		logger:          slog.Default(),
 		source:          source,
+		stopped:         make(chan struct{}),
 	}
 	c.state.Store(oldState)
 
@@ -330,3 +340,365 @@ func TestRun_SuccessfulReloadSwapsStateAndClosesOldConnectors(t *testing.T) {
 		t.Fatal("Run did not return after context cancel")
 	}
 }
+
+// TestRun_SQLChangeRebuildsOnlyChangedSources checks that a run_sql change is
+// applied by Run, re-introspecting only the changed source: the other
+// source's connector and its inconsistencies are carried over and it is not
+// closed with the old state.
+func TestRun_SQLChangeRebuildsOnlyChangedSources(t *testing.T) {
+	t.Parallel()
+
+	gomockCtrl := gomock.NewController(t)
+
+	keptConn := connectormock.NewMockConnector(gomockCtrl)
+	keptConn.EXPECT().GetSchema().Return(map[string]*graph.Schema{}, nil).AnyTimes()
+	// Closed once, by the new state's shutdown when Run returns.
+	keptConn.EXPECT().Close()
+
+	changedConn := connectormock.NewMockConnector(gomockCtrl)
+	changedClose := make(chan struct{})
+	changedConn.EXPECT().Close().Do(func() {
+		close(changedClose)
+	})
+
+	keptInconsistency := metadata.Inconsistency{
+		Kind:   metadata.InconsistencyKindTable,
+		Source: "kept",
+		Name:   "public.missing",
+		Reason: "table not found",
+		At:     time.Time{},
+	}
+
+	oldState := &controllerState{
+		connectors: map[string]connector.Connector{"kept": keptConn, "changed": changedConn},
+		metadata: &metadata.Metadata{
+			Databases: []metadata.DatabaseMetadata{
+				{Name: "kept", Kind: "postgres"},
+				{Name: "changed", Kind: "unsupported"},
+			},
+		},
+		inconsistencies: []metadata.Inconsistency{keptInconsistency},
+		refreshes:       newSchemaRefreshes(),
+		done:            make(chan struct{}),
+	}
+
+	updates := make(chan metadata.Update)
+	source := metadatamock.NewMockSource(gomockCtrl)
+	source.EXPECT().Watch(gomock.Any()).Return(updates)
+
+	c := &Controller{
+		logger:     slog.Default(),
+		source:     source,
+		sqlChanges: make(chan sqlChange, sqlChangeBuffer),
+		stopped:    make(chan struct{}),
+	}
+	c.state.Store(oldState)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx, slog.Default())
+		close(done)
+	}()
+
+	c.rebuildForSQLChange(t.Context(), []string{"changed"})
+
+	newState := c.state.Load()
+	if newState == oldState {
+		t.Fatal("controller state was not swapped after the run_sql change")
+	}
+
+	if newState.connectors["kept"] != keptConn {
+		t.Error("expected the unchanged source's connector to be reused")
+	}
+
+	if !slices.Contains(newState.inconsistencies, keptInconsistency) {
+		t.Errorf("expected the unchanged source's inconsistency to be kept, got %v", newState.inconsistencies)
+	}
+
+	select {
+	case <-changedClose:
+	case <-time.After(2 * time.Second):
+		t.Fatal("changed source's old connector Close was not invoked")
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after context cancel")
+	}
+}
+
+// --- schema refresh tests ---------------------------------------------------
+
+// TestRun_HandlesRefreshesOfSwappedInState checks that after a reload Run
+// waits on the refreshes of the state it swapped in, not the replaced one's.
+func TestRun_HandlesRefreshesOfSwappedInState(t *testing.T) {
+	t.Parallel()
+
+	oldState := &controllerState{
+		connectors: map[string]connector.Connector{},
+		refreshes:  newSchemaRefreshes(),
+		done:       make(chan struct{}),
+	}
+
+	updates := make(chan metadata.Update, 1)
+	updates <- metadata.Update{
+		Metadata: &metadata.Metadata{Databases: nil, RemoteSchemas: nil},
+		Err:      nil,
+	}
+
+	source := metadatamock.NewMockSource(gomock.NewController(t))
+	source.EXPECT().Watch(gomock.Any()).Return(updates)
+
+	c := &Controller{
+		logger:  slog.Default(),
+		source:  source,
+		stopped: make(chan struct{}),
+	}
+	c.state.Store(oldState)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx, slog.Default())
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for c.state.Load() == oldState {
+		if time.Now().After(deadline) {
+			t.Fatal("controller state was not swapped after the reload")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	newState := c.state.Load()
+	newState.refreshes.notify(remoteschema.SchemaRefresh{
+		Source: "payments", Changed: false, Err: errors.New("connection refused"),
+	})
+
+	for len(newState.refreshes.snapshot()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("refresh reported to the swapped-in state was not handled")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after context cancel")
+	}
+}
+
+func TestHandleSchemaRefresh_FailureIsInconsistentUntilRecovery(t *testing.T) {
+	t.Parallel()
+
+	state := &controllerState{
+		inconsistencies: []metadata.Inconsistency{
+			{Kind: metadata.InconsistencyKindRole, Name: "user"},
+		},
+		refreshes: newSchemaRefreshes(),
+		done:      make(chan struct{}),
+	}
+
+	c := &Controller{logger: slog.Default()}
+	c.state.Store(state)
+
+	c.handleSchemaRefresh(t.Context(), remoteschema.SchemaRefresh{
+		Source: "payments", Changed: false, Err: errors.New("connection refused"),
+	}, slog.Default())
+
+	incs := c.Inconsistencies()
+	if len(incs) != 2 {
+		t.Fatalf("expected build and refresh inconsistencies, got %+v", incs)
+	}
+
+	if incs[1].Kind != metadata.InconsistencyKindRemoteSchema || incs[1].Name != "payments" ||
+		!strings.Contains(incs[1].Reason, "connection refused") {
+		t.Errorf("unexpected refresh inconsistency: %+v", incs[1])
+	}
+
+	if len(state.inconsistencies) != 1 {
+		t.Errorf("build snapshot must not be modified, got %+v", state.inconsistencies)
+	}
+
+	c.handleSchemaRefresh(t.Context(), remoteschema.SchemaRefresh{
+		Source: "payments", Changed: false, Err: nil,
+	}, slog.Default())
+
+	if incs := c.Inconsistencies(); len(incs) != 1 {
+		t.Errorf("expected refresh inconsistency cleared on recovery, got %+v", incs)
+	}
+}
+
+func TestHandleSchemaRefresh_ChangedRebuildsState(t *testing.T) {
+	t.Parallel()
+
+	oldState := &controllerState{
+		metadata:  &metadata.Metadata{Databases: nil, RemoteSchemas: nil},
+		refreshes: newSchemaRefreshes(),
+		done:      make(chan struct{}),
+	}
+
+	c := &Controller{logger: slog.Default()}
+	c.state.Store(oldState)
+
+	c.handleSchemaRefresh(t.Context(), remoteschema.SchemaRefresh{
+		Source: "payments", Changed: true, Err: nil,
+	}, slog.Default())
+
+	if c.state.Load() == oldState {
+		t.Fatal("state should have been rebuilt after a schema change")
+	}
+
+	select {
+	case <-oldState.done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("old state was not shut down after the swap")
+	}
+}
+
+// stateTestIntrospection is a minimal remote schema introspection result.
+const stateTestIntrospection = `{"data":{"__schema":{
+  "queryType":{"name":"Query"},"mutationType":null,"subscriptionType":null,
+  "types":[
+    {"kind":"OBJECT","name":"Query","fields":[
+      {"name":"hello","args":[],"type":{"kind":"SCALAR","name":"String","ofType":null},
+       "isDeprecated":false,"deprecationReason":null}
+    ],"inputFields":null,"interfaces":[],"enumValues":null,"possibleTypes":null},
+    {"kind":"SCALAR","name":"String","fields":null,"inputFields":null,
+     "interfaces":null,"enumValues":null,"possibleTypes":null}
+  ],
+  "directives":[]
+}}}`
+
+// TestHandleSchemaRefresh_ChangedReusesOtherConnectors checks that a changed
+// remote schema re-introspects only that remote schema: the other sources'
+// connectors are reused and left open, only the changed one's old connector
+// is closed, and the other remote schemas' refresh failures are kept.
+func TestHandleSchemaRefresh_ChangedReusesOtherConnectors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(stateTestIntrospection))
+	}))
+	t.Cleanup(server.Close)
+
+	gomockCtrl := gomock.NewController(t)
+
+	// No Close expected: the unchanged database stays open.
+	keptConn := connectormock.NewMockConnector(gomockCtrl)
+	keptConn.EXPECT().GetSchema().Return(map[string]*graph.Schema{}, nil).AnyTimes()
+
+	changedConn := connectormock.NewMockConnector(gomockCtrl)
+	changedClose := make(chan struct{})
+	changedConn.EXPECT().Close().Do(func() {
+		close(changedClose)
+	})
+
+	oldState := &controllerState{
+		connectors: map[string]connector.Connector{"kept": keptConn, "payments": changedConn},
+		metadata: &metadata.Metadata{
+			Databases: []metadata.DatabaseMetadata{{Name: "kept", Kind: "postgres"}},
+			RemoteSchemas: []metadata.RemoteSchemaMetadata{
+				{
+					Name: "payments",
+					Definition: metadata.RemoteSchemaDefinition{
+						URL:            metadata.EnvString(server.URL),
+						TimeoutSeconds: 1,
+					},
+				},
+			},
+		},
+		refreshes: newSchemaRefreshes(),
+		done:      make(chan struct{}),
+	}
+	oldState.refreshes.fail(t.Context(), slog.Default(), "billing", "connection refused")
+	oldState.refreshes.fail(t.Context(), slog.Default(), "payments", "connection refused")
+
+	c := &Controller{logger: slog.Default()}
+	c.state.Store(oldState)
+
+	c.handleSchemaRefresh(t.Context(), remoteschema.SchemaRefresh{
+		Source: "payments", Changed: true, Err: nil,
+	}, slog.Default())
+
+	newState := c.state.Load()
+	if newState == oldState {
+		t.Fatal("state should have been rebuilt after a schema change")
+	}
+
+	t.Cleanup(func() { newState.closeConnectors(newState.reused) })
+
+	if newState.connectors["kept"] != keptConn {
+		t.Error("expected the unchanged source's connector to be reused")
+	}
+
+	if newState.connectors["payments"] == nil || newState.connectors["payments"] == changedConn {
+		t.Error("expected the changed remote schema to be re-introspected")
+	}
+
+	if incs := c.Inconsistencies(); len(incs) != 1 || incs[0].Name != "billing" {
+		t.Errorf("expected only the billing refresh failure to be kept, got %+v", incs)
+	}
+
+	select {
+	case <-changedClose:
+	case <-time.After(2 * time.Second):
+		t.Fatal("changed remote schema's old connector Close was not invoked")
+	}
+}
+
+func TestHandleSchemaRefresh_ChangedKeepsStateWhenRebuildLosesSource(t *testing.T) {
+	t.Parallel()
+
+	// The endpoint is gone by the time the rebuild introspects it, so the
+	// rebuilt state would drop the remote schema entirely.
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	oldState := &controllerState{
+		metadata: &metadata.Metadata{
+			Databases: nil,
+			RemoteSchemas: []metadata.RemoteSchemaMetadata{
+				{
+					Name: "payments",
+					Definition: metadata.RemoteSchemaDefinition{
+						URL:            metadata.EnvString(server.URL),
+						TimeoutSeconds: 1,
+					},
+				},
+			},
+		},
+		refreshes: newSchemaRefreshes(),
+		done:      make(chan struct{}),
+	}
+
+	c := &Controller{logger: slog.Default()}
+	c.state.Store(oldState)
+
+	c.handleSchemaRefresh(t.Context(), remoteschema.SchemaRefresh{
+		Source: "payments", Changed: true, Err: nil,
+	}, slog.Default())
+
+	if c.state.Load() != oldState {
+		t.Fatal("state must be kept when the rebuild loses the changed remote schema")
+	}
+
+	incs := c.Inconsistencies()
+	if len(incs) != 1 || incs[0].Name != "payments" ||
+		!strings.Contains(incs[0].Reason, "serving last good schema") {
+		t.Errorf("expected the failed rebuild recorded for payments, got %+v", incs)
+	}
+}
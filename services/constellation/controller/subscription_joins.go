@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/nhost/nhost/services/constellation/controller/planner"
+	"github.com/nhost/nhost/services/constellation/controller/planner/transform"
+	"github.com/nhost/nhost/services/constellation/internal/requestcontext"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// subscriptionJoins is what forwardUpdates needs to resolve the remote
+// relationships a subscription selects: the query plan and the inputs its
+// remote queries are built from.
+type subscriptionJoins struct {
+	plan      *planner.QueryPlan
+	fragments ast.FragmentDefinitionList
+	variables map[string]any
+}
+
+// planSubscriptionJoins plans a subscription the way execute plans a query.
+// When it selects remote relationships, the returned operation and fragments
+// are what the connector must run instead — relationship fields stripped and
+// their join columns added — and the returned joins are applied by
+// forwardUpdates to every result. Otherwise the operation is returned
+// unchanged with nil joins.
+func planSubscriptionJoins(
+	state *controllerState,
+	operation *ast.OperationDefinition,
+	fragments ast.FragmentDefinitionList,
+	variables map[string]any,
+	connectorName string,
+	role string,
+) (*ast.OperationDefinition, ast.FragmentDefinitionList, *subscriptionJoins, error) {
+	normalized := transform.BuildSubOperation(
+		operation, normalizeRootSelections(operation.SelectionSet, fragments, variables),
+	)
+	normalizedFragments := pruneFragments(fragments, variables)
+
+	plan, err := state.queryPlanner.Plan(normalized, normalizedFragments, role)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("planning subscription: %w", err)
+	}
+
+	if !plan.HasRemoteQueries() || plan.GetPrimaryQueryForConnector(connectorName) == nil {
+		return operation, fragments, nil, nil
+	}
+
+	execOp, execFragments := buildConnectorOperation(
+		plan, connectorName, normalized, normalized.SelectionSet, normalizedFragments,
+	)
+
+	return execOp, execFragments, &subscriptionJoins{
+		plan:      plan,
+		fragments: normalizedFragments,
+		variables: variables,
+	}, nil
+}
+
+// resolveSubscriptionJoins runs the remote-relationship join phase on one
+// subscription result and strips the join columns, so the client receives the
+// same shape as the equivalent query. It reports false when the joined result
+// is unchanged since the last one sent, as the remote side only changes the
+// payload when it is re-resolved.
+func (h *webSocketHandler) resolveSubscriptionJoins(
+	ctx context.Context,
+	sub *subscriptionState,
+	data jsontext.Value,
+	logger *slog.Logger,
+) (jsontext.Value, bool, error) {
+	var results map[string]any
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, false, fmt.Errorf("decoding subscription result: %w", err)
+	}
+
+	if err := resolvePlannedRelationships(
+		requestcontext.ClientHeadersToContext(ctx, h.clientHeaders(ctx)),
+		h.state,
+		results,
+		sub.joins.plan,
+		sub.joins.fragments,
+		sub.joins.variables,
+		h.session.Role,
+		h.session.Variables,
+		logger,
+	); err != nil {
+		return nil, false, err
+	}
+
+	removePhantomFieldsFromPlan(results, sub.joins.plan)
+
+	// Deterministic so equal results hash equally across polls.
+	joined, err := json.Marshal(results, json.Deterministic(true))
+	if err != nil {
+		return nil, false, fmt.Errorf("encoding subscription result: %w", err)
+	}
+
+	hash := strconv.FormatUint(xxhash.Sum64(joined), 16)
+	if hash == sub.lastHash {
+		return nil, false, nil
+	}
+
+	sub.lastHash = hash
+
+	return joined, true, nil
+}
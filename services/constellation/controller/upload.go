@@ -0,0 +1,229 @@
+package controller
+
+import (
+	json "encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nhost/nhost/services/constellation/internal/upload"
+)
+
+const (
+	multipartOperationsField = "operations"
+	multipartMapField        = "map"
+	uploadPathRoot           = "variables"
+
+	// maxUploadFiles bounds the file parts of a multipart request, checked
+	// against the map before any file is spooled.
+	maxUploadFiles = 100
+)
+
+// multipartBudget is what is left of a multipart request's byte limit, which
+// the contents of all of its parts share.
+type multipartBudget struct {
+	limit     int64
+	remaining int64
+}
+
+func (b *multipartBudget) take(n int64) error {
+	if n > b.remaining {
+		return fmt.Errorf("%w: limit is %d bytes", errRequestBodyTooLarge, b.limit)
+	}
+
+	b.remaining -= n
+
+	return nil
+}
+
+// readMultipartRequest reads a GraphQL multipart request
+// (https://github.com/jaydenseric/graphql-multipart-request-spec): the
+// operations field, the map field, then one file part per map entry. The
+// contents of all parts together are capped at maxBytes, and the map may name
+// at most maxUploadFiles files; files are spooled to disk and placed in the
+// request variables at the paths the map assigns them. On success the caller
+// must remove the returned files once the request is resolved.
+func readMultipartRequest(
+	mr *multipart.Reader, maxBytes int64,
+) (GraphQLRequest, []upload.File, error) {
+	budget := &multipartBudget{limit: maxBytes, remaining: maxBytes}
+
+	var req GraphQLRequest
+	if err := readMultipartJSON(mr, multipartOperationsField, budget, &req); err != nil {
+		return GraphQLRequest{}, nil, err
+	}
+
+	var fileMap map[string][]string
+	if err := readMultipartJSON(mr, multipartMapField, budget, &fileMap); err != nil {
+		return GraphQLRequest{}, nil, err
+	}
+
+	if len(fileMap) > maxUploadFiles {
+		return GraphQLRequest{}, nil, fmt.Errorf(
+			"%w: the map names %d files, at most %d are allowed",
+			errInvalidRequestBody, len(fileMap), maxUploadFiles,
+		)
+	}
+
+	files, err := readMultipartFiles(mr, fileMap, req.Variables, budget)
+	if err != nil {
+		removeUploads(files)
+		return GraphQLRequest{}, nil, err
+	}
+
+	return req, files, nil
+}
+
+// readMultipartJSON decodes the next part, which must be the field name, into
+// v, charging its size to budget.
+func readMultipartJSON(mr *multipart.Reader, name string, budget *multipartBudget, v any) error {
+	part, err := mr.NextPart()
+	if err != nil {
+		return fmt.Errorf("%w: reading %s field: %w", errInvalidRequestBody, name, err)
+	}
+	defer part.Close()
+
+	if part.FormName() != name {
+		return fmt.Errorf("%w: expected the %s field, got %q", errInvalidRequestBody, name, part.FormName())
+	}
+
+	body, err := io.ReadAll(io.LimitReader(part, budget.remaining+1))
+	if err != nil {
+		return fmt.Errorf("%w: reading %s field: %w", errInvalidRequestBody, name, err)
+	}
+
+	if err := budget.take(int64(len(body))); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("%w: decoding %s field: %w", errInvalidRequestBody, name, err)
+	}
+
+	return nil
+}
+
+// readMultipartFiles spools the file parts that follow the map field and sets
+// each one in variables at its mapped paths, charging their sizes to budget.
+// Every map entry needs exactly one part. The files spooled so far are
+// returned even on error.
+func readMultipartFiles(
+	mr *multipart.Reader,
+	fileMap map[string][]string,
+	variables map[string]any,
+	budget *multipartBudget,
+) ([]upload.File, error) {
+	files := make([]upload.File, 0, len(fileMap))
+	pending := make(map[string]struct{}, len(fileMap))
+
+	for key := range fileMap {
+		pending[key] = struct{}{}
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return files, fmt.Errorf("%w: reading file part: %w", errInvalidRequestBody, err)
+		}
+
+		key := part.FormName()
+		if _, ok := pending[key]; !ok {
+			part.Close()
+			return files, fmt.Errorf("%w: unexpected field %q", errInvalidRequestBody, key)
+		}
+
+		delete(pending, key)
+
+		file, err := upload.Spool(part, part.FileName(), part.Header.Get("Content-Type"), budget.remaining)
+		part.Close()
+
+		if errors.Is(err, upload.ErrTooLarge) {
+			return files, fmt.Errorf("%w: limit is %d bytes", errRequestBodyTooLarge, budget.limit)
+		}
+
+		if err != nil {
+			return files, fmt.Errorf("%w: %w", errInvalidRequestBody, err)
+		}
+
+		files = append(files, file)
+
+		if err := budget.take(file.Size); err != nil {
+			return files, err
+		}
+
+		for _, path := range fileMap[key] {
+			if err := setUpload(variables, path, file); err != nil {
+				return files, err
+			}
+		}
+	}
+
+	if len(pending) > 0 {
+		missing := slices.Sorted(maps.Keys(pending))
+
+		return files, fmt.Errorf("%w: missing file for map entry %q", errInvalidRequestBody, missing[0])
+	}
+
+	return files, nil
+}
+
+// setUpload replaces the null at the dot-separated path, which must start with
+// "variables", with file.
+func setUpload(variables map[string]any, path string, file upload.File) error {
+	segments := strings.Split(path, ".")
+	if len(segments) < 2 || segments[0] != uploadPathRoot {
+		return fmt.Errorf("%w: upload path %q must start with %q", errInvalidRequestBody, path, uploadPathRoot+".")
+	}
+
+	var container any = variables
+	for _, segment := range segments[1 : len(segments)-1] {
+		container = uploadPathChild(container, segment)
+	}
+
+	key := segments[len(segments)-1]
+
+	switch c := container.(type) {
+	case map[string]any:
+		if value, ok := c[key]; ok && value == nil {
+			c[key] = file
+			return nil
+		}
+	case []any:
+		if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(c) && c[index] == nil {
+			c[index] = file
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: upload path %q does not point at a null variable", errInvalidRequestBody, path)
+}
+
+// uploadPathChild returns the value at segment of an object or list, or nil.
+func uploadPathChild(container any, segment string) any {
+	switch c := container.(type) {
+	case map[string]any:
+		return c[segment]
+	case []any:
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(c) {
+			return c[index]
+		}
+	}
+
+	return nil
+}
+
+// removeUploads deletes the spooled files of a request.
+func removeUploads(files []upload.File) {
+	for _, file := range files {
+		_ = file.Remove()
+	}
+}
@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/nhost/nhost/services/constellation/internal/upload"
+)
+
+type multipartField struct {
+	name     string
+	filename string
+	content  string
+}
+
+// newMultipartReader encodes fields, in order, as a multipart body.
+func newMultipartReader(t *testing.T, fields ...multipartField) *multipart.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := multipart.NewWriter(&buf)
+
+	for _, f := range fields {
+		var (
+			part io.Writer
+			err  error
+		)
+
+		if f.filename != "" {
+			part, err = w.CreateFormFile(f.name, f.filename)
+		} else {
+			part, err = w.CreateFormField(f.name)
+		}
+
+		if err != nil {
+			t.Fatalf("creating part %s: %v", f.name, err)
+		}
+
+		if _, err := io.WriteString(part, f.content); err != nil {
+			t.Fatalf("writing part %s: %v", f.name, err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("closing multipart writer: %v", err)
+	}
+
+	return multipart.NewReader(&buf, w.Boundary())
+}
+
+func readUpload(t *testing.T, value any) string {
+	t.Helper()
+
+	file, ok := value.(upload.File)
+	if !ok {
+		t.Fatalf("expected upload.File, got %T (%v)", value, value)
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		t.Fatalf("opening upload: %v", err)
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("reading upload: %v", err)
+	}
+
+	return string(content)
+}
+
+func TestReadMultipartRequest(t *testing.T) {
+	t.Parallel()
+
+	mr := newMultipartReader(t,
+		multipartField{
+			name:    "operations",
+			content: `{"query":"mutation ($a: Upload, $b: [Upload]) { x }","variables":{"a":null,"b":[null,null]}}`,
+		},
+		multipartField{name: "map", content: `{"0":["variables.a"],"1":["variables.b.1"]}`},
+		multipartField{name: "0", filename: "a.txt", content: "alpha"},
+		multipartField{name: "1", filename: "b.txt", content: "beta"},
+	)
+
+	req, files, err := readMultipartRequest(mr, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer removeUploads(files)
+
+	if len(files) != 2 {
+		t.Fatalf("expected 2 spooled files, got %d", len(files))
+	}
+
+	if got := readUpload(t, req.Variables["a"]); got != "alpha" {
+		t.Errorf("variables.a = %q, want alpha", got)
+	}
+
+	list, _ := req.Variables["b"].([]any)
+	if len(list) != 2 || list[0] != nil {
+		t.Fatalf("variables.b = %v, want [null, file]", req.Variables["b"])
+	}
+
+	if got := readUpload(t, list[1]); got != "beta" {
+		t.Errorf("variables.b.1 = %q, want beta", got)
+	}
+}
+
+func TestReadMultipartRequestErrors(t *testing.T) {
+	t.Parallel()
+
+	// 62 bytes: with a 21-byte map, a 20-byte file exceeds the limit of 100
+	// that no part exceeds alone.
+	const operations = `{"query":"mutation ($a: Upload) { x }","variables":{"a":null}}`
+
+	entries := make([]string, 0, maxUploadFiles+1)
+	for i := range maxUploadFiles + 1 {
+		entries = append(entries, fmt.Sprintf(`"%d":[]`, i))
+	}
+
+	manyFilesMap := "{" + strings.Join(entries, ",") + "}"
+
+	tests := []struct {
+		name     string
+		fields   []multipartField
+		maxBytes int64
+		wantErr  error
+	}{
+		{
+			name: "map before operations",
+			fields: []multipartField{
+				{name: "map", content: `{}`},
+				{name: "operations", content: operations},
+			},
+			wantErr: errInvalidRequestBody,
+		},
+		{
+			name: "file part not in map",
+			fields: []multipartField{
+				{name: "operations", content: operations},
+				{name: "map", content: `{"0":["variables.a"]}`},
+				{name: "1", filename: "a.txt", content: "alpha"},
+			},
+			wantErr: errInvalidRequestBody,
+		},
+		{
+			name: "missing file part",
+			fields: []multipartField{
+				{name: "operations", content: operations},
+				{name: "map", content: `{"0":["variables.a"]}`},
+			},
+			wantErr: errInvalidRequestBody,
+		},
+		{
+			name: "path outside variables",
+			fields: []multipartField{
+				{name: "operations", content: operations},
+				{name: "map", content: `{"0":["query"]}`},
+				{name: "0", filename: "a.txt", content: "alpha"},
+			},
+			wantErr: errInvalidRequestBody,
+		},
+		{
+			name: "path to a non-null value",
+			fields: []multipartField{
+				{name: "operations", content: `{"query":"{ x }","variables":{"a":"set"}}`},
+				{name: "map", content: `{"0":["variables.a"]}`},
+				{name: "0", filename: "a.txt", content: "alpha"},
+			},
+			wantErr: errInvalidRequestBody,
+		},
+		{
+			name: "parts together larger than the limit",
+			fields: []multipartField{
+				{name: "operations", content: operations},
+				{name: "map", content: `{"0":["variables.a"]}`},
+				{name: "0", filename: "a.txt", content: string(bytes.Repeat([]byte("x"), 20))},
+			},
+			wantErr: errRequestBodyTooLarge,
+		},
+		{
+			name: "more files than allowed",
+			fields: []multipartField{
+				{name: "operations", content: `{"query":"{ x }"}`},
+				{name: "map", content: manyFilesMap},
+			},
+			maxBytes: 1 << 20,
+			wantErr:  errInvalidRequestBody,
+		},
+		{
+			name: "file larger than the limit",
+			fields: []multipartField{
+				{name: "operations", content: operations},
+				{name: "map", content: `{"0":["variables.a"]}`},
+				{name: "0", filename: "a.txt", content: string(bytes.Repeat([]byte("x"), 200))},
+			},
+			wantErr: errRequestBodyTooLarge,
+		},
+		{
+			name: "operations larger than the limit",
+			fields: []multipartField{
+				{name: "operations", content: `{"query":"` + string(bytes.Repeat([]byte("x"), 200)) + `"}`},
+			},
+			wantErr: errRequestBodyTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, files, err := readMultipartRequest(
+				newMultipartReader(t, tt.fields...), cmp.Or(tt.maxBytes, 100),
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+
+			if files != nil {
+				t.Errorf("expected no files on error, got %d", len(files))
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/nhost/nhost/services/constellation/controller/planner/transform"
 	"github.com/nhost/nhost/services/constellation/controller/websocket"
 	"github.com/nhost/nhost/services/constellation/internal/lib/syncmap"
+	"github.com/nhost/nhost/services/constellation/internal/requestcontext"
 	"github.com/nhost/nhost/services/constellation/subscription"
 	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/gqlerror"
@@ -32,6 +33,9 @@ type subscriptionState struct {
 	variables     map[string]any
 	lastHash      string // xxhash for change detection
 	stopCh        chan struct{}
+	// joins resolves the remote relationships the subscription selects on
+	// every result; nil when it selects none.
+	joins *subscriptionJoins
 }
 
 // webSocketHandler implements websocket.MessageHandler and bridges
@@ -49,8 +53,12 @@ type webSocketHandler struct {
 
 	// Per-connection state
 	session *middleware.SessionVariables
-	sendCh  chan<- *websocket.Message
-	subs    *syncmap.Map[string, *subscriptionState]
+	// initHeaders are the headers sent in connection_init. They are handed to
+	// subscription handlers as client headers, on top of the upgrade
+	// request's, since browsers cannot set headers on the upgrade request.
+	initHeaders http.Header
+	sendCh      chan<- *websocket.Message
+	subs        *syncmap.Map[string, *subscriptionState]
 }
 
 // newWebSocketHandler creates a new WebSocket message handler.
@@ -75,6 +83,7 @@ func newWebSocketHandler(
 		devMode:         devMode,
 		logger:          logger,
 		session:         nil,
+		initHeaders:     nil,
 		sendCh:          sendCh,
 		subs:            syncmap.New[string, *subscriptionState](),
 	}
@@ -94,6 +103,7 @@ func (h *webSocketHandler) OnConnectionInit(
 	}
 
 	h.session = session
+	h.initHeaders = headers
 
 	h.logger.DebugContext(
 		ctx, "connection initialized",
@@ -142,6 +152,13 @@ func (h *webSocketHandler) OnSubscribe(
 		return
 	}
 
+	if errs := h.state.introspection.Check(
+		h.state.validatedSchemas[h.session.Role], operation, fragments, h.session.Role,
+	); errs != nil {
+		h.sendErrors(id, formatGQLErrors(errs))
+		return
+	}
+
 	dbName := getConnectorForOperation(h.state, operation)
 
 	subHandler := h.state.subHandlers[dbName]
@@ -150,6 +167,14 @@ func (h *webSocketHandler) OnSubscribe(
 		return
 	}
 
+	operation, fragments, joins, err := planSubscriptionJoins(
+		h.state, operation, fragments, validatedVariables, dbName, h.session.Role,
+	)
+	if err != nil {
+		h.sendError(id, err.Error())
+		return
+	}
+
 	h.startSubscription(
 		ctx,
 		id,
@@ -158,6 +183,7 @@ func (h *webSocketHandler) OnSubscribe(
 		operation,
 		fragments,
 		validatedVariables,
+		joins,
 		logger,
 	)
 }
@@ -209,6 +235,7 @@ func (h *webSocketHandler) startSubscription(
 	operation *ast.OperationDefinition,
 	fragments ast.FragmentDefinitionList,
 	validatedVariables map[string]any,
+	joins *subscriptionJoins,
 	logger *slog.Logger,
 ) {
 	// Keep a reference to the handler so OnComplete/OnClose stop the
@@ -221,6 +248,7 @@ func (h *webSocketHandler) startSubscription(
 		variables:     validatedVariables,
 		lastHash:      "",
 		stopCh:        make(chan struct{}),
+		joins:         joins,
 	}
 
 	h.subs.Store(id, sub)
@@ -252,7 +280,9 @@ func (h *webSocketHandler) startSubscription(
 		return
 	}
 
-	updateCh, err := subHandler.Start(ctx, req, logger)
+	updateCh, err := subHandler.Start(
+		requestcontext.ClientHeadersToContext(ctx, h.clientHeaders(ctx)), req, logger,
+	)
 	if err != nil {
 		h.sendSubscriptionRuntimeError(ctx, id, logger, err)
 
@@ -264,6 +294,21 @@ func (h *webSocketHandler) startSubscription(
 	go h.forwardUpdates(ctx, sub, updateCh, logger)
 }
 
+// clientHeaders merges the connection_init headers over the upgrade request's
+// client headers, for handlers that forward client headers upstream.
+func (h *webSocketHandler) clientHeaders(ctx context.Context) http.Header {
+	headers := requestcontext.ClientHeadersFromContext(ctx).Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+
+	for name, values := range h.initHeaders {
+		headers[name] = values
+	}
+
+	return headers
+}
+
 // getConnectorForOperation determines which database connector should handle the operation.
 func getConnectorForOperation(
 	state *controllerState,
@@ -420,12 +465,18 @@ func (h *webSocketHandler) removeSubscription(id string) *subscriptionState {
 }
 
 // forwardUpdates reads from the update channel and sends WebSocket messages.
+// When the handler closes the channel on its own (a remote schema completing
+// its subscription), the client is sent complete; after an error frame, which
+// already ends the operation for the client, or on a metadata reload, which
+// closes the connection instead, nothing is sent.
 func (h *webSocketHandler) forwardUpdates(
 	ctx context.Context,
 	sub *subscriptionState,
 	updateCh <-chan subscription.Update,
 	logger *slog.Logger,
 ) {
+	lastWasError := false
+
 	for {
 		select {
 		case <-sub.stopCh:
@@ -436,26 +487,74 @@ func (h *webSocketHandler) forwardUpdates(
 			return
 		case update, ok := <-updateCh:
 			if !ok {
-				// Channel closed
 				logger.DebugContext(ctx, "update channel closed")
+				h.completeSubscription(sub, lastWasError)
+
 				return
 			}
 
-			if update.Error != nil {
+			data, send, err := h.joinUpdate(ctx, sub, update, logger)
+
+			lastWasError = err != nil
+
+			if err != nil {
 				// Mirror startSubscription's classification. Live-query
 				// subscriptions only build SQL inside the polling goroutine, so
 				// this is the sole place async plan failures reach the protocol
 				// layer for them.
-				h.sendSubscriptionRuntimeError(ctx, update.SubscriptionID, logger, update.Error)
+				h.sendSubscriptionRuntimeError(ctx, update.SubscriptionID, logger, err)
 
 				continue
 			}
 
-			h.sendNext(update.SubscriptionID, update.Data, nil)
+			if send {
+				h.sendNext(update.SubscriptionID, data, nil)
+			}
 		}
 	}
 }
 
+// joinUpdate returns the payload to send for an update: its data, with the
+// subscription's remote relationships resolved when it selects any. It reports
+// false when the joined payload is unchanged, and returns the update's error
+// or the join's.
+func (h *webSocketHandler) joinUpdate(
+	ctx context.Context,
+	sub *subscriptionState,
+	update subscription.Update,
+	logger *slog.Logger,
+) (jsontext.Value, bool, error) {
+	if update.Error != nil {
+		return nil, false, update.Error
+	}
+
+	if sub.joins == nil {
+		return update.Data, true, nil
+	}
+
+	return h.resolveSubscriptionJoins(ctx, sub, update.Data, logger)
+}
+
+// completeSubscription unregisters a subscription whose update channel the
+// handler closed and, unless the client has already been told it ended,
+// sends complete.
+func (h *webSocketHandler) completeSubscription(sub *subscriptionState, ended bool) {
+	select {
+	case <-h.state.done:
+		return
+	default:
+	}
+
+	if h.removeSubscription(sub.id) == nil || ended {
+		return
+	}
+
+	select {
+	case h.sendCh <- websocket.NewCompleteMessage(sub.id):
+	default:
+	}
+}
+
 // sendNext sends a next message to the client.
 func (h *webSocketHandler) sendNext(id string, data any, errors any) {
 	select {
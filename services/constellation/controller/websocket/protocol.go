@@ -67,6 +67,12 @@ func NewNextMessage(id string, data any, errors any) *Message {
 	return &Message{ID: id, Type: messageTypeNext, Payload: payload}
 }
 
+// NewCompleteMessage creates a complete message, telling the client the
+// server has finished the operation.
+func NewCompleteMessage(id string) *Message {
+	return &Message{ID: id, Type: messageTypeComplete, Payload: nil}
+}
+
 // NewErrorMessage creates an error message.
 func NewErrorMessage(id string, errs []map[string]any) *Message {
 	payload, err := json.Marshal(errs)
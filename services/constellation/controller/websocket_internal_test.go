@@ -14,6 +14,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/nhost/nhost/services/constellation/connector/schemamerge"
 	"github.com/nhost/nhost/services/constellation/controller/middleware"
+	"github.com/nhost/nhost/services/constellation/controller/planner"
 	"github.com/nhost/nhost/services/constellation/controller/websocket"
 	"github.com/nhost/nhost/services/constellation/internal/lib/syncmap"
 	"github.com/nhost/nhost/services/constellation/subscription"
@@ -129,14 +130,18 @@ func TestWebSocketHandlerOnSubscribeCoercesDefaultedDirectiveVariable(t *testing
 
 	sendCh := make(chan *websocket.Message, 1)
 
+	schemas := wsTestSchemas(t)
+	fieldToConnector := map[string]string{
+		schemamerge.FieldKey(ast.Subscription, "users"): "db",
+	}
+
 	h := &webSocketHandler{
 		state: &controllerState{
-			validatedSchemas: wsTestSchemas(t),
-			fieldToConnector: map[string]string{
-				schemamerge.FieldKey(ast.Subscription, "users"): "db",
-			},
-			subHandlers: map[string]subscription.Handler{"db": mockHandler},
-			queryCache:  newQueryCache(),
+			validatedSchemas: schemas,
+			fieldToConnector: fieldToConnector,
+			subHandlers:      map[string]subscription.Handler{"db": mockHandler},
+			queryCache:       newQueryCache(),
+			queryPlanner:     planner.New(schemas, fieldToConnector, nil, nil),
 		},
 		adminSecret:     "",
 		jwtAuth:         nil,
@@ -385,6 +390,7 @@ func TestStartSubscriptionStartErrorClassification(t *testing.T) {
 				op,
 				nil,
 				nil,
+				nil,
 				h.logger,
 			)
 
@@ -453,6 +459,7 @@ func TestStartSubscriptionQueryValidationErrorPreservesStructuredEnvelope(t *tes
 		op,
 		nil,
 		nil,
+		nil,
 		h.logger,
 	)
 
@@ -522,6 +529,7 @@ func TestStartSubscriptionNewRequestErrorClassification(t *testing.T) {
 		op,
 		nil,
 		nil,
+		nil,
 		h.logger,
 	)
 
@@ -676,6 +684,82 @@ func TestForwardUpdatesQueryValidationErrorPreservesStructuredEnvelope(t *testin
 	}
 }
 
+func TestForwardUpdatesCompletesClosedSubscription(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		updates      []subscription.Update
+		wantComplete bool
+	}{
+		{
+			name:         "handler completes after data",
+			updates:      []subscription.Update{subscription.NewUpdateData("sub-1", []byte(`{"n":1}`))},
+			wantComplete: true,
+		},
+		{
+			name:         "no complete after an error",
+			updates:      []subscription.Update{subscription.NewUpdateError("sub-1", errors.New("boom"))}, //nolint:err113
+			wantComplete: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sendCh := make(chan *websocket.Message, len(tc.updates)+1)
+
+			h := &webSocketHandler{
+				state:           &controllerState{},
+				adminSecret:     "",
+				jwtAuth:         nil,
+				pollingInterval: defaultPollingInterval,
+				devMode:         false,
+				logger:          slog.New(slog.DiscardHandler),
+				session:         &middleware.SessionVariables{Role: "user", Variables: nil},
+				initHeaders:     nil,
+				sendCh:          sendCh,
+				subs:            syncmap.New[string, *subscriptionState](),
+			}
+
+			sub := &subscriptionState{
+				id:            "sub-1",
+				handler:       nil,
+				query:         "subscription { notifications { id } }",
+				operationName: "",
+				variables:     nil,
+				lastHash:      "",
+				stopCh:        make(chan struct{}),
+			}
+			h.subs.Store(sub.id, sub)
+
+			updateCh := make(chan subscription.Update, len(tc.updates))
+			for _, update := range tc.updates {
+				updateCh <- update
+			}
+
+			close(updateCh)
+
+			h.forwardUpdates(context.Background(), sub, updateCh, h.logger)
+			close(sendCh)
+
+			var last *websocket.Message
+			for msg := range sendCh {
+				last = msg
+			}
+
+			if gotComplete := last != nil && last.Type == "complete"; gotComplete != tc.wantComplete {
+				t.Errorf("last message = %+v, want complete: %v", last, tc.wantComplete)
+			}
+
+			if _, ok := h.subs.Load(sub.id); ok {
+				t.Error("subscription still registered after the handler closed its channel")
+			}
+		})
+	}
+}
+
 // --- extractHeadersFromPayload tests --------------------------------------
 
 func TestExtractHeadersFromPayload(t *testing.T) {
@@ -234,7 +234,7 @@ func TestQueryRemoteRelationships(t *testing.T) { //nolint:paralleltest,maintidx
 		},
 
 		{
-			name: "subscriptions not supported",
+			name: "subscriptions over HTTP not supported",
 			query: query{
 				Query: `subscription {
 					userProfiles {
@@ -248,7 +248,7 @@ func TestQueryRemoteRelationships(t *testing.T) { //nolint:paralleltest,maintidx
 			expected: map[string]any{
 				"errors": []any{
 					map[string]any{
-						"message": "remote relationships are not supported in subscriptions",
+						"message": "remote relationships in subscriptions are only supported over WebSocket",
 					},
 				},
 			},
@@ -0,0 +1,65 @@
+package integration_test
+
+import (
+	json "encoding/json/v2"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/nhost/services/constellation/integration/subtest"
+)
+
+// TestSubscriptionRemoteRelationships verifies that a subscription selecting
+// remote relationships (db → db here) delivers the same shape as the
+// equivalent query, with the join columns stripped.
+func TestSubscriptionRemoteRelationships(t *testing.T) { //nolint:paralleltest
+	ReinitializeTestData(t)
+
+	const selection = `userProfiles(order_by: { id: asc }) {
+		id
+		user {
+			displayName
+		}
+	}`
+
+	headers := http.Header{}
+	headers.Set("x-hasura-admin-secret", adminSecret)
+
+	want, err := makeHTTPQuery(
+		t.Context(), constellationURL, query{Query: "query {" + selection + "}"}, headers,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := subtest.NewClient(t, wsURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Send(subtest.Message{
+		Type:    subtest.ConnectionInit,
+		Payload: initWithAdmin(),
+	}).Expect(func(msg subtest.Message) {
+		if msg.Type != subtest.ConnectionAck {
+			t.Fatalf("expected connection_ack, got %s", msg.Type)
+		}
+	}).Send(subtest.Message{
+		ID:      "1",
+		Type:    subtest.Subscribe,
+		Payload: subscribePayload("subscription {" + selection + "}"),
+	}).Expect(func(msg subtest.Message) {
+		if msg.Type != subtest.Next {
+			t.Fatalf("expected next, got type=%s payload=%s", msg.Type, string(msg.Payload))
+		}
+
+		var got any
+		if err := json.Unmarshal(msg.Payload, &got); err != nil {
+			t.Fatalf("could not unmarshal payload: %v", err)
+		}
+
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("subscription differs from the equivalent query (-query +subscription):\n%s", diff)
+		}
+	}).Close()
+}
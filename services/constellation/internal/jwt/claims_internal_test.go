@@ -9,7 +9,8 @@ import (
 )
 
 // TestJWKSAllowedMethods pins the JWKS algorithm allowlist: it must contain
-// only asymmetric (RS*) families that the static-key path also accepts, and
+// only asymmetric (RS*, PS*, ES*, EdDSA) families that the static-key path
+// also accepts, and
 // must never include any symmetric (HS*) algorithm or "none". A symmetric or
 // "none" entry would reintroduce the algorithm-confusion surface the allowlist
 // exists to close.
@@ -25,6 +26,13 @@ func TestJWKSAllowedMethods(t *testing.T) {
 		string(jwtconfig.AlgorithmRS256): true,
 		string(jwtconfig.AlgorithmRS384): true,
 		string(jwtconfig.AlgorithmRS512): true,
+		string(jwtconfig.AlgorithmPS256): true,
+		string(jwtconfig.AlgorithmPS384): true,
+		string(jwtconfig.AlgorithmPS512): true,
+		string(jwtconfig.AlgorithmES256): true,
+		string(jwtconfig.AlgorithmES384): true,
+		string(jwtconfig.AlgorithmES512): true,
+		string(jwtconfig.AlgorithmEdDSA): true,
 	}
 
 	for _, m := range methods {
@@ -1,7 +1,8 @@
 // Package jwt validates JWT bearer tokens against one or more configured
 // secrets and extracts Hasura-compatible session variables from the verified
-// claims. It supports HMAC and RSA algorithms, multiple secrets with
-// fall-through validation, and JWKS URLs for rotated key sets.
+// claims. It supports HMAC, RSA (PKCS#1 v1.5 and PSS), ECDSA and Ed25519
+// algorithms, multiple secrets selected by issuer/audience with fall-through
+// validation, and JWKS URLs for rotated key sets.
 //
 // Authenticator is the entry point: each request runs through every
 // configured secretValidator until one verifies the token; the matching
@@ -169,9 +170,12 @@ func (a *Authenticator) AuthenticateWithExpiration(
 func (a *Authenticator) authenticate(
 	headers http.Header, roleOverride string,
 ) (*SessionResult, *time.Time, error) {
-	var lastErr error
-
-	sawToken := false
+	var (
+		lastErr     error
+		routedToken string
+		routing     tokenRouting
+		sawToken    bool
+	)
 
 	for i, sv := range a.validators {
 		token := extractToken(headers, sv.headerCfg)
@@ -181,6 +185,23 @@ func (a *Authenticator) authenticate(
 
 		sawToken = true
 
+		// With several secrets configured (HASURA_GRAPHQL_JWT_SECRETS), a
+		// secret whose issuer/audience restriction cannot match the token is
+		// skipped before any signature work, so the token is only verified
+		// against the secrets of the issuer that minted it.
+		if token != routedToken {
+			routedToken = token
+			routing = peekTokenRouting(token)
+		}
+
+		if !sv.acceptsRouting(routing) {
+			if lastErr == nil {
+				lastErr = ErrNoMatchingSecret
+			}
+
+			continue
+		}
+
 		// A token was extracted by this secret. Signature/claim-time
 		// validation failures fall through to the next configured secret:
 		// Hasura accepts a token if ANY configured secret verifies it (the
@@ -2,6 +2,9 @@ package jwt_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -2487,3 +2490,433 @@ func TestConfigLiteralRoundTripsThroughNewAuthenticator(t *testing.T) {
 		t.Errorf("literal Config produced unexpected session (-want +got):\n%s", diff)
 	}
 }
+
+// marshalPublicKeyPEM PKIX-encodes pub as a "PUBLIC KEY" PEM block.
+func marshalPublicKeyPEM(t *testing.T, pub any) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func generateECDSAKey(t *testing.T, curve elliptic.Curve) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ECDSA key: %v", err)
+	}
+
+	return key
+}
+
+func signToken(
+	t *testing.T, method gojwt.SigningMethod, privKey any, claims gojwt.MapClaims,
+) string {
+	t.Helper()
+
+	tokenStr, err := gojwt.NewWithClaims(method, claims).SignedString(privKey)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return tokenStr
+}
+
+func TestAuthenticatorAsymmetricAlgorithms(t *testing.T) {
+	t.Parallel()
+
+	rsaPrivKey, rsaPubPEM := generateRSAKeyPair(t)
+	ec256 := generateECDSAKey(t, elliptic.P256())
+	ec384 := generateECDSAKey(t, elliptic.P384())
+	ec521 := generateECDSAKey(t, elliptic.P521())
+
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		alg     jwtconfig.Algorithm
+		pubPEM  string
+		method  gojwt.SigningMethod
+		privKey any
+	}{
+		{
+			name:    "ps256",
+			alg:     jwtconfig.AlgorithmPS256,
+			pubPEM:  rsaPubPEM,
+			method:  gojwt.SigningMethodPS256,
+			privKey: rsaPrivKey,
+		},
+		{
+			name:    "ps512",
+			alg:     jwtconfig.AlgorithmPS512,
+			pubPEM:  rsaPubPEM,
+			method:  gojwt.SigningMethodPS512,
+			privKey: rsaPrivKey,
+		},
+		{
+			name:    "es256",
+			alg:     jwtconfig.AlgorithmES256,
+			pubPEM:  marshalPublicKeyPEM(t, &ec256.PublicKey),
+			method:  gojwt.SigningMethodES256,
+			privKey: ec256,
+		},
+		{
+			name:    "es384",
+			alg:     jwtconfig.AlgorithmES384,
+			pubPEM:  marshalPublicKeyPEM(t, &ec384.PublicKey),
+			method:  gojwt.SigningMethodES384,
+			privKey: ec384,
+		},
+		{
+			name:    "es512",
+			alg:     jwtconfig.AlgorithmES512,
+			pubPEM:  marshalPublicKeyPEM(t, &ec521.PublicKey),
+			method:  gojwt.SigningMethodES512,
+			privKey: ec521,
+		},
+		{
+			name:    "eddsa",
+			alg:     jwtconfig.AlgorithmEdDSA,
+			pubPEM:  marshalPublicKeyPEM(t, edPub),
+			method:  gojwt.SigningMethodEdDSA,
+			privKey: edPriv,
+		},
+		{
+			name:    "ed25519 alias",
+			alg:     jwtconfig.AlgorithmEd25519,
+			pubPEM:  marshalPublicKeyPEM(t, edPub),
+			method:  gojwt.SigningMethodEdDSA,
+			privKey: edPriv,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auth, err := jwt.NewAuthenticator(context.Background(), jwtconfig.Config{
+				Secrets: []jwtconfig.Secret{{Type: tc.alg, Key: tc.pubPEM}},
+			}, slog.Default())
+			if err != nil {
+				t.Fatalf("NewAuthenticator() error = %v", err)
+			}
+			defer auth.Close()
+
+			tokenStr := signToken(t, tc.method, tc.privKey, gojwt.MapClaims{
+				"exp": gojwt.NewNumericDate(time.Now().Add(time.Hour)),
+				"https://hasura.io/jwt/claims": hasuraClaims(
+					[]string{"user"}, "user", nil,
+				),
+			})
+
+			result, err := auth.Authenticate(
+				http.Header{"Authorization": {"Bearer " + tokenStr}}, "",
+			)
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+
+			if result == nil || result.Role != "user" {
+				t.Errorf("expected role=user, got %+v", result)
+			}
+		})
+	}
+}
+
+func TestNewAuthenticatorRejectsMismatchedPublicKey(t *testing.T) {
+	t.Parallel()
+
+	_, rsaPubPEM := generateRSAKeyPair(t)
+	ec256 := generateECDSAKey(t, elliptic.P256())
+
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		alg     jwtconfig.Algorithm
+		pubPEM  string
+		wantErr error
+	}{
+		{
+			name:    "rsa key for es256",
+			alg:     jwtconfig.AlgorithmES256,
+			pubPEM:  rsaPubPEM,
+			wantErr: jwt.ErrNotECDSAPublicKey,
+		},
+		{
+			name:    "p-256 key for es384",
+			alg:     jwtconfig.AlgorithmES384,
+			pubPEM:  marshalPublicKeyPEM(t, &ec256.PublicKey),
+			wantErr: jwt.ErrECDSACurveMismatch,
+		},
+		{
+			name:    "ecdsa key for ps256",
+			alg:     jwtconfig.AlgorithmPS256,
+			pubPEM:  marshalPublicKeyPEM(t, &ec256.PublicKey),
+			wantErr: jwt.ErrNotRSAPublicKey,
+		},
+		{
+			name:    "ed25519 key for rs256",
+			alg:     jwtconfig.AlgorithmRS256,
+			pubPEM:  marshalPublicKeyPEM(t, edPub),
+			wantErr: jwt.ErrNotRSAPublicKey,
+		},
+		{
+			name:    "rsa key for eddsa",
+			alg:     jwtconfig.AlgorithmEdDSA,
+			pubPEM:  rsaPubPEM,
+			wantErr: jwt.ErrNotEd25519PublicKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := jwt.NewAuthenticator(context.Background(), jwtconfig.Config{
+				Secrets: []jwtconfig.Secret{{Type: tc.alg, Key: tc.pubPEM}},
+			}, slog.Default())
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("NewAuthenticator() error = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewAuthenticatorCertificateKey(t *testing.T) {
+	t.Parallel()
+
+	ecKey := generateECDSAKey(t, elliptic.P256())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &ecKey.PublicKey, ecKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	auth, err := jwt.NewAuthenticator(context.Background(), jwtconfig.Config{
+		Secrets: []jwtconfig.Secret{{Type: jwtconfig.AlgorithmES256, Key: certPEM}},
+	}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	defer auth.Close()
+
+	tokenStr := signToken(t, gojwt.SigningMethodES256, ecKey, gojwt.MapClaims{
+		"exp": gojwt.NewNumericDate(time.Now().Add(time.Hour)),
+		"https://hasura.io/jwt/claims": hasuraClaims(
+			[]string{"user"}, "user", nil,
+		),
+	})
+
+	result, err := auth.Authenticate(http.Header{"Authorization": {"Bearer " + tokenStr}}, "")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	if result == nil || result.Role != "user" {
+		t.Errorf("expected role=user, got %+v", result)
+	}
+}
+
+func TestAuthenticatorJWKURLECDSAKey(t *testing.T) {
+	t.Parallel()
+
+	ecKey := generateECDSAKey(t, elliptic.P256())
+	kid := "ec-kid"
+
+	jwksJSON, err := json.Marshal(map[string]any{
+		"keys": []map[string]any{
+			{
+				"kty": "EC",
+				"alg": "ES256",
+				"use": "sig",
+				"kid": kid,
+				"crv": "P-256",
+				"x":   base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+				"y":   base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal JWKS: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(jwksJSON)
+	}))
+	t.Cleanup(srv.Close)
+
+	auth, err := jwt.NewAuthenticator(context.Background(), jwtconfig.Config{
+		Secrets: []jwtconfig.Secret{{JWKURL: srv.URL}},
+	}, slog.Default())
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	defer auth.Close()
+
+	token := gojwt.NewWithClaims(gojwt.SigningMethodES256, gojwt.MapClaims{
+		"exp": gojwt.NewNumericDate(time.Now().Add(time.Hour)),
+		"https://hasura.io/jwt/claims": hasuraClaims(
+			[]string{"user"}, "user", nil,
+		),
+	})
+	token.Header["kid"] = kid
+
+	tokenStr, err := token.SignedString(ecKey)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	result, err := auth.Authenticate(http.Header{"Authorization": {"Bearer " + tokenStr}}, "")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+
+	if result == nil || result.Role != "user" {
+		t.Errorf("expected role=user, got %+v", result)
+	}
+}
+
+func TestAuthenticatorSelectsSecretByIssuerAndAudience(t *testing.T) {
+	t.Parallel()
+
+	const (
+		legacyKey = "legacy-issuer-shared-secret-key!"
+		newKey    = "new-issuer-shared-secret-key-32!"
+	)
+
+	ecKey := generateECDSAKey(t, elliptic.P256())
+	skew := uint(30)
+
+	cfg := jwtconfig.Config{
+		Secrets: []jwtconfig.Secret{
+			{
+				Type:   jwtconfig.AlgorithmHS256,
+				Key:    legacyKey,
+				Issuer: "https://legacy.example.com",
+			},
+			{
+				Type:     jwtconfig.AlgorithmES256,
+				Key:      marshalPublicKeyPEM(t, &ecKey.PublicKey),
+				Issuer:   "https://new.example.com",
+				Audience: jwtconfig.StringOrList{"app"},
+				// Per-secret skew: only tokens from the new issuer get leeway.
+				AllowedSkew: &skew,
+			},
+			{
+				Type:     jwtconfig.AlgorithmHS256,
+				Key:      newKey,
+				Issuer:   "https://new.example.com",
+				Audience: jwtconfig.StringOrList{"worker"},
+				Header: &jwtconfig.HeaderJSON{HeaderConfig: jwtconfig.HeaderConfig{
+					Type: jwtconfig.HeaderTypeCookie,
+					Name: "worker_token",
+				}},
+			},
+		},
+	}
+
+	auth, err := jwt.NewAuthenticator(context.Background(), cfg, slog.Default())
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+	defer auth.Close()
+
+	claims := func(iss, aud string, exp time.Time) gojwt.MapClaims {
+		return gojwt.MapClaims{
+			"exp": gojwt.NewNumericDate(exp),
+			"iss": iss,
+			"aud": aud,
+			"https://hasura.io/jwt/claims": hasuraClaims(
+				[]string{"user"}, "user", nil,
+			),
+		}
+	}
+
+	cases := []struct {
+		name    string
+		headers http.Header
+		wantErr error
+	}{
+		{
+			name: "legacy issuer verified by first secret",
+			headers: http.Header{"Authorization": {"Bearer " + signToken(
+				t, gojwt.SigningMethodHS256, []byte(legacyKey),
+				claims("https://legacy.example.com", "anything", time.Now().Add(time.Hour)),
+			)}},
+		},
+		{
+			name: "new issuer verified by es256 secret within its skew",
+			headers: http.Header{"Authorization": {"Bearer " + signToken(
+				t, gojwt.SigningMethodES256, ecKey,
+				claims("https://new.example.com", "app", time.Now().Add(-10*time.Second)),
+			)}},
+		},
+		{
+			name: "new issuer worker audience read from cookie",
+			headers: http.Header{"Cookie": {"worker_token=" + signToken(
+				t, gojwt.SigningMethodHS256, []byte(newKey),
+				claims("https://new.example.com", "worker", time.Now().Add(time.Hour)),
+			)}},
+		},
+		{
+			name: "unknown issuer matches no secret",
+			headers: http.Header{"Authorization": {"Bearer " + signToken(
+				t, gojwt.SigningMethodHS256, []byte(legacyKey),
+				claims("https://other.example.com", "app", time.Now().Add(time.Hour)),
+			)}},
+			wantErr: jwt.ErrNoMatchingSecret,
+		},
+		{
+			name: "legacy key cannot sign for new issuer",
+			headers: http.Header{"Authorization": {"Bearer " + signToken(
+				t, gojwt.SigningMethodHS256, []byte(legacyKey),
+				claims("https://new.example.com", "app", time.Now().Add(time.Hour)),
+			)}},
+			wantErr: gojwt.ErrTokenSignatureInvalid,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := auth.Authenticate(tc.headers, "")
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Authenticate() error = %v, want %v", err, tc.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+
+			if result == nil || result.Role != "user" {
+				t.Errorf("expected role=user, got %+v", result)
+			}
+		})
+	}
+}
@@ -47,8 +47,10 @@ var (
 // by the Algorithm* constants; any other value is rejected by [Secret.Validate].
 type Algorithm string
 
-// Supported JWT signing algorithms. HS* are HMAC over a shared secret; RS* are
-// RSA with a PEM-encoded public key.
+// Supported JWT signing algorithms. HS* are HMAC over a shared secret; RS* and
+// PS* are RSA with a PEM-encoded public key; ES* are ECDSA with a PEM-encoded
+// public key on the matching NIST curve; EdDSA is Ed25519 with a PEM-encoded
+// public key.
 const (
 	// AlgorithmHS256 is HMAC-SHA256 over a shared symmetric key.
 	AlgorithmHS256 Algorithm = "HS256"
@@ -62,8 +64,47 @@ const (
 	AlgorithmRS384 Algorithm = "RS384"
 	// AlgorithmRS512 is RSASSA-PKCS1-v1_5 with SHA-512 and a PEM-encoded public key.
 	AlgorithmRS512 Algorithm = "RS512"
+	// AlgorithmPS256 is RSASSA-PSS with SHA-256 and a PEM-encoded public key.
+	AlgorithmPS256 Algorithm = "PS256"
+	// AlgorithmPS384 is RSASSA-PSS with SHA-384 and a PEM-encoded public key.
+	AlgorithmPS384 Algorithm = "PS384"
+	// AlgorithmPS512 is RSASSA-PSS with SHA-512 and a PEM-encoded public key.
+	AlgorithmPS512 Algorithm = "PS512"
+	// AlgorithmES256 is ECDSA on P-256 with SHA-256 and a PEM-encoded public key.
+	AlgorithmES256 Algorithm = "ES256"
+	// AlgorithmES384 is ECDSA on P-384 with SHA-384 and a PEM-encoded public key.
+	AlgorithmES384 Algorithm = "ES384"
+	// AlgorithmES512 is ECDSA on P-521 with SHA-512 and a PEM-encoded public key.
+	AlgorithmES512 Algorithm = "ES512"
+	// AlgorithmEdDSA is EdDSA over Ed25519 with a PEM-encoded public key.
+	AlgorithmEdDSA Algorithm = "EdDSA"
+	// AlgorithmEd25519 is Hasura's spelling of [AlgorithmEdDSA]. Both names
+	// accept the same keys and verify tokens whose `alg` header is "EdDSA".
+	AlgorithmEd25519 Algorithm = "Ed25519"
 )
 
+// SigningMethod returns the JWS `alg` header value that tokens verified by
+// this algorithm carry. It is the identity for every algorithm except
+// [AlgorithmEd25519], which maps to "EdDSA".
+func (a Algorithm) SigningMethod() string {
+	if a == AlgorithmEd25519 {
+		return string(AlgorithmEdDSA)
+	}
+
+	return string(a)
+}
+
+// IsHMAC reports whether the algorithm verifies with a shared symmetric key
+// rather than a PEM-encoded public key.
+func (a Algorithm) IsHMAC() bool {
+	switch a { //nolint:exhaustive // only the HMAC family is of interest
+	case AlgorithmHS256, AlgorithmHS384, AlgorithmHS512:
+		return true
+	default:
+		return false
+	}
+}
+
 // ClaimsFormat describes how Hasura claims are encoded in the JWT.
 type ClaimsFormat string
 
@@ -173,12 +214,12 @@ type Secret struct {
 	Type Algorithm `json:"type,omitempty"`
 	// Key is the signing key material for the static form: the raw shared
 	// secret, used as its UTF-8 bytes to match Hasura/Nhost Auth, for HS*, or a
-	// PEM-encoded RSA public key for RS*. Required when Type is set; omit when
-	// using JWKURL.
+	// PEM-encoded public key (or X.509 certificate) of the matching family for
+	// RS*/PS*/ES*/EdDSA. Required when Type is set; omit when using JWKURL.
 	Key string `json:"key,omitempty"`
 	// Kid pins validation to a specific key ID. Optional; when set, JWTs
 	// without a matching `kid` header are rejected. Applies to both JWKURL
-	// secrets and static RSA keys.
+	// secrets and static asymmetric keys.
 	Kid string `json:"kid,omitempty"`
 	// JWKURL is the JWKS endpoint to fetch signing keys from. Mutually
 	// exclusive with Type and Key. Required when not using a static key.
@@ -359,7 +400,10 @@ func (s *Secret) validateClaimsConfig() error {
 func validateAlgorithm(alg Algorithm) error {
 	switch alg {
 	case AlgorithmHS256, AlgorithmHS384, AlgorithmHS512,
-		AlgorithmRS256, AlgorithmRS384, AlgorithmRS512:
+		AlgorithmRS256, AlgorithmRS384, AlgorithmRS512,
+		AlgorithmPS256, AlgorithmPS384, AlgorithmPS512,
+		AlgorithmES256, AlgorithmES384, AlgorithmES512,
+		AlgorithmEdDSA, AlgorithmEd25519:
 		return nil
 	default:
 		return fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, alg)
@@ -64,9 +64,41 @@ func TestJWTSecretValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid ES256 with key",
+			secret: jwtconfig.Secret{
+				Type: jwtconfig.AlgorithmES256,
+				Key:  "-----BEGIN PUBLIC KEY-----\n...",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid PS256 with key",
+			secret: jwtconfig.Secret{
+				Type: jwtconfig.AlgorithmPS256,
+				Key:  "-----BEGIN PUBLIC KEY-----\n...",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid EdDSA with key",
+			secret: jwtconfig.Secret{
+				Type: jwtconfig.AlgorithmEdDSA,
+				Key:  "-----BEGIN PUBLIC KEY-----\n...",
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid Ed25519 alias with key",
+			secret: jwtconfig.Secret{
+				Type: jwtconfig.AlgorithmEd25519,
+				Key:  "-----BEGIN PUBLIC KEY-----\n...",
+			},
+			wantErr: false,
+		},
 		{
 			name:    "unsupported algorithm",
-			secret:  jwtconfig.Secret{Type: "ES256", Key: "key"},
+			secret:  jwtconfig.Secret{Type: "ES256K", Key: "key"},
 			wantErr: true,
 		},
 		{
@@ -283,6 +315,31 @@ func TestParseConfig(t *testing.T) {
 	}
 }
 
+func TestAlgorithmSigningMethod(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		alg  jwtconfig.Algorithm
+		want string
+	}{
+		{alg: jwtconfig.AlgorithmHS256, want: "HS256"},
+		{alg: jwtconfig.AlgorithmPS512, want: "PS512"},
+		{alg: jwtconfig.AlgorithmES384, want: "ES384"},
+		{alg: jwtconfig.AlgorithmEdDSA, want: "EdDSA"},
+		{alg: jwtconfig.AlgorithmEd25519, want: "EdDSA"},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.alg), func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.alg.SigningMethod(); got != tc.want {
+				t.Errorf("SigningMethod() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestParseConfigPEMNewlines(t *testing.T) {
 	t.Parallel()
 
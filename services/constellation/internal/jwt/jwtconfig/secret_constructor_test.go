@@ -195,7 +195,7 @@ func TestNewStaticSecretValidationFailures(t *testing.T) {
 		},
 		{
 			name:        "unsupported algorithm",
-			alg:         "ES256",
+			alg:         "ES256K",
 			key:         "k",
 			opts:        nil,
 			wantErrSubs: "unsupported algorithm",
@@ -4,9 +4,34 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/nhost/nhost/services/constellation/internal/jwt/jwtconfig"
 )
 
+// tokenRouting carries the issuer and audience read from a token before its
+// signature is checked. It is used only to choose which configured secrets to
+// try; ok is false when the token could not be decoded, in which case every
+// secret remains a candidate and reports its own parse error.
+type tokenRouting struct {
+	issuer   string
+	audience []string
+	ok       bool
+}
+
+// peekTokenRouting decodes the token payload without verifying it and returns
+// its `iss` and `aud` claims.
+func peekTokenRouting(token string) tokenRouting {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return tokenRouting{issuer: "", audience: nil, ok: false}
+	}
+
+	issuer, _ := claims.GetIssuer()
+	audience, _ := claims.GetAudience()
+
+	return tokenRouting{issuer: issuer, audience: audience, ok: true}
+}
+
 // extractToken extracts a JWT token string from HTTP headers based on the header config.
 func extractToken(headers http.Header, cfg jwtconfig.HeaderConfig) string {
 	switch cfg.Type {
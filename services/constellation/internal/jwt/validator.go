@@ -2,6 +2,10 @@ package jwt
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -9,6 +13,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -21,6 +26,10 @@ var (
 	ErrUnexpectedClaimsType = errors.New("unexpected claims type")
 	ErrPEMDecode            = errors.New("failed to decode PEM block")
 	ErrNotRSAPublicKey      = errors.New("key is not an RSA public key")
+	ErrNotECDSAPublicKey    = errors.New("key is not an ECDSA public key")
+	ErrNotEd25519PublicKey  = errors.New("key is not an Ed25519 public key")
+	ErrECDSACurveMismatch   = errors.New("ECDSA key curve does not match algorithm")
+	ErrNoMatchingSecret     = errors.New("no jwt secret matches the token issuer or audience")
 
 	errMissingExpiration = errors.New("missing expiration claim")
 )
@@ -38,6 +47,8 @@ type secretValidator struct {
 	keyFunc    jwt.Keyfunc
 	opts       []jwt.ParserOption
 	headerCfg  jwtconfig.HeaderConfig
+	issuer     string
+	audience   []string
 	jwksCancel context.CancelFunc // non-nil if using JWK URL, cancels refresh goroutine
 }
 
@@ -56,6 +67,8 @@ func newSecretValidator(
 		keyFunc:    nil,
 		opts:       nil,
 		headerCfg:  secret.EffectiveHeaderConfig(),
+		issuer:     secret.Issuer,
+		audience:   secret.Audience,
 		jwksCancel: nil,
 	}
 
@@ -94,8 +107,7 @@ func (sv *secretValidator) initJWKS(
 }
 
 func (sv *secretValidator) initStatic(secret jwtconfig.Secret, logger *slog.Logger) error {
-	switch secret.Type {
-	case jwtconfig.AlgorithmHS256, jwtconfig.AlgorithmHS384, jwtconfig.AlgorithmHS512:
+	if secret.Type.IsHMAC() {
 		key := []byte(secret.Key)
 		if logger != nil {
 			logger.Debug(
@@ -108,29 +120,27 @@ func (sv *secretValidator) initStatic(secret jwtconfig.Secret, logger *slog.Logg
 			return key, nil
 		}
 
-	case jwtconfig.AlgorithmRS256, jwtconfig.AlgorithmRS384, jwtconfig.AlgorithmRS512:
-		pubKey, err := parseRSAPublicKey(secret.Key)
-		if err != nil {
-			return fmt.Errorf("failed to parse RSA public key: %w", err)
-		}
+		return nil
+	}
 
-		kid := secret.Kid
-		sv.keyFunc = func(t *jwt.Token) (any, error) {
-			if kid != "" {
-				tokenKid, _ := t.Header["kid"].(string)
-				if tokenKid != kid {
-					return nil, fmt.Errorf(
-						"%w: got %q, expected %q",
-						ErrTokenKidMismatch, tokenKid, kid,
-					)
-				}
-			}
+	pubKey, err := parsePublicKey(secret.Type, secret.Key)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s public key: %w", secret.Type, err)
+	}
 
-			return pubKey, nil
+	kid := secret.Kid
+	sv.keyFunc = func(t *jwt.Token) (any, error) {
+		if kid != "" {
+			tokenKid, _ := t.Header["kid"].(string)
+			if tokenKid != kid {
+				return nil, fmt.Errorf(
+					"%w: got %q, expected %q",
+					ErrTokenKidMismatch, tokenKid, kid,
+				)
+			}
 		}
 
-	default:
-		return fmt.Errorf("%w: %s", jwtconfig.ErrUnsupportedAlgorithm, secret.Type)
+		return pubKey, nil
 	}
 
 	return nil
@@ -164,6 +174,32 @@ func (sv *secretValidator) parseAndValidate(tokenStr string) (map[string]any, ti
 	return claims, expiresAt.Time, nil
 }
 
+// acceptsRouting reports whether the token's unverified issuer and audience
+// are compatible with this secret's issuer/audience restrictions. Secrets with
+// no restriction accept every token. This is only a selection step — the
+// parser options re-check both claims after the signature is verified.
+func (sv *secretValidator) acceptsRouting(r tokenRouting) bool {
+	if !r.ok {
+		return true
+	}
+
+	if sv.issuer != "" && r.issuer != sv.issuer {
+		return false
+	}
+
+	if len(sv.audience) == 0 {
+		return true
+	}
+
+	for _, aud := range sv.audience {
+		if slices.Contains(r.audience, aud) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // close shuts down any background goroutines (e.g. JWKS refresh).
 func (sv *secretValidator) close() {
 	if sv.jwksCancel != nil {
@@ -171,50 +207,115 @@ func (sv *secretValidator) close() {
 	}
 }
 
-// parseRSAPublicKey parses a PEM-encoded RSA public key.
-func parseRSAPublicKey(key string) (*rsa.PublicKey, error) {
+// parsePublicKey decodes a PEM-encoded public key (PKIX, PKCS1 for RSA, or an
+// X.509 certificate wrapping the key) and checks that it belongs to the key
+// family alg verifies with. The returned value is the concrete key type the
+// golang-jwt signing method for alg expects.
+func parsePublicKey(alg jwtconfig.Algorithm, key string) (crypto.PublicKey, error) {
 	block, _ := pem.Decode([]byte(key))
 	if block == nil {
 		return nil, ErrPEMDecode
 	}
 
-	// Try PKIX first (most common for public keys).
-	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
-	if err == nil {
+	pub, err := decodePublicKeyBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	switch alg { //nolint:exhaustive // HMAC algorithms never reach this path
+	case jwtconfig.AlgorithmRS256, jwtconfig.AlgorithmRS384, jwtconfig.AlgorithmRS512,
+		jwtconfig.AlgorithmPS256, jwtconfig.AlgorithmPS384, jwtconfig.AlgorithmPS512:
 		rsaPub, ok := pub.(*rsa.PublicKey)
 		if !ok {
 			return nil, ErrNotRSAPublicKey
 		}
 
 		return rsaPub, nil
+	case jwtconfig.AlgorithmES256, jwtconfig.AlgorithmES384, jwtconfig.AlgorithmES512:
+		ecPub, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, ErrNotECDSAPublicKey
+		}
+
+		if ecPub.Curve != ecdsaCurve(alg) {
+			return nil, fmt.Errorf(
+				"%w: %s requires %s, got %s",
+				ErrECDSACurveMismatch, alg, ecdsaCurve(alg).Params().Name, ecPub.Params().Name,
+			)
+		}
+
+		return ecPub, nil
+	case jwtconfig.AlgorithmEdDSA, jwtconfig.AlgorithmEd25519:
+		edPub, ok := pub.(ed25519.PublicKey)
+		if !ok {
+			return nil, ErrNotEd25519PublicKey
+		}
+
+		return edPub, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", jwtconfig.ErrUnsupportedAlgorithm, alg)
 	}
+}
 
-	// Try PKCS1 as fallback.
+func decodePublicKeyBlock(block *pem.Block) (crypto.PublicKey, error) {
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse X.509 certificate: %w", err)
+		}
+
+		return cert.PublicKey, nil
+	}
+
+	// Try PKIX first (most common for public keys).
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err == nil {
+		return pub, nil
+	}
+
+	// Try PKCS1 as fallback (RSA only).
 	rsaPub, err := x509.ParsePKCS1PublicKey(block.Bytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse RSA public key: %w", err)
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
 	}
 
 	return rsaPub, nil
 }
 
+// ecdsaCurve returns the curve RFC 7518 pairs with an ES* algorithm.
+func ecdsaCurve(alg jwtconfig.Algorithm) elliptic.Curve { //nolint:ireturn // stdlib curve interface
+	switch alg { //nolint:exhaustive // only the ES* family is of interest
+	case jwtconfig.AlgorithmES384:
+		return elliptic.P384()
+	case jwtconfig.AlgorithmES512:
+		return elliptic.P521()
+	default:
+		return elliptic.P256()
+	}
+}
+
 // jwksAllowedMethods returns the signing-algorithm allowlist pinned at the
 // parser layer for JWKS-backed secrets. A JWKS secret has no configured
 // algorithm (Type is empty by construction — jwtconfig rejects Type/Key with
-// JWKURL), so the allowlist is derived from the
-// asymmetric families the static-key path already accepts (RS*). It
-// deliberately excludes every symmetric (HS*) algorithm and "none": a JWKS
-// endpoint only ever serves asymmetric public keys, so pinning the RSA family
-// eliminates reliance on the JWT library's type-assertion and
-// none-magic-constant guards regardless of what a remote JWKS serves. EC*/PS*
-// are intentionally omitted because the static-key path does not support them
-// either; add them here (and to the static path) only if a deployment is
-// expected to use them.
+// JWKURL), so the allowlist is derived from the asymmetric families the
+// static-key path accepts (RS*, PS*, ES*, EdDSA). It deliberately excludes
+// every symmetric (HS*) algorithm and "none": a JWKS endpoint only ever serves
+// public keys, so pinning the asymmetric families eliminates reliance on the
+// JWT library's type-assertion and none-magic-constant guards regardless of
+// what a remote JWKS serves. The key type a JWK resolves to still has to match
+// the token's algorithm — an RSA JWK cannot verify an ES256 token.
 func jwksAllowedMethods() []string {
 	return []string{
 		string(jwtconfig.AlgorithmRS256),
 		string(jwtconfig.AlgorithmRS384),
 		string(jwtconfig.AlgorithmRS512),
+		string(jwtconfig.AlgorithmPS256),
+		string(jwtconfig.AlgorithmPS384),
+		string(jwtconfig.AlgorithmPS512),
+		string(jwtconfig.AlgorithmES256),
+		string(jwtconfig.AlgorithmES384),
+		string(jwtconfig.AlgorithmES512),
+		string(jwtconfig.AlgorithmEdDSA),
 	}
 }
 
@@ -224,7 +325,7 @@ func buildParserOptions(secret jwtconfig.Secret) []jwt.ParserOption {
 	}
 
 	if secret.JWKURL == "" {
-		opts = append(opts, jwt.WithValidMethods([]string{string(secret.Type)}))
+		opts = append(opts, jwt.WithValidMethods([]string{secret.Type.SigningMethod()}))
 	} else {
 		opts = append(opts, jwt.WithValidMethods(jwksAllowedMethods()))
 	}
@@ -0,0 +1,123 @@
+// Package upload carries files received in a GraphQL multipart request
+// (https://github.com/jaydenseric/graphql-multipart-request-spec) from the
+// HTTP handler to the remote schemas that accept them. The handler spools each
+// file part to disk with [Spool] and places the resulting [File] in the
+// request variables where the spec's map puts it; a remote schema finds them
+// again with [Collect] and streams them upstream.
+//
+// A File is a value, not a pointer, because variable coercion dereferences
+// pointers before handing values on to connectors.
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ScalarName is the GraphQL scalar the multipart request spec uses for files.
+const ScalarName = "Upload"
+
+// ErrTooLarge is returned by [Spool] when a file exceeds its size limit.
+var ErrTooLarge = errors.New("file too large")
+
+// File is a file part of a multipart request, spooled to a temporary file.
+// It encodes as JSON null, which is how the spec represents a file inside
+// the operations field.
+type File struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	path        string
+}
+
+// Spool copies r to a temporary file, failing with [ErrTooLarge] once more
+// than maxBytes are read. The caller owns the returned File and must
+// [File.Remove] it.
+func Spool(r io.Reader, filename, contentType string, maxBytes int64) (File, error) {
+	tmp, err := os.CreateTemp("", "constellation-upload-*")
+	if err != nil {
+		return File{}, fmt.Errorf("creating upload file: %w", err)
+	}
+	defer tmp.Close()
+
+	f := File{
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        0,
+		path:        tmp.Name(),
+	}
+
+	f.Size, err = io.Copy(tmp, io.LimitReader(r, maxBytes+1))
+	if err == nil && f.Size > maxBytes {
+		err = fmt.Errorf("%w: limit is %d bytes", ErrTooLarge, maxBytes)
+	}
+
+	if err != nil {
+		_ = f.Remove()
+		return File{}, fmt.Errorf("spooling upload %q: %w", filename, err)
+	}
+
+	return f, nil
+}
+
+// Open opens the spooled contents for reading.
+func (f File) Open() (io.ReadCloser, error) {
+	r, err := os.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("opening upload %q: %w", f.Filename, err)
+	}
+
+	return r, nil
+}
+
+// Remove deletes the spooled contents.
+func (f File) Remove() error {
+	if err := os.Remove(f.path); err != nil {
+		return fmt.Errorf("removing upload %q: %w", f.Filename, err)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the file as null.
+func (File) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+// Ref is a File found in a request's variables, at the dot-separated spec
+// path ("variables.files.0") it occupies.
+type Ref struct {
+	Path string
+	File File
+}
+
+// Collect returns every File in variables, ordered by path.
+func Collect(variables map[string]any) []Ref {
+	var refs []Ref
+
+	collect(variables, "variables", &refs)
+
+	slices.SortFunc(refs, func(a, b Ref) int { return strings.Compare(a.Path, b.Path) })
+
+	return refs
+}
+
+func collect(value any, path string, refs *[]Ref) {
+	switch v := value.(type) {
+	case File:
+		*refs = append(*refs, Ref{Path: path, File: v})
+	case map[string]any:
+		for key, child := range v {
+			collect(child, path+"."+key, refs)
+		}
+	case []any:
+		for i, child := range v {
+			collect(child, path+"."+strconv.Itoa(i), refs)
+		}
+	}
+}
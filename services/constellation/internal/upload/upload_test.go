@@ -0,0 +1,86 @@
+package upload_test
+
+import (
+	json "encoding/json/v2"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nhost/nhost/services/constellation/internal/upload"
+)
+
+func TestSpool(t *testing.T) {
+	t.Parallel()
+
+	file, err := upload.Spool(strings.NewReader("hello"), "hello.txt", "text/plain", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Cleanup(func() { _ = file.Remove() })
+
+	if file.Size != 5 || file.Filename != "hello.txt" || file.ContentType != "text/plain" {
+		t.Errorf("unexpected file %+v", file)
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		t.Fatalf("opening file: %v", err)
+	}
+	defer r.Close()
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("reading file: %v", err)
+	}
+
+	if string(content) != "hello" {
+		t.Errorf("content = %q, want hello", content)
+	}
+}
+
+func TestSpoolRejectsFileOverLimit(t *testing.T) {
+	t.Parallel()
+
+	_, err := upload.Spool(strings.NewReader("hello!"), "hello.txt", "", 5)
+	if !errors.Is(err, upload.ErrTooLarge) {
+		t.Fatalf("expected ErrTooLarge, got %v", err)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	a := upload.File{Filename: "a"}
+	b := upload.File{Filename: "b"}
+
+	variables := map[string]any{
+		"single": a,
+		"input":  map[string]any{"files": []any{nil, b}},
+		"other":  "value",
+	}
+
+	got := upload.Collect(variables)
+	want := []upload.Ref{
+		{Path: "variables.input.files.1", File: b},
+		{Path: "variables.single", File: a},
+	}
+
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(x, y upload.File) bool {
+		return x.Filename == y.Filename
+	})); diff != "" {
+		t.Errorf("Collect mismatch (-want +got):\n%s", diff)
+	}
+
+	encoded, err := json.Marshal(variables, json.Deterministic(true))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	const wantJSON = `{"input":{"files":[null,null]},"other":"value","single":null}`
+	if string(encoded) != wantJSON {
+		t.Errorf("files must encode as null: got %s, want %s", encoded, wantJSON)
+	}
+}
@@ -26,6 +26,29 @@ func tableColumnRenamer(cfg map[string]ColumnConfig) columnRenamer {
 	}
 }
 
+// fileRecorderKey is the unexported context key carrying the callback
+// installed by WithFileRecorder.
+type fileRecorderKey struct{}
+
+// WithFileRecorder returns a child context under which FromDetect and
+// FromDetectWithHasura report to record every metadata file they read or
+// attempt to read: the TOML file itself, or each file of a Hasura YAML layout
+// including !include targets and absent optional files. The file metadata
+// source watches exactly this set for edits.
+func WithFileRecorder(ctx context.Context, record func(path string)) context.Context {
+	ctx = context.WithValue(ctx, fileRecorderKey{}, record)
+
+	return hasura.WithReadRecorder(ctx, record)
+}
+
+// recordFile reports path to the callback installed by WithFileRecorder, if
+// any.
+func recordFile(ctx context.Context, path string) {
+	if record, ok := ctx.Value(fileRecorderKey{}).(func(string)); ok {
+		record(path)
+	}
+}
+
 // FromDetect loads metadata by detecting the format from the path.
 //
 // If path ends in ".toml" it is read as a single TOML file at that exact
@@ -38,6 +61,8 @@ func tableColumnRenamer(cfg map[string]ColumnConfig) columnRenamer {
 // metadata/internal/hasura for the full convention.
 func FromDetect(ctx context.Context, path string) (*Metadata, error) {
 	if strings.HasSuffix(filepath.Base(path), ".toml") {
+		recordFile(ctx, path)
+
 		data, err := os.ReadFile(path)
 		if err != nil {
 			return nil, fmt.Errorf("reading TOML metadata file %s: %w", path, err)
@@ -75,6 +100,8 @@ func FromDetectWithHasura(
 	ctx context.Context, path string,
 ) (*Metadata, []byte, error) {
 	if strings.HasSuffix(filepath.Base(path), ".toml") {
+		recordFile(ctx, path)
+
 		data, err := os.ReadFile(path)
 		if err != nil {
 			return nil, nil, fmt.Errorf("reading TOML metadata file %s: %w", path, err)
@@ -125,12 +152,32 @@ func fromHasura(h *hasura.Metadata) *Metadata {
 		remoteSchemas[i] = convertRemoteSchema(rs)
 	}
 
+	var introspection GraphQLSchemaIntrospection
+	if h.GraphQLSchemaIntrospection != nil {
+		introspection.DisabledForRoles = h.GraphQLSchemaIntrospection.DisabledForRoles
+	}
+
 	return &Metadata{
-		Databases:     databases,
-		RemoteSchemas: remoteSchemas,
+		Databases:                  databases,
+		RemoteSchemas:              remoteSchemas,
+		GraphQLSchemaIntrospection: introspection,
+		APILimits:                  convertAPILimits(h.APILimits),
 	}
 }
 
+func convertAPILimits(h *hasura.APILimits) APILimits {
+	if h == nil {
+		return APILimits{Disabled: false, TimeLimit: nil}
+	}
+
+	limits := APILimits{Disabled: h.Disabled, TimeLimit: nil}
+	if h.TimeLimit != nil {
+		limits.TimeLimit = &RoleLimit{Global: h.TimeLimit.Global, PerRole: h.TimeLimit.PerRole}
+	}
+
+	return limits
+}
+
 func convertDatabaseURL(h hasura.DatabaseURL) EnvString {
 	if h.IsFromEnv() {
 		return EnvString("{{" + h.FromEnv + "}}")
@@ -184,6 +231,7 @@ func convertDatabaseCustomization(h hasura.DatabaseSourceCustomization) Customiz
 		TypeNamesSuffix:     h.TypeNames.Suffix,
 		TypeNamesMapping:    nil,
 		FieldNames:          nil,
+		NamingConvention:    NamingConvention(h.NamingConvention),
 	}
 }
 
@@ -192,6 +240,7 @@ func convertTable(h hasura.TableMetadata) TableMetadata {
 		Table:               convertTableSource(h.Table),
 		IsEnum:              h.IsEnum,
 		Configuration:       convertTableConfiguration(h.Configuration),
+		ApolloFederation:    convertApolloFederation(h.ApolloFederation),
 		ObjectRelationships: convertObjectRelationships(h.ObjectRelationships),
 		ArrayRelationships:  convertArrayRelationships(h.ArrayRelationships),
 		RemoteRelationships: convertRemoteRelationships(h.RemoteRelationships),
@@ -213,6 +262,14 @@ func convertTable(h hasura.TableMetadata) TableMetadata {
 	return t
 }
 
+func convertApolloFederation(h *hasura.ApolloFederation) *ApolloFederation {
+	if h == nil {
+		return nil
+	}
+
+	return &ApolloFederation{Enable: h.Enable}
+}
+
 // applyRemoteSchemaColumnRenames rewrites a remote-schema ManualConfiguration so
 // that ColumnMapping keys/values and `$sql_column` references in remote field
 // path arguments use the configured GraphQL column names. Downstream code reads
@@ -643,17 +700,76 @@ func convertRemoteSchema(h hasura.RemoteSchemaMetadata) RemoteSchemaMetadata {
 		Name:    h.Name,
 		Comment: h.Comment,
 		Definition: RemoteSchemaDefinition{
-			URL:                  convertRemoteSchemaURL(h.Definition),
-			TimeoutSeconds:       h.Definition.TimeoutSeconds,
-			Customization:        convertRemoteSchemaCustomization(h.Definition.Customization),
-			Headers:              convertRemoteSchemaHeaders(h.Definition.Headers),
-			ForwardClientHeaders: h.Definition.ForwardClientHeaders,
+			URL:                   convertRemoteSchemaURL(h.Definition),
+			TimeoutSeconds:        h.Definition.TimeoutSeconds,
+			Customization:         convertRemoteSchemaCustomization(h.Definition.Customization),
+			Headers:               convertRemoteSchemaHeaders(h.Definition.Headers),
+			ForwardClientHeaders:  h.Definition.ForwardClientHeaders,
+			IntrospectionCacheTTL: h.Definition.IntrospectionCacheTTL,
+			RequestTransform:      convertRequestTransform(h.Definition.RequestTransform),
+			ResponseTransform:     convertResponseTransform(h.Definition.ResponseTransform),
 		},
 		Permissions:         convertRemoteSchemaPermissions(h.Permissions),
 		RemoteRelationships: remoteRelationships,
 	}
 }
 
+// convertRequestTransform flattens Hasura's request_transform: the
+// request_headers block is lifted into AddHeaders/RemoveHeaders and the
+// two spellings of query_params are split into QueryParams and
+// QueryParamsTemplate.
+func convertRequestTransform(h *hasura.RequestTransformation) *RemoteSchemaRequestTransform {
+	if h == nil {
+		return nil
+	}
+
+	out := &RemoteSchemaRequestTransform{
+		TemplateEngine:      h.TemplateEngine,
+		Method:              h.Method,
+		URL:                 h.URL,
+		QueryParams:         nil,
+		QueryParamsTemplate: "",
+		AddHeaders:          nil,
+		RemoveHeaders:       nil,
+		Body:                convertTransformBody(h.Body),
+	}
+
+	if h.QueryParams != nil {
+		out.QueryParams = h.QueryParams.Params
+		out.QueryParamsTemplate = h.QueryParams.Template
+	}
+
+	if h.RequestHeaders != nil {
+		out.AddHeaders = h.RequestHeaders.AddHeaders
+		out.RemoveHeaders = h.RequestHeaders.RemoveHeaders
+	}
+
+	return out
+}
+
+func convertResponseTransform(h *hasura.ResponseTransformation) *RemoteSchemaResponseTransform {
+	if h == nil {
+		return nil
+	}
+
+	return &RemoteSchemaResponseTransform{
+		TemplateEngine: h.TemplateEngine,
+		Body:           convertTransformBody(h.Body),
+	}
+}
+
+func convertTransformBody(h *hasura.TransformBody) *RemoteSchemaTransformBody {
+	if h == nil {
+		return nil
+	}
+
+	return &RemoteSchemaTransformBody{
+		Action:       h.Action,
+		Template:     h.Template,
+		FormTemplate: h.FormTemplate,
+	}
+}
+
 // convertRemoteSchemaCustomization normalizes a Hasura remote schema's
 // definition.customization (root_fields_namespace + type_names + field_names)
 // into the shared Customization shape.
@@ -680,6 +796,7 @@ func convertRemoteSchemaCustomization(h hasura.RemoteSchemaCustomization) Custom
 		TypeNamesSuffix:     h.TypeNames.Suffix,
 		TypeNamesMapping:    h.TypeNames.Mapping,
 		FieldNames:          fieldNames,
+		NamingConvention:    "",
 	}
 }
 
@@ -701,10 +818,20 @@ func convertRemoteSchemaTypeRelationship(
 			}
 		}
 
+		var toRemoteSchema *ToRemoteSchemaRelationship
+		if rel.Definition.ToRemoteSchema != nil {
+			toRemoteSchema = &ToRemoteSchemaRelationship{
+				RemoteSchema: rel.Definition.ToRemoteSchema.RemoteSchema,
+				LHSFields:    rel.Definition.ToRemoteSchema.LHSFields,
+				RemoteField:  convertRemoteFieldCalls(rel.Definition.ToRemoteSchema.RemoteField),
+			}
+		}
+
 		relationships[i] = RemoteSchemaRelationshipDef{
 			Name: rel.Name,
 			Definition: RemoteSchemaRelationshipDefinition{
-				ToSource: toSource,
+				ToSource:       toSource,
+				ToRemoteSchema: toRemoteSchema,
 			},
 		}
 	}
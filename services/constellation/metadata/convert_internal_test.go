@@ -174,6 +174,7 @@ func TestConvertDatabase(t *testing.T) {
 				Suffix:  "_T",
 				Mapping: map[string]string{"users": "ignored"},
 			},
+			NamingConvention: "graphql-default",
 		},
 		Tables: []hasura.TableMetadata{
 			{
@@ -214,6 +215,7 @@ func TestConvertDatabase(t *testing.T) {
 		TypeNamesSuffix:     "_T",
 		TypeNamesMapping:    nil,
 		FieldNames:          nil,
+		NamingConvention:    NamingConventionGraphQLDefault,
 	}
 	if diff := cmp.Diff(wantCustomization, got.Customization); diff != "" {
 		t.Errorf("Customization mismatch (-want +got):\n%s", diff)
@@ -232,8 +234,9 @@ func TestConvertTable(t *testing.T) {
 	t.Parallel()
 
 	h := hasura.TableMetadata{
-		Table:  hasura.TableSource{Name: "posts", Schema: "public"},
-		IsEnum: true,
+		Table:            hasura.TableSource{Name: "posts", Schema: "public"},
+		IsEnum:           true,
+		ApolloFederation: &hasura.ApolloFederation{Enable: "v1"},
 		Configuration: hasura.TableConfiguration{
 			CustomName: "blogPosts",
 			ColumnConfig: map[string]hasura.ColumnConfig{
@@ -303,8 +306,9 @@ func TestConvertTable(t *testing.T) {
 	got := convertTable(h)
 
 	want := TableMetadata{
-		Table:  TableSource{Name: "posts", Schema: "public"},
-		IsEnum: true,
+		Table:            TableSource{Name: "posts", Schema: "public"},
+		IsEnum:           true,
+		ApolloFederation: &ApolloFederation{Enable: "v1"},
 		Configuration: TableConfiguration{
 			CustomName: "blogPosts",
 			ColumnConfig: map[string]ColumnConfig{
@@ -811,9 +815,10 @@ func TestConvertRemoteSchema(t *testing.T) {
 		Name:    "payments",
 		Comment: "Payment service",
 		Definition: hasura.RemoteSchemaDefinition{
-			URLFromEnv:           "PAYMENTS_URL",
-			TimeoutSeconds:       60,
-			ForwardClientHeaders: true,
+			URLFromEnv:            "PAYMENTS_URL",
+			TimeoutSeconds:        60,
+			ForwardClientHeaders:  true,
+			IntrospectionCacheTTL: 300,
 			Customization: hasura.RemoteSchemaCustomization{
 				RootFieldsNamespace: "payments",
 				TypeNames: hasura.TypeNamesCustomization{
@@ -837,6 +842,23 @@ func TestConvertRemoteSchema(t *testing.T) {
 			Headers: []hasura.RemoteSchemaHeader{
 				{Name: "x-api-key", Value: hasura.EnvValue{FromEnv: "PAYMENTS_KEY"}},
 			},
+			RequestTransform: &hasura.RequestTransformation{
+				Version:        2,
+				TemplateEngine: "Kriti",
+				URL:            "{{$base_url}}/v2",
+				QueryParams: &hasura.TransformQueryParams{
+					Params: map[string]string{"tenant": "{{$session_variables['x-hasura-tenant']}}"},
+				},
+				RequestHeaders: &hasura.TransformHeaders{
+					AddHeaders:    map[string]string{"x-tenant": "{{$session_variables['x-hasura-tenant']}}"},
+					RemoveHeaders: []string{"x-hasura-role"},
+				},
+				Body: &hasura.TransformBody{Action: "transform", Template: "{{$body}}"},
+			},
+			ResponseTransform: &hasura.ResponseTransformation{
+				Version: 2,
+				Body:    &hasura.TransformBody{Action: "transform", Template: `{"data": {{$body.result}}}`},
+			},
 		},
 		Permissions: []hasura.RemoteSchemaPermission{
 			{
@@ -864,6 +886,20 @@ func TestConvertRemoteSchema(t *testing.T) {
 							},
 						},
 					},
+					{
+						Name: "customer",
+						Definition: hasura.RemoteSchemaRelationshipDefinition{
+							ToRemoteSchema: &hasura.ToRemoteSchemaRelationship{
+								RemoteSchema: "crm",
+								LHSFields:    []string{"customer_id"},
+								RemoteField: map[string]hasura.RemoteFieldCall{
+									"customer": {
+										Arguments: map[string]string{"id": "$customer_id"},
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -875,9 +911,10 @@ func TestConvertRemoteSchema(t *testing.T) {
 		Name:    "payments",
 		Comment: "Payment service",
 		Definition: RemoteSchemaDefinition{
-			URL:                  "{{PAYMENTS_URL}}",
-			TimeoutSeconds:       60,
-			ForwardClientHeaders: true,
+			URL:                   "{{PAYMENTS_URL}}",
+			TimeoutSeconds:        60,
+			ForwardClientHeaders:  true,
+			IntrospectionCacheTTL: 300,
 			// Remote schemas express root-field prefix/suffix through a
 			// field_names entry (not RootFieldsPrefix/Suffix), so those stay
 			// empty; mapping and the field_names slice carry through in order.
@@ -906,6 +943,17 @@ func TestConvertRemoteSchema(t *testing.T) {
 			Headers: []RemoteSchemaHeader{
 				{Name: "x-api-key", ValueFromEnv: "PAYMENTS_KEY"},
 			},
+			RequestTransform: &RemoteSchemaRequestTransform{
+				TemplateEngine: "Kriti",
+				URL:            "{{$base_url}}/v2",
+				QueryParams:    map[string]string{"tenant": "{{$session_variables['x-hasura-tenant']}}"},
+				AddHeaders:     map[string]string{"x-tenant": "{{$session_variables['x-hasura-tenant']}}"},
+				RemoveHeaders:  []string{"x-hasura-role"},
+				Body:           &RemoteSchemaTransformBody{Action: "transform", Template: "{{$body}}"},
+			},
+			ResponseTransform: &RemoteSchemaResponseTransform{
+				Body: &RemoteSchemaTransformBody{Action: "transform", Template: `{"data": {{$body.result}}}`},
+			},
 		},
 		Permissions: []RemoteSchemaPermission{
 			{
@@ -933,6 +981,20 @@ func TestConvertRemoteSchema(t *testing.T) {
 							},
 						},
 					},
+					{
+						Name: "customer",
+						Definition: RemoteSchemaRelationshipDefinition{
+							ToRemoteSchema: &ToRemoteSchemaRelationship{
+								RemoteSchema: "crm",
+								LHSFields:    []string{"customer_id"},
+								RemoteField: map[string]RemoteFieldCall{
+									"customer": {
+										Arguments: map[string]string{"id": "$customer_id"},
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -443,6 +443,20 @@ func TestCustomizationIsZero(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "hasura-default naming convention",
+			in: metadata.Customization{
+				NamingConvention: metadata.NamingConventionHasuraDefault,
+			},
+			want: true,
+		},
+		{
+			name: "graphql-default naming convention",
+			in: metadata.Customization{
+				NamingConvention: metadata.NamingConventionGraphQLDefault,
+			},
+			want: false,
+		},
 	}
 
 	for _, tt := range tests {
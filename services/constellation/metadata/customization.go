@@ -7,11 +7,9 @@ package metadata
 // (remote_schemas[].definition.customization) are parsed into this single
 // shape by the converters in convert.go. The two Hasura shapes differ
 // (databases use root_fields{namespace,prefix,suffix} + type_names{prefix,
-// suffix}; remote schemas use root_fields_namespace + type_names{prefix,
-// suffix,mapping} + field_names), but both reduce to the fields below. The
-// zero value applies no changes; callers gate on IsZero.
-//
-// Hasura's naming_convention option is intentionally not modeled.
+// suffix} + naming_convention; remote schemas use root_fields_namespace +
+// type_names{prefix,suffix,mapping} + field_names), but both reduce to the
+// fields below. The zero value applies no changes; callers gate on IsZero.
 type Customization struct {
 	// RootFieldsNamespace, when non-empty, wraps every root field of the
 	// source under a single field of this name on each root operation type
@@ -34,8 +32,23 @@ type Customization struct {
 	// FieldNames renames fields on specific parent types. Populated from a
 	// remote schema's field_names; nil for databases.
 	FieldNames []FieldNameCustomization `json:"field_names,omitempty" toml:"field_names,omitempty"`
+	// NamingConvention selects the casing of generated names. Populated
+	// from a database's naming_convention; empty (or hasura-default) keeps
+	// the snake_case names the connector generates.
+	NamingConvention NamingConvention `json:"naming_convention,omitempty" toml:"naming_convention,omitempty"`
 }
 
+// NamingConvention is Hasura's naming_convention source customization.
+type NamingConvention string
+
+const (
+	// NamingConventionHasuraDefault keeps the generated snake_case names.
+	NamingConventionHasuraDefault NamingConvention = "hasura-default"
+	// NamingConventionGraphQLDefault renames fields and arguments to
+	// camelCase, types to PascalCase, and enum values to UPPER_CASE.
+	NamingConventionGraphQLDefault NamingConvention = "graphql-default"
+)
+
 // FieldNameCustomization renames the fields of a single parent type. Mirrors
 // one entry of Hasura's remote-schema customization.field_names.
 type FieldNameCustomization struct {
@@ -58,5 +71,6 @@ func (c Customization) IsZero() bool {
 		c.TypeNamesPrefix == "" &&
 		c.TypeNamesSuffix == "" &&
 		len(c.TypeNamesMapping) == 0 &&
-		len(c.FieldNames) == 0
+		len(c.FieldNames) == 0 &&
+		c.NamingConvention != NamingConventionGraphQLDefault
 }
@@ -3,6 +3,7 @@ package metadata_test
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/nhost/nhost/services/constellation/metadata"
@@ -47,3 +48,61 @@ func TestFromDetect_NonTOMLFallsThroughToHasuraYAML(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+func TestWithFileRecorder_RecordsHasuraLayoutAndIncludes(t *testing.T) {
+	t.Parallel()
+
+	metaPath := writeMinimalHasuraYAML(t)
+	dir := filepath.Dir(metaPath)
+
+	var got []string
+
+	ctx := metadata.WithFileRecorder(t.Context(), func(path string) {
+		got = append(got, path)
+	})
+
+	if _, _, err := metadata.FromDetectWithHasura(ctx, metaPath); err != nil {
+		t.Fatalf("FromDetectWithHasura: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "databases", "databases.yaml"),
+		filepath.Join(dir, "databases", "default", "tables", "tables.yaml"),
+		filepath.Join(dir, "databases", "default", "tables", "public_users.yaml"),
+		// Absent optional files are recorded too, so creating them later
+		// can be noticed.
+		filepath.Join(dir, "remote_schemas.yaml"),
+		filepath.Join(dir, "graphql_schema_introspection.yaml"),
+		filepath.Join(dir, "api_limits.yaml"),
+	}
+
+	slices.Sort(got)
+	slices.Sort(want)
+
+	if !slices.Equal(got, want) {
+		t.Errorf("recorded files = %v, want %v", got, want)
+	}
+}
+
+func TestWithFileRecorder_RecordsTOMLFile(t *testing.T) {
+	t.Parallel()
+
+	tomlPath := filepath.Join(t.TempDir(), "meta.toml")
+	if err := os.WriteFile(tomlPath, []byte("[[databases]]\nname = \"default\"\n"), 0o600); err != nil {
+		t.Fatalf("writing temp file: %v", err)
+	}
+
+	var got []string
+
+	ctx := metadata.WithFileRecorder(t.Context(), func(path string) {
+		got = append(got, path)
+	})
+
+	if _, err := metadata.FromDetect(ctx, tomlPath); err != nil {
+		t.Fatalf("FromDetect: %v", err)
+	}
+
+	if len(got) != 1 || got[0] != tomlPath {
+		t.Errorf("recorded files = %v, want [%s]", got, tomlPath)
+	}
+}
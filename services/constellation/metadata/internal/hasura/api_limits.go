@@ -0,0 +1,22 @@
+package hasura
+
+import "encoding/json/jsontext"
+
+// APILimits is Hasura's `api_limits` top-level metadata object, set through
+// `set_api_limits`. In the YAML directory layout it lives in
+// `<root>/api_limits.yaml`. Only the time limit is modeled; the depth, node,
+// rate and batch limits are preserved in Unknown.
+type APILimits struct {
+	Disabled  bool       `json:"disabled"             yaml:"disabled"`
+	TimeLimit *RoleLimit `json:"time_limit,omitempty" yaml:"time_limit,omitempty"`
+
+	Unknown jsontext.Value `json:",unknown" yaml:"-"`
+}
+
+// RoleLimit is a limit's global value and its per-role overrides.
+type RoleLimit struct {
+	Global  int            `json:"global"             yaml:"global"`
+	PerRole map[string]int `json:"per_role,omitempty" yaml:"per_role,omitempty"`
+
+	Unknown jsontext.Value `json:",unknown" yaml:"-"`
+}
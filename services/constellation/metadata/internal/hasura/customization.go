@@ -18,13 +18,14 @@ type RemoteSchemaCustomization struct {
 }
 
 // DatabaseSourceCustomization mirrors a database source's customization
-// block (sources[].customization). Hasura's naming_convention is
-// intentionally not modeled.
+// block (sources[].customization).
 type DatabaseSourceCustomization struct {
 	// RootFields namespaces and/or prefixes/suffixes the source's root fields.
 	RootFields RootFieldsCustomization `json:"root_fields" yaml:"root_fields"`
 	// TypeNames renames types by prefix/suffix.
 	TypeNames TypeNamesCustomization `json:"type_names" yaml:"type_names"`
+	// NamingConvention is "hasura-default" or "graphql-default".
+	NamingConvention string `json:"naming_convention,omitempty" yaml:"naming_convention,omitempty"`
 
 	Unknown jsontext.Value `json:",unknown" yaml:"-"`
 }
@@ -0,0 +1,15 @@
+package hasura
+
+import "encoding/json/jsontext"
+
+// GraphQLSchemaIntrospection is Hasura's `graphql_schema_introspection`
+// top-level metadata object, set through `set_graphql_introspection_options`.
+// In the YAML directory layout it lives in
+// `<root>/graphql_schema_introspection.yaml`.
+type GraphQLSchemaIntrospection struct {
+	// DisabledForRoles lists the roles whose __schema/__type queries are
+	// rejected.
+	DisabledForRoles []string `json:"disabled_for_roles" yaml:"disabled_for_roles"`
+
+	Unknown jsontext.Value `json:",unknown" yaml:"-"`
+}
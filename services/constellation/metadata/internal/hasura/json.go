@@ -22,10 +22,12 @@ var ErrUnsupportedMetadataVersion = errors.New("unsupported metadata version")
 // FromJSON ∘ ToJSON round-trip. Per-struct unknowns are captured on the
 // individual wire types via their own `json:",unknown"` fields.
 type v3Metadata struct {
-	Version       int                    `json:"version"`
-	Sources       []DatabaseMetadata     `json:"sources"`
-	RemoteSchemas []RemoteSchemaMetadata `json:"remote_schemas,omitempty"`
-	Unknown       jsontext.Value         `json:",unknown"`
+	Version                    int                         `json:"version"`
+	Sources                    []DatabaseMetadata          `json:"sources"`
+	RemoteSchemas              []RemoteSchemaMetadata      `json:"remote_schemas,omitempty"`
+	GraphQLSchemaIntrospection *GraphQLSchemaIntrospection `json:"graphql_schema_introspection,omitempty"` //nolint:lll
+	APILimits                  *APILimits                  `json:"api_limits,omitempty"`
+	Unknown                    jsontext.Value              `json:",unknown"`
 }
 
 // FromJSON parses a Hasura v3 metadata JSON blob (as stored in hdb_catalog.hdb_metadata)
@@ -55,9 +57,11 @@ func FromJSON(data []byte) (*Metadata, error) {
 	}
 
 	return &Metadata{
-		Databases:     v3.Sources,
-		RemoteSchemas: v3.RemoteSchemas,
-		Unknown:       v3.Unknown,
+		Databases:                  v3.Sources,
+		RemoteSchemas:              v3.RemoteSchemas,
+		GraphQLSchemaIntrospection: v3.GraphQLSchemaIntrospection,
+		APILimits:                  v3.APILimits,
+		Unknown:                    v3.Unknown,
 	}, nil
 }
 
@@ -79,10 +83,12 @@ func ToJSON(m *Metadata) ([]byte, error) {
 	}
 
 	v3 := v3Metadata{
-		Version:       version,
-		Sources:       sources,
-		RemoteSchemas: m.RemoteSchemas,
-		Unknown:       m.Unknown,
+		Version:                    version,
+		Sources:                    sources,
+		RemoteSchemas:              m.RemoteSchemas,
+		GraphQLSchemaIntrospection: m.GraphQLSchemaIntrospection,
+		APILimits:                  m.APILimits,
+		Unknown:                    m.Unknown,
 	}
 
 	// Deterministic so the file-source export is byte-stable across process
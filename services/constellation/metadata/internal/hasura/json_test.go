@@ -308,6 +308,64 @@ func TestFromJSON_DatabaseURLFromEnv(t *testing.T) {
 	}
 }
 
+func TestFromJSON_GraphQLSchemaIntrospection(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`{
+		"version": 3,
+		"sources": [],
+		"graphql_schema_introspection": {
+			"disabled_for_roles": ["anonymous", "user"]
+		}
+	}`)
+
+	meta, err := hasura.FromJSON(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.GraphQLSchemaIntrospection == nil {
+		t.Fatal("expected graphql_schema_introspection to be parsed")
+	}
+
+	want := []string{"anonymous", "user"}
+	if diff := cmp.Diff(want, meta.GraphQLSchemaIntrospection.DisabledForRoles); diff != "" {
+		t.Errorf("disabled_for_roles mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(meta.Unknown) != 0 {
+		t.Errorf("graphql_schema_introspection must not land in Unknown, got %s", meta.Unknown)
+	}
+}
+
+func TestFromJSON_APILimits(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`{
+		"version": 3,
+		"sources": [],
+		"api_limits": {
+			"disabled": false,
+			"depth_limit": {"global": 10},
+			"time_limit": {"global": 5, "per_role": {"user": 2}}
+		}
+	}`)
+
+	meta, err := hasura.FromJSON(input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if meta.APILimits == nil || meta.APILimits.TimeLimit == nil {
+		t.Fatal("expected api_limits.time_limit to be parsed")
+	}
+
+	want := hasura.RoleLimit{Global: 5, PerRole: map[string]int{"user": 2}, Unknown: nil}
+	if diff := cmp.Diff(want, *meta.APILimits.TimeLimit); diff != "" {
+		t.Errorf("time_limit mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestFromJSON_ConvertRemoteRelationships(t *testing.T) {
 	t.Parallel()
 
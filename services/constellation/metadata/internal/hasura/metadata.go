@@ -16,11 +16,14 @@ import (
 	"github.com/goccy/go-yaml"
 )
 
-// Metadata is the Hasura v3 top-level envelope: a list of database sources and
-// a list of remote GraphQL schemas.
+// Metadata is the Hasura v3 top-level envelope: a list of database sources, a
+// list of remote GraphQL schemas, the schema-introspection options and the API
+// limits.
 type Metadata struct {
-	Databases     []DatabaseMetadata     `json:"databases"                yaml:"databases"`
-	RemoteSchemas []RemoteSchemaMetadata `json:"remote_schemas,omitempty" yaml:"remote_schemas,omitempty"`
+	Databases                  []DatabaseMetadata          `json:"databases"                              yaml:"databases"`                              //nolint:lll
+	RemoteSchemas              []RemoteSchemaMetadata      `json:"remote_schemas,omitempty"               yaml:"remote_schemas,omitempty"`               //nolint:lll
+	GraphQLSchemaIntrospection *GraphQLSchemaIntrospection `json:"graphql_schema_introspection,omitempty" yaml:"graphql_schema_introspection,omitempty"` //nolint:lll
+	APILimits                  *APILimits                  `json:"api_limits,omitempty"                   yaml:"api_limits,omitempty"`                   //nolint:lll
 
 	Unknown jsontext.Value `json:",unknown" yaml:"-"`
 }
@@ -77,6 +80,20 @@ func readFileFrom(ctx context.Context) readFileFunc {
 	return os.ReadFile
 }
 
+// WithReadRecorder returns a child context whose file-reading seam reports to
+// record every path FromYAML and nested !include directives attempt to read,
+// including optional files that turn out to be absent. Callers use it to learn
+// which files make up the loaded metadata.
+func WithReadRecorder(ctx context.Context, record func(path string)) context.Context {
+	readFile := readFileFrom(ctx)
+
+	return withReadFile(ctx, func(path string) ([]byte, error) {
+		record(path)
+
+		return readFile(path)
+	})
+}
+
 // FromYAML loads database metadata from the Hasura v3 directory layout and
 // follows !include tags.
 //
@@ -87,8 +104,10 @@ func readFileFrom(ctx context.Context) readFileFunc {
 // file inside it such as "<dir>/metadata.yaml" — the file itself is never
 // opened. The loader resolves a fixed layout relative to the root:
 //
-//   - <root>/databases/databases.yaml (required) — the database list
-//   - <root>/remote_schemas.yaml      (optional) — the remote schemas list
+//   - <root>/databases/databases.yaml           (required) — the database list
+//   - <root>/remote_schemas.yaml                (optional) — the remote schemas list
+//   - <root>/graphql_schema_introspection.yaml  (optional) — introspection options
+//   - <root>/api_limits.yaml                    (optional) — API limits
 //
 // Both files may use !include directives to pull in further YAML files; the
 // include base directory travels through ctx so nested includes resolve
@@ -133,13 +152,51 @@ func FromYAML(ctx context.Context, metadataPath string) (*Metadata, error) {
 		return nil, fmt.Errorf("failed to read file %s: %w", remoteSchemasPath, err)
 	}
 
+	introspection, err := readOptionsFile[GraphQLSchemaIntrospection](
+		ctx, baseDir, "graphql_schema_introspection.yaml", "graphql schema introspection",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	apiLimits, err := readOptionsFile[APILimits](ctx, baseDir, "api_limits.yaml", "api limits")
+	if err != nil {
+		return nil, err
+	}
+
 	return &Metadata{
-		Databases:     databases,
-		RemoteSchemas: remoteSchemas,
-		Unknown:       nil,
+		Databases:                  databases,
+		RemoteSchemas:              remoteSchemas,
+		GraphQLSchemaIntrospection: introspection,
+		APILimits:                  apiLimits,
+		Unknown:                    nil,
 	}, nil
 }
 
+// readOptionsFile reads an optional top-level options file such as
+// `<root>/graphql_schema_introspection.yaml`. An absent file yields nil; what
+// names the options in errors.
+func readOptionsFile[T any](ctx context.Context, baseDir, name, what string) (*T, error) {
+	path := filepath.Join(baseDir, name)
+
+	data, err := readFileFrom(ctx)(path)
+
+	switch {
+	case err == nil:
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil //nolint:nilnil // an absent options file is not an error
+	default:
+		return nil, fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+
+	var options T
+	if err := yaml.UnmarshalContext(ctx, data, &options); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", what, err)
+	}
+
+	return &options, nil
+}
+
 // parseIncludePath extracts the path from an include directive like "!include path" or "!path".
 func parseIncludePath(s string) (string, bool) {
 	s = strings.TrimSpace(s)
@@ -71,11 +71,34 @@ func TestFromYAML_MalformedRemoteSchemasYAML(t *testing.T) {
 	}
 }
 
+func TestFromYAML_MalformedGraphQLSchemaIntrospectionYAML(t *testing.T) {
+	t.Parallel()
+
+	ctx := withReadFile(context.Background(), func(path string) ([]byte, error) {
+		if strings.HasSuffix(path, "graphql_schema_introspection.yaml") {
+			return []byte("disabled_for_roles: [unterminated"), nil
+		}
+
+		return []byte("[]"), nil
+	})
+
+	m, err := FromYAML(ctx, "anywhere/metadata.yaml")
+	if err == nil {
+		t.Fatalf("expected error, got metadata: %+v", m)
+	}
+
+	if !strings.Contains(err.Error(), "failed to unmarshal graphql schema introspection") {
+		t.Errorf("expected unmarshal graphql schema introspection context, got %v", err)
+	}
+}
+
 func TestFromYAML_RemoteSchemasMissingIsNotAnError(t *testing.T) {
 	t.Parallel()
 
 	ctx := withReadFile(context.Background(), func(path string) ([]byte, error) {
-		if strings.HasSuffix(path, "remote_schemas.yaml") {
+		if strings.HasSuffix(path, "remote_schemas.yaml") ||
+			strings.HasSuffix(path, "graphql_schema_introspection.yaml") ||
+			strings.HasSuffix(path, "api_limits.yaml") {
 			return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
 		}
 
@@ -35,7 +35,8 @@ type RemoteSchemaRelationshipDef struct {
 
 // RemoteSchemaRelationshipDefinition contains the relationship definition.
 type RemoteSchemaRelationshipDefinition struct {
-	ToSource *RemoteSchemaToSourceRelationship `json:"to_source,omitempty" yaml:"to_source,omitempty"`
+	ToSource       *RemoteSchemaToSourceRelationship `json:"to_source,omitempty"        yaml:"to_source,omitempty"`
+	ToRemoteSchema *ToRemoteSchemaRelationship       `json:"to_remote_schema,omitempty" yaml:"to_remote_schema,omitempty"`
 
 	Unknown jsontext.Value `json:",unknown" yaml:"-"`
 }
@@ -60,12 +61,15 @@ type RemoteSchemaTableRef struct {
 
 // RemoteSchemaDefinition defines the connection settings for a remote schema.
 type RemoteSchemaDefinition struct {
-	URL                  string                    `json:"url,omitempty"                    yaml:"url,omitempty"`                    //nolint:lll
-	URLFromEnv           string                    `json:"url_from_env,omitempty"           yaml:"url_from_env,omitempty"`           //nolint:lll
-	TimeoutSeconds       int                       `json:"timeout_seconds,omitempty"        yaml:"timeout_seconds,omitempty"`        //nolint:lll
-	Customization        RemoteSchemaCustomization `json:"customization"                    yaml:"customization"`                    //nolint:lll
-	Headers              []RemoteSchemaHeader      `json:"headers,omitempty"                yaml:"headers,omitempty"`                //nolint:lll
-	ForwardClientHeaders bool                      `json:"forward_client_headers,omitempty" yaml:"forward_client_headers,omitempty"` //nolint:lll
+	URL                   string                    `json:"url,omitempty"                     yaml:"url,omitempty"`                     //nolint:lll
+	URLFromEnv            string                    `json:"url_from_env,omitempty"            yaml:"url_from_env,omitempty"`            //nolint:lll
+	TimeoutSeconds        int                       `json:"timeout_seconds,omitempty"         yaml:"timeout_seconds,omitempty"`         //nolint:lll
+	Customization         RemoteSchemaCustomization `json:"customization"                     yaml:"customization"`                     //nolint:lll
+	Headers               []RemoteSchemaHeader      `json:"headers,omitempty"                 yaml:"headers,omitempty"`                 //nolint:lll
+	ForwardClientHeaders  bool                      `json:"forward_client_headers,omitempty"  yaml:"forward_client_headers,omitempty"`  //nolint:lll
+	IntrospectionCacheTTL int                       `json:"introspection_cache_ttl,omitempty" yaml:"introspection_cache_ttl,omitempty"` //nolint:lll
+	RequestTransform      *RequestTransformation    `json:"request_transform,omitempty"       yaml:"request_transform,omitempty"`       //nolint:lll
+	ResponseTransform     *ResponseTransformation   `json:"response_transform,omitempty"      yaml:"response_transform,omitempty"`      //nolint:lll
 
 	Unknown jsontext.Value `json:",unknown" yaml:"-"`
 }
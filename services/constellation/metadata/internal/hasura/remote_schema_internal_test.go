@@ -165,3 +165,67 @@ func TestRemoteSchemaHeader_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+// TestRequestTransformation_UnmarshalJSON covers both spellings of body
+// (version 1 string, version 2 object) and of query_params (map, string), and
+// that ToJSON re-emits query_params in the form it was read.
+func TestRequestTransformation_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		input           string
+		wantAction      string
+		wantTemplate    string
+		wantParams      map[string]string
+		wantParamsTmpl  string
+		wantQueryParams string
+	}{
+		{
+			name: "version 2",
+			input: `{"version":2,"template_engine":"Kriti",` +
+				`"query_params":{"a":"{{$body.x}}"},` +
+				`"body":{"action":"transform","template":"{{$body}}"}}`,
+			wantAction:      "transform",
+			wantTemplate:    "{{$body}}",
+			wantParams:      map[string]string{"a": "{{$body.x}}"},
+			wantQueryParams: `{"a":"{{$body.x}}"}`,
+		},
+		{
+			name:            "version 1 body and query_params string",
+			input:           `{"version":1,"query_params":"a=1","body":"{{$body}}"}`,
+			wantAction:      "transform",
+			wantTemplate:    "{{$body}}",
+			wantParamsTmpl:  "a=1",
+			wantQueryParams: `"a=1"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var rt RequestTransformation
+			if err := json.Unmarshal([]byte(tc.input), &rt); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			if rt.Body.Action != tc.wantAction || rt.Body.Template != tc.wantTemplate {
+				t.Errorf("body = %+v, want action %q template %q", rt.Body, tc.wantAction, tc.wantTemplate)
+			}
+
+			if rt.QueryParams.Template != tc.wantParamsTmpl || len(rt.QueryParams.Params) != len(tc.wantParams) {
+				t.Errorf("query_params = %+v", rt.QueryParams)
+			}
+
+			out, err := json.Marshal(rt.QueryParams)
+			if err != nil {
+				t.Fatalf("marshal query_params: %v", err)
+			}
+
+			if string(out) != tc.wantQueryParams {
+				t.Errorf("marshal query_params = %s, want %s", out, tc.wantQueryParams)
+			}
+		})
+	}
+}
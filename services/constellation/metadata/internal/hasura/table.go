@@ -41,16 +41,17 @@ var (
 
 // TableMetadata is the Hasura representation of a tracked table.
 type TableMetadata struct {
-	Table               TableSource          `json:"table"                          yaml:"table"`
-	IsEnum              bool                 `json:"is_enum,omitzero"               yaml:"is_enum,omitempty"`
-	Configuration       TableConfiguration   `json:"configuration"                  yaml:"configuration,omitempty"`
-	ObjectRelationships []ObjectRelationship `json:"object_relationships,omitempty" yaml:"object_relationships,omitempty"`
-	ArrayRelationships  []ArrayRelationship  `json:"array_relationships,omitempty"  yaml:"array_relationships,omitempty"`
-	RemoteRelationships []RemoteRelationship `json:"remote_relationships,omitempty" yaml:"remote_relationships,omitempty"`
-	SelectPermissions   []SelectPermission   `json:"select_permissions,omitempty"   yaml:"select_permissions,omitempty"`
-	InsertPermissions   []InsertPermission   `json:"insert_permissions,omitempty"   yaml:"insert_permissions,omitempty"`
-	UpdatePermissions   []UpdatePermission   `json:"update_permissions,omitempty"   yaml:"update_permissions,omitempty"`
-	DeletePermissions   []DeletePermission   `json:"delete_permissions,omitempty"   yaml:"delete_permissions,omitempty"`
+	Table               TableSource          `json:"table"                              yaml:"table"`
+	IsEnum              bool                 `json:"is_enum,omitzero"                   yaml:"is_enum,omitempty"`
+	Configuration       TableConfiguration   `json:"configuration"                      yaml:"configuration,omitempty"`
+	ApolloFederation    *ApolloFederation    `json:"apollo_federation_config,omitempty" yaml:"apollo_federation_config,omitempty"`
+	ObjectRelationships []ObjectRelationship `json:"object_relationships,omitempty"     yaml:"object_relationships,omitempty"`
+	ArrayRelationships  []ArrayRelationship  `json:"array_relationships,omitempty"      yaml:"array_relationships,omitempty"`
+	RemoteRelationships []RemoteRelationship `json:"remote_relationships,omitempty"     yaml:"remote_relationships,omitempty"`
+	SelectPermissions   []SelectPermission   `json:"select_permissions,omitempty"       yaml:"select_permissions,omitempty"`
+	InsertPermissions   []InsertPermission   `json:"insert_permissions,omitempty"       yaml:"insert_permissions,omitempty"`
+	UpdatePermissions   []UpdatePermission   `json:"update_permissions,omitempty"       yaml:"update_permissions,omitempty"`
+	DeletePermissions   []DeletePermission   `json:"delete_permissions,omitempty"       yaml:"delete_permissions,omitempty"`
 
 	Unknown jsontext.Value `json:",unknown" yaml:"-"`
 }
@@ -124,6 +125,12 @@ type TableConfiguration struct {
 	Unknown jsontext.Value `json:",unknown" yaml:"-"`
 }
 
+// ApolloFederation is a table's apollo_federation_config block. Hasura only
+// accepts "v1" for Enable; any non-empty value opts the table in.
+type ApolloFederation struct {
+	Enable string `json:"enable" yaml:"enable"`
+}
+
 // SelectPermission binds a role to its select-permission configuration.
 type SelectPermission struct {
 	Role       string                 `json:"role"       yaml:"role"`
@@ -0,0 +1,148 @@
+package hasura
+
+import (
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+	"fmt"
+)
+
+// RequestTransformation is Hasura's request_transform block (version 1 and 2
+// share this shape except for the body, see TransformBody).
+type RequestTransformation struct {
+	Version        int                   `json:"version,omitempty"         yaml:"version,omitempty"`
+	TemplateEngine string                `json:"template_engine,omitempty" yaml:"template_engine,omitempty"`
+	Method         string                `json:"method,omitempty"          yaml:"method,omitempty"`
+	URL            string                `json:"url,omitempty"             yaml:"url,omitempty"`
+	QueryParams    *TransformQueryParams `json:"query_params,omitempty"    yaml:"query_params,omitempty"`
+	RequestHeaders *TransformHeaders     `json:"request_headers,omitempty" yaml:"request_headers,omitempty"`
+	Body           *TransformBody        `json:"body,omitempty"            yaml:"body,omitempty"`
+
+	Unknown jsontext.Value `json:",unknown" yaml:"-"`
+}
+
+// ResponseTransformation is Hasura's response_transform block.
+type ResponseTransformation struct {
+	Version        int            `json:"version,omitempty"         yaml:"version,omitempty"`
+	TemplateEngine string         `json:"template_engine,omitempty" yaml:"template_engine,omitempty"`
+	Body           *TransformBody `json:"body,omitempty"            yaml:"body,omitempty"`
+
+	Unknown jsontext.Value `json:",unknown" yaml:"-"`
+}
+
+// TransformHeaders is the request_headers block of a request transform.
+type TransformHeaders struct {
+	AddHeaders    map[string]string `json:"add_headers,omitempty"    yaml:"add_headers,omitempty"`
+	RemoveHeaders []string          `json:"remove_headers,omitempty" yaml:"remove_headers,omitempty"`
+
+	Unknown jsontext.Value `json:",unknown" yaml:"-"`
+}
+
+// TransformBody is the body block of a request or response transform.
+// Version 2 spells it as an object ({action, template, form_template});
+// version 1 as a bare template string, which is read as
+// {action: transform, template: <string>}.
+type TransformBody struct {
+	Action       string            `json:"action"                  yaml:"action"`
+	Template     string            `json:"template,omitempty"      yaml:"template,omitempty"`
+	FormTemplate map[string]string `json:"form_template,omitempty" yaml:"form_template,omitempty"`
+
+	Unknown jsontext.Value `json:",unknown" yaml:"-"`
+}
+
+// UnmarshalYAML accepts both the version 1 string and the version 2 object.
+func (b *TransformBody) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		b.Action = "transform"
+		b.Template = s
+
+		return nil
+	}
+
+	type raw TransformBody
+
+	if err := unmarshal((*raw)(b)); err != nil {
+		return fmt.Errorf("unmarshaling transform body: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON accepts both the version 1 string and the version 2 object.
+func (b *TransformBody) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		b.Action = "transform"
+		b.Template = s
+
+		return nil
+	}
+
+	type raw TransformBody
+
+	if err := json.Unmarshal(data, (*raw)(b)); err != nil {
+		return fmt.Errorf("unmarshaling transform body: %w", err)
+	}
+
+	return nil
+}
+
+// TransformQueryParams is the query_params of a request transform: either a
+// map of parameter name to string template, or a single string template
+// rendering the whole query string.
+type TransformQueryParams struct {
+	Params   map[string]string `json:"-" yaml:"-"`
+	Template string            `json:"-" yaml:"-"`
+}
+
+// UnmarshalYAML accepts both the map and the string form.
+func (q *TransformQueryParams) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		q.Template = s
+
+		return nil
+	}
+
+	if err := unmarshal(&q.Params); err != nil {
+		return fmt.Errorf("unmarshaling query_params: %w", err)
+	}
+
+	return nil
+}
+
+// UnmarshalJSON accepts both the map and the string form.
+func (q *TransformQueryParams) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		q.Template = s
+
+		return nil
+	}
+
+	if err := json.Unmarshal(data, &q.Params); err != nil {
+		return fmt.Errorf("unmarshaling query_params: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalJSON inverts UnmarshalJSON, emitting whichever form was read.
+func (q TransformQueryParams) MarshalJSON() ([]byte, error) {
+	var (
+		b   []byte
+		err error
+	)
+
+	if q.Params == nil {
+		b, err = json.Marshal(q.Template)
+	} else {
+		b, err = json.Marshal(q.Params)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("marshaling query_params: %w", err)
+	}
+
+	return b, nil
+}
@@ -4,6 +4,8 @@
 // YAML/JSON.
 package metadata
 
+import "time"
+
 // RoleAdmin is the reserved role name for the unrestricted administrator.
 // The admin role bypasses per-table permission checks and is treated as a
 // permission shortcut by every connector that builds role-scoped schemas
@@ -14,4 +16,48 @@ const RoleAdmin = "admin"
 type Metadata struct {
 	Databases     []DatabaseMetadata     `json:"databases"                toml:"databases"`
 	RemoteSchemas []RemoteSchemaMetadata `json:"remote_schemas,omitempty" toml:"remote_schemas,omitempty"`
+	// GraphQLSchemaIntrospection holds the introspection options (Hasura's
+	// set_graphql_introspection_options).
+	GraphQLSchemaIntrospection GraphQLSchemaIntrospection `json:"graphql_schema_introspection,omitzero" toml:"graphql_schema_introspection,omitempty"` //nolint:lll
+	// APILimits holds the request limits (Hasura's set_api_limits).
+	APILimits APILimits `json:"api_limits,omitzero" toml:"api_limits,omitempty"`
+}
+
+// GraphQLSchemaIntrospection controls which roles may run __schema / __type
+// introspection queries.
+type GraphQLSchemaIntrospection struct {
+	// DisabledForRoles lists the roles whose introspection queries are
+	// rejected with a validation error.
+	DisabledForRoles []string `json:"disabled_for_roles,omitempty" toml:"disabled_for_roles,omitempty"`
+}
+
+// APILimits limits what a request may do. Only the time limit is enforced.
+// Limits never apply to the admin role.
+type APILimits struct {
+	// Disabled turns every limit off without removing its configuration.
+	Disabled bool `json:"disabled,omitempty" toml:"disabled,omitempty"`
+	// TimeLimit bounds, in seconds, how long a request's database statements
+	// may run.
+	TimeLimit *RoleLimit `json:"time_limit,omitempty" toml:"time_limit,omitempty"`
+}
+
+// RoleLimit is a limit with a global value and per-role overrides.
+type RoleLimit struct {
+	Global  int            `json:"global"             toml:"global"`
+	PerRole map[string]int `json:"per_role,omitempty" toml:"per_role,omitempty"`
+}
+
+// TimeLimitForRole returns the statement time limit for role, or 0 when no
+// limit applies: limits are disabled or unset, or role is admin.
+func (l APILimits) TimeLimitForRole(role string) time.Duration {
+	if l.Disabled || l.TimeLimit == nil || role == RoleAdmin {
+		return 0
+	}
+
+	seconds, ok := l.TimeLimit.PerRole[role]
+	if !ok {
+		seconds = l.TimeLimit.Global
+	}
+
+	return time.Duration(max(seconds, 0)) * time.Second
 }
@@ -0,0 +1,49 @@
+package metadata_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nhost/nhost/services/constellation/metadata"
+)
+
+func TestAPILimitsTimeLimitForRole(t *testing.T) {
+	t.Parallel()
+
+	limits := metadata.APILimits{
+		Disabled: false,
+		TimeLimit: &metadata.RoleLimit{
+			Global:  10,
+			PerRole: map[string]int{"user": 2, "reporting": 0},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		limits metadata.APILimits
+		role   string
+		want   time.Duration
+	}{
+		{name: "per-role override", limits: limits, role: "user", want: 2 * time.Second},
+		{name: "global default", limits: limits, role: "anonymous", want: 10 * time.Second},
+		{name: "per-role zero lifts the limit", limits: limits, role: "reporting", want: 0},
+		{name: "admin is never limited", limits: limits, role: metadata.RoleAdmin, want: 0},
+		{
+			name:   "disabled",
+			limits: metadata.APILimits{Disabled: true, TimeLimit: limits.TimeLimit},
+			role:   "user",
+			want:   0,
+		},
+		{name: "unset", limits: metadata.APILimits{}, role: "user", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.limits.TimeLimitForRole(tt.role); got != tt.want {
+				t.Errorf("TimeLimitForRole(%q) = %s, want %s", tt.role, got, tt.want)
+			}
+		})
+	}
+}
@@ -26,6 +26,10 @@ type RemoteSchemaRelationshipDefinition struct {
 	// ToSource describes a join from the remote schema's type into a
 	// database source. Nil for any other relationship kind.
 	ToSource *RemoteSchemaToSourceRelationship `json:"to_source,omitempty" toml:"to_source,omitempty"`
+	// ToRemoteSchema describes a join from the remote schema's type into a
+	// field of another remote schema: lhs_fields of the type are bound into
+	// the remote_field arguments. Nil for any other relationship kind.
+	ToRemoteSchema *ToRemoteSchemaRelationship `json:"to_remote_schema,omitempty" toml:"to_remote_schema,omitempty"`
 }
 
 // RemoteSchemaToSourceRelationship defines a relationship from a remote schema to a database.
@@ -61,6 +65,61 @@ type RemoteSchemaDefinition struct {
 	// ForwardClientHeaders, when true, forwards the incoming client
 	// request's headers to the remote endpoint in addition to Headers.
 	ForwardClientHeaders bool `json:"forward_client_headers,omitempty" toml:"forward_client_headers,omitempty"` //nolint:lll
+	// IntrospectionCacheTTL is how many seconds an introspection result is
+	// trusted before the remote endpoint is introspected again in the
+	// background. Zero introspects only when the schema is built.
+	IntrospectionCacheTTL int `json:"introspection_cache_ttl,omitempty" toml:"introspection_cache_ttl,omitempty"` //nolint:lll
+	// RequestTransform rewrites every request sent to the remote endpoint
+	// (URL, method, query parameters, headers and body) from templates.
+	// Nil forwards requests unchanged.
+	RequestTransform *RemoteSchemaRequestTransform `json:"request_transform,omitempty" toml:"request_transform,omitempty"` //nolint:lll
+	// ResponseTransform rewrites the remote endpoint's response body before
+	// it is parsed as a GraphQL response. Nil leaves responses unchanged.
+	ResponseTransform *RemoteSchemaResponseTransform `json:"response_transform,omitempty" toml:"response_transform,omitempty"` //nolint:lll
+}
+
+// RemoteSchemaRequestTransform is a remote schema's request_transform.
+// Templates use Hasura's Kriti language and can reference $body (the GraphQL
+// request), $session_variables and $base_url (the configured URL). URL,
+// header values and query parameter values are string templates: the
+// rendered text is used as is.
+type RemoteSchemaRequestTransform struct {
+	// TemplateEngine names the template language; only "Kriti" (the
+	// default when empty) is supported.
+	TemplateEngine string `json:"template_engine,omitempty" toml:"template_engine,omitempty"`
+	// Method overrides the HTTP method. Empty keeps POST.
+	Method string `json:"method,omitempty" toml:"method,omitempty"`
+	// URL overrides the request URL. Empty keeps the configured URL.
+	URL string `json:"url,omitempty" toml:"url,omitempty"`
+	// QueryParams replaces the URL's query string with these parameters.
+	QueryParams map[string]string `json:"query_params,omitempty" toml:"query_params,omitempty"`
+	// QueryParamsTemplate replaces the URL's query string with the rendered
+	// text. Hasura accepts query_params as either form; at most one is set.
+	QueryParamsTemplate string `json:"query_params_template,omitempty" toml:"query_params_template,omitempty"` //nolint:lll
+	// AddHeaders sets these headers after all other headers are applied.
+	AddHeaders map[string]string `json:"add_headers,omitempty" toml:"add_headers,omitempty"`
+	// RemoveHeaders drops these headers before AddHeaders is applied.
+	RemoveHeaders []string `json:"remove_headers,omitempty" toml:"remove_headers,omitempty"`
+	// Body rewrites the request body. Nil sends the GraphQL request as is.
+	Body *RemoteSchemaTransformBody `json:"body,omitempty" toml:"body,omitempty"`
+}
+
+// RemoteSchemaResponseTransform is a remote schema's response_transform. Its
+// body template can reference $body (the decoded response, null when it is not
+// JSON) and $session_variables, and must render a GraphQL response.
+type RemoteSchemaResponseTransform struct {
+	TemplateEngine string                     `json:"template_engine,omitempty" toml:"template_engine,omitempty"`
+	Body           *RemoteSchemaTransformBody `json:"body,omitempty"            toml:"body,omitempty"`
+}
+
+// RemoteSchemaTransformBody describes how a transform rewrites a body. Action
+// is "transform" (render Template as JSON), "remove" (send no body) or
+// "x_www_form_urlencoded" (send FormTemplate's rendered string templates as a
+// form); responses only support "transform".
+type RemoteSchemaTransformBody struct {
+	Action       string            `json:"action"                  toml:"action"`
+	Template     string            `json:"template,omitempty"      toml:"template,omitempty"`
+	FormTemplate map[string]string `json:"form_template,omitempty" toml:"form_template,omitempty"`
 }
 
 // RemoteSchemaHeader defines a header to be sent with requests to the remote schema.
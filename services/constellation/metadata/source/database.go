@@ -1,6 +1,6 @@
 // Package source provides runtime implementations of metadata.Source —
-// either a one-shot file loader or a poller that watches
-// hdb_catalog.hdb_metadata in a PostgreSQL database.
+// either a file loader that reloads when the metadata files change or a
+// poller that watches hdb_catalog.hdb_metadata in a PostgreSQL database.
 package source
 
 import (
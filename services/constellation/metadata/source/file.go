@@ -3,43 +3,75 @@ package source
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/nhost/nhost/services/constellation/metadata"
 )
 
 // metadataLoader is the minimal filesystem surface FileMetadataSource needs.
 // Extracted so tests can substitute a fake (see file_internal_test.go). The
 // second return value is the pre-conversion Hasura JSON snapshot when the path
-// resolved to a Hasura YAML directory layout; nil for TOML paths.
+// resolved to a Hasura YAML directory layout; nil for TOML paths. Loaders
+// report the files they read through the recorder metadata.WithFileRecorder
+// installs on ctx; those files are what Watch reloads on.
 type metadataLoader func(
 	ctx context.Context, path string,
 ) (*metadata.Metadata, []byte, error)
 
-// FileMetadataSource loads metadata from a file path once.
+// FileMetadataSource loads metadata from a file path and reloads it when the
+// files it was loaded from change.
+//
+// watched holds the files the last load read (the TOML file, or every file of
+// a Hasura YAML layout including !include targets). It is written by InitialLoad (caller goroutine) and by reloads (Watch
+// goroutine), so it is published through an atomic pointer like hasuraJSON.
 type FileMetadataSource struct {
 	path       string
 	loader     metadataLoader
+	debounce   time.Duration
+	logger     *slog.Logger
 	hasuraJSON atomic.Pointer[[]byte]
-	ch         chan metadata.Update
-	closeOnce  sync.Once
+	watched    atomic.Pointer[watchedFiles]
+
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
-// NewFileMetadataSource creates a source that loads metadata from a file.
-func NewFileMetadataSource(path string) *FileMetadataSource {
-	return newFileMetadataSource(path, metadata.FromDetectWithHasura)
+// NewFileMetadataSource creates a source that loads metadata from a file and
+// reloads it once the files it was loaded from have not changed for the
+// debounce interval.
+func NewFileMetadataSource(
+	path string,
+	debounce time.Duration,
+	logger *slog.Logger,
+) *FileMetadataSource {
+	return newFileMetadataSource(path, metadata.FromDetectWithHasura, debounce, logger)
 }
 
 // newFileMetadataSource builds the source over an arbitrary metadataLoader.
 // Used by tests through a fake loader; not exported because the loader type
 // is internal.
-func newFileMetadataSource(path string, loader metadataLoader) *FileMetadataSource {
+func newFileMetadataSource(
+	path string,
+	loader metadataLoader,
+	debounce time.Duration,
+	logger *slog.Logger,
+) *FileMetadataSource {
 	return &FileMetadataSource{
 		path:       path,
 		loader:     loader,
+		debounce:   debounce,
+		logger:     logger,
 		hasuraJSON: atomic.Pointer[[]byte]{},
-		ch:         make(chan metadata.Update),
+		watched:    atomic.Pointer[watchedFiles]{},
+		done:       make(chan struct{}),
 		closeOnce:  sync.Once{},
 	}
 }
@@ -48,26 +80,242 @@ func newFileMetadataSource(path string, loader metadataLoader) *FileMetadataSour
 func (s *FileMetadataSource) InitialLoad(
 	ctx context.Context,
 ) (*metadata.Metadata, error) {
-	meta, raw, err := s.loader(ctx, s.path)
+	return s.load(ctx)
+}
+
+// load runs the loader, recording the files it reads, and publishes the file
+// set and Hasura snapshot on success. On failure the files the attempt read
+// are added to the current set, so fixing whichever file broke the load is
+// noticed even when it was not part of the last good load.
+func (s *FileMetadataSource) load(ctx context.Context) (*metadata.Metadata, error) {
+	var files []string
+
+	recordCtx := metadata.WithFileRecorder(ctx, func(path string) {
+		files = append(files, path)
+	})
+
+	meta, raw, err := s.loader(recordCtx, s.path)
 	if err != nil {
+		s.storeWatched(append(files, s.watchedFiles().paths...))
+
 		return nil, fmt.Errorf("loading metadata from file: %w", err)
 	}
 
+	s.storeWatched(files)
+
 	if raw != nil {
 		s.hasuraJSON.Store(&raw)
+	} else {
+		s.hasuraJSON.Store(nil)
 	}
 
 	return meta, nil
 }
 
-// Watch returns the channel since file-based metadata has no reloads.
-func (s *FileMetadataSource) Watch(_ context.Context) <-chan metadata.Update {
-	return s.ch
+// watchedFiles is the set of files a load read, the files Watch reloads on.
+type watchedFiles struct {
+	paths []string
+}
+
+func (s *FileMetadataSource) storeWatched(paths []string) {
+	for i, path := range paths {
+		paths[i] = filepath.Clean(path)
+	}
+
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	s.watched.Store(&watchedFiles{paths: paths})
+}
+
+func (s *FileMetadataSource) watchedFiles() watchedFiles {
+	if watched := s.watched.Load(); watched != nil {
+		return *watched
+	}
+
+	return watchedFiles{paths: nil}
+}
+
+// Watch watches the files the last load read and sends a reload on the
+// returned channel once they change. The channel is closed when ctx is
+// cancelled or Close is called.
+//
+// The directories holding the files are watched rather than the files
+// themselves, because editors often save by writing a new file and renaming
+// it over the old one, which ends a watch on the file. Changes are debounced:
+// a reload happens only once no watched file has changed for the debounce
+// interval, so a save or a multi-file edit produces a single reload. A load
+// that fails is sent as an Update carrying the error, leaving the current
+// state in place, and watching continues.
+//
+// If the platform watcher cannot be created the error is logged and the
+// channel only closes, leaving the initially loaded metadata in place.
+//
+// Like DatabaseMetadataSource.Watch, Watch must be called exactly once, after
+// InitialLoad has established the set of files to watch.
+func (s *FileMetadataSource) Watch(ctx context.Context) <-chan metadata.Update {
+	ch := make(chan metadata.Update)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		s.logger.ErrorContext(
+			ctx, "failed to watch metadata files, reloads are disabled",
+			slog.String("error", err.Error()),
+		)
+	}
+
+	if watcher != nil {
+		// Watch the directories before returning, so no edit made after
+		// Watch returns can be missed.
+		s.syncWatchedDirs(ctx, watcher)
+	}
+
+	go func() {
+		defer close(ch)
+
+		if watcher == nil {
+			select {
+			case <-ctx.Done():
+			case <-s.done:
+			}
+
+			return
+		}
+
+		defer watcher.Close()
+
+		s.watch(ctx, watcher, ch)
+	}()
+
+	return ch
+}
+
+func (s *FileMetadataSource) watch(
+	ctx context.Context, watcher *fsnotify.Watcher, ch chan<- metadata.Update,
+) {
+	var settled <-chan time.Time
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if s.affectsWatchedFiles(event.Name) {
+				// A created directory may now hold a watched file.
+				s.syncWatchedDirs(ctx, watcher)
+
+				settled = time.After(s.debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			s.logger.WarnContext(ctx, "metadata file watcher error", slog.String("error", err.Error()))
+		case <-settled:
+			settled = nil
+
+			update := s.reload(ctx)
+
+			// The reload may have changed which files make up the metadata
+			// (an added or removed !include), so watch the fresh set.
+			s.syncWatchedDirs(ctx, watcher)
+
+			select {
+			case ch <- update:
+			case <-ctx.Done():
+				return
+			case <-s.done:
+				return
+			}
+		case <-ctx.Done():
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *FileMetadataSource) reload(ctx context.Context) metadata.Update {
+	s.logger.InfoContext(ctx, "metadata files changed, reloading", slog.String("path", s.path))
+
+	meta, err := s.load(ctx)
+	if err != nil {
+		return metadata.Update{Metadata: nil, Err: err}
+	}
+
+	return metadata.Update{Metadata: meta, Err: nil}
+}
+
+// affectsWatchedFiles reports whether an event on path concerns a watched
+// file: the file itself, or a directory on the way to one (created or
+// removed as a whole).
+func (s *FileMetadataSource) affectsWatchedFiles(path string) bool {
+	path = filepath.Clean(path)
+	prefix := path + string(filepath.Separator)
+
+	for _, file := range s.watchedFiles().paths {
+		if file == path || strings.HasPrefix(file, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// syncWatchedDirs makes watcher watch exactly the directories of the watched
+// files. A file whose directory does not exist yet (an optional file such as
+// remote_schemas.yaml under a missing directory) is covered by watching its
+// nearest existing ancestor, so creating the directory is noticed.
+func (s *FileMetadataSource) syncWatchedDirs(ctx context.Context, watcher *fsnotify.Watcher) {
+	want := make(map[string]struct{})
+
+	for _, file := range s.watchedFiles().paths {
+		want[existingDir(filepath.Dir(file))] = struct{}{}
+	}
+
+	for _, dir := range watcher.WatchList() {
+		if _, ok := want[dir]; ok {
+			delete(want, dir)
+
+			continue
+		}
+
+		_ = watcher.Remove(dir)
+	}
+
+	for dir := range want {
+		if err := watcher.Add(dir); err != nil {
+			s.logger.WarnContext(
+				ctx, "failed to watch metadata directory",
+				slog.String("path", dir), slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// existingDir returns dir or, when it does not exist, its nearest existing
+// ancestor.
+func existingDir(dir string) string {
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+
+		dir = parent
+	}
 }
 
 // HasuraSnapshotJSON returns the pre-conversion Hasura wire form retained
-// from the last InitialLoad. For TOML-sourced paths the snapshot is nil. The
-// resource_version is always 0 for file sources since there is no
+// from the last successful load. For TOML-sourced paths the snapshot is nil.
+// The resource_version is always 0 for file sources since there is no
 // hdb_catalog.hdb_metadata-style counter.
 //
 // Fidelity caveat: the snapshot is a best-effort inspection view, NOT a
@@ -87,7 +335,8 @@ func (s *FileMetadataSource) HasuraSnapshotJSON() ([]byte, int64) {
 	return nil, 0
 }
 
-// Close closes the update channel. Safe to call multiple times.
+// Close stops any active Watch goroutine, which closes its channel. Safe to
+// call multiple times.
 func (s *FileMetadataSource) Close() {
-	s.closeOnce.Do(func() { close(s.ch) })
+	s.closeOnce.Do(func() { close(s.done) })
 }
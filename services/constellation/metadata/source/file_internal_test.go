@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/nhost/nhost/services/constellation/metadata"
 )
@@ -20,6 +22,8 @@ func TestFileMetadataSource_InitialLoad_LoaderErrorPropagates(t *testing.T) {
 		func(_ context.Context, _ string) (*metadata.Metadata, []byte, error) {
 			return nil, nil, errLoaderBoom
 		},
+		time.Second,
+		slog.Default(),
 	)
 	defer src.Close()
 
@@ -51,6 +55,8 @@ func TestFileMetadataSource_InitialLoad_LoaderReturnsMetadataPassthrough(t *test
 
 			return want, nil, nil
 		},
+		time.Second,
+		slog.Default(),
 	)
 	defer src.Close()
 
@@ -86,6 +92,8 @@ func TestFileMetadataSource_HasuraSnapshotJSON_StoresLoaderBytes(t *testing.T) {
 		func(_ context.Context, _ string) (*metadata.Metadata, []byte, error) {
 			return &metadata.Metadata{Databases: nil, RemoteSchemas: nil}, want, nil
 		},
+		time.Second,
+		slog.Default(),
 	)
 	defer src.Close()
 
@@ -115,6 +123,8 @@ func TestFileMetadataSource_HasuraSnapshotJSON_NilWhenLoaderReturnsNoHasura(t *t
 		func(_ context.Context, _ string) (*metadata.Metadata, []byte, error) {
 			return &metadata.Metadata{Databases: nil, RemoteSchemas: nil}, nil, nil
 		},
+		time.Second,
+		slog.Default(),
 	)
 	defer src.Close()
 
@@ -142,6 +152,8 @@ func TestFileMetadataSource_HasuraSnapshotJSON_NilBeforeLoad(t *testing.T) {
 		func(_ context.Context, _ string) (*metadata.Metadata, []byte, error) {
 			return nil, nil, nil
 		},
+		time.Second,
+		slog.Default(),
 	)
 	defer src.Close()
 
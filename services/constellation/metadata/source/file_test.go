@@ -1,11 +1,15 @@
 package source_test
 
 import (
+	"context"
 	"encoding/json"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/nhost/nhost/services/constellation/metadata"
 	"github.com/nhost/nhost/services/constellation/metadata/source"
 )
 
@@ -28,7 +32,7 @@ database_url = "postgres://localhost/db"
 		t.Fatalf("writing temp file: %v", err)
 	}
 
-	src := source.NewFileMetadataSource(tomlPath)
+	src := source.NewFileMetadataSource(tomlPath, time.Second, slog.Default())
 	defer src.Close()
 
 	meta, err := src.InitialLoad(t.Context())
@@ -84,7 +88,7 @@ func TestFileMetadataSource_HasuraSnapshotJSON_PreservesPreConversionRemoteField
 		t.Fatalf("writing databases metadata: %v", err)
 	}
 
-	src := source.NewFileMetadataSource(filepath.Join(dir, "metadata.yaml"))
+	src := source.NewFileMetadataSource(filepath.Join(dir, "metadata.yaml"), time.Second, slog.Default())
 	defer src.Close()
 
 	meta, err := src.InitialLoad(t.Context())
@@ -141,7 +145,7 @@ func TestFileMetadataSource_HasuraSnapshotJSON_PreservesPreConversionRemoteField
 func TestFileMetadataSource_InitialLoad_MissingFile(t *testing.T) {
 	t.Parallel()
 
-	src := source.NewFileMetadataSource("/does/not/exist.toml")
+	src := source.NewFileMetadataSource("/does/not/exist.toml", time.Second, slog.Default())
 	defer src.Close()
 
 	if _, err := src.InitialLoad(t.Context()); err == nil {
@@ -152,7 +156,7 @@ func TestFileMetadataSource_InitialLoad_MissingFile(t *testing.T) {
 func TestFileMetadataSource_Watch_ChannelClosesOnClose(t *testing.T) {
 	t.Parallel()
 
-	src := source.NewFileMetadataSource("/irrelevant")
+	src := source.NewFileMetadataSource("/irrelevant", time.Second, slog.Default())
 
 	ch := src.Watch(t.Context())
 
@@ -166,8 +170,175 @@ func TestFileMetadataSource_Watch_ChannelClosesOnClose(t *testing.T) {
 func TestFileMetadataSource_Close_Idempotent(t *testing.T) {
 	t.Parallel()
 
-	src := source.NewFileMetadataSource("/irrelevant")
+	src := source.NewFileMetadataSource("/irrelevant", time.Second, slog.Default())
 
 	src.Close()
 	src.Close() // must not panic
 }
+
+// watchDebounce keeps the Watch tests fast; a change is reloaded once no
+// watched file has changed for this long.
+const watchDebounce = 10 * time.Millisecond
+
+func writeFile(t *testing.T, path, body string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatalf("creating %s: %v", filepath.Dir(path), err)
+	}
+
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
+
+func nextUpdate(t *testing.T, ch <-chan metadata.Update) metadata.Update {
+	t.Helper()
+
+	select {
+	case update, ok := <-ch:
+		if !ok {
+			t.Fatal("watch channel closed before an update arrived")
+		}
+
+		return update
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a metadata update")
+	}
+
+	return metadata.Update{}
+}
+
+func tomlDatabases(names ...string) string {
+	var body string
+	for _, name := range names {
+		body += "[[databases]]\nname = \"" + name + "\"\nkind = \"postgres\"\n\n"
+	}
+
+	return body
+}
+
+func TestFileMetadataSource_Watch_ReloadsOnTomlChange(t *testing.T) {
+	t.Parallel()
+
+	tomlPath := filepath.Join(t.TempDir(), "meta.toml")
+	writeFile(t, tomlPath, tomlDatabases("default"))
+
+	src := source.NewFileMetadataSource(tomlPath, watchDebounce, slog.Default())
+	defer src.Close()
+
+	if _, err := src.InitialLoad(t.Context()); err != nil {
+		t.Fatalf("InitialLoad: %v", err)
+	}
+
+	ch := src.Watch(t.Context())
+
+	writeFile(t, tomlPath, tomlDatabases("default", "analytics"))
+
+	update := nextUpdate(t, ch)
+	if update.Err != nil {
+		t.Fatalf("reload error: %v", update.Err)
+	}
+
+	if got := len(update.Metadata.Databases); got != 2 {
+		t.Errorf("reloaded databases = %d, want 2", got)
+	}
+}
+
+// TestFileMetadataSource_Watch_ParseErrorKeepsWatching checks that a broken
+// edit is reported as an Update carrying the error (so the controller keeps its
+// current state) and that fixing the file afterwards still reloads.
+func TestFileMetadataSource_Watch_ParseErrorKeepsWatching(t *testing.T) {
+	t.Parallel()
+
+	tomlPath := filepath.Join(t.TempDir(), "meta.toml")
+	writeFile(t, tomlPath, tomlDatabases("default"))
+
+	src := source.NewFileMetadataSource(tomlPath, watchDebounce, slog.Default())
+	defer src.Close()
+
+	if _, err := src.InitialLoad(t.Context()); err != nil {
+		t.Fatalf("InitialLoad: %v", err)
+	}
+
+	ch := src.Watch(t.Context())
+
+	writeFile(t, tomlPath, "[[databases]\nname = ")
+
+	if update := nextUpdate(t, ch); update.Err == nil || update.Metadata != nil {
+		t.Fatalf("update = %+v, want an error and no metadata", update)
+	}
+
+	writeFile(t, tomlPath, tomlDatabases("fixed"))
+
+	update := nextUpdate(t, ch)
+	if update.Err != nil {
+		t.Fatalf("reload error after fix: %v", update.Err)
+	}
+
+	if got := update.Metadata.Databases[0].Name; got != "fixed" {
+		t.Errorf("reloaded database = %q, want fixed", got)
+	}
+}
+
+// TestFileMetadataSource_Watch_ReloadsOnIncludeChange edits a table file that
+// is only reachable through nested !include directives.
+func TestFileMetadataSource_Watch_ReloadsOnIncludeChange(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "databases", "databases.yaml"), `- name: default
+  kind: postgres
+  tables: "!include default/tables/tables.yaml"
+`)
+	writeFile(t, filepath.Join(dir, "databases", "default", "tables", "tables.yaml"),
+		`- "!include public_users.yaml"
+`)
+
+	usersPath := filepath.Join(dir, "databases", "default", "tables", "public_users.yaml")
+	writeFile(t, usersPath, `table:
+  name: users
+  schema: public
+`)
+
+	src := source.NewFileMetadataSource(filepath.Join(dir, "metadata.yaml"), watchDebounce, slog.Default())
+	defer src.Close()
+
+	if _, err := src.InitialLoad(t.Context()); err != nil {
+		t.Fatalf("InitialLoad: %v", err)
+	}
+
+	ch := src.Watch(t.Context())
+
+	writeFile(t, usersPath, `table:
+  name: users
+  schema: public
+configuration:
+  custom_name: members
+`)
+
+	update := nextUpdate(t, ch)
+	if update.Err != nil {
+		t.Fatalf("reload error: %v", update.Err)
+	}
+
+	if got := update.Metadata.Databases[0].Tables[0].Configuration.CustomName; got != "members" {
+		t.Errorf("reloaded table custom_name = %q, want members", got)
+	}
+}
+
+func TestFileMetadataSource_Watch_ClosesOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	src := source.NewFileMetadataSource("/irrelevant", watchDebounce, slog.Default())
+	defer src.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	ch := src.Watch(ctx)
+
+	cancel()
+
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed after ctx cancellation")
+	}
+}
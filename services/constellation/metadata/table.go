@@ -4,16 +4,30 @@ import "slices"
 
 // TableMetadata contains the metadata for a single tracked table.
 type TableMetadata struct {
-	Table               TableSource          `json:"table"                          toml:"table"`
-	IsEnum              bool                 `json:"is_enum,omitzero"               toml:"is_enum,omitempty"`
-	Configuration       TableConfiguration   `json:"configuration,omitzero"         toml:"configuration,omitempty"`
-	ObjectRelationships []ObjectRelationship `json:"object_relationships,omitempty" toml:"object_relationships,omitempty"`
-	ArrayRelationships  []ArrayRelationship  `json:"array_relationships,omitempty"  toml:"array_relationships,omitempty"`
-	RemoteRelationships []RemoteRelationship `json:"remote_relationships,omitempty" toml:"remote_relationships,omitempty"`
-	SelectPermissions   []SelectPermission   `json:"select_permissions,omitempty"   toml:"select_permissions,omitempty"`
-	InsertPermissions   []InsertPermission   `json:"insert_permissions,omitempty"   toml:"insert_permissions,omitempty"`
-	UpdatePermissions   []UpdatePermission   `json:"update_permissions,omitempty"   toml:"update_permissions,omitempty"`
-	DeletePermissions   []DeletePermission   `json:"delete_permissions,omitempty"   toml:"delete_permissions,omitempty"`
+	Table               TableSource          `json:"table"                              toml:"table"`
+	IsEnum              bool                 `json:"is_enum,omitzero"                   toml:"is_enum,omitempty"`
+	Configuration       TableConfiguration   `json:"configuration,omitzero"             toml:"configuration,omitempty"`
+	ApolloFederation    *ApolloFederation    `json:"apollo_federation_config,omitempty" toml:"apollo_federation_config,omitempty"`
+	ObjectRelationships []ObjectRelationship `json:"object_relationships,omitempty"     toml:"object_relationships,omitempty"`
+	ArrayRelationships  []ArrayRelationship  `json:"array_relationships,omitempty"      toml:"array_relationships,omitempty"`
+	RemoteRelationships []RemoteRelationship `json:"remote_relationships,omitempty"     toml:"remote_relationships,omitempty"`
+	SelectPermissions   []SelectPermission   `json:"select_permissions,omitempty"       toml:"select_permissions,omitempty"`
+	InsertPermissions   []InsertPermission   `json:"insert_permissions,omitempty"       toml:"insert_permissions,omitempty"`
+	UpdatePermissions   []UpdatePermission   `json:"update_permissions,omitempty"       toml:"update_permissions,omitempty"`
+	DeletePermissions   []DeletePermission   `json:"delete_permissions,omitempty"       toml:"delete_permissions,omitempty"`
+}
+
+// ApolloFederation opts a table into Apollo Federation: its GraphQL type
+// becomes an entity keyed by the primary key. Enable mirrors Hasura's
+// apollo_federation_config.enable, whose only accepted value is "v1".
+type ApolloFederation struct {
+	Enable string `json:"enable" toml:"enable"`
+}
+
+// FederationEnabled reports whether the table is exposed as an Apollo
+// Federation entity.
+func (t *TableMetadata) FederationEnabled() bool {
+	return t.ApolloFederation != nil && t.ApolloFederation.Enable != ""
 }
 
 // TableSource identifies a table in the database.
@@ -21,6 +21,8 @@ let
       ../../.golangci.yaml
       ../../govulncheck.yaml
       ../../internal/lib/oapi
+      (fs.fileFilter (f: f.hasExt "go") ../../internal/lib/jsontmpl)
+      (fs.fileFilter (f: f.hasExt "go") ../../internal/lib/schemadiff)
       (fs.fileFilter (f: f.hasExt "go") ./.)
       # oapi-codegen inputs consumed by `go generate` in the hermetic build.
       ./api/openapi.yaml